@@ -8,6 +8,7 @@ import (
 	"crypto/hmac"
 	"crypto/sha1" // #nosec
 	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/hex"
 	"hash"
 	"strings"
@@ -40,6 +41,67 @@ func ValidatePrefix(message, key []byte, signature string) bool {
 	}
 }
 
+// Sign returns the hex encoded hmac signature of the message.
+func Sign(h func() hash.Hash, message, key []byte) string {
+	mac := hmac.New(h, key)
+	mac.Write(message) // #nosec
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateAny checks the hmac signature of the message
+// against each of the candidate keys, returning true if
+// any of the keys produced the hex encoded signature. It
+// is used to accept both the old and new secret while a
+// webhook secret is being rotated.
+func ValidateAny(h func() hash.Hash, message []byte, keys []string, signature string) bool {
+	for _, key := range Keys(keys) {
+		if Validate(h, message, []byte(key), signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidatePrefixAny checks the prefixed hmac signature of
+// the message against each of the candidate keys.
+func ValidatePrefixAny(message []byte, keys []string, signature string) bool {
+	for _, key := range Keys(keys) {
+		if ValidatePrefix(message, []byte(key), signature) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateToken checks a shared secret token, as sent by
+// providers that do not sign the payload, against each of
+// the candidate keys using a constant time comparison.
+func ValidateToken(token string, keys []string) bool {
+	if token == "" {
+		return false
+	}
+	for _, key := range Keys(keys) {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(key)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// Keys returns the candidate keys that are not empty. An
+// empty key would allow anyone to forge a valid signature,
+// so a list holding only empty keys means no secret is
+// configured.
+func Keys(keys []string) []string {
+	var out []string
+	for _, key := range keys {
+		if key != "" {
+			out = append(out, key)
+		}
+	}
+	return out
+}
+
 func validate(h func() hash.Hash, message, key, signature []byte) bool {
 	mac := hmac.New(h, key)
 	mac.Write(message) // #nosec
@@ -168,3 +168,77 @@ func TestValidate(t *testing.T) {
 		}
 	}
 }
+
+func TestValidatePrefixAny(t *testing.T) {
+	msg := []byte("hello world")
+	sig := "sha1=f25bad540601ff3131736e24a48dd928fa9ccc93"
+
+	if !ValidatePrefixAny(msg, []string{"oldsecret", "topsecret"}, sig) {
+		t.Errorf("Want signature valid for any matching key")
+	}
+	if ValidatePrefixAny(msg, []string{"oldsecret", "newsecret"}, sig) {
+		t.Errorf("Want signature invalid when no key matches")
+	}
+	if ValidatePrefixAny(msg, nil, sig) {
+		t.Errorf("Want signature invalid when no keys are provided")
+	}
+
+	empty := "sha256=" + Sign(sha256.New, msg, []byte(""))
+	if ValidatePrefixAny(msg, []string{"", "real"}, empty) {
+		t.Errorf("Want signature made with an empty key invalid")
+	}
+}
+
+func TestValidateAny(t *testing.T) {
+	msg := []byte("bonjour monde")
+	sig := "8ca57e2afbad9fea8860404575c2d61827995c62aacd4c514eae4c404896390b"
+
+	if !ValidateAny(sha256.New, msg, []string{"topsecret", "newsecret"}, sig) {
+		t.Errorf("Want signature valid for any matching key")
+	}
+	if ValidateAny(sha256.New, msg, []string{"newsecret"}, sig) {
+		t.Errorf("Want signature invalid when no key matches")
+	}
+
+	empty := Sign(sha256.New, msg, []byte(""))
+	if ValidateAny(sha256.New, msg, []string{"", "real"}, empty) {
+		t.Errorf("Want signature made with an empty key invalid")
+	}
+}
+
+func TestKeys(t *testing.T) {
+	if got := Keys([]string{"", "real", ""}); len(got) != 1 || got[0] != "real" {
+		t.Errorf("Want empty keys dropped, got %v", got)
+	}
+	if got := Keys([]string{"", ""}); len(got) != 0 {
+		t.Errorf("Want no keys when all keys are empty, got %v", got)
+	}
+}
+
+func TestValidateToken(t *testing.T) {
+	tests := []struct {
+		token string
+		keys  []string
+		res   bool
+	}{
+		{token: "topsecret", keys: []string{"topsecret"}, res: true},
+		{token: "topsecret", keys: []string{"oldsecret", "topsecret"}, res: true},
+		{token: "topsecret", keys: []string{"oldsecret"}, res: false},
+		{token: "", keys: []string{""}, res: false},
+		{token: "topsecret", keys: nil, res: false},
+	}
+
+	for _, test := range tests {
+		if res := ValidateToken(test.token, test.keys); res != test.res {
+			t.Errorf("Want valid %v for token %q with keys %v",
+				test.res, test.token, test.keys)
+		}
+	}
+}
+
+func TestSign(t *testing.T) {
+	sig := Sign(sha256.New, []byte("bonjour monde"), []byte("topsecret"))
+	if want := "8ca57e2afbad9fea8860404575c2d61827995c62aacd4c514eae4c404896390b"; sig != want {
+		t.Errorf("Want signature %s, got %s", want, sig)
+	}
+}
@@ -37,5 +37,16 @@ type (
 		GetOrganisationInstallation(ctx context.Context, organisation string) (*Installation, *Response, error)
 
 		GetUserInstallation(ctx context.Context, user string) (*Installation, *Response, error)
+
+		// ListHookDeliveries returns the recent deliveries of the
+		// app webhook.
+		ListHookDeliveries(ctx context.Context, opts *ListOptions) ([]*HookDelivery, *Response, error)
+
+		// FindHookDelivery returns an app webhook delivery,
+		// including the request and response.
+		FindHookDelivery(ctx context.Context, delivery string) (*HookDelivery, *Response, error)
+
+		// RedeliverHook sends an app webhook delivery again.
+		RedeliverHook(ctx context.Context, delivery string) (*Response, error)
 	}
 )
@@ -106,8 +106,10 @@ type (
 		Milestones    MilestoneService
 		Releases      ReleaseService
 		PullRequests  PullRequestService
+		Reactions     ReactionService
 		Repositories  RepositoryService
 		Reviews       ReviewService
+		Search        SearchService
 		Users         UserService
 		Webhooks      WebhookService
 		Commits       CommitService
@@ -111,6 +111,18 @@ const (
 	ActionDismissed
 	// check run / check suite
 	ActionCompleted
+	// auto merge / merge queues
+	ActionAutoMergeEnabled
+	ActionAutoMergeDisabled
+	ActionEnqueued
+	ActionDequeued
+	ActionChecksRequested
+	ActionDestroyed
+	// review threads
+	ActionResolved
+	ActionUnresolved
+	// deployment protection rules
+	ActionRequested
 )
 
 // String returns the string representation of Action.
@@ -156,6 +168,24 @@ func (a Action) String() (s string) {
 		return "converted_to_draft"
 	case ActionCompleted:
 		return "completed"
+	case ActionAutoMergeEnabled:
+		return "auto_merge_enabled"
+	case ActionAutoMergeDisabled:
+		return "auto_merge_disabled"
+	case ActionEnqueued:
+		return "enqueued"
+	case ActionDequeued:
+		return "dequeued"
+	case ActionChecksRequested:
+		return "checks_requested"
+	case ActionDestroyed:
+		return "destroyed"
+	case ActionResolved:
+		return "resolved"
+	case ActionUnresolved:
+		return "unresolved"
+	case ActionRequested:
+		return "requested"
 	default:
 		return ""
 	}
@@ -213,6 +243,24 @@ func (a *Action) UnmarshalJSON(data []byte) error {
 		*a = ActionReviewRequested
 	case "review_request_removed":
 		*a = ActionReviewRequestRemoved
+	case "auto_merge_enabled":
+		*a = ActionAutoMergeEnabled
+	case "auto_merge_disabled":
+		*a = ActionAutoMergeDisabled
+	case "enqueued":
+		*a = ActionEnqueued
+	case "dequeued":
+		*a = ActionDequeued
+	case "checks_requested":
+		*a = ActionChecksRequested
+	case "destroyed":
+		*a = ActionDestroyed
+	case "resolved":
+		*a = ActionResolved
+	case "unresolved":
+		*a = ActionUnresolved
+	case "requested":
+		*a = ActionRequested
 	}
 	return nil
 }
@@ -263,3 +311,10 @@ func (d Driver) String() (s string) {
 // SearchTimeFormat is a time.Time format string for ISO8601 which is the
 // format that GitHub requires for times specified as part of a search query.
 const SearchTimeFormat = "2006-01-02T15:04:05Z"
+
+// Sort orders accepted by IssueListOptions and
+// PullRequestListOptions.
+const (
+	SortCreated = "created"
+	SortUpdated = "updated"
+)
@@ -27,7 +27,7 @@ func TestStateJSON(t *testing.T) {
 }
 
 func TestActionJSON(t *testing.T) {
-	for i := ActionCreate; i < ActionCompleted; i++ {
+	for i := ActionCreate; i <= ActionRequested; i++ {
 		in := i
 		t.Run(in.String(), func(t *testing.T) {
 			b, err := json.Marshal(in)
@@ -0,0 +1,286 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/bluekeyes/go-gitdiff/gitdiff"
+)
+
+// ErrLineNotInDiff indicates a file line is not part of
+// any hunk of a diff, so it cannot be commented on.
+var ErrLineNotInDiff = errors.New("line is not part of the diff")
+
+type (
+	// DiffLineType identifies whether a diff line was
+	// added, deleted or is unchanged context.
+	DiffLineType string
+
+	// DiffSide identifies the side of a diff a line
+	// belongs to. The left side is the old version of the
+	// file and the right side is the new version.
+	DiffSide string
+
+	// Diff represents a unified diff and its parsed structure.
+	Diff struct {
+		Raw   string
+		Files []*DiffFile
+	}
+
+	// DiffFile represents the changes to a single file.
+	DiffFile struct {
+		OldPath string
+		NewPath string
+		OldMode string
+		NewMode string
+		Added   bool
+		Deleted bool
+		Renamed bool
+		Copied  bool
+		Binary  bool
+		Hunks   []*DiffHunk
+		Patch   string
+	}
+
+	// DiffHunk represents a contiguous block of changes.
+	DiffHunk struct {
+		Header   string
+		OldStart int
+		OldLines int
+		NewStart int
+		NewLines int
+		Lines    []*DiffLine
+	}
+
+	// DiffLine represents a single line of a hunk.
+	DiffLine struct {
+		Type    DiffLineType
+		Content string
+
+		// OldLine and NewLine are the line numbers in the old
+		// and new version of the file, zero if the line does
+		// not exist on that side.
+		OldLine int
+		NewLine int
+
+		// Position is the offset of the line from the first
+		// hunk header of the file, as used by GitHub review
+		// comments.
+		Position int
+	}
+
+	// DiffPosition locates a file line within a diff.
+	DiffPosition struct {
+		Path     string
+		OldPath  string
+		Side     DiffSide
+		Type     DiffLineType
+		OldLine  int
+		NewLine  int
+		Position int
+	}
+)
+
+// DiffLineType values.
+const (
+	DiffLineContext DiffLineType = "context"
+	DiffLineAdded   DiffLineType = "added"
+	DiffLineDeleted DiffLineType = "deleted"
+)
+
+// DiffSide values.
+const (
+	DiffSideLeft  DiffSide = "LEFT"
+	DiffSideRight DiffSide = "RIGHT"
+)
+
+// ParseDiff parses a unified git diff.
+func ParseDiff(raw string) (*Diff, error) {
+	files, _, err := gitdiff.Parse(strings.NewReader(raw))
+	if err != nil {
+		return nil, err
+	}
+	diff := &Diff{Raw: raw, Files: []*DiffFile{}}
+	for _, f := range files {
+		diff.Files = append(diff.Files, convertDiffFile(f))
+	}
+	return diff, nil
+}
+
+// FormatChanges renders the changes as a unified git diff. It
+// is used by drivers whose API returns the patch of each
+// changed file rather than a complete diff.
+func FormatChanges(changes []*Change) string {
+	sb := &strings.Builder{}
+	for _, c := range changes {
+		oldPath := c.PreviousPath
+		if oldPath == "" {
+			oldPath = c.Path
+		}
+		fmt.Fprintf(sb, "diff --git a/%s b/%s\n", oldPath, c.Path)
+		switch {
+		case c.Added:
+			sb.WriteString("new file mode 100644\n")
+		case c.Deleted:
+			sb.WriteString("deleted file mode 100644\n")
+		case c.Renamed:
+			fmt.Fprintf(sb, "rename from %s\nrename to %s\n", oldPath, c.Path)
+		}
+		if c.Patch == "" {
+			continue
+		}
+		if strings.HasPrefix(c.Patch, "@@") {
+			from, to := "a/"+oldPath, "b/"+c.Path
+			if c.Added {
+				from = "/dev/null"
+			}
+			if c.Deleted {
+				to = "/dev/null"
+			}
+			fmt.Fprintf(sb, "--- %s\n+++ %s\n", from, to)
+		}
+		sb.WriteString(c.Patch)
+		if !strings.HasSuffix(c.Patch, "\n") {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
+
+// File returns the changes to the file with the given old or
+// new path, or nil if the file is not part of the diff.
+func (d *Diff) File(path string) *DiffFile {
+	for _, f := range d.Files {
+		if f.NewPath == path || f.OldPath == path {
+			return f
+		}
+	}
+	return nil
+}
+
+// Position locates the line of the file at path on the given
+// side of the diff. It returns ErrLineNotInDiff if the line is
+// not part of a hunk.
+func (d *Diff) Position(path string, line int, side DiffSide) (*DiffPosition, error) {
+	f := d.File(path)
+	if f == nil {
+		return nil, fmt.Errorf("file %s: %w", path, ErrLineNotInDiff)
+	}
+	if side == "" {
+		side = DiffSideRight
+	}
+	for _, h := range f.Hunks {
+		for _, l := range h.Lines {
+			if side == DiffSideLeft {
+				if l.Type == DiffLineAdded || l.OldLine != line {
+					continue
+				}
+			} else if l.Type == DiffLineDeleted || l.NewLine != line {
+				continue
+			}
+			return &DiffPosition{
+				Path:     f.NewPath,
+				OldPath:  f.OldPath,
+				Side:     side,
+				Type:     l.Type,
+				OldLine:  l.OldLine,
+				NewLine:  l.NewLine,
+				Position: l.Position,
+			}, nil
+		}
+	}
+	return nil, fmt.Errorf("file %s line %d: %w", path, line, ErrLineNotInDiff)
+}
+
+// ReviewLine returns the value of ReviewCommentInput.Line the
+// given driver expects for a comment on this position. GitHub
+// addresses review comments by their offset in the diff while
+// the other providers use the line number in the file.
+func (p *DiffPosition) ReviewLine(driver Driver) int {
+	if driver == DriverGithub {
+		return p.Position
+	}
+	if p.Side == DiffSideLeft {
+		return p.OldLine
+	}
+	return p.NewLine
+}
+
+func convertDiffFile(from *gitdiff.File) *DiffFile {
+	to := &DiffFile{
+		OldPath: from.OldName,
+		NewPath: from.NewName,
+		Added:   from.IsNew,
+		Deleted: from.IsDelete,
+		Renamed: from.IsRename,
+		Copied:  from.IsCopy,
+		Binary:  from.IsBinary,
+		Patch:   from.String(),
+		Hunks:   []*DiffHunk{},
+	}
+	if from.OldMode != 0 {
+		to.OldMode = fmt.Sprintf("%06o", uint32(from.OldMode))
+	}
+	if from.NewMode != 0 {
+		to.NewMode = fmt.Sprintf("%06o", uint32(from.NewMode))
+	}
+	// an index line with a mode applies to both versions
+	if to.NewMode == "" && !to.Deleted {
+		to.NewMode = to.OldMode
+	}
+	if to.OldPath == "" {
+		to.OldPath = to.NewPath
+	}
+	if to.NewPath == "" {
+		to.NewPath = to.OldPath
+	}
+
+	// the line following the first hunk header is position 1
+	// and the headers of subsequent hunks count as a line.
+	position := 0
+	for i, frag := range from.TextFragments {
+		if i > 0 {
+			position++
+		}
+		hunk := &DiffHunk{
+			Header:   frag.Comment,
+			OldStart: int(frag.OldPosition),
+			OldLines: int(frag.OldLines),
+			NewStart: int(frag.NewPosition),
+			NewLines: int(frag.NewLines),
+			Lines:    []*DiffLine{},
+		}
+		oldLine, newLine := int(frag.OldPosition), int(frag.NewPosition)
+		for _, l := range frag.Lines {
+			position++
+			line := &DiffLine{
+				Content:  strings.TrimSuffix(l.Line, "\n"),
+				Position: position,
+			}
+			switch l.Op {
+			case gitdiff.OpAdd:
+				line.Type = DiffLineAdded
+				line.NewLine = newLine
+				newLine++
+			case gitdiff.OpDelete:
+				line.Type = DiffLineDeleted
+				line.OldLine = oldLine
+				oldLine++
+			default:
+				line.Type = DiffLineContext
+				line.OldLine = oldLine
+				line.NewLine = newLine
+				oldLine++
+				newLine++
+			}
+			hunk.Lines = append(hunk.Lines, line)
+		}
+		to.Hunks = append(to.Hunks, hunk)
+	}
+	return to
+}
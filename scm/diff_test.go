@@ -0,0 +1,92 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDiff(t *testing.T) {
+	raw, err := os.ReadFile("testdata/diffs/pr.diff")
+	require.NoError(t, err)
+
+	diff, err := ParseDiff(string(raw))
+	require.NoError(t, err)
+	require.Len(t, diff.Files, 2)
+
+	readme := diff.Files[0]
+	assert.Equal(t, "README.md", readme.NewPath)
+	assert.Equal(t, "100644", readme.NewMode)
+	assert.False(t, readme.Binary)
+	require.Len(t, readme.Hunks, 2)
+	assert.Equal(t, "Usage", readme.Hunks[1].Header)
+	assert.Equal(t, 21, readme.Hunks[1].NewStart)
+
+	first := readme.Hunks[0].Lines[1]
+	assert.Equal(t, DiffLineAdded, first.Type)
+	assert.Equal(t, "A short description.", first.Content)
+	assert.Equal(t, 0, first.OldLine)
+	assert.Equal(t, 2, first.NewLine)
+	assert.Equal(t, 2, first.Position)
+
+	logo := diff.File("logo.png")
+	require.NotNil(t, logo)
+	assert.True(t, logo.Added)
+	assert.True(t, logo.Binary)
+}
+
+func TestDiffPosition(t *testing.T) {
+	raw, err := os.ReadFile("testdata/diffs/pr.diff")
+	require.NoError(t, err)
+	diff, err := ParseDiff(string(raw))
+	require.NoError(t, err)
+
+	tests := []struct {
+		line     int
+		side     DiffSide
+		position int
+		github   int
+		gitlab   int
+	}{
+		// added line in the first hunk
+		{line: 2, side: DiffSideRight, position: 2, github: 2, gitlab: 2},
+		// deleted line addressed by its old line number
+		{line: 4, side: DiffSideLeft, position: 5, github: 5, gitlab: 4},
+		// added line in the second hunk, after its header
+		{line: 24, side: DiffSideRight, position: 11, github: 11, gitlab: 24},
+	}
+	for _, test := range tests {
+		pos, err := diff.Position("README.md", test.line, test.side)
+		require.NoError(t, err)
+		assert.Equal(t, test.position, pos.Position)
+		assert.Equal(t, test.github, pos.ReviewLine(DriverGithub))
+		assert.Equal(t, test.gitlab, pos.ReviewLine(DriverGitlab))
+	}
+
+	_, err = diff.Position("README.md", 10, DiffSideRight)
+	assert.True(t, errors.Is(err, ErrLineNotInDiff))
+}
+
+func TestFormatChanges(t *testing.T) {
+	changes := []*Change{
+		{
+			Path:  "main.go",
+			Added: true,
+			Patch: "@@ -0,0 +1,2 @@\n+package main\n+\n",
+		},
+	}
+	diff, err := ParseDiff(FormatChanges(changes))
+	require.NoError(t, err)
+	require.Len(t, diff.Files, 1)
+	assert.True(t, diff.Files[0].Added)
+	assert.Equal(t, "main.go", diff.Files[0].NewPath)
+	require.Len(t, diff.Files[0].Hunks, 1)
+	assert.Len(t, diff.Files[0].Hunks[0].Lines, 2)
+}
@@ -10,12 +10,47 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
 	"strings"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
 
+// NewWebHookService creates a new instance of the webhook service without the rest of the client.
+// The service does not validate the webhook secret, see NewWebHookServiceWithSecretValidation.
+func NewWebHookService() scm.WebhookService {
+	return &webhookService{}
+}
+
+// NewWebHookServiceWithSecretValidation creates a new instance of the webhook
+// service which rejects webhooks that do not carry one of the secrets.
+func NewWebHookServiceWithSecretValidation() scm.WebhookService {
+	return &webhookService{validateSecret: true}
+}
+
+// ValidateWebhookSecret enables validation of the webhook secret by
+// the client webhook service. Azure DevOps does not sign service hooks,
+// so the secret is only validated when enabled, which lets a shared
+// secret be passed to every driver. It can be passed as a factory
+// client option.
+func ValidateWebhookSecret(client *scm.Client) {
+	if s, ok := client.Webhooks.(*webhookService); ok {
+		s.validateSecret = true
+	}
+}
+
+// IssueWorkItemType returns a client option setting the type of the
+// work items created for issues, such as "User Story". By default the
+// first of the Issue, Bug and Task types the project has is used.
+func IssueWorkItemType(name string) func(*scm.Client) {
+	return func(client *scm.Client) {
+		if s, ok := client.Issues.(*issueService); ok {
+			s.workItemType = name
+		}
+	}
+}
+
 // New returns a new azure API client.
 func New(uri string) (*scm.Client, error) {
 	base, err := url.Parse(uri)
@@ -33,13 +68,15 @@ func New(uri string) (*scm.Client, error) {
 	client.Driver = scm.DriverAzure
 	client.Contents = &contentService{client}
 	client.Git = &gitService{client}
-	client.Issues = &issueService{client}
+	client.Issues = &issueService{client: client}
 	client.Organizations = &organizationService{client}
-	client.PullRequests = &pullService{&issueService{client}}
+	client.PullRequests = &pullService{&issueService{client: client}}
 	client.Repositories = &RepositoryService{client}
+	client.Reactions = &reactionService{client}
 	client.Reviews = &reviewService{client}
+	client.Search = &searchService{client}
 	client.Users = &userService{client}
-	client.Webhooks = &webhookService{client}
+	client.Webhooks = &webhookService{client: client}
 	return client.Client, nil
 }
 
@@ -64,8 +101,12 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	if in != nil {
 		buf := new(bytes.Buffer)
 		_ = json.NewEncoder(buf).Encode(in)
+		contentType := "application/json"
+		if _, ok := in.(jsonPatch); ok {
+			contentType = "application/json-patch+json"
+		}
 		req.Header = map[string][]string{
-			"Content-Type": {"application/json"},
+			"Content-Type": {contentType},
 		}
 		req.Body = buf
 	}
@@ -99,6 +140,26 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	return res, decodeErr
 }
 
+// stream wraps the Client.Do function by creating the Request and returning the raw response body.
+// The caller must close the body.
+func (c *wrapper) stream(ctx context.Context, method, path string) (io.ReadCloser, *scm.Response, error) {
+	req := &scm.Request{
+		Method: method,
+		Path:   path,
+	}
+	res, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.Status > 300 {
+		defer res.Body.Close()
+		err := new(Error)
+		_ = json.NewDecoder(res.Body).Decode(err)
+		return nil, res, err
+	}
+	return res.Body, res, nil
+}
+
 // Error represents am Azure error.
 type Error struct {
 	Message string `json:"message"`
@@ -201,6 +201,14 @@ func convertFileEntry(from *content) *scm.FileEntry {
 	return &scm.FileEntry{Path: from.Path, Sha: from.CommitID}
 }
 
+// versionType returns the type of version descriptor for the ref.
+func versionType(ref string) string {
+	if len(ref) == 40 {
+		return "commit"
+	}
+	return "branch"
+}
+
 func generateURIFromRef(ref string) (uri string) {
 	if ref != "" {
 		if len(ref) == 40 {
@@ -7,6 +7,7 @@ package azure
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
@@ -131,6 +132,218 @@ func (s *gitService) CompareCommits(ctx context.Context, repo, source, target st
 	return convertChangeList(changes), res, err
 }
 
+// CompareDiff returns the diff of ref2 against its merge base with
+// ref1. Azure DevOps does not return patches, so the diff is built
+// from the changed line blocks of each file and the file contents.
+func (s *gitService) CompareDiff(ctx context.Context, repo, ref1, ref2 string) (*scm.Diff, *scm.Response, error) {
+	ro, err := decodeRepo(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	return compareDiff(ctx, s.client, ro, ref1, ref2)
+}
+
+// compareDiff returns the unified diff of head against its merge
+// base with base. The contents of every changed file are requested
+// on both sides to render the changed lines.
+func compareDiff(ctx context.Context, client *wrapper, ro *repoObj, base, head string) (*scm.Diff, *scm.Response, error) {
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/diffs/get?view=azure-devops-rest-6.0
+	var changes []*file
+	var out *compare
+	var res *scm.Response
+	for {
+		endpoint := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/diffs/commits?", ro.org, ro.project, ro.name)
+		endpoint += fmt.Sprintf("baseVersion=%s&baseVersionType=%s&", base, versionType(base))
+		endpoint += fmt.Sprintf("targetVersion=%s&targetVersionType=%s&", head, versionType(head))
+		endpoint += fmt.Sprintf("$skip=%d&api-version=6.0", len(changes))
+		out = new(compare)
+		var err error
+		res, err = client.do(ctx, "GET", endpoint, nil, out)
+		if err != nil {
+			return nil, res, err
+		}
+		changes = append(changes, out.Changes...)
+		if out.AllChangesIncluded || len(out.Changes) == 0 {
+			break
+		}
+	}
+	mergeBase := out.CommonCommit
+	if mergeBase == "" {
+		mergeBase = out.BaseCommit
+	}
+
+	in := &fileDiffsInput{
+		BaseVersionCommit:   mergeBase,
+		TargetVersionCommit: out.TargetCommit,
+		FileDiffParams:      []*fileDiffParams{},
+	}
+	files := []*file{}
+	for _, c := range changes {
+		if c.Item.IsFolder || c.Item.GitObjectType == "tree" {
+			continue
+		}
+		originalPath := c.OriginalPath
+		if originalPath == "" {
+			originalPath = c.Item.Path
+		}
+		in.FileDiffParams = append(in.FileDiffParams, &fileDiffParams{
+			OriginalPath: originalPath,
+			Path:         c.Item.Path,
+		})
+		files = append(files, c)
+	}
+	if len(files) == 0 {
+		return &scm.Diff{Files: []*scm.DiffFile{}}, res, nil
+	}
+
+	// https://learn.microsoft.com/en-us/rest/api/azure/devops/git/file-diffs/get-file-diffs?view=azure-devops-rest-7.1
+	endpoint := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/filediffs?api-version=7.1-preview.1", ro.org, ro.project, ro.name)
+	diffs := []*fileDiff{}
+	res, err := client.do(ctx, "POST", endpoint, in, &diffs)
+	if err != nil {
+		return nil, res, err
+	}
+
+	to := []*scm.Change{}
+	for i, c := range files {
+		change := convertChange(c)
+		params := in.FileDiffParams[i]
+		if params.OriginalPath != params.Path {
+			change.PreviousPath = strings.TrimPrefix(params.OriginalPath, "/")
+		}
+		change.Path = strings.TrimPrefix(change.Path, "/")
+		if i >= len(diffs) {
+			to = append(to, change)
+			continue
+		}
+		var original, modified []string
+		if !change.Added {
+			original, res, err = fileLines(ctx, client, ro, params.OriginalPath, mergeBase)
+			if err != nil {
+				return nil, res, err
+			}
+		}
+		if !change.Deleted {
+			modified, res, err = fileLines(ctx, client, ro, params.Path, out.TargetCommit)
+			if err != nil {
+				return nil, res, err
+			}
+		}
+		change.Patch = formatLineDiffBlocks(diffs[i].LineDiffBlocks, original, modified)
+		to = append(to, change)
+	}
+	diff, err := scm.ParseDiff(scm.FormatChanges(to))
+	return diff, res, err
+}
+
+// fileLines returns the lines of the file at the commit.
+func fileLines(ctx context.Context, client *wrapper, ro *repoObj, path, commit string) ([]string, *scm.Response, error) {
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/items/get?view=azure-devops-rest-6.0
+	endpoint := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/items?path=%s&includeContent=true&$format=json", ro.org, ro.project, ro.name, path)
+	endpoint += generateURIFromRef(commit)
+	endpoint += "&api-version=6.0"
+	out := new(content)
+	res, err := client.do(ctx, "GET", endpoint, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return strings.Split(strings.TrimSuffix(out.Content, "\n"), "\n"), res, nil
+}
+
+// formatLineDiffBlocks renders the changed line blocks as hunks
+// without context lines.
+func formatLineDiffBlocks(blocks []*lineDiffBlock, original, modified []string) string {
+	sb := &strings.Builder{}
+	for _, b := range blocks {
+		if b.ChangeType == "none" {
+			continue
+		}
+		fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", b.OriginalLineNumberStart, b.OriginalLinesCount, b.ModifiedLineNumberStart, b.ModifiedLinesCount)
+		for _, line := range sliceLines(original, b.OriginalLineNumberStart, b.OriginalLinesCount) {
+			sb.WriteString("-" + line + "\n")
+		}
+		for _, line := range sliceLines(modified, b.ModifiedLineNumberStart, b.ModifiedLinesCount) {
+			sb.WriteString("+" + line + "\n")
+		}
+	}
+	return sb.String()
+}
+
+// sliceLines returns count lines starting at the one based line
+// number start, clamped to the lines of the file.
+func sliceLines(lines []string, start, count int) []string {
+	if count <= 0 || start < 1 || start > len(lines) {
+		return nil
+	}
+	end := start - 1 + count
+	if end > len(lines) {
+		end = len(lines)
+	}
+	return lines[start-1 : end]
+}
+
+func (s *gitService) Compare(ctx context.Context, repo, base, head string, _ *scm.ListOptions) (*scm.Comparison, *scm.Response, error) {
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/diffs/get?view=azure-devops-rest-6.0
+	ro, err := decodeRepo(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/diffs/commits?", ro.org, ro.project, ro.name)
+	endpoint += fmt.Sprintf("baseVersion=%s&baseVersionType=%s&", base, versionType(base))
+	endpoint += fmt.Sprintf("targetVersion=%s&targetVersionType=%s&api-version=6.0", head, versionType(head))
+	out := new(compare)
+	res, err := s.client.do(ctx, "GET", endpoint, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+	to := &scm.Comparison{
+		Status:    scm.ComparisonStatusOf(int(out.AheadCount), int(out.BehindCount)),
+		AheadBy:   int(out.AheadCount),
+		BehindBy:  int(out.BehindCount),
+		MergeBase: out.CommonCommit,
+		Commits:   []*scm.Commit{},
+		Changes:   convertChangeList(out.Changes),
+	}
+	if out.AheadCount == 0 {
+		return to, res, nil
+	}
+
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/commits/get-commits?view=azure-devops-rest-6.0
+	endpoint = fmt.Sprintf("%s/%s/_apis/git/repositories/%s/commits?", ro.org, ro.project, ro.name)
+	endpoint += fmt.Sprintf("searchCriteria.itemVersion.version=%s&searchCriteria.itemVersion.versionType=%s&", head, versionType(head))
+	endpoint += fmt.Sprintf("searchCriteria.compareVersion.version=%s&searchCriteria.compareVersion.versionType=%s&api-version=6.0", base, versionType(base))
+	commits := new(commitList)
+	res, err = s.client.do(ctx, "GET", endpoint, nil, &commits)
+	if err != nil {
+		return nil, res, err
+	}
+	// commits are listed newest first
+	for i := len(commits.Value) - 1; i >= 0; i-- {
+		to.Commits = append(to.Commits, convertCommit(commits.Value[i]))
+	}
+	return to, res, nil
+}
+
+func (s *gitService) FindTree(ctx context.Context, repo, ref string, recursive bool) (*scm.Tree, *scm.Response, error) {
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/items/list?view=azure-devops-rest-6.0
+	ro, err := decodeRepo(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	level := "OneLevel"
+	if recursive {
+		level = "Full"
+	}
+	endpoint := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/items?scopePath=/&recursionLevel=%s", ro.org, ro.project, ro.name, level)
+	endpoint += generateURIFromRef(ref)
+	endpoint += "&api-version=6.0"
+	out := new(contentList)
+	res, err := s.client.do(ctx, "GET", endpoint, nil, &out)
+	return convertTree(out.Value), res, err
+}
+
 func (s *gitService) GetDefaultBranch(ctx context.Context, repo string) (*scm.Reference, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
@@ -147,6 +360,16 @@ type gitRefsResult struct {
 }
 type gitRefs []gitRef
 
+// gitRefUpdates is the result of a refs update, which reports the
+// rejected updates per ref rather than with an error status.
+type gitRefUpdates struct {
+	Value []struct {
+		Name         string `json:"name"`
+		UpdateStatus string `json:"updateStatus"`
+		Success      bool   `json:"success"`
+	} `json:"value"`
+}
+
 type branchList struct {
 	Value []*branch `json:"value"`
 	Count int       `json:"count"`
@@ -194,13 +417,15 @@ type gitCommit struct {
 		Edit   int `json:"Edit"`
 		Delete int `json:"Delete"`
 	} `json:"changeCounts"`
-	URL       string `json:"url"`
-	RemoteURL string `json:"remoteUrl"`
+	Parents   []string `json:"parents"`
+	URL       string   `json:"url"`
+	RemoteURL string   `json:"remoteUrl"`
 }
 
 type file struct {
-	ChangeType string `json:"changeType"`
-	Item       struct {
+	ChangeType   string `json:"changeType"`
+	OriginalPath string `json:"originalPath"`
+	Item         struct {
 		CommitID         string `json:"commitId"`
 		GitObjectType    string `json:"gitObjectType"`
 		IsFolder         bool   `json:"isFolder"`
@@ -225,6 +450,31 @@ type compare struct {
 	TargetCommit string  `json:"targetCommit"`
 }
 
+type fileDiffsInput struct {
+	BaseVersionCommit   string            `json:"baseVersionCommit"`
+	TargetVersionCommit string            `json:"targetVersionCommit"`
+	FileDiffParams      []*fileDiffParams `json:"fileDiffParams"`
+}
+
+type fileDiffParams struct {
+	OriginalPath string `json:"originalPath"`
+	Path         string `json:"path"`
+}
+
+type fileDiff struct {
+	OriginalPath   string           `json:"originalPath"`
+	Path           string           `json:"path"`
+	LineDiffBlocks []*lineDiffBlock `json:"lineDiffBlocks"`
+}
+
+type lineDiffBlock struct {
+	ChangeType              string `json:"changeType"`
+	OriginalLineNumberStart int    `json:"originalLineNumberStart"`
+	OriginalLinesCount      int    `json:"originalLinesCount"`
+	ModifiedLineNumberStart int    `json:"modifiedLineNumberStart"`
+	ModifiedLinesCount      int    `json:"modifiedLinesCount"`
+}
+
 func convertBranchList(from []*branch) []*scm.Reference {
 	to := []*scm.Reference{}
 	for _, v := range from {
@@ -279,6 +529,7 @@ func convertCommit(from *gitCommit) *scm.Commit {
 			Email: from.Committer.Email,
 			Date:  from.Committer.Date,
 		},
+		Parents: from.Parents,
 	}
 }
 
@@ -305,3 +556,21 @@ func convertChange(from *file) *scm.Change {
 
 	return returnVal
 }
+
+func convertTree(from []*content) *scm.Tree {
+	to := &scm.Tree{Entries: []*scm.TreeEntry{}}
+	for _, v := range from {
+		// the scope folder itself is included in the listing
+		if v.Path == "/" {
+			to.Sha = v.ObjectID
+			continue
+		}
+		to.Entries = append(to.Entries, &scm.TreeEntry{
+			Path: strings.TrimPrefix(v.Path, "/"),
+			Type: v.GitObjectType,
+			Sha:  v.ObjectID,
+			Link: v.URL,
+		})
+	}
+	return to
+}
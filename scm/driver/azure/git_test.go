@@ -44,6 +44,34 @@ func TestGitFindCommit(t *testing.T) {
 	}
 }
 
+func TestGitFindTree(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/PROJ/_apis/git/repositories/REPOID/items").
+		MatchParam("recursionLevel", "Full").
+		MatchParam("versionDescriptor.version", "main").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tree.json")
+
+	client := NewDefault()
+	got, _, err := client.Git.FindTree(context.Background(), "ORG/PROJ/REPOID", "main", true)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Tree)
+	raw, _ := os.ReadFile("testdata/tree.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestGitCreateRef(t *testing.T) {
 	defer gock.Off()
 
@@ -159,3 +187,125 @@ func TestGitCompareCommits(t *testing.T) {
 	}
 
 }
+
+func TestGitCompare(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/PROJ/_apis/git/repositories/REPOID/diffs/commits").
+		MatchParam("baseVersion", "9788e5ddf8b387cb79228628f34d8dc18582d606").
+		MatchParam("baseVersionType", "commit").
+		MatchParam("targetVersion", "66df312dad61e84dd896d1e8d14ee3dce53b62f0").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare.json")
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/PROJ/_apis/git/repositories/REPOID/commits").
+		MatchParam("searchCriteria.itemVersion.version", "66df312dad61e84dd896d1e8d14ee3dce53b62f0").
+		MatchParam("searchCriteria.compareVersion.version", "9788e5ddf8b387cb79228628f34d8dc18582d606").
+		Reply(200).
+		Type("application/json").
+		File("testdata/commits.json")
+
+	client := NewDefault()
+	got, _, err := client.Git.Compare(context.Background(), "ORG/PROJ/REPOID", "9788e5ddf8b387cb79228628f34d8dc18582d606", "66df312dad61e84dd896d1e8d14ee3dce53b62f0", &scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := os.ReadFile("testdata/comparison.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+// mockFileDiffs mocks the line blocks and contents of the file
+// changed by testdata/compare.json.
+func mockFileDiffs() {
+	gock.New("https://dev.azure.com/").
+		Post("/ORG/PROJ/_apis/git/repositories/REPOID/filediffs").
+		JSON(map[string]interface{}{
+			"baseVersionCommit":   "9788e5ddf8b387cb79228628f34d8dc18582d606",
+			"targetVersionCommit": "66df312dad61e84dd896d1e8d14ee3dce53b62f0",
+			"fileDiffParams": []map[string]string{
+				{"originalPath": "/testfile", "path": "/testfile"},
+			},
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/file_diffs.json")
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/PROJ/_apis/git/repositories/REPOID/items").
+		MatchParam("path", "/testfile").
+		MatchParam("versionDescriptor.version", "9788e5ddf8b387cb79228628f34d8dc18582d606").
+		Reply(200).
+		Type("application/json").
+		File("testdata/file_base.json")
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/PROJ/_apis/git/repositories/REPOID/items").
+		MatchParam("path", "/testfile").
+		MatchParam("versionDescriptor.version", "66df312dad61e84dd896d1e8d14ee3dce53b62f0").
+		Reply(200).
+		Type("application/json").
+		File("testdata/file_target.json")
+}
+
+// checkFileDiff checks the diff built from testdata/file_diffs.json.
+func checkFileDiff(t *testing.T, got *scm.Diff) {
+	if len(got.Files) != 1 {
+		t.Fatalf("Want 1 file, got %d", len(got.Files))
+	}
+	file := got.Files[0]
+	if file.OldPath != "testfile" || file.NewPath != "testfile" {
+		t.Errorf("Unexpected paths %s and %s", file.OldPath, file.NewPath)
+	}
+	var lines []string
+	for _, h := range file.Hunks {
+		for _, l := range h.Lines {
+			lines = append(lines, string(l.Type)+" "+l.Content)
+		}
+	}
+	want := []string{"deleted line two", "added line 2", "added line four"}
+	if diff := cmp.Diff(want, lines); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if got := file.Hunks[1].Lines[0].NewLine; got != 4 {
+		t.Errorf("Want added line 4, got %d", got)
+	}
+}
+
+func TestGitCompareDiff(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/PROJ/_apis/git/repositories/REPOID/diffs/commits").
+		MatchParam("baseVersion", "master").
+		MatchParam("baseVersionType", "branch").
+		MatchParam("targetVersion", "66df312dad61e84dd896d1e8d14ee3dce53b62f0").
+		MatchParam("targetVersionType", "commit").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare.json")
+
+	mockFileDiffs()
+
+	client := NewDefault()
+	got, _, err := client.Git.CompareDiff(context.Background(), "ORG/PROJ/REPOID", "master", "66df312dad61e84dd896d1e8d14ee3dce53b62f0")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	checkFileDiff(t, got)
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
@@ -6,12 +6,25 @@ package azure
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
 
+// defaultWorkItemTypes are the work item types tried in turn when
+// creating an issue, covering the Basic, Agile, Scrum and CMMI
+// process templates.
+var defaultWorkItemTypes = []string{"Issue", "Bug", "Task"}
+
 type issueService struct {
 	client *wrapper
+
+	// workItemType is the work item type of created issues.
+	workItemType string
 }
 
 func (s *issueService) Search(ctx context.Context, options scm.SearchOptions) ([]*scm.SearchIssue, *scm.Response, error) {
@@ -74,8 +87,44 @@ func (s *issueService) ListComments(ctx context.Context, repo string, index int,
 	return nil, nil, scm.ErrNotSupported
 }
 
+// Create creates a work item in the project of the repository. The
+// work item type set with IssueWorkItemType is used, otherwise the
+// first of the Issue, Bug and Task types the project has.
 func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	ro, err := decodeRepo(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	types := defaultWorkItemTypes
+	if s.workItemType != "" {
+		types = []string{s.workItemType}
+	}
+	var res *scm.Response
+	for _, name := range types {
+		// https://docs.microsoft.com/en-us/rest/api/azure/devops/wit/work-items/create?view=azure-devops-rest-6.0
+		endpoint := fmt.Sprintf("%s/%s/_apis/wit/workitems/$%s?api-version=6.0", ro.org, ro.project, url.PathEscape(name))
+		out := new(workItem)
+		res, err = s.client.do(ctx, "POST", endpoint, convertWorkItemPatch(input), out)
+		// the project process has no work item type of the name.
+		if res != nil && res.Status == http.StatusNotFound {
+			continue
+		}
+		return convertWorkItem(out), res, err
+	}
+	return nil, res, err
+}
+
+// Update updates the work item with the number.
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	ro, err := decodeRepo(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/wit/work-items/update?view=azure-devops-rest-6.0
+	endpoint := fmt.Sprintf("%s/%s/_apis/wit/workitems/%d?api-version=6.0", ro.org, ro.project, number)
+	out := new(workItem)
+	res, err := s.client.do(ctx, "PATCH", endpoint, convertWorkItemPatch(input), out)
+	return convertWorkItem(out), res, err
 }
 
 func (s *issueService) CreateComment(ctx context.Context, repo string, number int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
@@ -97,3 +146,96 @@ func (s *issueService) Lock(ctx context.Context, repo string, number int) (*scm.
 func (s *issueService) Unlock(ctx context.Context, repo string, number int) (*scm.Response, error) {
 	return nil, scm.ErrNotSupported
 }
+
+// jsonPatch is a JSON patch document, sent with the
+// application/json-patch+json content type.
+type jsonPatch []*jsonPatchOperation
+
+type jsonPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+type workItemIdentity struct {
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName"`
+	ImageURL    string `json:"imageUrl"`
+}
+
+type workItem struct {
+	ID     int `json:"id"`
+	Fields struct {
+		Title       string            `json:"System.Title"`
+		Description string            `json:"System.Description"`
+		State       string            `json:"System.State"`
+		Tags        string            `json:"System.Tags"`
+		CreatedBy   workItemIdentity  `json:"System.CreatedBy"`
+		AssignedTo  *workItemIdentity `json:"System.AssignedTo"`
+		CreatedDate time.Time         `json:"System.CreatedDate"`
+		ChangedDate time.Time         `json:"System.ChangedDate"`
+	} `json:"fields"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"_links"`
+}
+
+// convertWorkItemPatch returns the patch setting the work item fields
+// from the input. Work items have a single assignee and no milestone
+// or confidentiality, only the first assignee is used.
+func convertWorkItemPatch(from *scm.IssueInput) jsonPatch {
+	to := jsonPatch{}
+	add := func(field string, value interface{}) {
+		to = append(to, &jsonPatchOperation{Op: "add", Path: "/fields/" + field, Value: value})
+	}
+	if from.Title != "" {
+		add("System.Title", from.Title)
+	}
+	if from.Body != "" {
+		add("System.Description", from.Body)
+	}
+	if len(from.Labels) > 0 {
+		add("System.Tags", strings.Join(from.Labels, "; "))
+	}
+	if len(from.Assignees) > 0 {
+		add("System.AssignedTo", from.Assignees[0])
+	}
+	if from.DueDate != nil {
+		add("Microsoft.VSTS.Scheduling.DueDate", from.DueDate.Format(time.RFC3339))
+	}
+	return to
+}
+
+func convertWorkItem(from *workItem) *scm.Issue {
+	to := &scm.Issue{
+		Number: from.ID,
+		Title:  from.Fields.Title,
+		Body:   from.Fields.Description,
+		Link:   from.Links.HTML.Href,
+		State:  from.Fields.State,
+		Author: scm.User{
+			Login:  from.Fields.CreatedBy.UniqueName,
+			Name:   from.Fields.CreatedBy.DisplayName,
+			Avatar: from.Fields.CreatedBy.ImageURL,
+		},
+		Created: from.Fields.CreatedDate,
+		Updated: from.Fields.ChangedDate,
+	}
+	switch from.Fields.State {
+	case "Done", "Closed", "Resolved", "Removed":
+		to.Closed = true
+	}
+	if from.Fields.Tags != "" {
+		to.Labels = strings.Split(from.Fields.Tags, "; ")
+	}
+	if a := from.Fields.AssignedTo; a != nil {
+		to.Assignees = []scm.User{{
+			Login:  a.UniqueName,
+			Name:   a.DisplayName,
+			Avatar: a.ImageURL,
+		}}
+	}
+	return to
+}
@@ -0,0 +1,127 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestIssueCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Post(`/ORG/PROJ/_apis/wit/workitems/\$Issue`).
+		BodyString(`"path":"/fields/System.Tags","value":"bug; triaged"}.*"path":"/fields/System.AssignedTo","value":"fabrikamfiber4@hotmail.com"}.*"path":"/fields/Microsoft.VSTS.Scheduling.DueDate","value":"2021-03-31T00:00:00Z"}`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/workitem.json")
+
+	due := time.Date(2021, time.March, 31, 0, 0, 0, 0, time.UTC)
+	input := &scm.IssueInput{
+		Title:     "Found a bug",
+		Body:      "I'm having a problem with this.",
+		Labels:    []string{"bug", "triaged"},
+		Assignees: []string{"fabrikamfiber4@hotmail.com"},
+		DueDate:   &due,
+	}
+
+	client := NewDefault()
+	got, _, err := client.Issues.Create(context.Background(), "ORG/PROJ/REPOID", input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Issue)
+	raw, _ := os.ReadFile("testdata/workitem.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueCreateWorkItemTypeFallback(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Post(`/ORG/PROJ/_apis/wit/workitems/\$Issue`).
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"message":"VS402323: Work item type Issue does not exist in project PROJ."}`)
+
+	gock.New("https://dev.azure.com/").
+		Post(`/ORG/PROJ/_apis/wit/workitems/\$Bug`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/workitem.json")
+
+	client := NewDefault()
+	got, _, err := client.Issues.Create(context.Background(), "ORG/PROJ/REPOID", &scm.IssueInput{Title: "Found a bug"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := got.Number, 131489; got != want {
+		t.Errorf("Want work item %d, got %d", want, got)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestIssueCreateWorkItemType(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Post(`/ORG/PROJ/_apis/wit/workitems/\$User Story`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/workitem.json")
+
+	client := NewDefault()
+	IssueWorkItemType("User Story")(client)
+	_, _, err := client.Issues.Create(context.Background(), "ORG/PROJ/REPOID", &scm.IssueInput{Title: "Found a bug"})
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestIssueUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Patch("/ORG/PROJ/_apis/wit/workitems/131489").
+		BodyString(`^\[{"op":"add","path":"/fields/System.Title","value":"Found a bug"}\]`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/workitem.json")
+
+	client := NewDefault()
+	got, _, err := client.Issues.Update(context.Background(), "ORG/PROJ/REPOID", 131489, &scm.IssueInput{Title: "Found a bug"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got.Number != 131489 {
+		t.Errorf("Want work item 131489, got %d", got.Number)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
@@ -7,6 +7,7 @@ package azure
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
@@ -82,15 +83,77 @@ func (s *pullService) List(ctx context.Context, repo string, opts *scm.PullReque
 	// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull-requests/get-pull-request?view=azure-devops-rest-6.0
 	endpoint := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests?api-version=6.0&$skip=%d&$top=%d",
 		ro.org, ro.project, ro.name, skip, top)
+	if opts.Head != "" {
+		endpoint += "&searchCriteria.sourceRefName=" + scm.ExpandRef(opts.Head, "refs/heads")
+	}
+	if opts.Base != "" {
+		endpoint += "&searchCriteria.targetRefName=" + scm.ExpandRef(opts.Base, "refs/heads")
+	}
 	out := new(prList)
 	res, err := s.client.do(ctx, "GET", endpoint, nil, out)
-	return convertPullRequests(out), res, err
+	// the creator and reviewers can only be filtered on by id,
+	// so the user, label and Since filters are emulated.
+	return scm.FilterPullRequests(convertPullRequests(out), opts), res, err
+}
+
+func (s *pullService) ListForCommit(ctx context.Context, repo, sha string) ([]*scm.PullRequest, *scm.Response, error) {
+	ro, err := decodeRepo(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull-request-query/get?view=azure-devops-rest-6.0
+	endpoint := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequestquery?api-version=6.0", ro.org, ro.project, ro.name)
+	in := &prQuery{
+		Queries: []prQueryInput{
+			{Type: "lastMergeCommit", Items: []string{sha}},
+			{Type: "commit", Items: []string{sha}},
+		},
+	}
+	out := new(prQuery)
+	res, err := s.client.do(ctx, "POST", endpoint, in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	prs := []*scm.PullRequest{}
+	seen := map[int]bool{}
+	for _, result := range out.Results {
+		for i := range result[sha] {
+			pr := convertPullRequest(&result[sha][i])
+			if !seen[pr.Number] {
+				seen[pr.Number] = true
+				prs = append(prs, pr)
+			}
+		}
+	}
+	return prs, res, nil
 }
 
 func (s *pullService) ListChanges(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
 
+// FindDiff returns the diff of the last merged source commit of the
+// pull request against its merge base with the target commit.
+func (s *pullService) FindDiff(ctx context.Context, repo string, number int) (*scm.Diff, *scm.Response, error) {
+	ro, err := decodeRepo(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/pull-requests/get-pull-request?view=azure-devops-rest-6.0
+	endpoint := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d?api-version=6.0",
+		ro.org, ro.project, ro.name, number)
+	out := new(pr)
+	res, err := s.client.do(ctx, "GET", endpoint, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if out.LastMergeSourceCommit == nil || out.LastMergeTargetCommit == nil {
+		return nil, res, fmt.Errorf("pull request %d has no source or target commit", number)
+	}
+	return compareDiff(ctx, s.client, ro, out.LastMergeTargetCommit.CommitID, out.LastMergeSourceCommit.CommitID)
+}
+
 func (s *pullService) ListCommits(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Commit, *scm.Response, error) {
 	ro, err := decodeRepo(repo)
 	if err != nil {
@@ -163,6 +226,7 @@ func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRe
 		Description:   input.Body,
 		SourceRefName: scm.ExpandRef(input.Head, "refs/heads"),
 		TargetRefName: scm.ExpandRef(input.Base, "refs/heads"),
+		IsDraft:       input.Draft,
 	}
 	out := new(pr)
 	res, err := s.client.do(ctx, "POST", endpoint, in, out)
@@ -173,16 +237,225 @@ func (s *pullService) DeletePullRequest(ctx context.Context, repo string, prID i
 	return nil, scm.ErrNotSupported
 }
 
+func (s *pullService) MarkReady(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(ctx, repo, number, false)
+}
+
+func (s *pullService) ConvertToDraft(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(ctx, repo, number, true)
+}
+
+// EnableAutoMerge sets the pull request to auto-complete once its
+// policies pass. Auto-complete is set on behalf of the authenticated
+// user.
+func (s *pullService) EnableAutoMerge(ctx context.Context, repo string, number int, opts *scm.AutoMergeOptions) (*scm.Response, error) {
+	ro, err := decodeRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// https://learn.microsoft.com/en-us/rest/api/azure/devops/core/?view=azure-devops-rest-6.0
+	out := new(connectionData)
+	res, err := s.client.do(ctx, "GET", fmt.Sprintf("%s/_apis/connectionData", ro.org), nil, out)
+	if err != nil {
+		return res, err
+	}
+
+	// https://learn.microsoft.com/en-us/rest/api/azure/devops/git/pull-requests/update?view=azure-devops-rest-6.0
+	endpoint := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d?api-version=6.0",
+		ro.org, ro.project, ro.name, number)
+	in := &prAutoCompleteInput{
+		AutoCompleteSetBy: identityRef{ID: out.AuthenticatedUser.ID},
+	}
+	if opts != nil {
+		in.CompletionOptions = &completionOptions{
+			MergeStrategy:      convertMergeStrategy(opts.MergeMethod),
+			MergeCommitMessage: strings.TrimSpace(opts.CommitTitle + "\n\n" + opts.CommitMessage),
+			DeleteSourceBranch: opts.DeleteSourceBranch,
+		}
+	}
+	return s.client.do(ctx, "PATCH", endpoint, in, nil)
+}
+
+// DisableAutoMerge cancels auto-complete by clearing the identity
+// that set it.
+func (s *pullService) DisableAutoMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	ro, err := decodeRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// https://learn.microsoft.com/en-us/rest/api/azure/devops/git/pull-requests/update?view=azure-devops-rest-6.0
+	endpoint := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d?api-version=6.0",
+		ro.org, ro.project, ro.name, number)
+	in := &prAutoCompleteInput{
+		AutoCompleteSetBy: identityRef{ID: emptyIdentity},
+	}
+	return s.client.do(ctx, "PATCH", endpoint, in, nil)
+}
+
+func (s *pullService) EnqueueMerge(ctx context.Context, repo string, number int, opts *scm.AutoMergeOptions) (*scm.MergeQueueEntry, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *pullService) DequeueMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) FindMergeQueueEntry(ctx context.Context, repo string, number int) (*scm.MergeQueueEntry, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+// mergePollInterval is the delay between polls of an asynchronous merge
+// operation.
+var mergePollInterval = time.Second
+
+// UpdateBranch merges the target branch into the pull request source
+// branch and returns the new head sha. Azure only supports the merge
+// method.
+func (s *pullService) UpdateBranch(ctx context.Context, repo string, number int, method string) (string, *scm.Response, error) {
+	if method != "" && method != scm.UpdateBranchMerge {
+		return "", nil, scm.ErrNotSupported
+	}
+	ro, err := decodeRepo(repo)
+	if err != nil {
+		return "", nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d?api-version=6.0",
+		ro.org, ro.project, ro.name, number)
+	out := new(pr)
+	res, err := s.client.do(ctx, "GET", endpoint, nil, out)
+	if err != nil {
+		return "", res, err
+	}
+	if out.LastMergeSourceCommit == nil || out.LastMergeTargetCommit == nil {
+		return "", res, fmt.Errorf("pull request %d has no source or target commit", number)
+	}
+	head := out.LastMergeSourceCommit.CommitID
+
+	// https://learn.microsoft.com/en-us/rest/api/azure/devops/git/merges/create?view=azure-devops-rest-6.0
+	endpoint = fmt.Sprintf("%s/%s/_apis/git/repositories/%s/merges?api-version=6.0-preview.1",
+		ro.org, ro.project, ro.name)
+	in := &mergeInput{
+		Parents: []string{head, out.LastMergeTargetCommit.CommitID},
+		Comment: fmt.Sprintf("Merge %s into %s",
+			scm.TrimRef(out.TargetRefName), scm.TrimRef(out.SourceRefName)),
+	}
+	merge := new(mergeOperation)
+	res, err = s.client.do(ctx, "POST", endpoint, in, merge)
+	if err != nil {
+		return "", res, err
+	}
+
+	// https://learn.microsoft.com/en-us/rest/api/azure/devops/git/merges/get?view=azure-devops-rest-6.0
+	endpoint = fmt.Sprintf("%s/%s/_apis/git/repositories/%s/merges/%d?api-version=6.0-preview.1",
+		ro.org, ro.project, ro.name, merge.MergeOperationID)
+	for merge.Status == "queued" || merge.Status == "inProgress" || merge.Status == "notSet" {
+		select {
+		case <-ctx.Done():
+			return "", res, ctx.Err()
+		case <-time.After(mergePollInterval):
+		}
+		res, err = s.client.do(ctx, "GET", endpoint, nil, merge)
+		if err != nil {
+			return "", res, err
+		}
+	}
+	switch merge.Status {
+	case "completed":
+	case "conflicts":
+		return "", res, scm.MergeConflict{Number: number}
+	default:
+		return "", res, fmt.Errorf("merge operation %d failed: %s", merge.MergeOperationID, merge.DetailedStatus.FailureMessage)
+	}
+
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/refs/update-refs?view=azure-devops-rest-6.0
+	endpoint = fmt.Sprintf("%s/%s/_apis/git/repositories/%s/refs?api-version=6.0", ro.org, ro.project, ro.name)
+	refs := gitRefs{{
+		Name:        out.SourceRefName,
+		OldObjectID: head,
+		NewObjectID: merge.DetailedStatus.MergeCommitID,
+	}}
+	updates := new(gitRefUpdates)
+	res, err = s.client.do(ctx, "POST", endpoint, refs, updates)
+	if err != nil {
+		return "", res, err
+	}
+	for _, update := range updates.Value {
+		if !update.Success {
+			return "", res, fmt.Errorf("updating %s was rejected: %s", update.Name, update.UpdateStatus)
+		}
+	}
+	return merge.DetailedStatus.MergeCommitID, res, nil
+}
+
+func (s *pullService) setDraft(ctx context.Context, repo string, number int, draft bool) (*scm.Response, error) {
+	ro, err := decodeRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	// https://learn.microsoft.com/en-us/rest/api/azure/devops/git/pull-requests/update?view=azure-devops-rest-6.0
+	endpoint := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/pullrequests/%d?api-version=6.0",
+		ro.org, ro.project, ro.name, number)
+	in := &prDraftInput{
+		IsDraft: draft,
+	}
+	return s.client.do(ctx, "PATCH", endpoint, in, nil)
+}
+
 type prInput struct {
 	SourceRefName string `json:"sourceRefName"`
 	TargetRefName string `json:"targetRefName"`
 	Title         string `json:"title"`
 	Description   string `json:"description"`
+	IsDraft       bool   `json:"isDraft,omitempty"`
 	Reviewers     []struct {
 		ID string `json:"id"`
 	} `json:"reviewers"`
 }
 
+// emptyIdentity clears the identity which set auto-complete.
+const emptyIdentity = "00000000-0000-0000-0000-000000000000"
+
+type identityRef struct {
+	ID string `json:"id"`
+}
+
+type connectionData struct {
+	AuthenticatedUser identityRef `json:"authenticatedUser"`
+}
+
+type completionOptions struct {
+	MergeStrategy      string `json:"mergeStrategy,omitempty"`
+	MergeCommitMessage string `json:"mergeCommitMessage,omitempty"`
+	DeleteSourceBranch bool   `json:"deleteSourceBranch,omitempty"`
+}
+
+type prAutoCompleteInput struct {
+	AutoCompleteSetBy identityRef        `json:"autoCompleteSetBy"`
+	CompletionOptions *completionOptions `json:"completionOptions,omitempty"`
+}
+
+type mergeInput struct {
+	Parents []string `json:"parents"`
+	Comment string   `json:"comment,omitempty"`
+}
+
+type mergeOperation struct {
+	MergeOperationID int    `json:"mergeOperationId"`
+	Status           string `json:"status"`
+	DetailedStatus   struct {
+		MergeCommitID  string `json:"mergeCommitId"`
+		FailureMessage string `json:"failureMessage"`
+	} `json:"detailedStatus"`
+}
+
+type prDraftInput struct {
+	IsDraft bool `json:"isDraft"`
+}
+
 type prUpdate struct {
 	Title         *string `json:"title"`
 	Description   *string `json:"description"`
@@ -219,6 +492,7 @@ type pr struct {
 	ClosedDate            null.String `json:"closedDate"`
 	Title                 string      `json:"title"`
 	Description           string      `json:"description"`
+	IsDraft               bool        `json:"isDraft"`
 	SourceRefName         string      `json:"sourceRefName"`
 	TargetRefName         string      `json:"targetRefName"`
 	MergeStatus           string      `json:"mergeStatus"`
@@ -272,6 +546,16 @@ type prList struct {
 	Values []pr `json:"value"`
 }
 
+type prQuery struct {
+	Queries []prQueryInput    `json:"queries,omitempty"`
+	Results []map[string][]pr `json:"results,omitempty"`
+}
+
+type prQueryInput struct {
+	Type  string   `json:"type"`
+	Items []string `json:"items"`
+}
+
 var (
 	PrAbandoned = "abandoned"
 	PrCompleted = "completed"
@@ -282,6 +566,21 @@ type prUpdateInput struct {
 	LastMergeSourceCommit *commitRef `json:"lastMergeSourceCommit,omitempty"`
 }
 
+// convertMergeStrategy maps the merge method onto the Azure merge
+// strategy.
+func convertMergeStrategy(method string) string {
+	switch method {
+	case "merge":
+		return "noFastForward"
+	case "squash":
+		return "squash"
+	case "rebase":
+		return "rebase"
+	default:
+		return ""
+	}
+}
+
 func convertPullRequests(from *prList) []*scm.PullRequest {
 	var prs []*scm.PullRequest
 	for index := range from.Values {
@@ -317,6 +616,7 @@ func convertPullRequest(from *pr) *scm.PullRequest {
 		Link:   fmt.Sprintf("%s/pullrequest/%d", from.Repository.WebURL, from.PullRequestID),
 		Closed: from.ClosedDate.Valid,
 		Merged: from.Status == "completed",
+		Draft:  from.IsDraft,
 		Ref:    fmt.Sprintf("refs/pull/%d/merge", from.PullRequestID),
 		Head: scm.PullRequestBranch{
 			Sha: headSha,
@@ -330,6 +630,19 @@ func convertPullRequest(from *pr) *scm.PullRequest {
 			Login:  from.CreatedBy.UniqueName,
 			Avatar: from.CreatedBy.ImageURL,
 		},
-		Created: from.CreationDate,
+		Reviewers: convertReviewers(from),
+		Created:   from.CreationDate,
+	}
+}
+
+func convertReviewers(from *pr) []scm.User {
+	var to []scm.User
+	for _, reviewer := range from.Reviewers {
+		to = append(to, scm.User{
+			Login:  reviewer.UniqueName,
+			Name:   reviewer.DisplayName,
+			Avatar: reviewer.ImageURL,
+		})
 	}
+	return to
 }
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 
@@ -49,6 +50,37 @@ func TestPullCreate(t *testing.T) {
 	}
 }
 
+func TestPullFindDiff(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/PROJ/_apis/git/repositories/REPOID/pullrequests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_active.json")
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/PROJ/_apis/git/repositories/REPOID/diffs/commits").
+		MatchParam("baseVersion", "b748ab7eb49b8627214f22f631f878c4af9893b5").
+		MatchParam("targetVersion", "01768d964c03e97260af0bd8cd9e5cd1f9ac6356").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare.json")
+
+	mockFileDiffs()
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.FindDiff(context.Background(), "ORG/PROJ/REPOID", 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	checkFileDiff(t, got)
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
 func TestPullFind(t *testing.T) {
 	defer gock.Off()
 
@@ -146,6 +178,201 @@ func TestClose(t *testing.T) {
 
 }
 
+func TestPullMarkReady(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Patch("/ORG/PROJ/_apis/git/repositories/REPOID/pullrequests/1").
+		JSON(map[string]bool{"isDraft": false}).
+		Reply(200)
+
+	client := NewDefault()
+	_, err := client.PullRequests.MarkReady(context.Background(), "ORG/PROJ/REPOID", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPullEnableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/_apis/connectionData").
+		Reply(200).
+		Type("application/json").
+		File("testdata/connection_data.json")
+
+	gock.New("https://dev.azure.com/").
+		Patch("/ORG/PROJ/_apis/git/repositories/REPOID/pullrequests/1").
+		JSON(map[string]interface{}{
+			"autoCompleteSetBy": map[string]string{"id": "54d125f7-69f7-4191-904f-c5b96b6261c8"},
+			"completionOptions": map[string]interface{}{
+				"mergeStrategy":      "squash",
+				"mergeCommitMessage": "Merged PR 1: my first pull request",
+				"deleteSourceBranch": true,
+			},
+		}).
+		Reply(200)
+
+	client := NewDefault()
+	_, err := client.PullRequests.EnableAutoMerge(context.Background(), "ORG/PROJ/REPOID", 1, &scm.AutoMergeOptions{
+		MergeMethod:        "squash",
+		CommitTitle:        "Merged PR 1: my first pull request",
+		DeleteSourceBranch: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPullDisableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Patch("/ORG/PROJ/_apis/git/repositories/REPOID/pullrequests/1").
+		JSON(map[string]interface{}{
+			"autoCompleteSetBy": map[string]string{"id": "00000000-0000-0000-0000-000000000000"},
+		}).
+		Reply(200)
+
+	client := NewDefault()
+	_, err := client.PullRequests.DisableAutoMerge(context.Background(), "ORG/PROJ/REPOID", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPullUpdateBranch(t *testing.T) {
+	defer gock.Off()
+
+	before := mergePollInterval
+	mergePollInterval = 0
+	defer func() { mergePollInterval = before }()
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/PROJ/_apis/git/repositories/REPOID/pullrequests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_active.json")
+
+	gock.New("https://dev.azure.com/").
+		Post("/ORG/PROJ/_apis/git/repositories/REPOID/merges").
+		JSON(map[string]interface{}{
+			"parents": []string{
+				"01768d964c03e97260af0bd8cd9e5cd1f9ac6356",
+				"b748ab7eb49b8627214f22f631f878c4af9893b5",
+			},
+			"comment": "Merge main into pr_branch",
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/merge_queued.json")
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/PROJ/_apis/git/repositories/REPOID/merges/42").
+		Reply(200).
+		Type("application/json").
+		File("testdata/merge_completed.json")
+
+	gock.New("https://dev.azure.com/").
+		Post("/ORG/PROJ/_apis/git/repositories/REPOID/refs").
+		JSON([]map[string]string{{
+			"name":        "refs/heads/pr_branch",
+			"oldObjectId": "01768d964c03e97260af0bd8cd9e5cd1f9ac6356",
+			"newObjectId": "c2a9f5b0e6d74e2a8f3b1d0c9e8f7a6b5c4d3e2f",
+		}}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/branch_create.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.UpdateBranch(context.Background(), "ORG/PROJ/REPOID", 1, scm.UpdateBranchMerge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "c2a9f5b0e6d74e2a8f3b1d0c9e8f7a6b5c4d3e2f"; got != want {
+		t.Errorf("Want head sha %q, got %q", want, got)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestPullUpdateBranchRejected(t *testing.T) {
+	defer gock.Off()
+
+	before := mergePollInterval
+	mergePollInterval = 0
+	defer func() { mergePollInterval = before }()
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/PROJ/_apis/git/repositories/REPOID/pullrequests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_active.json")
+
+	gock.New("https://dev.azure.com/").
+		Post("/ORG/PROJ/_apis/git/repositories/REPOID/merges").
+		Reply(201).
+		Type("application/json").
+		File("testdata/merge_queued.json")
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/PROJ/_apis/git/repositories/REPOID/merges/42").
+		Reply(200).
+		Type("application/json").
+		File("testdata/merge_completed.json")
+
+	gock.New("https://dev.azure.com/").
+		Post("/ORG/PROJ/_apis/git/repositories/REPOID/refs").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"value":[{"name":"refs/heads/pr_branch","updateStatus":"staleOldObjectId","success":false}],"count":1}`)
+
+	client := NewDefault()
+	_, _, err := client.PullRequests.UpdateBranch(context.Background(), "ORG/PROJ/REPOID", 1, scm.UpdateBranchMerge)
+	if err == nil {
+		t.Error("Want an error for a rejected ref update")
+	}
+}
+
+func TestPullUpdateBranchConflict(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/PROJ/_apis/git/repositories/REPOID/pullrequests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_active.json")
+
+	gock.New("https://dev.azure.com/").
+		Post("/ORG/PROJ/_apis/git/repositories/REPOID/merges").
+		Reply(201).
+		Type("application/json").
+		File("testdata/merge_conflicts.json")
+
+	client := NewDefault()
+	_, _, err := client.PullRequests.UpdateBranch(context.Background(), "ORG/PROJ/REPOID", 1, scm.UpdateBranchMerge)
+	if !scm.IsMergeConflict(err) {
+		t.Errorf("Expected merge conflict error, got %v", err)
+	}
+}
+
+func TestPullConvertToDraft(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Patch("/ORG/PROJ/_apis/git/repositories/REPOID/pullrequests/1").
+		JSON(map[string]bool{"isDraft": true}).
+		Reply(200)
+
+	client := NewDefault()
+	_, err := client.PullRequests.ConvertToDraft(context.Background(), "ORG/PROJ/REPOID", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
 func TestPullListCommits(t *testing.T) {
 	defer gock.Off()
 
@@ -171,3 +398,86 @@ func TestPullListCommits(t *testing.T) {
 		t.Log(diff)
 	}
 }
+
+func TestPullList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/PROJ/_apis/git/repositories/REPOID/pullrequests").
+		MatchParam("searchCriteria.sourceRefName", "refs/heads/pr_branch").
+		MatchParam("searchCriteria.targetRefName", "refs/heads/main").
+		Times(4).
+		Reply(200).
+		Type("application/json").
+		File("testdata/prs.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.List(context.Background(), "ORG/PROJ/REPOID", &scm.PullRequestListOptions{Page: 1, Size: 10, Head: "pr_branch", Base: "main"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.PullRequest)
+	raw, _ := os.ReadFile("testdata/pr_active.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff([]*scm.PullRequest{want}, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	got, _, err = client.PullRequests.List(context.Background(), "ORG/PROJ/REPOID", &scm.PullRequestListOptions{Page: 1, Size: 10, Head: "pr_branch", Base: "main", Author: "someone@example.com"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(got) != 0 {
+		t.Errorf("Want pull requests filtered by author, got %d", len(got))
+	}
+
+	got, _, err = client.PullRequests.List(context.Background(), "ORG/PROJ/REPOID", &scm.PullRequestListOptions{Page: 1, Size: 10, Head: "pr_branch", Base: "main", Reviewer: "someone@example.com"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(got) != 0 {
+		t.Errorf("Want pull requests filtered by reviewer, got %d", len(got))
+	}
+
+	since := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	got, _, err = client.PullRequests.List(context.Background(), "ORG/PROJ/REPOID", &scm.PullRequestListOptions{Page: 1, Size: 10, Head: "pr_branch", Base: "main", UpdatedAfter: &since})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(got) != 0 {
+		t.Errorf("Want pull requests filtered by update time, got %d", len(got))
+	}
+}
+
+func TestPullListForCommit(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Post("/ORG/PROJ/_apis/git/repositories/REPOID/pullrequestquery").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_query.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.ListForCommit(context.Background(), "ORG/PROJ/REPOID", "01768d964c03e97260af0bd8cd9e5cd1f9ac6356")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.PullRequest)
+	raw, _ := os.ReadFile("testdata/pr_active.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff([]*scm.PullRequest{want}, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
@@ -0,0 +1,27 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package azure
+
+import (
+	"context"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type reactionService struct {
+	client *wrapper
+}
+
+func (s *reactionService) List(ctx context.Context, repo string, target scm.ReactionTarget, opts *scm.ListOptions) ([]*scm.Reaction, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *reactionService) Create(ctx context.Context, repo string, target scm.ReactionTarget, content scm.ReactionContent) (*scm.Reaction, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *reactionService) Delete(ctx context.Context, repo string, target scm.ReactionTarget, content scm.ReactionContent) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
@@ -7,6 +7,7 @@ package azure
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 	"strings"
 
@@ -123,6 +124,24 @@ func (s *RepositoryService) Delete(ctx context.Context, repo string) (*scm.Respo
 	return res, err
 }
 
+// Archive downloads the repository contents as a zip archive, the only
+// archive format supported by Azure DevOps.
+func (s *RepositoryService) Archive(ctx context.Context, repo, ref string, format scm.ArchiveFormat) (io.ReadCloser, *scm.Response, error) {
+	// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/items/get?view=azure-devops-rest-6.0
+	if format != scm.ArchiveFormatZip {
+		return nil, nil, scm.ErrNotSupported
+	}
+	ro, err := decodeRepo(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/items?path=/&$format=zip&download=true", ro.org, ro.project, ro.name)
+	endpoint += generateURIFromRef(ref)
+	endpoint += "&api-version=6.0"
+	return s.client.stream(ctx, "GET", endpoint)
+}
+
 // Find returns the repository by name.
 func (s *RepositoryService) Find(ctx context.Context, repo string) (*scm.Repository, *scm.Response, error) {
 	// https://docs.microsoft.com/en-us/rest/api/azure/devops/git/repositories/get?view=azure-devops-rest-4.1
@@ -194,6 +213,26 @@ func (s *RepositoryService) DeleteHook(ctx context.Context, repo, id string) (*s
 	return nil, scm.ErrNotSupported
 }
 
+// ListHookDeliveries returns the recent deliveries of a repository webhook.
+func (s *RepositoryService) ListHookDeliveries(ctx context.Context, repo, id string, opts *scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+// FindHookDelivery returns a repository webhook delivery.
+func (s *RepositoryService) FindHookDelivery(ctx context.Context, repo, id, delivery string) (*scm.HookDelivery, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+// RedeliverHook sends a repository webhook delivery again.
+func (s *RepositoryService) RedeliverHook(ctx context.Context, repo, id, delivery string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+// TestHook triggers a test delivery of a repository webhook.
+func (s *RepositoryService) TestHook(ctx context.Context, repo, id string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
 type project struct {
 	ID string `json:"id"`
 }
@@ -7,6 +7,7 @@ package azure
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"os"
 	"testing"
 
@@ -103,6 +104,40 @@ func TestRepositoryFind(t *testing.T) {
 	}
 }
 
+func TestRepositoryArchive(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://dev.azure.com/").
+		Get("/ORG/PROJ/_apis/git/repositories/REPOID/items").
+		MatchParam("$format", "zip").
+		MatchParam("download", "true").
+		Reply(200).
+		Type("application/zip").
+		BodyString("archive")
+
+	client := NewDefault()
+	rc, _, err := client.Repositories.Archive(context.Background(), "ORG/PROJ/REPOID", "main", scm.ArchiveFormatZip)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := string(data), "archive"; got != want {
+		t.Errorf("Want archive contents %q, got %q", want, got)
+	}
+
+	_, _, err = client.Repositories.Archive(context.Background(), "ORG/PROJ/REPOID", "main", scm.ArchiveFormatTarball)
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error for tarball archives")
+	}
+}
+
 func TestRepositoryCreate(t *testing.T) {
 	defer gock.Off()
 
@@ -45,3 +45,19 @@ func (s *reviewService) Create(ctx context.Context, repo string, number int, inp
 func (s *reviewService) Delete(ctx context.Context, repo string, number, id int) (*scm.Response, error) {
 	return nil, scm.ErrNotSupported
 }
+
+func (s *reviewService) CreateComment(ctx context.Context, repo string, number int, input *scm.ReviewCommentInput) (*scm.ReviewComment, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *reviewService) ListThreads(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.ReviewThread, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *reviewService) ResolveThread(ctx context.Context, repo string, number int, id string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *reviewService) UnresolveThread(ctx context.Context, repo string, number int, id string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
@@ -0,0 +1,31 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package azure
+
+import (
+	"context"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type searchService struct {
+	client *wrapper
+}
+
+func (s *searchService) Code(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchCode, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *searchService) Repositories(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *searchService) Issues(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchIssue, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *searchService) Commits(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchCommit, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
@@ -9,23 +9,87 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
+	"github.com/jenkins-x/go-scm/pkg/hmac"
 	"github.com/jenkins-x/go-scm/scm"
 	"github.com/jenkins-x/go-scm/scm/driver/internal/null"
 )
 
 type webhookService struct {
 	client *wrapper
+
+	// validateSecret enables validation of the secret the
+	// service hook was configured with.
+	validateSecret bool
 }
 
 func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
+	return s.ParseWithSecrets(req, fn.Secrets())
+}
+
+func (s *webhookService) ParseWithSecrets(req *http.Request, fn scm.SecretsFunc) (scm.Webhook, error) {
 	data, err := io.ReadAll(
 		io.LimitReader(req.Body, 10000000),
 	)
 	if err != nil {
 		return nil, err
 	}
+
+	hook, err := s.parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	// azure devops does not sign the payload. Get the secret
+	// used to verify the credentials the service hook was
+	// configured with. The secret is only validated when
+	// enabled, and if no key is provided, no validation is
+	// performed.
+	if !s.validateSecret {
+		return hook, nil
+	}
+	keys, err := fn(hook)
+	if err != nil {
+		return hook, err
+	}
+	keys = hmac.Keys(keys)
+	if len(keys) == 0 {
+		return hook, nil
+	}
+
+	if !validateSecret(req, keys) {
+		return hook, scm.ErrSignatureInvalid
+	}
+	return hook, nil
+}
+
+// validateSecret checks the request carries one of the secret
+// keys. The service hook may be configured to send the secret
+// as the basic authentication password (or as the full
+// username:password pair), as a custom http header where the
+// key is in the form "Header-Name: value", or as the secret
+// query parameter of the url.
+func validateSecret(req *http.Request, keys []string) bool {
+	if username, password, ok := req.BasicAuth(); ok {
+		return hmac.ValidateToken(password, keys) ||
+			hmac.ValidateToken(username+":"+password, keys)
+	}
+	for _, key := range keys {
+		name, value, ok := strings.Cut(key, ":")
+		if !ok || strings.TrimSpace(name) == "" {
+			continue
+		}
+		header := req.Header.Get(strings.TrimSpace(name))
+		if hmac.ValidateToken(header, []string{strings.TrimSpace(value)}) {
+			return true
+		}
+	}
+	return hmac.ValidateToken(req.FormValue("secret"), keys)
+}
+
+func (s *webhookService) parse(data []byte) (scm.Webhook, error) {
 	// we need to read the json data then look at the eventType
 	var unstructuredJSON map[string]interface{}
 	jsonErr := json.Unmarshal(data, &unstructuredJSON)
@@ -62,7 +126,7 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 			return nil, err
 		}
 		dst := convertUpdatePullRequestHook(src)
-		dst.Action = scm.ActionUpdate
+		dst.Action = getUpdatePullRequestAction(src)
 		return dst, nil
 	case "git.pullrequest.merged":
 		// https://docs.microsoft.com/en-us/azure/devops/service-hooks/events?view=azure-devops#git.pullrequest.merged
@@ -88,6 +152,19 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	}
 }
 
+// getUpdatePullRequestAction returns the update action. A draft being
+// published or converted is only described by the event message.
+func getUpdatePullRequestAction(src *updatePullRequestHook) scm.Action {
+	text := strings.ToLower(src.Message.Text)
+	switch {
+	case strings.Contains(text, "published the pull request"):
+		return scm.ActionReadyForReview
+	case src.Resource.IsDraft && strings.Contains(text, "draft"):
+		return scm.ActionConvertedToDraft
+	}
+	return scm.ActionUpdate
+}
+
 func getIssueCommentAction(src *issueCommentPullRequestHook) scm.Action {
 	if src.Resource.Comment.IsDeleted {
 		return scm.ActionDelete
@@ -172,6 +249,7 @@ func convertCreatePullRequestHook(src *createPullRequestHook) (returnVal *scm.Pu
 			Link:   src.Resource.URL,
 			Closed: false,
 			Merged: false,
+			Draft:  src.Resource.IsDraft,
 			Author: scm.User{
 				Login:  src.Resource.CreatedBy.DisplayName,
 				Name:   src.Resource.CreatedBy.DisplayName,
@@ -211,6 +289,7 @@ func convertUpdatePullRequestHook(src *updatePullRequestHook) (returnVal *scm.Pu
 			Link:   src.Resource.URL,
 			Closed: src.Resource.ClosedDate.Valid,
 			Merged: false,
+			Draft:  src.Resource.IsDraft,
 			Author: scm.User{
 				Login:  src.Resource.CreatedBy.DisplayName,
 				Name:   src.Resource.CreatedBy.DisplayName,
@@ -457,6 +536,7 @@ type createPullRequestHook struct {
 		CreationDate          time.Time `json:"creationDate"`
 		Title                 string    `json:"title"`
 		Description           string    `json:"description"`
+		IsDraft               bool      `json:"isDraft"`
 		SourceRefName         string    `json:"sourceRefName"`
 		TargetRefName         string    `json:"targetRefName"`
 		MergeStatus           string    `json:"mergeStatus"`
@@ -530,6 +610,7 @@ type updatePullRequestHook struct {
 		} `json:"createdBy"`
 		CreationDate    time.Time `json:"creationDate"`
 		Description     string    `json:"description"`
+		IsDraft         bool      `json:"isDraft"`
 		LastMergeCommit struct {
 			CommitID string `json:"commitId"`
 			URL      string `json:"url"`
@@ -0,0 +1,259 @@
+package azure
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/hookenc"
+)
+
+// Encode returns the Azure DevOps service hook request for the
+// hook. Azure DevOps does not sign the payload, so the secret
+// is sent as the basic authentication password, which is how
+// service hooks are usually configured.
+func (s *webhookService) Encode(hook scm.Webhook, secret string) (*http.Request, error) {
+	var (
+		event    string
+		message  string
+		resource interface{}
+	)
+	switch v := hook.(type) {
+	case *scm.PushHook:
+		event, resource = "git.push", encodePushResource(v)
+	case *scm.PullRequestHook:
+		event, message = encodePullRequestEvent(v)
+		resource = encodePullRequestResource(&v.PullRequest, &v.Repo, &v.Sender)
+	case *scm.IssueCommentHook:
+		if v.Issue.PullRequest == nil {
+			return nil, scm.ErrNotSupported
+		}
+		event, resource = "ms.vss-code.git-pullrequest-comment-event", encodeCommentResource(v)
+	default:
+		return nil, scm.ErrNotSupported
+	}
+
+	payload := &encodedEvent{
+		ID:          hookenc.DeliveryID(""),
+		EventType:   event,
+		PublisherID: "tfs",
+		Scope:       "all",
+		Resource:    resource,
+	}
+	payload.Message.Text = message
+	req, _, err := hookenc.NewRequest(payload)
+	if err != nil {
+		return nil, err
+	}
+	if secret != "" {
+		req.SetBasicAuth("", secret)
+	}
+	return req, nil
+}
+
+func encodePullRequestEvent(src *scm.PullRequestHook) (event, message string) {
+	switch src.Action {
+	case scm.ActionOpen, scm.ActionCreate:
+		return "git.pullrequest.created", "created a pull request"
+	case scm.ActionMerge:
+		return "git.pullrequest.merged", "merged the pull request"
+	case scm.ActionReadyForReview:
+		return "git.pullrequest.updated", "published the pull request"
+	case scm.ActionConvertedToDraft:
+		return "git.pullrequest.updated", "marked the pull request as a draft"
+	default:
+		return "git.pullrequest.updated", "updated the pull request"
+	}
+}
+
+//
+// encoded data structures
+//
+
+type (
+	encodedEvent struct {
+		ID          string `json:"id"`
+		EventType   string `json:"eventType"`
+		PublisherID string `json:"publisherId"`
+		Scope       string `json:"scope"`
+		Message     struct {
+			Text string `json:"text"`
+		} `json:"message"`
+		Resource interface{} `json:"resource"`
+	}
+
+	encodedIdentity struct {
+		ID          string `json:"id"`
+		DisplayName string `json:"displayName"`
+		UniqueName  string `json:"uniqueName"`
+		ImageURL    string `json:"imageUrl,omitempty"`
+	}
+
+	encodedRepository struct {
+		ID            string `json:"id"`
+		Name          string `json:"name"`
+		DefaultBranch string `json:"defaultBranch,omitempty"`
+		Project       struct {
+			Name string `json:"name"`
+		} `json:"project"`
+		RemoteURL string `json:"remoteUrl,omitempty"`
+		WebURL    string `json:"webUrl,omitempty"`
+		SSHURL    string `json:"sshUrl,omitempty"`
+	}
+
+	encodedCommitRef struct {
+		CommitID string `json:"commitId"`
+	}
+
+	encodedPushResource struct {
+		Commits []struct {
+			Comment string `json:"comment"`
+		} `json:"commits"`
+		PushedBy   encodedIdentity `json:"pushedBy"`
+		RefUpdates []struct {
+			Name        string `json:"name"`
+			OldObjectID string `json:"oldObjectId"`
+			NewObjectID string `json:"newObjectId"`
+		} `json:"refUpdates"`
+		Repository encodedRepository `json:"repository"`
+	}
+
+	encodedPullRequestResource struct {
+		PullRequestID         int               `json:"pullRequestId"`
+		Status                string            `json:"status"`
+		CreatedBy             encodedIdentity   `json:"createdBy"`
+		CreationDate          time.Time         `json:"creationDate"`
+		ClosedDate            *time.Time        `json:"closedDate,omitempty"`
+		Title                 string            `json:"title"`
+		Description           string            `json:"description"`
+		IsDraft               bool              `json:"isDraft"`
+		SourceRefName         string            `json:"sourceRefName"`
+		TargetRefName         string            `json:"targetRefName"`
+		LastMergeSourceCommit encodedCommitRef  `json:"lastMergeSourceCommit"`
+		Repository            encodedRepository `json:"repository"`
+		URL                   string            `json:"url"`
+	}
+
+	encodedCommentResource struct {
+		PullRequest encodedPullRequestResource `json:"pullRequest"`
+		Comment     struct {
+			ID              int             `json:"id"`
+			Content         string          `json:"content"`
+			PublishedDate   time.Time       `json:"publishedDate"`
+			LastUpdatedDate time.Time       `json:"lastUpdatedDate"`
+			CommentType     string          `json:"commentType"`
+			IsDeleted       bool            `json:"isDeleted"`
+			Author          encodedIdentity `json:"author"`
+		} `json:"comment"`
+	}
+)
+
+//
+// native data structure encoding
+//
+
+func encodePushResource(src *scm.PushHook) *encodedPushResource {
+	dst := &encodedPushResource{
+		PushedBy: encodedIdentity{
+			ID:          src.Sender.Login,
+			DisplayName: src.Sender.Name,
+			UniqueName:  src.Sender.Email,
+			ImageURL:    src.Sender.Avatar,
+		},
+		Repository: encodedRepository{
+			ID:        src.Repo.ID,
+			Name:      src.Repo.Name,
+			RemoteURL: src.Repo.Clone,
+		},
+	}
+	if src.Repo.Branch != "" {
+		dst.Repository.DefaultBranch = scm.ExpandRef(src.Repo.Branch, "refs/heads/")
+	}
+	dst.Repository.Project.Name = src.Repo.Namespace
+	for _, c := range src.Commits {
+		dst.Commits = append(dst.Commits, struct {
+			Comment string `json:"comment"`
+		}{Comment: c.Message})
+	}
+	after := src.After
+	if after == "" {
+		after = src.Commit.Sha
+	}
+	dst.RefUpdates = append(dst.RefUpdates, struct {
+		Name        string `json:"name"`
+		OldObjectID string `json:"oldObjectId"`
+		NewObjectID string `json:"newObjectId"`
+	}{Name: src.Ref, OldObjectID: src.Before, NewObjectID: after})
+	return dst
+}
+
+func encodePullRequestResource(src *scm.PullRequest, repo *scm.Repository, sender *scm.User) *encodedPullRequestResource {
+	dst := &encodedPullRequestResource{
+		PullRequestID: src.Number,
+		Status:        "active",
+		CreatedBy: encodedIdentity{
+			ID:          sender.Login,
+			DisplayName: src.Author.Name,
+			UniqueName:  src.Author.Email,
+			ImageURL:    src.Author.Avatar,
+		},
+		CreationDate:  src.Created,
+		Title:         src.Title,
+		Description:   src.Body,
+		IsDraft:       src.Draft,
+		SourceRefName: src.Ref,
+		TargetRefName: scm.ExpandRef(src.Target, "refs/heads/"),
+		URL:           src.Link,
+		Repository: encodedRepository{
+			ID:     repo.ID,
+			Name:   repo.Name,
+			WebURL: repo.Link,
+			SSHURL: repo.CloneSSH,
+		},
+	}
+	if dst.SourceRefName == "" {
+		dst.SourceRefName = scm.ExpandRef(src.Source, "refs/heads/")
+	}
+	dst.Repository.Project.Name = repo.Namespace
+	dst.LastMergeSourceCommit.CommitID = src.Sha
+	switch {
+	case src.Merged:
+		dst.Status = "completed"
+	case src.Closed:
+		dst.Status = "abandoned"
+		closed := src.Updated
+		if closed.IsZero() {
+			closed = src.Created
+		}
+		dst.ClosedDate = &closed
+	}
+	return dst
+}
+
+func encodeCommentResource(src *scm.IssueCommentHook) *encodedCommentResource {
+	dst := &encodedCommentResource{
+		PullRequest: *encodePullRequestResource(src.Issue.PullRequest, &src.Repo, &src.Sender),
+	}
+	// the pull request author and creator are not described by
+	// the comment sender.
+	dst.PullRequest.CreatedBy.ID = src.Issue.Author.Login
+	dst.Comment.ID = src.Comment.ID
+	dst.Comment.Content = src.Comment.Body
+	dst.Comment.CommentType = "text"
+	dst.Comment.Author = encodedIdentity{
+		ID:          src.Comment.Author.Login,
+		DisplayName: src.Comment.Author.Name,
+		UniqueName:  src.Comment.Author.Email,
+	}
+	dst.Comment.PublishedDate = src.Comment.Created
+	dst.Comment.LastUpdatedDate = src.Comment.Updated
+	switch src.Action {
+	case scm.ActionDelete:
+		dst.Comment.IsDeleted = true
+	case scm.ActionCreate:
+		// azure devops reports a comment as created when it has
+		// not been updated since it was published.
+		dst.Comment.LastUpdatedDate = src.Comment.Created
+	}
+	return dst
+}
@@ -0,0 +1,65 @@
+package azure
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+func TestWebhookEncode(t *testing.T) {
+	tests := []string{
+		"testdata/webhooks/push.json",
+		"testdata/webhooks/pr_created.json",
+		"testdata/webhooks/pr_updated.json",
+		"testdata/webhooks/pr_ready.json",
+		"testdata/webhooks/pr_draft.json",
+		"testdata/webhooks/pr_merged.json",
+		"testdata/webhooks/issue_comment.json",
+		"testdata/webhooks/issue_comment_edit.json",
+		"testdata/webhooks/issue_comment_delete.json",
+	}
+
+	s := new(webhookService)
+	for _, test := range tests {
+		t.Run(test, func(t *testing.T) {
+			data, err := os.ReadFile(test)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, _ := http.NewRequest("POST", "/", bytes.NewReader(data))
+			want, err := s.Parse(r, noSecretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req, err := s.Encode(want, "71295b197fa25f4356d2fb9965df3f2379d903d7")
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := s.Parse(req, secretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Encoded webhook does not round trip")
+				t.Log(diff)
+			}
+		})
+	}
+}
+
+func TestWebhookEncode_NotSupported(t *testing.T) {
+	s := new(webhookService)
+	_, err := s.Encode(&scm.IssueHook{}, "71295b197fa25f4356d2fb9965df3f2379d903d7")
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect not supported error, got %v", err)
+	}
+}
+
+func noSecretFunc(scm.Webhook) (string, error) {
+	return "", nil
+}
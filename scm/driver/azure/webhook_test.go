@@ -41,6 +41,18 @@ func TestWebhooks(t *testing.T) {
 			after:  "testdata/webhooks/pr_updated.json.golden",
 			obj:    new(scm.PullRequestHook),
 		},
+		// pull request published
+		{
+			before: "testdata/webhooks/pr_ready.json",
+			after:  "testdata/webhooks/pr_ready.json.golden",
+			obj:    new(scm.PullRequestHook),
+		},
+		// pull request converted to draft
+		{
+			before: "testdata/webhooks/pr_draft.json",
+			after:  "testdata/webhooks/pr_draft.json.golden",
+			obj:    new(scm.PullRequestHook),
+		},
 		// pull request merged
 		{
 			before: "testdata/webhooks/pr_merged.json",
@@ -120,6 +132,78 @@ func TestWebhooks(t *testing.T) {
 	}
 }
 
+func TestWebhookBasicAuth(t *testing.T) {
+	f, _ := os.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+	r.SetBasicAuth("jenkins-x", "71295b197fa25f4356d2fb9965df3f2379d903d7")
+
+	s := &webhookService{validateSecret: true}
+	_, err := s.Parse(r, secretFunc)
+	if err != nil {
+		t.Errorf("Expect valid credentials, got %v", err)
+	}
+}
+
+func TestWebhookBasicAuthInvalid(t *testing.T) {
+	f, _ := os.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("GET", "/?secret=71295b197fa25f4356d2fb9965df3f2379d903d7", bytes.NewBuffer(f))
+	r.SetBasicAuth("jenkins-x", "xxxxxinvalidxxxxxx")
+
+	s := &webhookService{validateSecret: true}
+	_, err := s.Parse(r, secretFunc)
+	if err != scm.ErrSignatureInvalid {
+		t.Errorf("Expect invalid signature error, got %v", err)
+	}
+}
+
+func TestWebhookCustomHeader(t *testing.T) {
+	f, _ := os.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-Webhook-Token", "topsecret")
+
+	s := &webhookService{validateSecret: true}
+	_, err := s.ParseWithSecrets(r, func(scm.Webhook) ([]string, error) {
+		return []string{"71295b197fa25f4356d2fb9965df3f2379d903d7", "X-Webhook-Token: topsecret"}, nil
+	})
+	if err != nil {
+		t.Errorf("Expect valid credentials, got %v", err)
+	}
+}
+
+func TestWebhookMissingCredentials(t *testing.T) {
+	f, _ := os.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+
+	s := &webhookService{validateSecret: true}
+	_, err := s.Parse(r, secretFunc)
+	if err != scm.ErrSignatureInvalid {
+		t.Errorf("Expect invalid signature error, got %v", err)
+	}
+}
+
+func TestWebhookSecretNotValidatedByDefault(t *testing.T) {
+	f, _ := os.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+
+	s := NewWebHookService()
+	_, err := s.Parse(r, secretFunc)
+	if err != nil {
+		t.Errorf("Expect the secret to be ignored unless enabled, got %v", err)
+	}
+}
+
+func TestValidateWebhookSecret(t *testing.T) {
+	f, _ := os.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+
+	client := NewDefault()
+	ValidateWebhookSecret(client)
+	_, err := client.Webhooks.Parse(r, secretFunc)
+	if err != scm.ErrSignatureInvalid {
+		t.Errorf("Expect invalid signature error, got %v", err)
+	}
+}
+
 func secretFunc(scm.Webhook) (string, error) {
 	return "71295b197fa25f4356d2fb9965df3f2379d903d7", nil
 }
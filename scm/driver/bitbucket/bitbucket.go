@@ -43,7 +43,9 @@ func New(uri string) (*scm.Client, error) {
 	client.Organizations = &organizationService{client}
 	client.PullRequests = &pullService{&issueService{client}}
 	client.Repositories = &repositoryService{client}
+	client.Reactions = &reactionService{client}
 	client.Reviews = &reviewService{client}
+	client.Search = &searchService{client}
 	client.Users = &userService{client}
 	client.Webhooks = &webhookService{client}
 	return client.Client, nil
@@ -114,6 +116,26 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	return res, json.NewDecoder(res.Body).Decode(out)
 }
 
+// stream wraps the Client.Do function by creating the Request and
+// returning the raw response body. The caller must close the body.
+func (c *wrapper) stream(ctx context.Context, method, path string) (io.ReadCloser, *scm.Response, error) {
+	req := &scm.Request{
+		Method: method,
+		Path:   path,
+	}
+	res, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.Status > 300 {
+		res.Body.Close()
+		return nil, res, errors.New(
+			http.StatusText(res.Status),
+		)
+	}
+	return res.Body, res, nil
+}
+
 // pagination represents Bitbucket pagination properties
 // embedded in list responses.
 type pagination struct {
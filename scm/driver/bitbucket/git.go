@@ -5,6 +5,7 @@
 package bitbucket
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"strings"
@@ -132,6 +133,109 @@ func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string
 	return convertDiffstats(out), res, err
 }
 
+// Compare returns the comparison of the head commit to the base
+// commit. Bitbucket does not report how far apart the commits are,
+// so the commits on each side are listed to count them.
+func (s *gitService) Compare(ctx context.Context, repo, base, head string, _ *scm.ListOptions) (*scm.Comparison, *scm.Response, error) {
+	ahead, res, err := s.listCommitsExcluding(ctx, repo, head, base)
+	if err != nil {
+		return nil, res, err
+	}
+	behind, res, err := s.listCommitsExcluding(ctx, repo, base, head)
+	if err != nil {
+		return nil, res, err
+	}
+	path := fmt.Sprintf("2.0/repositories/%s/merge-base/%s..%s", repo, base, head)
+	mergeBase := new(commit)
+	res, err = s.client.do(ctx, "GET", path, nil, mergeBase)
+	if err != nil {
+		return nil, res, err
+	}
+	// the diffstat spec compares the first commit to its
+	// merge base with the second.
+	path = fmt.Sprintf("2.0/repositories/%s/diffstat/%s..%s?pagelen=100", repo, head, base)
+	changes := []*scm.Change{}
+	for {
+		out := new(diffstats)
+		res, err = s.client.do(ctx, "GET", path, nil, out)
+		if err != nil {
+			return nil, res, err
+		}
+		changes = append(changes, convertDiffstats(out)...)
+		if out.Next == "" {
+			break
+		}
+		path = out.Next
+	}
+	return &scm.Comparison{
+		Status:    scm.ComparisonStatusOf(len(ahead), len(behind)),
+		AheadBy:   len(ahead),
+		BehindBy:  len(behind),
+		MergeBase: mergeBase.Hash,
+		Commits:   ahead,
+		Changes:   changes,
+	}, res, nil
+}
+
+// listCommitsExcluding returns the commits reachable from include
+// but not from exclude, oldest first.
+func (s *gitService) listCommitsExcluding(ctx context.Context, repo, include, exclude string) ([]*scm.Commit, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/commits/%s?exclude=%s&pagelen=100", repo, include, exclude)
+	to := []*scm.Commit{}
+	for {
+		out := new(commits)
+		res, err := s.client.do(ctx, "GET", path, nil, out)
+		if err != nil {
+			return nil, res, err
+		}
+		to = append(to, convertCommitList(out)...)
+		if out.Next == "" {
+			for i, j := 0, len(to)-1; i < j; i, j = i+1, j-1 {
+				to[i], to[j] = to[j], to[i]
+			}
+			return to, res, nil
+		}
+		path = out.Next
+	}
+}
+
+// CompareDiff returns the diff of ref2 against ref1. The diff spec
+// compares the first commit to its merge base with the second, so
+// the refs are swapped.
+func (s *gitService) CompareDiff(ctx context.Context, repo, ref1, ref2 string) (*scm.Diff, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/diff/%s..%s", repo, ref2, ref1)
+	buf := new(bytes.Buffer)
+	res, err := s.client.do(ctx, "GET", path, nil, buf)
+	if err != nil {
+		return nil, res, err
+	}
+	diff, err := scm.ParseDiff(buf.String())
+	return diff, res, err
+}
+
+// FindTree returns the git tree for the given ref. Bitbucket does not
+// expose git tree objects, so the tree is built from the source listing
+// and entries do not carry a blob sha.
+func (s *gitService) FindTree(ctx context.Context, repo, ref string, recursive bool) (*scm.Tree, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/src/%s/?pagelen=100", repo, ref)
+	if recursive {
+		path += fmt.Sprintf("&max_depth=%d", maxTreeDepth)
+	}
+	to := &scm.Tree{Entries: []*scm.TreeEntry{}}
+	for {
+		out := new(srcEntries)
+		res, err := s.client.do(ctx, "GET", path, nil, out)
+		if err != nil {
+			return nil, res, err
+		}
+		to.Entries = append(to.Entries, convertSrcEntryList(out)...)
+		if out.Next == "" {
+			return to, res, nil
+		}
+		path = out.Next
+	}
+}
+
 func (s *gitService) GetDefaultBranch(ctx context.Context, repo string) (*scm.Reference, *scm.Response, error) {
 	repository, res, err := s.client.Repositories.Find(ctx, repo)
 	if err != nil {
@@ -140,6 +244,27 @@ func (s *gitService) GetDefaultBranch(ctx context.Context, repo string) (*scm.Re
 	return s.FindBranch(ctx, repo, repository.Branch)
 }
 
+// maxTreeDepth is the directory depth requested when listing
+// the source tree recursively.
+const maxTreeDepth = 100
+
+type srcEntries struct {
+	pagination
+	Values []*srcEntry `json:"values"`
+}
+
+type srcEntry struct {
+	Path       string   `json:"path"`
+	Type       string   `json:"type"`
+	Size       int64    `json:"size"`
+	Attributes []string `json:"attributes"`
+	Links      struct {
+		Self struct {
+			Href string `json:"href"`
+		} `json:"self"`
+	} `json:"links"`
+}
+
 type branch struct {
 	Type   string `json:"type"`
 	Name   string `json:"name"`
@@ -239,6 +364,9 @@ type commit struct {
 		HTML   string `json:"html"`
 		Type   string `json:"type"`
 	} `json:"summary"`
+	Parents []struct {
+		Hash string `json:"hash"`
+	} `json:"parents"`
 	Date    time.Time `json:"date"`
 	Message string    `json:"message"`
 	Type    string    `json:"type"`
@@ -270,6 +398,10 @@ func convertCommitList(from *commits) []*scm.Commit {
 }
 
 func convertCommit(from *commit) *scm.Commit {
+	var parents []string
+	for _, p := range from.Parents {
+		parents = append(parents, p.Hash)
+	}
 	return &scm.Commit{
 		Message: from.Message,
 		Sha:     from.Hash,
@@ -288,6 +420,7 @@ func convertCommit(from *commit) *scm.Commit {
 			Login:  from.Author.User.Username,
 			Avatar: from.Author.User.Links.Avatar.Href,
 		},
+		Parents: parents,
 	}
 }
 
@@ -322,3 +455,37 @@ func convertTag(from *branch) *scm.Reference {
 		Sha:  from.Target.Hash,
 	}
 }
+
+func convertSrcEntryList(from *srcEntries) []*scm.TreeEntry {
+	to := []*scm.TreeEntry{}
+	for _, v := range from.Values {
+		to = append(to, convertSrcEntry(v))
+	}
+	return to
+}
+
+func convertSrcEntry(from *srcEntry) *scm.TreeEntry {
+	to := &scm.TreeEntry{
+		Path: from.Path,
+		Type: scm.TreeEntryBlob,
+		Mode: "100644",
+		Size: from.Size,
+		Link: from.Links.Self.Href,
+	}
+	if from.Type == "commit_directory" {
+		to.Type = scm.TreeEntryTree
+		to.Mode = "040000"
+	}
+	for _, attr := range from.Attributes {
+		switch attr {
+		case "executable":
+			to.Mode = "100755"
+		case "link":
+			to.Mode = "120000"
+		case "subrepository":
+			to.Type = scm.TreeEntryCommit
+			to.Mode = "160000"
+		}
+	}
+	return to
+}
@@ -72,6 +72,45 @@ func TestGitFindBranch(t *testing.T) {
 	}
 }
 
+func TestGitFindTree(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/src/master/").
+		MatchParam("max_depth", "100").
+		MatchParam("page", "2").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tree_2.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/src/master/").
+		MatchParam("max_depth", "100").
+		MatchParam("pagelen", "100").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tree.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Git.FindTree(context.Background(), "atlassian/stash-example-plugin", "master", true)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Tree)
+	raw, _ := os.ReadFile("testdata/tree.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestGitFindTag(t *testing.T) {
 	defer gock.Off()
 
@@ -256,6 +295,30 @@ func TestGitCompareCommits(t *testing.T) {
 	}
 }
 
+func TestGitCompareDiff(t *testing.T) {
+	defer gock.Off()
+
+	// the diff spec lists the head commit first
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/diff/425863f9dbe56d70c8dcdbf2e4e0805e85591fcc..master").
+		Reply(200).
+		Type("text/plain").
+		File("testdata/pr.diff")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Git.CompareDiff(context.Background(), "atlassian/atlaskit", "master", "425863f9dbe56d70c8dcdbf2e4e0805e85591fcc")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := len(got.Files), 2; got != want {
+		t.Errorf("Want %d files, got %d", want, got)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
 func TestGitGetDefaultBranch(t *testing.T) {
 	defer gock.Off()
 
@@ -289,3 +352,52 @@ func TestGitGetDefaultBranch(t *testing.T) {
 		t.Log(diff)
 	}
 }
+
+func TestGitCompare(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/commits/feature").
+		MatchParam("exclude", "master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare_commits.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/commits/master").
+		MatchParam("exclude", "feature").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare_commits_behind.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/merge-base/master..feature").
+		Reply(200).
+		Type("application/json").
+		File("testdata/merge_base.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/diffstat/feature..master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/diffstat.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Git.Compare(context.Background(), "atlassian/stash-example-plugin", "master", "feature", &scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := os.ReadFile("testdata/compare.json.golden")
+	err = json.Unmarshal(raw, want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
@@ -66,7 +66,14 @@ func (s *issueService) FindComment(ctx context.Context, repo string, index, id i
 }
 
 func (s *issueService) List(ctx context.Context, repo string, opts scm.IssueListOptions) ([]*scm.Issue, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("2.0/repositories/%s/issues?%s", repo, encodeIssueListOptions(opts))
+	out := new(issues)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	err = copyPagination(out.pagination, res)
+	return convertIssueList(out), res, err
 }
 
 func convertIssueCommentList(from []*issueComment) []*scm.Comment {
@@ -84,8 +91,104 @@ func (s *issueService) ListComments(ctx context.Context, repo string, index int,
 	return convertIssueCommentList(out), res, err
 }
 
+// Create creates an issue in the repository issue tracker. Bitbucket
+// issues have a single assignee and no labels or due date, only the
+// first assignee is used and labels and due date are ignored.
 func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("2.0/repositories/%s/issues", repo)
+	out := new(issue)
+	res, err := s.client.do(ctx, "POST", path, convertIssueInput(input), out)
+	return convertIssue(out), res, err
+}
+
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/issues/%d", repo, number)
+	out := new(issue)
+	res, err := s.client.do(ctx, "PUT", path, convertIssueInput(input), out)
+	return convertIssue(out), res, err
+}
+
+type issue struct {
+	ID      int    `json:"id"`
+	Title   string `json:"title"`
+	State   string `json:"state"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	Reporter user  `json:"reporter"`
+	Assignee *user `json:"assignee"`
+	Links    struct {
+		HTML link `json:"html"`
+	} `json:"links"`
+	CreatedOn time.Time `json:"created_on"`
+	UpdatedOn time.Time `json:"updated_on"`
+}
+
+type issues struct {
+	pagination
+	Values []*issue `json:"values"`
+}
+
+type issueContent struct {
+	Raw string `json:"raw"`
+}
+
+type issueAssignee struct {
+	AccountID string `json:"account_id"`
+}
+
+type issueMilestone struct {
+	ID int `json:"id"`
+}
+
+type issueInput struct {
+	Title     string          `json:"title,omitempty"`
+	Content   *issueContent   `json:"content,omitempty"`
+	Assignee  *issueAssignee  `json:"assignee,omitempty"`
+	Milestone *issueMilestone `json:"milestone,omitempty"`
+}
+
+func convertIssueInput(from *scm.IssueInput) *issueInput {
+	to := &issueInput{Title: from.Title}
+	if from.Body != "" {
+		to.Content = &issueContent{Raw: from.Body}
+	}
+	if len(from.Assignees) > 0 {
+		to.Assignee = &issueAssignee{AccountID: from.Assignees[0]}
+	}
+	if from.Milestone != 0 {
+		to.Milestone = &issueMilestone{ID: from.Milestone}
+	}
+	return to
+}
+
+func convertIssueList(from *issues) []*scm.Issue {
+	to := []*scm.Issue{}
+	for _, v := range from.Values {
+		to = append(to, convertIssue(v))
+	}
+	return to
+}
+
+func convertIssue(from *issue) *scm.Issue {
+	to := &scm.Issue{
+		Number:  from.ID,
+		Title:   from.Title,
+		Body:    from.Content.Raw,
+		Link:    from.Links.HTML.Href,
+		State:   from.State,
+		Author:  *convertUser(&from.Reporter),
+		Created: from.CreatedOn,
+		Updated: from.UpdatedOn,
+	}
+	switch from.State {
+	case "resolved", "invalid", "duplicate", "wontfix", "closed":
+		to.Closed = true
+	}
+	if from.Assignee != nil {
+		to.Assignees = []scm.User{*convertUser(from.Assignee)}
+	}
+	return to
 }
 
 type issueCommentInput struct {
@@ -6,9 +6,15 @@ package bitbucket
 
 import (
 	"context"
+	"encoding/json"
+	"os"
 	"testing"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestIssueFind(t *testing.T) {
@@ -26,9 +32,40 @@ func TestIssueCommentFind(t *testing.T) {
 }
 
 func TestIssueList(t *testing.T) {
-	_, _, err := NewDefault().Issues.List(context.Background(), "", scm.IssueListOptions{})
-	if err != nil && err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/issues").
+		MatchParam("q", `^\(state = "new" OR state = "open" OR state = "on hold"\) AND assignee.nickname = "bbuilder" AND milestone.name = "v1.0" AND updated_on >= 2023-05-01T00:00:00Z$`).
+		MatchParam("sort", "-updated_on").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issues.json")
+
+	since := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	opts := scm.IssueListOptions{
+		Open:      true,
+		Assignee:  "bbuilder",
+		Milestone: "v1.0",
+		Since:     &since,
+		Sort:      scm.SortUpdated,
+	}
+	client, _ := New("https://api.bitbucket.org")
+	got, res, err := client.Issues.List(context.Background(), "atlassian/stash-example-plugin", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Issue{}
+	raw, _ := os.ReadFile("testdata/issues.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if res.Page.Next != 2 {
+		t.Errorf("Want next page 2, got %d", res.Page.Next)
 	}
 }
 
@@ -37,9 +74,62 @@ func TestIssueListComments(t *testing.T) {
 }
 
 func TestIssueCreate(t *testing.T) {
-	_, _, err := NewDefault().Issues.Create(context.Background(), "", &scm.IssueInput{})
-	if err != nil && err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/stash-example-plugin/issues").
+		JSON(map[string]interface{}{
+			"title":     "Found a bug",
+			"content":   map[string]string{"raw": "I'm having a problem with this."},
+			"assignee":  map[string]string{"account_id": "5c3f9a2b4d5e6f7a8b9c0d1e"},
+			"milestone": map[string]int{"id": 3},
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	input := &scm.IssueInput{
+		Title:     "Found a bug",
+		Body:      "I'm having a problem with this.",
+		Assignees: []string{"5c3f9a2b4d5e6f7a8b9c0d1e"},
+		Milestone: 3,
+	}
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Issues.Create(context.Background(), "atlassian/stash-example-plugin", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Issue)
+	raw, _ := os.ReadFile("testdata/issue.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestIssueUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Put("/2.0/repositories/atlassian/stash-example-plugin/issues/42").
+		JSON(map[string]interface{}{"title": "Found a bug"}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Issues.Update(context.Background(), "atlassian/stash-example-plugin", 42, &scm.IssueInput{Title: "Found a bug"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Number != 42 {
+		t.Errorf("Want issue 42, got %d", got.Number)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
 	}
 }
 
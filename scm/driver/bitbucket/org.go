@@ -90,6 +90,26 @@ func (s *organizationService) ListMemberships(ctx context.Context, opts *scm.Lis
 	return nil, nil, scm.ErrNotSupported
 }
 
+func (s *organizationService) FindHook(ctx context.Context, org, id string) (*scm.Hook, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *organizationService) ListHooks(ctx context.Context, org string, opts *scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *organizationService) CreateHook(ctx context.Context, org string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *organizationService) UpdateHook(ctx context.Context, org string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *organizationService) DeleteHook(ctx context.Context, org, id string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
 func convertOrganizationList(from *organizationList) []*scm.Organization {
 	to := []*scm.Organization{}
 	for _, v := range from.Values {
@@ -112,10 +112,19 @@ type prComment struct {
 		Type   string `json:"type"`
 	} `json:"content"`
 	Inline struct {
-		To   int    `json:"to,omitempty"`
-		From int    `json:"from,omitempty"`
-		Path string `json:"path,omitempty"`
+		To        int    `json:"to,omitempty"`
+		From      int    `json:"from,omitempty"`
+		StartTo   int    `json:"start_to,omitempty"`
+		StartFrom int    `json:"start_from,omitempty"`
+		Path      string `json:"path,omitempty"`
+		Outdated  bool   `json:"outdated,omitempty"`
 	} `json:"inline,omitempty"`
+	Parent *struct {
+		ID int `json:"id"`
+	} `json:"parent,omitempty"`
+	Resolution *struct {
+		Type string `json:"type"`
+	} `json:"resolution,omitempty"`
 	Deleted   bool      `json:"deleted"`
 	UpdatedOn time.Time `json:"updated_on"`
 	CreatedOn time.Time `json:"created_on"`
@@ -165,6 +174,17 @@ func (s *pullService) ListComments(ctx context.Context, repo string, index int,
 	return convertPRCommentList(out), res, err
 }
 
+func (s *pullService) ListForCommit(ctx context.Context, repo, sha string) ([]*scm.PullRequest, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/commit/%s/pullrequests", repo, sha)
+	out := new(pullRequests)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	err = copyPagination(out.pagination, res)
+	return convertPullRequests(ctx, s, out), res, err
+}
+
 func (s *pullService) ListChanges(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
 	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/diffstat?%s", repo, number, encodeListOptions(opts))
 	out := new(diffstats)
@@ -176,6 +196,17 @@ func (s *pullService) ListChanges(ctx context.Context, repo string, number int,
 	return convertDiffstats(out), res, err
 }
 
+func (s *pullService) FindDiff(ctx context.Context, repo string, number int) (*scm.Diff, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/diff", repo, number)
+	buf := new(bytes.Buffer)
+	res, err := s.client.do(ctx, "GET", path, nil, buf)
+	if err != nil {
+		return nil, res, err
+	}
+	diff, err := scm.ParseDiff(buf.String())
+	return diff, res, err
+}
+
 func (s *pullService) ListLabels(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Label, *scm.Response, error) {
 	// Get all comments, parse out labels (removing and added based off time)
 	cs, res, err := s.ListComments(ctx, repo, number, opts)
@@ -254,9 +285,15 @@ type prPatchBranch struct {
 type prInput struct {
 	Title   string        `json:"title,omitempty"`
 	Source  prPatchBranch `json:"source,omitempty"`
+	Draft   bool          `json:"draft,omitempty"`
 	Project string
 }
 
+type prDraftInput struct {
+	Title string `json:"title"`
+	Draft bool   `json:"draft"`
+}
+
 func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
 	path := fmt.Sprintf("2.0/repositories/%s/pullrequests", repo)
 	in := &prInput{
@@ -266,6 +303,7 @@ func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRe
 				Name: input.Head,
 			},
 		},
+		Draft: input.Draft,
 	}
 	out := new(pullRequest)
 	res, err := s.client.do(ctx, "POST", path, in, out)
@@ -288,6 +326,59 @@ func (s *pullService) DeletePullRequest(ctx context.Context, repo string, prID i
 	return nil, scm.ErrNotSupported
 }
 
+func (s *pullService) MarkReady(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(ctx, repo, number, false)
+}
+
+func (s *pullService) ConvertToDraft(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(ctx, repo, number, true)
+}
+
+// EnableAutoMerge is not supported. Bitbucket Cloud auto-merge can
+// only be turned on from the pull request page, the REST API neither
+// exposes nor accepts the setting. Callers should wait for the build
+// statuses and call Merge instead.
+func (s *pullService) EnableAutoMerge(ctx context.Context, repo string, number int, opts *scm.AutoMergeOptions) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+// DisableAutoMerge is not supported, see EnableAutoMerge.
+func (s *pullService) DisableAutoMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) EnqueueMerge(ctx context.Context, repo string, number int, opts *scm.AutoMergeOptions) (*scm.MergeQueueEntry, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *pullService) DequeueMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) FindMergeQueueEntry(ctx context.Context, repo string, number int) (*scm.MergeQueueEntry, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *pullService) UpdateBranch(ctx context.Context, repo string, number int, method string) (string, *scm.Response, error) {
+	return "", nil, scm.ErrNotSupported
+}
+
+// setDraft updates the pull request draft flag. The title is
+// required when updating a pull request so it is looked up first.
+func (s *pullService) setDraft(ctx context.Context, repo string, number int, draft bool) (*scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d", repo, number)
+	out := new(pullRequest)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return res, err
+	}
+	in := &prDraftInput{
+		Title: out.Title,
+		Draft: draft,
+	}
+	return s.client.do(ctx, "PUT", path, in, nil)
+}
+
 type prSource struct {
 	Commit struct {
 		Type   string `json:"type"`
@@ -322,6 +413,7 @@ type pullRequest struct {
 	Source       prSource      `json:"source"`
 	Destination  prDestination `json:"destination"`
 	Locked       bool          `json:"locked"`
+	Draft        bool          `json:"draft"`
 	Author       user          `json:"author"`
 	Reviewers    []user        `json:"reviewers"`
 	Participants []user        `json:"participants"`
@@ -371,6 +463,7 @@ func convertPullRequest(from *pullRequest) *scm.PullRequest {
 		State:    strings.ToLower(from.State),
 		Closed:   closed,
 		Merged:   from.State == "MERGED",
+		Draft:    from.Draft,
 		Created:  from.CreatedDate,
 		Updated:  from.UpdatedDate,
 		Author: scm.User{
@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 
@@ -55,6 +56,75 @@ func TestPullList(t *testing.T) {
 	}
 }
 
+func TestPullListFilters(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/octocat/hello-world/pullrequests").
+		MatchParam("q", `^source.branch.name = "somestuff" AND destination.branch.name = "master" AND author.nickname = "JamesS"$`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pulls.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.List(context.Background(), "octocat/hello-world", &scm.PullRequestListOptions{Head: "somestuff", Base: "master", Author: "JamesS"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(got) != 2 {
+		t.Errorf("Want 2 pull requests, got %d", len(got))
+	}
+}
+
+func TestPullListReviewerSort(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/octocat/hello-world/pullrequests").
+		MatchParam("q", `^reviewers.nickname = "JamesS" AND updated_on >= 2018-01-01T00:00:00Z$`).
+		MatchParam("sort", "^created_on$").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pulls.json")
+
+	since := time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC)
+	client := NewDefault()
+	_, _, err := client.PullRequests.List(context.Background(), "octocat/hello-world", &scm.PullRequestListOptions{Reviewer: "JamesS", UpdatedAfter: &since, Sort: scm.SortCreated, Ascending: true})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPullListForCommit(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/octocat/hello-world/commit/a6e5e7d797edf751cbd839d6bd4aef86c941eec9/pullrequests").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pulls.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.ListForCommit(context.Background(), "octocat/hello-world", "a6e5e7d797edf751cbd839d6bd4aef86c941eec9")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.PullRequest{}
+	raw, _ := os.ReadFile("testdata/pulls.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestPullListChanges(t *testing.T) {
 	defer gock.Off()
 
@@ -141,3 +211,85 @@ func TestPullCreate(t *testing.T) {
 		}
 	}
 }
+
+func TestPullMarkReady(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("2.0/repositories/octocat/hello-world/pullrequests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_create.json")
+
+	gock.New("https://api.bitbucket.org").
+		Put("2.0/repositories/octocat/hello-world/pullrequests/1").
+		JSON(map[string]interface{}{"title": "added awesome to Jenkinsfile", "draft": false}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_create.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.PullRequests.MarkReady(context.Background(), "octocat/hello-world", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected the pull request to be updated")
+	}
+}
+
+func TestPullConvertToDraft(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("2.0/repositories/octocat/hello-world/pullrequests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_create.json")
+
+	gock.New("https://api.bitbucket.org").
+		Put("2.0/repositories/octocat/hello-world/pullrequests/1").
+		JSON(map[string]interface{}{"title": "added awesome to Jenkinsfile", "draft": true}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_create.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.PullRequests.ConvertToDraft(context.Background(), "octocat/hello-world", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected the pull request to be updated")
+	}
+}
+
+func TestPullFindDiff(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/atlaskit/pullrequests/4982/diff").
+		Reply(200).
+		Type("text/plain").
+		File("testdata/pr.diff")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.PullRequests.FindDiff(context.Background(), "atlassian/atlaskit", 4982)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := len(got.Files), 2; got != want {
+		t.Fatalf("Want %d files, got %d", want, got)
+	}
+	if got, want := got.Files[0].NewPath, "README.md"; got != want {
+		t.Errorf("Want file %q, got %q", want, got)
+	}
+	if got, want := len(got.Files[0].Hunks), 2; got != want {
+		t.Errorf("Want %d hunks, got %d", want, got)
+	}
+	if !got.Files[1].Added || !got.Files[1].Binary {
+		t.Errorf("Want added binary file %s", got.Files[1].NewPath)
+	}
+}
@@ -226,6 +226,20 @@ func (s *repositoryService) Delete(context.Context, string) (*scm.Response, erro
 	return nil, scm.ErrNotSupported
 }
 
+// Archive downloads the repository archive. Bitbucket serves archives
+// from the website rather than the API host, so the api. prefix is
+// removed from the base url.
+func (s *repositoryService) Archive(ctx context.Context, repo, ref string, format scm.ArchiveFormat) (io.ReadCloser, *scm.Response, error) {
+	switch format {
+	case scm.ArchiveFormatTarball, scm.ArchiveFormatZip:
+	default:
+		return nil, nil, scm.ErrNotSupported
+	}
+	host := strings.TrimPrefix(s.client.BaseURL.Host, "api.")
+	path := fmt.Sprintf("%s://%s/%s/get/%s.%s", s.client.BaseURL.Scheme, host, repo, url.PathEscape(ref), format)
+	return s.client.stream(ctx, "GET", path)
+}
+
 // Find returns the repository by name.
 func (s *repositoryService) Find(ctx context.Context, repo string) (*scm.Repository, *scm.Response, error) {
 	path := fmt.Sprintf("2.0/repositories/%s", repo)
@@ -309,38 +323,42 @@ func (s *repositoryService) ListStatus(ctx context.Context, repo, ref string, op
 
 // CreateHook creates a new repository webhook.
 func (s *repositoryService) CreateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
-	targetText := input.Target
-	if input.Secret != "" {
-		target, err := url.Parse(input.Target)
-		if err != nil {
-			return nil, nil, err
-		}
-		params := target.Query()
-		params.Set("secret", input.Secret)
-		target.RawQuery = params.Encode()
-		targetText = target.String()
+	in, err := convertHookInput(input)
+	if err != nil {
+		return nil, nil, err
 	}
-
-	path := fmt.Sprintf("2.0/repositories/%s/hooks", repo)
-	in := new(hookInput)
-	in.URL = targetText
-	in.Active = true
 	in.Description = input.Name
 	if in.Description == "" {
 		in.Description = "my webhook"
 	}
-	// nolint
-	in.Events = append(
-		input.NativeEvents,
-		convertHookEvents(input.Events)...,
-	)
+	path := fmt.Sprintf("2.0/repositories/%s/hooks", repo)
 	out := new(hook)
 	res, err := s.client.do(ctx, "POST", path, in, out)
 	return convertHook(out), res, wrapError(res, err)
 }
 
+// UpdateHook edits a repository webhook. The hook description is
+// left unchanged, because the input name holds the hook uuid.
 func (s *repositoryService) UpdateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	current, res, err := s.FindHook(ctx, repo, input.Name)
+	if err != nil {
+		return nil, res, err
+	}
+	in, err := convertHookInput(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	in.Description = current.Name
+	path := fmt.Sprintf("2.0/repositories/%s/hooks/%s", repo, current.ID)
+	out := new(hook)
+	res, err = s.client.do(ctx, "PUT", path, in, out)
+	return convertHook(out), res, wrapError(res, err)
+}
+
+// ConvertHookEvents returns the events reported for a webhook
+// created from the input.
+func (s *repositoryService) ConvertHookEvents(input *scm.HookInput) []string {
+	return append(append([]string(nil), input.NativeEvents...), convertHookEvents(input.Events)...)
 }
 
 // CreateStatus creates a new commit status.
@@ -364,6 +382,22 @@ func (s *repositoryService) DeleteHook(ctx context.Context, repo, id string) (*s
 	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
+func (s *repositoryService) ListHookDeliveries(context.Context, string, string, *scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *repositoryService) FindHookDelivery(context.Context, string, string, string) (*scm.HookDelivery, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *repositoryService) RedeliverHook(context.Context, string, string, string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *repositoryService) TestHook(context.Context, string, string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
 // helper function to convert from the gogs repository list to
 // the common repository structure.
 func convertRepositoryList(from *repositories) []*scm.Repository {
@@ -431,6 +465,33 @@ func convertHook(from *hook) *scm.Hook {
 	}
 }
 
+// convertHookInput returns the request body used to create or
+// edit a repository webhook. The secret is passed as a query
+// parameter of the target url.
+func convertHookInput(input *scm.HookInput) (*hookInput, error) {
+	targetText := input.Target
+	if input.Secret != "" {
+		target, err := url.Parse(input.Target)
+		if err != nil {
+			return nil, err
+		}
+		params := target.Query()
+		params.Set("secret", input.Secret)
+		target.RawQuery = params.Encode()
+		targetText = target.String()
+	}
+
+	in := new(hookInput)
+	in.URL = targetText
+	in.Active = true
+	// nolint
+	in.Events = append(
+		input.NativeEvents,
+		convertHookEvents(input.Events)...,
+	)
+	return in, nil
+}
+
 func convertHookEvents(from scm.HookEvents) []string {
 	var events []string
 	if from.Push {
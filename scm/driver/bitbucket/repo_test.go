@@ -7,6 +7,7 @@ package bitbucket
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"os"
 	"strings"
 	"testing"
@@ -65,6 +66,33 @@ func TestRepositoryFind_NotFound(t *testing.T) {
 	}
 }
 
+func TestRepositoryArchive(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://bitbucket.org").
+		Get("/atlassian/stash-example-plugin/get/master.tar.gz").
+		Reply(200).
+		Type("application/x-tar").
+		BodyString("archive")
+
+	client, _ := New("https://api.bitbucket.org")
+	rc, _, err := client.Repositories.Archive(context.Background(), "atlassian/stash-example-plugin", "master", scm.ArchiveFormatTarball)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := string(data), "archive"; got != want {
+		t.Errorf("Want archive contents %q, got %q", want, got)
+	}
+}
+
 func TestListCollaborators(t *testing.T) {
 	defer gock.Off()
 
@@ -384,6 +412,53 @@ func TestRepositoryHookCreate(t *testing.T) {
 	}
 }
 
+func TestRepositoryHookUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/hooks/{d53603cc-3f67-45ea-b310-aaa5ef6ec061}").
+		Reply(200).
+		Type("application/json").
+		File("testdata/hook.json")
+
+	gock.New("https://api.bitbucket.org").
+		Put("/2.0/repositories/atlassian/stash-example-plugin/hooks/{d53603cc-3f67-45ea-b310-aaa5ef6ec061}").
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"description": "beta.drone.io",
+			"url":         "http://example.com/webhook?secret=topsecret",
+			"active":      true,
+			"events":      []string{"repo:push"},
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/hook.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Repositories.UpdateHook(context.Background(), "atlassian/stash-example-plugin", &scm.HookInput{
+		Name:   "{d53603cc-3f67-45ea-b310-aaa5ef6ec061}",
+		Target: "http://example.com/webhook",
+		Secret: "topsecret",
+		Events: scm.HookEvents{Push: true},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Hook)
+	raw, _ := os.ReadFile("testdata/hook.json.golden")
+	err = json.Unmarshal(raw, want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestConvertFromState(t *testing.T) {
 	tests := []struct {
 		src scm.State
@@ -6,42 +6,397 @@ package bitbucket
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
 
+// Bitbucket Cloud has no review resource. Approvals and change
+// requests are read from the participant state of the pull request
+// and have no id. Comment reviews are top level inline comments and
+// use the comment id.
 type reviewService struct {
 	client *wrapper
 }
 
 func (s *reviewService) Find(ctx context.Context, repo string, number, id int) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/comments/%d", repo, number, id)
+	out := new(prComment)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertCommentReview(out), res, nil
 }
 
 func (s *reviewService) List(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	to := []*scm.Review{}
+	if opts.Page <= 1 {
+		path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d", repo, number)
+		out := new(prParticipants)
+		res, err := s.client.do(ctx, "GET", path, nil, out)
+		if err != nil {
+			return nil, res, err
+		}
+		to = append(to, convertParticipantReviewList(out.Participants)...)
+	}
+
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/comments?%s", repo, number, encodeListOptions(opts))
+	out := new(pullRequestComments)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	err = copyPagination(out.pagination, res)
+	for _, c := range out.Values {
+		if c.Inline.Path != "" && c.Parent == nil && !c.Deleted {
+			to = append(to, convertCommentReview(c))
+		}
+	}
+	return to, res, err
 }
 
+// Create adds the review comments to the pull request and then
+// approves or requests changes depending on the review event.
 func (s *reviewService) Create(ctx context.Context, repo string, number int, input *scm.ReviewInput) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	var action string
+	switch input.Event {
+	case "COMMENT":
+	case "APPROVE", scm.ReviewStateApproved:
+		action = "approve"
+	case "REQUEST_CHANGES", scm.ReviewStateChangesRequested:
+		action = "request-changes"
+	default:
+		return nil, nil, scm.ErrNotSupported
+	}
+
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/comments", repo, number)
+	review := &scm.Review{
+		Body:  input.Body,
+		Sha:   input.Sha,
+		State: scm.ReviewStateCommented,
+	}
+	var res *scm.Response
+	for _, c := range input.Comments {
+		in := convertReviewCommentInput(c)
+		out := new(prComment)
+		var err error
+		res, err = s.client.do(ctx, "POST", path, in, out)
+		if err != nil {
+			return nil, res, err
+		}
+		if review.ID == 0 {
+			review.ID = out.ID
+		}
+	}
+	if input.Body != "" {
+		in := new(reviewCommentInput)
+		in.Content.Raw = input.Body
+		var err error
+		res, err = s.client.do(ctx, "POST", path, in, nil)
+		if err != nil {
+			return nil, res, err
+		}
+	}
+	if action == "" {
+		return review, res, nil
+	}
+
+	path = fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/%s", repo, number, action)
+	out := new(prParticipant)
+	res, err := s.client.do(ctx, "POST", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	review.State = convertParticipantState(out)
+	review.Author = *convertUser(&out.User)
+	return review, res, nil
 }
 
 func (s *reviewService) Delete(ctx context.Context, repo string, number, id int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/comments/%d", repo, number, id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
 func (s *reviewService) ListComments(ctx context.Context, repo string, prID, reviewID int, options *scm.ListOptions) ([]*scm.ReviewComment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	to := []*scm.ReviewComment{}
+	opts := &scm.ListOptions{Page: 1, Size: 50}
+	for {
+		path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/comments?%s", repo, prID, encodeListOptions(opts))
+		out := new(pullRequestComments)
+		res, err := s.client.do(ctx, "GET", path, nil, out)
+		if err != nil {
+			return nil, res, err
+		}
+		for _, c := range out.Values {
+			if c.ID == reviewID || (c.Parent != nil && c.Parent.ID == reviewID) {
+				comment := convertReviewComment(c)
+				comment.ThreadID = strconv.Itoa(reviewID)
+				to = append(to, comment)
+			}
+		}
+		if out.Next == "" {
+			return to, res, nil
+		}
+		opts.Page++
+	}
+}
+
+// CreateComment adds an inline comment to the pull request, or replies
+// to the comment with the InReplyTo or ThreadID id.
+func (s *reviewService) CreateComment(ctx context.Context, repo string, number int, input *scm.ReviewCommentInput) (*scm.ReviewComment, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/comments", repo, number)
+	out := new(prComment)
+	res, err := s.client.do(ctx, "POST", path, convertReviewCommentInput(input), out)
+	if err != nil {
+		return nil, res, err
+	}
+	comment := convertReviewComment(out)
+	if comment.ThreadID == "" {
+		comment.ThreadID = input.ThreadID
+	}
+	return comment, res, nil
+}
+
+// ListThreads returns the top level inline comments of the page with
+// their replies. The thread id is the id of the top level comment.
+func (s *reviewService) ListThreads(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.ReviewThread, *scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/comments?%s", repo, number, encodeListOptions(opts))
+	out := new(pullRequestComments)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	err = copyPagination(out.pagination, res)
+	return convertReviewThreadList(out.Values), res, err
+}
+
+func (s *reviewService) ResolveThread(ctx context.Context, repo string, number int, id string) (*scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/comments/%s/resolve", repo, number, id)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
+func (s *reviewService) UnresolveThread(ctx context.Context, repo string, number int, id string) (*scm.Response, error) {
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/comments/%s/resolve", repo, number, id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
 func (s *reviewService) Update(ctx context.Context, repo string, prID, reviewID int, body string) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/comments/%d", repo, prID, reviewID)
+	in := new(prCommentInput)
+	in.Content.Raw = body
+	out := new(prComment)
+	res, err := s.client.do(ctx, "PUT", path, in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertCommentReview(out), res, nil
 }
 
 func (s *reviewService) Submit(ctx context.Context, repo string, prID, reviewID int, input *scm.ReviewSubmitInput) (*scm.Review, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
 
+// Dismiss removes the approval of the current user. Bitbucket does
+// not allow removing the approval of another participant.
 func (s *reviewService) Dismiss(ctx context.Context, repo string, prID, reviewID int, msg string) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("2.0/repositories/%s/pullrequests/%d/approve", repo, prID)
+	res, err := s.client.do(ctx, "DELETE", path, nil, nil)
+	if err != nil {
+		return nil, res, err
+	}
+	return &scm.Review{
+		ID:    reviewID,
+		Body:  msg,
+		State: scm.ReviewStateDismissed,
+	}, res, nil
+}
+
+type prParticipant struct {
+	User           user      `json:"user"`
+	Role           string    `json:"role"`
+	Approved       bool      `json:"approved"`
+	State          string    `json:"state"`
+	ParticipatedOn time.Time `json:"participated_on"`
+}
+
+type prParticipants struct {
+	Participants []*prParticipant `json:"participants"`
+}
+
+type reviewCommentInline struct {
+	Path      string `json:"path"`
+	To        int    `json:"to,omitempty"`
+	From      int    `json:"from,omitempty"`
+	StartTo   int    `json:"start_to,omitempty"`
+	StartFrom int    `json:"start_from,omitempty"`
+}
+
+type reviewCommentParent struct {
+	ID int `json:"id"`
+}
+
+type reviewCommentInput struct {
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	Inline *reviewCommentInline `json:"inline,omitempty"`
+	Parent *reviewCommentParent `json:"parent,omitempty"`
+}
+
+// convertReviewCommentInput returns the comment input placed on the
+// file lines, or the reply to the parent comment.
+func convertReviewCommentInput(from *scm.ReviewCommentInput) *reviewCommentInput {
+	to := new(reviewCommentInput)
+	to.Content.Raw = from.Body
+	parent := from.InReplyTo
+	if parent == 0 && from.ThreadID != "" {
+		parent, _ = strconv.Atoi(from.ThreadID)
+	}
+	if parent != 0 {
+		to.Parent = &reviewCommentParent{ID: parent}
+		return to
+	}
+	to.Inline = &reviewCommentInline{Path: from.Path}
+	start := 0
+	if from.StartLine != from.Line {
+		start = from.StartLine
+	}
+	if from.Side == scm.DiffSideLeft {
+		to.Inline.From = from.Line
+		to.Inline.StartFrom = start
+	} else {
+		to.Inline.To = from.Line
+		to.Inline.StartTo = start
+	}
+	return to
+}
+
+func convertParticipantState(from *prParticipant) string {
+	switch {
+	case from.Approved || from.State == "approved":
+		return scm.ReviewStateApproved
+	case from.State == "changes_requested":
+		return scm.ReviewStateChangesRequested
+	default:
+		return ""
+	}
+}
+
+func convertParticipantReviewList(from []*prParticipant) []*scm.Review {
+	to := []*scm.Review{}
+	for _, v := range from {
+		state := convertParticipantState(v)
+		if state == "" {
+			continue
+		}
+		to = append(to, &scm.Review{
+			State:   state,
+			Author:  *convertUser(&v.User),
+			Created: v.ParticipatedOn,
+			Updated: v.ParticipatedOn,
+		})
+	}
+	return to
+}
+
+func convertCommentReview(from *prComment) *scm.Review {
+	return &scm.Review{
+		ID:   from.ID,
+		Body: from.Content.Raw,
+		Link: from.Links.HTML.Href,
+		Author: scm.User{
+			Name:   from.User.DisplayName,
+			Login:  from.User.AccountID,
+			Avatar: from.User.Links.Avatar.Href,
+		},
+		State:   scm.ReviewStateCommented,
+		Created: from.CreatedOn,
+		Updated: from.UpdatedOn,
+	}
+}
+
+func convertReviewComment(from *prComment) *scm.ReviewComment {
+	to := &scm.ReviewComment{
+		ID:        from.ID,
+		Body:      from.Content.Raw,
+		Path:      from.Inline.Path,
+		Line:      from.Inline.To,
+		StartLine: from.Inline.StartTo,
+		Side:      scm.DiffSideRight,
+		Link:      from.Links.HTML.Href,
+		Author: scm.User{
+			Name:   from.User.DisplayName,
+			Login:  from.User.AccountID,
+			Avatar: from.User.Links.Avatar.Href,
+		},
+		Created: from.CreatedOn,
+		Updated: from.UpdatedOn,
+	}
+	if to.Line == 0 {
+		to.Line = from.Inline.From
+		to.StartLine = from.Inline.StartFrom
+		to.Side = scm.DiffSideLeft
+	}
+	if to.Line == 0 {
+		to.Side = ""
+	}
+	to.FileLine = to.Line
+	if from.Parent != nil {
+		to.InReplyTo = from.Parent.ID
+	} else {
+		to.ThreadID = strconv.Itoa(from.ID)
+	}
+	return to
+}
+
+// convertReviewThreadList groups the comments under their top level
+// inline comment. Replies whose top level comment is not in the list
+// are dropped.
+func convertReviewThreadList(from []*prComment) []*scm.ReviewThread {
+	to := []*scm.ReviewThread{}
+	threads := map[int]*scm.ReviewThread{}
+	for _, c := range from {
+		if c.Parent != nil || c.Inline.Path == "" || c.Deleted {
+			continue
+		}
+		comment := convertReviewComment(c)
+		thread := &scm.ReviewThread{
+			ID:        comment.ThreadID,
+			Path:      comment.Path,
+			Line:      comment.Line,
+			StartLine: comment.StartLine,
+			Side:      comment.Side,
+			Resolved:  c.Resolution != nil,
+			Outdated:  c.Inline.Outdated,
+			Comments:  []*scm.ReviewComment{comment},
+		}
+		threads[c.ID] = thread
+		to = append(to, thread)
+	}
+	roots := map[int]int{}
+	for _, c := range from {
+		if c.Parent != nil {
+			roots[c.ID] = c.Parent.ID
+		}
+	}
+	for _, c := range from {
+		if c.Parent == nil || c.Deleted {
+			continue
+		}
+		root := c.Parent.ID
+		for roots[root] != 0 {
+			root = roots[root]
+		}
+		thread, ok := threads[root]
+		if !ok {
+			continue
+		}
+		comment := convertReviewComment(c)
+		comment.ThreadID = thread.ID
+		thread.Comments = append(thread.Comments, comment)
+	}
+	return to
 }
@@ -6,35 +6,318 @@ package bitbucket
 
 import (
 	"context"
+	"encoding/json"
+	"os"
+	"strconv"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestReviewFind(t *testing.T) {
-	_, _, err := NewDefault().Reviews.Find(context.Background(), "", 0, 0)
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1/comments/3001").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_comment.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Reviews.Find(context.Background(), "atlassian/stash-example-plugin", 1, 3001)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Review)
+	raw, _ := os.ReadFile("testdata/pr_comment.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
 }
 
 func TestReviewList(t *testing.T) {
-	_, _, err := NewDefault().Reviews.List(context.Background(), "", 0, &scm.ListOptions{})
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_participants.json")
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1/comments").
+		MatchParam("page", "1").
+		MatchParam("pagelen", "30").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_comments.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Reviews.List(context.Background(), "atlassian/stash-example-plugin", 1, &scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Review{}
+	raw, _ := os.ReadFile("testdata/pr_reviews.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReviewListComments(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1/comments").
+		MatchParam("page", "1").
+		MatchParam("pagelen", "50").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_comments.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Reviews.ListComments(context.Background(), "atlassian/stash-example-plugin", 1, 3001, &scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.ReviewComment{}
+	raw, _ := os.ReadFile("testdata/pr_review_comments.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
 }
 
 func TestReviewCreate(t *testing.T) {
-	_, _, err := NewDefault().Reviews.Create(context.Background(), "", 0, &scm.ReviewInput{})
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1/comments").
+		JSON(map[string]interface{}{
+			"content": map[string]string{"raw": "Should this be configurable?"},
+			"inline":  map[string]interface{}{"path": "docs/README.md", "to": 24},
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr_comment.json")
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1/approve").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_approve.json")
+
+	input := &scm.ReviewInput{
+		Event: "APPROVE",
+		Comments: []*scm.ReviewCommentInput{
+			{Body: "Should this be configurable?", Path: "docs/README.md", Line: 24},
+		},
+	}
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Reviews.Create(context.Background(), "atlassian/stash-example-plugin", 1, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != 3001 {
+		t.Errorf("Want review id 3001, got %d", got.ID)
+	}
+	if got.State != scm.ReviewStateApproved {
+		t.Errorf("Want review state %s, got %s", scm.ReviewStateApproved, got.State)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewCreateRequestChanges(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1/comments").
+		JSON(map[string]interface{}{
+			"content": map[string]string{"raw": "Please add tests."},
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr_comment.json")
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1/request-changes").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"type": "participant", "user": {"account_id": "5b2e8f1a3c4d5e6f7a8b9c0d"}, "approved": false, "state": "changes_requested"}`)
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Reviews.Create(context.Background(), "atlassian/stash-example-plugin", 1, &scm.ReviewInput{
+		Body:  "Please add tests.",
+		Event: "REQUEST_CHANGES",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.State != scm.ReviewStateChangesRequested {
+		t.Errorf("Want review state %s, got %s", scm.ReviewStateChangesRequested, got.State)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewCreatePending(t *testing.T) {
+	_, _, err := NewDefault().Reviews.Create(context.Background(), "atlassian/stash-example-plugin", 1, &scm.ReviewInput{})
 	if err != scm.ErrNotSupported {
 		t.Errorf("Expect Not Supported error")
 	}
 }
 
 func TestReviewDelete(t *testing.T) {
-	_, err := NewDefault().Reviews.Delete(context.Background(), "", 0, 0)
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Delete("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1/comments/3001").
+		Reply(204)
+
+	client, _ := New("https://api.bitbucket.org")
+	_, err := client.Reviews.Delete(context.Background(), "atlassian/stash-example-plugin", 1, 3001)
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestReviewDismiss(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Delete("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1/approve").
+		Reply(204)
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Reviews.Dismiss(context.Background(), "atlassian/stash-example-plugin", 1, 0, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.State != scm.ReviewStateDismissed {
+		t.Errorf("Want review state %s, got %s", scm.ReviewStateDismissed, got.State)
+	}
+}
+
+func TestReviewCreateComment(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1/comments").
+		JSON(map[string]interface{}{
+			"content": map[string]string{"raw": "Should this be configurable?"},
+			"inline": map[string]interface{}{
+				"path":     "docs/README.md",
+				"to":       24,
+				"start_to": 22,
+			},
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr_comment.json")
+
+	input := &scm.ReviewCommentInput{
+		Body:      "Should this be configurable?",
+		Path:      "docs/README.md",
+		Line:      24,
+		StartLine: 22,
+	}
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Reviews.CreateComment(context.Background(), "atlassian/stash-example-plugin", 1, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ThreadID != strconv.Itoa(got.ID) {
+		t.Errorf("Want thread id %d, got %q", got.ID, got.ThreadID)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewCreateCommentReply(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1/comments").
+		JSON(map[string]interface{}{
+			"content": map[string]string{"raw": "Done."},
+			"parent":  map[string]interface{}{"id": 3001},
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr_comment.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	_, _, err := client.Reviews.CreateComment(context.Background(), "atlassian/stash-example-plugin", 1, &scm.ReviewCommentInput{Body: "Done.", InReplyTo: 3001})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewListThreads(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1/comments").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_comments.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	got, _, err := client.Reviews.ListThreads(context.Background(), "atlassian/stash-example-plugin", 1, &scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.ReviewThread{}
+	raw, _ := os.ReadFile("testdata/pr_threads.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReviewResolveThread(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Post("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1/comments/3001/resolve").
+		Reply(200).
+		Type("application/json")
+
+	gock.New("https://api.bitbucket.org").
+		Delete("/2.0/repositories/atlassian/stash-example-plugin/pullrequests/1/comments/3001/resolve").
+		Reply(204)
+
+	client, _ := New("https://api.bitbucket.org")
+	if _, err := client.Reviews.ResolveThread(context.Background(), "atlassian/stash-example-plugin", 1, "3001"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.Reviews.UnresolveThread(context.Background(), "atlassian/stash-example-plugin", 1, "3001"); err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
 	}
 }
@@ -0,0 +1,141 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bitbucket
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/pkg/errors"
+)
+
+// searchService implements the search API on top of the
+// workspace code search endpoint. Bitbucket Cloud has no
+// repository, issue or commit search API.
+type searchService struct {
+	client *wrapper
+}
+
+type codeSearchResults struct {
+	pagination
+	Values []*codeSearchResult `json:"values"`
+}
+
+type codeSearchResult struct {
+	ContentMatches []struct {
+		Lines []struct {
+			Line     int `json:"line"`
+			Segments []struct {
+				Text  string `json:"text"`
+				Match bool   `json:"match"`
+			} `json:"segments"`
+		} `json:"lines"`
+	} `json:"content_matches"`
+	File struct {
+		Path   string `json:"path"`
+		Commit struct {
+			Hash       string     `json:"hash"`
+			Repository repository `json:"repository"`
+		} `json:"commit"`
+	} `json:"file"`
+}
+
+// Code searches the code of the workspace of the query Repo,
+// or of the query Org when no Repo is set. Code search must be
+// enabled for the workspace.
+func (s *searchService) Code(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchCode, *scm.Response, error) {
+	workspace := q.Org
+	if q.Repo != "" {
+		workspace, _ = scm.Split(q.Repo)
+	}
+	if workspace == "" {
+		return nil, nil, errors.New("bitbucket code search requires a repository or workspace")
+	}
+	if opts == nil {
+		opts = &scm.ListOptions{}
+	}
+	params, _ := url.ParseQuery(encodeListOptions(opts))
+	params.Set("search_query", encodeCodeSearchQuery(q))
+	path := fmt.Sprintf("2.0/workspaces/%s/search/code?%s", workspace, params.Encode())
+	out := new(codeSearchResults)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, wrapError(res, err)
+	}
+	// the code search response has no next link, so the next
+	// page is derived from the total size.
+	if out.Page*out.PageLen < out.Size {
+		res.Page.First = 1
+		res.Page.Next = out.Page + 1
+	}
+	return convertCodeSearchResults(out), res, nil
+}
+
+func (s *searchService) Repositories(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *searchService) Issues(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchIssue, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *searchService) Commits(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchCommit, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+// encodeCodeSearchQuery returns the query in the bitbucket
+// search syntax, scoping it with repo, path and lang modifiers.
+func encodeCodeSearchQuery(q *scm.SearchQuery) string {
+	terms := []string{}
+	if q.Text != "" {
+		terms = append(terms, q.Text)
+	}
+	if q.Repo != "" {
+		_, name := scm.Split(q.Repo)
+		terms = append(terms, "repo:"+name)
+	}
+	if q.Path != "" {
+		terms = append(terms, "path:"+q.Path)
+	}
+	if q.Language != "" {
+		terms = append(terms, "lang:"+q.Language)
+	}
+	return strings.Join(terms, " ")
+}
+
+func convertCodeSearchResults(from *codeSearchResults) []*scm.SearchCode {
+	to := []*scm.SearchCode{}
+	for _, v := range from.Values {
+		to = append(to, convertCodeSearchResult(v))
+	}
+	return to
+}
+
+func convertCodeSearchResult(from *codeSearchResult) *scm.SearchCode {
+	repo := convertRepository(&from.File.Commit.Repository)
+	to := &scm.SearchCode{
+		Name:       path.Base(from.File.Path),
+		Path:       from.File.Path,
+		Sha:        from.File.Commit.Hash,
+		Link:       fmt.Sprintf("%s/src/%s/%s", repo.Link, from.File.Commit.Hash, from.File.Path),
+		Repository: *repo,
+	}
+	for _, match := range from.ContentMatches {
+		lines := []string{}
+		for _, line := range match.Lines {
+			text := ""
+			for _, segment := range line.Segments {
+				text += segment.Text
+			}
+			lines = append(lines, text)
+		}
+		to.Fragments = append(to.Fragments, strings.Join(lines, "\n"))
+	}
+	return to
+}
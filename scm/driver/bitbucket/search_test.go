@@ -0,0 +1,60 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestSearchCode(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.bitbucket.org").
+		Get("/2.0/workspaces/atlassian/search/code").
+		MatchParam("search_query", "main repo:stash-example-plugin lang:go").
+		MatchParam("page", "1").
+		MatchParam("pagelen", "10").
+		Reply(200).
+		Type("application/json").
+		File("testdata/search_code.json")
+
+	client, _ := New("https://api.bitbucket.org")
+	q := &scm.SearchQuery{Text: "main", Repo: "atlassian/stash-example-plugin", Language: "go"}
+	got, res, err := client.Search.Code(context.Background(), q, &scm.ListOptions{Page: 1, Size: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.SearchCode{}
+	raw, _ := os.ReadFile("testdata/search_code.json.golden")
+	if err := json.Unmarshal(raw, &want); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if got, want := res.Page.Next, 0; got != want {
+		t.Errorf("Want next page %d, got %d", want, got)
+	}
+}
+
+func TestSearchCodeNoWorkspace(t *testing.T) {
+	client, _ := New("https://api.bitbucket.org")
+	_, _, err := client.Search.Code(context.Background(), &scm.SearchQuery{Text: "main"}, nil)
+	if err == nil {
+		t.Errorf("Expect an error when no workspace is given")
+	}
+}
@@ -5,9 +5,11 @@
 package bitbucket
 
 import (
+	"fmt"
 	"net/url"
 	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -79,9 +81,53 @@ func encodeIssueListOptions(opts scm.IssueListOptions) string {
 	} else if opts.Closed {
 		params.Set("state", "closed")
 	}
+	var query []string
+	if opts.Open && !opts.Closed {
+		query = append(query, `(state = "new" OR state = "open" OR state = "on hold")`)
+	} else if opts.Closed && !opts.Open {
+		query = append(query, `(state = "resolved" OR state = "invalid" OR state = "duplicate" OR state = "wontfix" OR state = "closed")`)
+	}
+	if opts.Author != "" {
+		query = append(query, fmt.Sprintf("reporter.nickname = %q", opts.Author))
+	}
+	if opts.Assignee != "" {
+		query = append(query, fmt.Sprintf("assignee.nickname = %q", opts.Assignee))
+	}
+	if opts.Milestone != "" {
+		query = append(query, fmt.Sprintf("milestone.name = %q", opts.Milestone))
+	}
+	if opts.Since != nil {
+		query = append(query, fmt.Sprintf("updated_on >= %s", opts.Since.Format(scm.SearchTimeFormat)))
+	}
+	if len(query) > 0 {
+		params.Set("q", strings.Join(query, " AND "))
+	}
+	if sort := encodeSort(opts.Sort, opts.Ascending); sort != "" {
+		params.Set("sort", sort)
+	}
 	return params.Encode()
 }
 
+// encodeSort returns the sort parameter for the given sort
+// order, newest first unless ascending is set.
+func encodeSort(sort string, ascending bool) string {
+	switch sort {
+	case scm.SortCreated:
+		sort = "created_on"
+	case scm.SortUpdated:
+		sort = "updated_on"
+	case "":
+		if !ascending {
+			return ""
+		}
+		sort = "created_on"
+	}
+	if !ascending {
+		sort = "-" + sort
+	}
+	return sort
+}
+
 func encodePullRequestListOptions(opts *scm.PullRequestListOptions) string {
 	params := url.Values{}
 	if opts.Page != 0 {
@@ -99,6 +145,28 @@ func encodePullRequestListOptions(opts *scm.PullRequestListOptions) string {
 	} else if opts.Closed {
 		params.Set("state", "closed")
 	}
+	var query []string
+	if opts.Head != "" {
+		query = append(query, fmt.Sprintf("source.branch.name = %q", opts.Head))
+	}
+	if opts.Base != "" {
+		query = append(query, fmt.Sprintf("destination.branch.name = %q", opts.Base))
+	}
+	if opts.Author != "" {
+		query = append(query, fmt.Sprintf("author.nickname = %q", opts.Author))
+	}
+	if opts.Reviewer != "" {
+		query = append(query, fmt.Sprintf("reviewers.nickname = %q", opts.Reviewer))
+	}
+	if opts.UpdatedAfter != nil {
+		query = append(query, fmt.Sprintf("updated_on >= %s", opts.UpdatedAfter.Format(scm.SearchTimeFormat)))
+	}
+	if len(query) > 0 {
+		params.Set("q", strings.Join(query, " AND "))
+	}
+	if sort := encodeSort(opts.Sort, opts.Ascending); sort != "" {
+		params.Set("sort", sort)
+	}
 	return params.Encode()
 }
 
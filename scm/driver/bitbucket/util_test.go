@@ -5,7 +5,9 @@
 package bitbucket
 
 import (
+	"net/url"
 	"testing"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -49,6 +51,46 @@ func Test_encodeIssueListOptions(t *testing.T) {
 	}
 }
 
+func Test_encodeIssueListOptions_Filters(t *testing.T) {
+	since := time.Date(2023, time.May, 1, 0, 0, 0, 0, time.UTC)
+	opts := scm.IssueListOptions{
+		Page:      2,
+		Size:      30,
+		Closed:    true,
+		Author:    "octocat",
+		Assignee:  "bbuilder",
+		Milestone: "v1.0",
+		Since:     &since,
+		Sort:      scm.SortUpdated,
+	}
+	want := url.Values{
+		"page":    {"2"},
+		"pagelen": {"30"},
+		"state":   {"closed"},
+		"q":       {`(state = "resolved" OR state = "invalid" OR state = "duplicate" OR state = "wontfix" OR state = "closed") AND reporter.nickname = "octocat" AND assignee.nickname = "bbuilder" AND milestone.name = "v1.0" AND updated_on >= 2023-05-01T00:00:00Z`},
+		"sort":    {"-updated_on"},
+	}.Encode()
+	got := encodeIssueListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded issue list options %q, got %q", want, got)
+	}
+}
+
+func Test_encodeIssueListOptions_Ascending(t *testing.T) {
+	opts := scm.IssueListOptions{
+		Open:      true,
+		Ascending: true,
+	}
+	want := url.Values{
+		"q":    {`(state = "new" OR state = "open" OR state = "on hold")`},
+		"sort": {"created_on"},
+	}.Encode()
+	got := encodeIssueListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded issue list options %q, got %q", want, got)
+	}
+}
+
 func Test_encodePullRequestListOptions(t *testing.T) {
 	t.Parallel()
 	opts := &scm.PullRequestListOptions{
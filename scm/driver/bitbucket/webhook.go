@@ -15,6 +15,7 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/jenkins-x/go-scm/pkg/hmac"
 	"github.com/jenkins-x/go-scm/scm"
 )
 
@@ -28,6 +29,10 @@ type webhookService struct {
 
 // Parse for the bitbucket cloud webhook payloads see: https://support.atlassian.com/bitbucket-cloud/docs/event-payloads/
 func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
+	return s.ParseWithSecrets(req, fn.Secrets())
+}
+
+func (s *webhookService) ParseWithSecrets(req *http.Request, fn scm.SecretsFunc) (scm.Webhook, error) {
 	data, err := io.ReadAll(
 		io.LimitReader(req.Body, 10000000),
 	)
@@ -44,6 +49,10 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	case "pullrequest:created":
 		hook, err = s.parsePullRequestHook(data)
 	case "pullrequest:updated":
+		// the payload neither describes what changed nor holds the
+		// previous state, so marking a draft ready for review or
+		// converting it to a draft cannot be told apart from other
+		// updates. Only the current draft flag is reported.
 		hook, err = s.parsePullRequestHook(data)
 		hook.(*scm.PullRequestHook).Action = scm.ActionSync
 	case "pullrequest:fulfilled":
@@ -66,14 +75,26 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	// get the gogs signature key to verify the payload
 	// signature. If no key is provided, no validation
 	// is performed.
-	key, err := fn(hook)
+	keys, err := fn(hook)
 	if err != nil {
 		return hook, err
-	} else if key == "" {
+	}
+	keys = hmac.Keys(keys)
+	if len(keys) == 0 {
+		return hook, nil
+	}
+
+	// webhooks configured with a secret are signed using
+	// the X-Hub-Signature header. Older webhooks can only
+	// pass the secret as a query parameter in the url.
+	if sig := req.Header.Get("X-Hub-Signature"); sig != "" {
+		if !hmac.ValidatePrefixAny(data, keys, sig) {
+			return hook, scm.ErrSignatureInvalid
+		}
 		return hook, nil
 	}
 
-	if req.FormValue("secret") != key {
+	if !hmac.ValidateToken(req.FormValue("secret"), keys) {
 		return hook, scm.ErrSignatureInvalid
 	}
 
@@ -371,6 +392,7 @@ type (
 		} `json:"links"`
 		Title       string `json:"title"`
 		ID          int    `json:"id"`
+		Draft       bool   `json:"draft"`
 		Destination struct {
 			Commit struct {
 				Hash  string `json:"hash"`
@@ -674,6 +696,7 @@ func (s *webhookService) convertPullRequestHook(src *webhook) (*scm.PullRequestH
 			Link:   src.PullRequest.Links.HTML.Href,
 			Closed: src.PullRequest.State != "OPEN",
 			Merged: src.PullRequest.State == "MERGED",
+			Draft:  src.PullRequest.Draft,
 			Author: scm.User{
 				Login:  validUser(src.PullRequest.Author.AccountID, src.PullRequest.Author.Username),
 				Name:   src.PullRequest.Author.DisplayName,
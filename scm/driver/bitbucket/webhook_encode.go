@@ -0,0 +1,332 @@
+package bitbucket
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/go-scm/pkg/hmac"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/hookenc"
+)
+
+// Encode returns the Bitbucket webhook request for the hook,
+// signed with the sha256 signature.
+//
+// Bitbucket reports created branches and tags as pushes, so a
+// created BranchHook or TagHook is parsed back as a PushHook.
+func (s *webhookService) Encode(hook scm.Webhook, secret string) (*http.Request, error) {
+	var (
+		event   string
+		guid    string
+		payload interface{}
+	)
+	switch v := hook.(type) {
+	case *scm.PushHook:
+		event, guid, payload = "repo:push", v.GUID, encodePushHook(v)
+	case *scm.BranchHook:
+		event, payload = "repo:push", encodeRefHook(v.Action, "branch", &v.Ref, &v.Repo, &v.Sender)
+	case *scm.TagHook:
+		event, payload = "repo:push", encodeRefHook(v.Action, "tag", &v.Ref, &v.Repo, &v.Sender)
+	case *scm.PullRequestHook:
+		event, payload = encodePullRequestEvent(v.Action), encodePullRequestHook(v)
+	case *scm.PullRequestCommentHook:
+		event, payload = "pullrequest:comment_created", encodePullRequestCommentHook(v)
+	default:
+		return nil, scm.ErrNotSupported
+	}
+
+	req, data, err := hookenc.NewRequest(payload)
+	if err != nil {
+		return nil, err
+	}
+	id := hookenc.DeliveryID(guid)
+	req.Header.Set("X-Event-Key", event)
+	req.Header.Set("X-Hook-UUID", id)
+	req.Header.Set("X-Request-UUID", id)
+	if secret != "" {
+		req.Header.Set("X-Hub-Signature", "sha256="+hmac.Sign(sha256.New, data, []byte(secret)))
+	}
+	return req, nil
+}
+
+//
+// encoded data structures
+//
+
+type (
+	encodedLink struct {
+		Href string `json:"href"`
+	}
+
+	encodedUser struct {
+		Username    string `json:"username,omitempty"`
+		DisplayName string `json:"display_name"`
+		AccountID   string `json:"account_id,omitempty"`
+		Links       struct {
+			Avatar encodedLink `json:"avatar"`
+		} `json:"links"`
+	}
+
+	encodedRepository struct {
+		Scm      string `json:"scm,omitempty"`
+		Name     string `json:"name"`
+		FullName string `json:"full_name"`
+		UUID     string `json:"uuid"`
+		Links    struct {
+			HTML encodedLink `json:"html"`
+		} `json:"links"`
+		IsPrivate bool `json:"is_private"`
+	}
+
+	encodedRef struct {
+		Type   string `json:"type"`
+		Name   string `json:"name"`
+		Target struct {
+			Hash   string `json:"hash"`
+			Author struct {
+				Raw  string      `json:"raw"`
+				User encodedUser `json:"user"`
+			} `json:"author"`
+			Links struct {
+				HTML encodedLink `json:"html"`
+			} `json:"links"`
+			Date    time.Time `json:"date"`
+			Message string    `json:"message"`
+			Type    string    `json:"type"`
+		} `json:"target"`
+	}
+
+	encodedPushHook struct {
+		Push struct {
+			Changes []encodedChange `json:"changes"`
+		} `json:"push"`
+		Repository encodedRepository `json:"repository"`
+		Actor      encodedUser       `json:"actor"`
+	}
+
+	encodedChange struct {
+		Forced  bool        `json:"forced"`
+		Old     *encodedRef `json:"old"`
+		New     *encodedRef `json:"new"`
+		Created bool        `json:"created"`
+		Closed  bool        `json:"closed"`
+	}
+
+	encodedPullRequestBranch struct {
+		Commit struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
+		Branch struct {
+			Name string `json:"name"`
+		} `json:"branch"`
+		Repository encodedRepository `json:"repository"`
+	}
+
+	encodedPullRequest struct {
+		ID          int    `json:"id"`
+		Title       string `json:"title"`
+		Description string `json:"description"`
+		State       string `json:"state"`
+		Draft       bool   `json:"draft"`
+		Links       struct {
+			HTML encodedLink `json:"html"`
+		} `json:"links"`
+		Source      encodedPullRequestBranch `json:"source"`
+		Destination encodedPullRequestBranch `json:"destination"`
+		Author      encodedUser              `json:"author"`
+		CreatedOn   time.Time                `json:"created_on"`
+		UpdatedOn   time.Time                `json:"updated_on"`
+	}
+
+	encodedPullRequestHook struct {
+		PullRequest encodedPullRequest `json:"pullrequest"`
+		Repository  encodedRepository  `json:"repository"`
+		Actor       encodedUser        `json:"actor"`
+	}
+
+	encodedPullRequestCommentHook struct {
+		PullRequest encodedPullRequest `json:"pullrequest"`
+		Comment     struct {
+			ID      int `json:"id"`
+			Content struct {
+				Raw string `json:"raw"`
+			} `json:"content"`
+			User      encodedUser `json:"user"`
+			CreatedOn time.Time   `json:"created_on"`
+			UpdatedOn time.Time   `json:"updated_on"`
+		} `json:"comment"`
+		Repository encodedRepository `json:"repository"`
+		Actor      encodedUser       `json:"actor"`
+	}
+)
+
+//
+// native data structure encoding
+//
+
+func encodePushHook(src *scm.PushHook) *encodedPushHook {
+	dst := &encodedPushHook{
+		Repository: *encodeRepository(&src.Repo),
+		Actor:      *encodeUser(&src.Sender),
+	}
+	ref := &encodedRef{Type: "branch", Name: scm.TrimRef(src.Ref)}
+	if scm.IsTag(src.Ref) {
+		ref.Type = "tag"
+	}
+	ref.Target.Type = "commit"
+	ref.Target.Hash = src.Commit.Sha
+	if src.After != "" {
+		ref.Target.Hash = src.After
+	}
+	ref.Target.Message = src.Commit.Message
+	ref.Target.Date = src.Commit.Author.Date
+	ref.Target.Links.HTML.Href = src.Commit.Link
+	ref.Target.Author.Raw = encodeRawAuthor(&src.Commit.Author)
+	ref.Target.Author.User = encodedUser{
+		Username:    src.Commit.Author.Login,
+		DisplayName: src.Commit.Author.Name,
+	}
+	ref.Target.Author.User.Links.Avatar.Href = src.Commit.Author.Avatar
+	dst.Push.Changes = []encodedChange{{
+		Forced:  src.Forced,
+		Created: src.Created,
+		New:     ref,
+	}}
+	return dst
+}
+
+func encodeRefHook(action scm.Action, refType string, ref *scm.Reference, repo *scm.Repository, sender *scm.User) *encodedPushHook {
+	dst := &encodedPushHook{
+		Repository: *encodeRepository(repo),
+		Actor:      *encodeUser(sender),
+	}
+	change := &encodedRef{Type: refType, Name: ref.Name}
+	change.Target.Type = "commit"
+	change.Target.Hash = ref.Sha
+	if action == scm.ActionDelete {
+		dst.Push.Changes = []encodedChange{{Old: change, Closed: true}}
+	} else {
+		dst.Push.Changes = []encodedChange{{New: change, Created: true}}
+	}
+	return dst
+}
+
+func encodePullRequestHook(src *scm.PullRequestHook) *encodedPullRequestHook {
+	return &encodedPullRequestHook{
+		PullRequest: *encodePullRequest(&src.PullRequest, &src.Repo),
+		Repository:  *encodeRepository(&src.Repo),
+		Actor:       *encodeUser(&src.Sender),
+	}
+}
+
+func encodePullRequestCommentHook(src *scm.PullRequestCommentHook) *encodedPullRequestCommentHook {
+	dst := &encodedPullRequestCommentHook{
+		PullRequest: *encodePullRequest(&src.PullRequest, &src.Repo),
+		Repository:  *encodeRepository(&src.Repo),
+		Actor:       *encodeUser(&src.Sender),
+	}
+	dst.Comment.ID = src.Comment.ID
+	dst.Comment.Content.Raw = src.Comment.Body
+	// the comment author is identified by the account id.
+	dst.Comment.User = encodedUser{
+		AccountID:   src.Comment.Author.Login,
+		DisplayName: src.Comment.Author.Name,
+	}
+	dst.Comment.User.Links.Avatar.Href = src.Comment.Author.Avatar
+	dst.Comment.CreatedOn = src.Comment.Created
+	dst.Comment.UpdatedOn = src.Comment.Updated
+	return dst
+}
+
+func encodePullRequest(src *scm.PullRequest, repo *scm.Repository) *encodedPullRequest {
+	dst := &encodedPullRequest{
+		ID:          src.Number,
+		Title:       src.Title,
+		Description: src.Body,
+		State:       encodePullRequestState(src),
+		Draft:       src.Draft,
+		Author:      *encodeUser(&src.Author),
+		CreatedOn:   src.Created,
+		UpdatedOn:   src.Updated,
+	}
+	dst.Links.HTML.Href = src.Link
+
+	head := src.Head.Repo
+	if src.Fork != "" {
+		head.FullName = src.Fork
+	} else if head.FullName == "" {
+		head = *repo
+	}
+	dst.Source.Repository = *encodeRepository(&head)
+	dst.Source.Branch.Name = src.Source
+	dst.Source.Commit.Hash = src.Sha
+
+	base := src.Base.Repo
+	if base.FullName == "" {
+		base = *repo
+	}
+	dst.Destination.Repository = *encodeRepository(&base)
+	dst.Destination.Branch.Name = src.Target
+	dst.Destination.Commit.Hash = src.Base.Sha
+	return dst
+}
+
+func encodePullRequestState(src *scm.PullRequest) string {
+	switch {
+	case src.Merged:
+		return "MERGED"
+	case src.Closed:
+		return "DECLINED"
+	default:
+		return "OPEN"
+	}
+}
+
+func encodePullRequestEvent(action scm.Action) string {
+	switch action {
+	case scm.ActionOpen, scm.ActionCreate:
+		return "pullrequest:created"
+	case scm.ActionMerge:
+		return "pullrequest:fulfilled"
+	case scm.ActionClose:
+		return "pullrequest:rejected"
+	default:
+		return "pullrequest:updated"
+	}
+}
+
+func encodeRepository(src *scm.Repository) *encodedRepository {
+	dst := &encodedRepository{
+		Scm:       "git",
+		Name:      src.Name,
+		FullName:  src.FullName,
+		UUID:      src.ID,
+		IsPrivate: src.Private,
+	}
+	if dst.FullName == "" && src.Name != "" {
+		dst.FullName = scm.Join(src.Namespace, src.Name)
+	}
+	dst.Links.HTML.Href = src.Link
+	return dst
+}
+
+func encodeUser(src *scm.User) *encodedUser {
+	dst := &encodedUser{
+		Username:    src.Login,
+		DisplayName: src.Name,
+	}
+	dst.Links.Avatar.Href = src.Avatar
+	return dst
+}
+
+// encodeRawAuthor returns the git author in the raw format
+// included in commit payloads.
+func encodeRawAuthor(src *scm.Signature) string {
+	if src.Email == "" {
+		return src.Name
+	}
+	return strings.TrimSpace(fmt.Sprintf("%s <%s>", src.Name, src.Email))
+}
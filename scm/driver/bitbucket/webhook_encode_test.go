@@ -0,0 +1,120 @@
+package bitbucket
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+func TestWebhookEncode(t *testing.T) {
+	tests := []struct {
+		event string
+		file  string
+	}{
+		{event: "repo:push", file: "testdata/webhooks/push.json"},
+		{event: "repo:push", file: "testdata/webhooks/push_tag_create.json"},
+		{event: "repo:push", file: "testdata/webhooks/push_tag_delete.json"},
+		{event: "repo:push", file: "testdata/webhooks/push_branch_create.json"},
+		{event: "repo:push", file: "testdata/webhooks/push_branch_delete.json"},
+		{event: "pullrequest:created", file: "testdata/webhooks/pr_created.json"},
+		{event: "pullrequest:created", file: "testdata/webhooks/pr_created_slashbranch.json"},
+		{event: "pullrequest:updated", file: "testdata/webhooks/pr_updated.json"},
+		{event: "pullrequest:fulfilled", file: "testdata/webhooks/pr_fulfilled.json"},
+		{event: "pullrequest:rejected", file: "testdata/webhooks/pr_declined.json"},
+	}
+
+	s := new(webhookService)
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			data, err := os.ReadFile(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, _ := http.NewRequest("POST", "/", bytes.NewReader(data))
+			r.Header.Set("X-Event-Key", test.event)
+			r.Header.Set("X-Hook-UUID", "ee8d97b4-1479-43f1-9cac-fbbd1b80da55")
+			want, err := s.Parse(r, noSecretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req, err := s.Encode(want, "71295b197fa25f4356d2fb9965df3f2379d903d7")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := req.Header.Get("X-Event-Key"); got != test.event {
+				t.Errorf("Want event %s, got %s", test.event, got)
+			}
+
+			got, err := s.Parse(req, secretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Encoded webhook does not round trip")
+				t.Log(diff)
+			}
+		})
+	}
+}
+
+func TestWebhookEncode_PullRequestComment(t *testing.T) {
+	hook := &scm.PullRequestCommentHook{
+		Action: scm.ActionCreate,
+		PullRequest: scm.PullRequest{
+			Number:  1,
+			Title:   "Update README",
+			Sha:     "7d1a175411ef",
+			Source:  "feature",
+			Target:  "master",
+			Fork:    "brydzewski/foo",
+			Author:  scm.User{Login: "brydzewski"},
+			Created: time.Date(2018, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		Repo: scm.Repository{ID: "{a1b2}", Namespace: "brydzewski", Name: "foo", FullName: "brydzewski/foo"},
+		Comment: scm.Comment{
+			ID:     42,
+			Body:   "/lgtm",
+			Author: scm.User{Login: "557058:c8b5c2e9", Name: "Brad Rydzewski"},
+		},
+		Sender: scm.User{Login: "brydzewski"},
+	}
+
+	s := new(webhookService)
+	req, err := s.Encode(hook, "71295b197fa25f4356d2fb9965df3f2379d903d7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := s.Parse(req, secretFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	comment, ok := got.(*scm.PullRequestCommentHook)
+	if !ok {
+		t.Fatalf("Expect pull request comment hook, got %T", got)
+	}
+	if diff := cmp.Diff(hook.Comment, comment.Comment); diff != "" {
+		t.Errorf("Unexpected comment")
+		t.Log(diff)
+	}
+	if comment.PullRequest.Number != 1 || comment.PullRequest.Sha != "7d1a175411ef" {
+		t.Errorf("Unexpected pull request %+v", comment.PullRequest)
+	}
+}
+
+func TestWebhookEncode_NotSupported(t *testing.T) {
+	s := new(webhookService)
+	_, err := s.Encode(&scm.IssueHook{}, "71295b197fa25f4356d2fb9965df3f2379d903d7")
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect not supported error, got %v", err)
+	}
+}
+
+func noSecretFunc(scm.Webhook) (string, error) {
+	return "", nil
+}
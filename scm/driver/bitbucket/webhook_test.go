@@ -107,6 +107,14 @@ func TestWebhooks(t *testing.T) {
 			after:  "testdata/webhooks/pr_updated.json.golden",
 			obj:    new(scm.PullRequestHook),
 		},
+		// draft pull request updated
+		{
+			sig:    "71295b197fa25f4356d2fb9965df3f2379d903d7",
+			event:  "pullrequest:updated",
+			before: "testdata/webhooks/pr_updated_draft.json",
+			after:  "testdata/webhooks/pr_updated_draft.json.golden",
+			obj:    new(scm.PullRequestHook),
+		},
 		// pull request fulfilled (merged)
 		{
 			sig:    "71295b197fa25f4356d2fb9965df3f2379d903d7",
@@ -221,6 +229,49 @@ func TestWebhookValidated(t *testing.T) {
 	}
 }
 
+func TestWebhookSignatureValidated(t *testing.T) {
+	// the sha can be recalculated with the below command
+	// openssl dgst -sha256 -hmac <secret> <file>
+
+	f, _ := os.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+	r.Header.Set("x-event-key", "repo:push")
+	r.Header.Set("X-Hub-Signature", "sha256=811688563e3cc0d2bd3f5b277b0b5835c06cbc0bbefeb582498888925675d014")
+
+	s := new(webhookService)
+	_, err := s.Parse(r, secretFunc)
+	if err != nil {
+		t.Errorf("Expect valid signature, got %v", err)
+	}
+}
+
+func TestWebhookSignatureInvalid(t *testing.T) {
+	f, _ := os.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("GET", "/?secret=71295b197fa25f4356d2fb9965df3f2379d903d7", bytes.NewBuffer(f))
+	r.Header.Set("x-event-key", "repo:push")
+	r.Header.Set("X-Hub-Signature", "sha256=8ca57e2afbad9fea8860404575c2d61827995c62aacd4c514eae4c404896390b")
+
+	s := new(webhookService)
+	_, err := s.Parse(r, secretFunc)
+	if err != scm.ErrSignatureInvalid {
+		t.Errorf("Expect invalid signature error, got %v", err)
+	}
+}
+
+func TestWebhookRotatedSecret(t *testing.T) {
+	f, _ := os.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("GET", "/?secret=71295b197fa25f4356d2fb9965df3f2379d903d7", bytes.NewBuffer(f))
+	r.Header.Set("x-event-key", "repo:push")
+
+	s := new(webhookService)
+	_, err := s.ParseWithSecrets(r, func(scm.Webhook) ([]string, error) {
+		return []string{"newsecret", "71295b197fa25f4356d2fb9965df3f2379d903d7"}, nil
+	})
+	if err != nil {
+		t.Errorf("Expect valid signature, got %v", err)
+	}
+}
+
 func secretFunc(scm.Webhook) (string, error) {
 	return "71295b197fa25f4356d2fb9965df3f2379d903d7", nil
 }
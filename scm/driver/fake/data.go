@@ -31,10 +31,15 @@ type Data struct {
 	CurrentUser                scm.User
 	Users                      []*scm.User
 	Hooks                      map[string][]*scm.Hook
+	HookDeliveries             map[string][]*scm.HookDelivery
+	OrganizationHooks          map[string][]*scm.Hook
 	Releases                   map[string]map[int]*scm.Release
 	Deployments                map[string][]*scm.Deployment
 	DeploymentStatus           map[string][]*scm.DeploymentStatus
 
+	// hook id:secret
+	HookSecrets map[string]string
+
 	// All Labels That Exist In The Repo
 	RepoLabelsExisting []string
 	// org/repo#number:label
@@ -47,9 +52,13 @@ type Data struct {
 	// org/repo#issuecommentid
 	IssueCommentsDeleted []string
 
+	// org/repo#number:reaction
+	IssueReactionsAdded []string
 	// org/repo#issuecommentid:reaction
-	IssueReactionsAdded   []string
 	CommentReactionsAdded []string
+	// org/repo#kind/id:reaction, where kind is issue, pull,
+	// issue_comment, pull_comment or review_comment
+	ReactionsAdded []string
 
 	// org/repo#number:assignee
 	AssigneesAdded []string
@@ -76,6 +85,15 @@ type Data struct {
 
 	// ContentDir the directory used to implement the Content service to access files and directories
 	ContentDir string
+
+	// AutoMerge the auto merge options enabled on each pull request
+	AutoMerge map[int]*scm.AutoMergeOptions
+
+	// MergeQueue the pull request numbers waiting in the merge queue, in order
+	MergeQueue []int
+
+	// CommitChanges the files changed by each commit in Commits, keyed by sha
+	CommitChanges map[string][]*scm.Change
 }
 
 // DeletedRef represents a ref that has been deleted
@@ -97,10 +115,12 @@ func NewData() *Data {
 		PullRequestLabelsRemoved:  []string{},
 		PullRequestLabelsExisting: []string{},
 		PullRequestsCreated:       map[int]*scm.PullRequestInput{},
+		AutoMerge:                 map[int]*scm.AutoMergeOptions{},
 		Reviews:                   map[int][]*scm.Review{},
 		Statuses:                  map[string][]*scm.Status{},
 		IssueEvents:               map[int][]*scm.ListedIssueEvent{},
 		Commits:                   map[string]*scm.Commit{},
+		CommitChanges:             map[string][]*scm.Change{},
 		MilestoneMap:              map[string]int{},
 		CommitMap:                 map[string][]scm.Commit{},
 		RemoteFiles:               map[string]map[string]string{},
@@ -112,9 +132,13 @@ func NewData() *Data {
 		IssueCommentsDeleted:      []string{},
 		IssueReactionsAdded:       []string{},
 		CommentReactionsAdded:     []string{},
+		ReactionsAdded:            []string{},
 		AssigneesAdded:            []string{},
 		UserPermissions:           map[string]map[string]string{},
 		Hooks:                     map[string][]*scm.Hook{},
+		HookDeliveries:            map[string][]*scm.HookDelivery{},
+		OrganizationHooks:         map[string][]*scm.Hook{},
+		HookSecrets:               map[string]string{},
 		Deployments:               map[string][]*scm.Deployment{},
 		DeploymentStatus:          map[string][]*scm.DeploymentStatus{},
 	}
@@ -31,7 +31,9 @@ func NewDefault() (*scm.Client, *Data) {
 	client.PullRequests = &pullService{client: client, data: data}
 	client.Repositories = &repositoryService{client: client, data: data}
 	client.Releases = &releaseService{client: client, data: data}
+	client.Reactions = &reactionService{client: client, data: data}
 	client.Reviews = &reviewService{client: client, data: data}
+	client.Search = &searchService{client: client, data: data}
 	client.Users = &userService{client: client, data: data}
 
 	client.Username = data.CurrentUser.Login
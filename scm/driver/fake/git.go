@@ -1,8 +1,15 @@
 package fake
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha1" // #nosec
+	"encoding/hex"
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/jenkins-x/go-scm/scm"
@@ -63,6 +70,101 @@ func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string
 	panic("implement me")
 }
 
+// Compare walks the parents of the commits in data.Commits to compare
+// the head commit to the base commit.
+func (s *gitService) Compare(ctx context.Context, repo, base, head string, opts *scm.ListOptions) (*scm.Comparison, *scm.Response, error) {
+	f := s.data
+	if f.Commits[base] == nil || f.Commits[head] == nil {
+		return nil, nil, scm.ErrNotFound
+	}
+	baseAncestors := s.ancestors(base)
+	headAncestors := s.ancestors(head)
+
+	ahead := s.commitsExcluding(head, baseAncestors)
+	behind := s.commitsExcluding(base, headAncestors)
+
+	// the merge base is the nearest commit to head that is
+	// also reachable from base.
+	mergeBase := ""
+	queue := []string{head}
+	seen := map[string]bool{}
+	for len(queue) > 0 && mergeBase == "" {
+		sha := queue[0]
+		queue = queue[1:]
+		if seen[sha] {
+			continue
+		}
+		seen[sha] = true
+		if baseAncestors[sha] {
+			mergeBase = sha
+		} else if c := f.Commits[sha]; c != nil {
+			queue = append(queue, c.Parents...)
+		}
+	}
+	return &scm.Comparison{
+		Status:    scm.ComparisonStatusOf(len(ahead), len(behind)),
+		AheadBy:   len(ahead),
+		BehindBy:  len(behind),
+		MergeBase: mergeBase,
+		Commits:   ahead,
+	}, nil, nil
+}
+
+// ancestors returns the set of commits reachable from sha, including
+// sha itself.
+func (s *gitService) ancestors(sha string) map[string]bool {
+	set := map[string]bool{}
+	stack := []string{sha}
+	for len(stack) > 0 {
+		sha := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if set[sha] {
+			continue
+		}
+		set[sha] = true
+		if c := s.data.Commits[sha]; c != nil {
+			stack = append(stack, c.Parents...)
+		}
+	}
+	return set
+}
+
+// commitsExcluding returns the commits reachable from sha that are not
+// in exclude, parents before their children.
+func (s *gitService) commitsExcluding(sha string, exclude map[string]bool) []*scm.Commit {
+	list := []*scm.Commit{}
+	seen := map[string]bool{}
+	var visit func(string)
+	visit = func(sha string) {
+		c := s.data.Commits[sha]
+		if c == nil || seen[sha] || exclude[sha] {
+			return
+		}
+		seen[sha] = true
+		for _, p := range c.Parents {
+			visit(p)
+		}
+		list = append(list, c)
+	}
+	visit(sha)
+	return list
+}
+
+// CompareDiff renders the changes in data.CommitChanges of the
+// commits reachable from ref2 but not from ref1, oldest first.
+func (s *gitService) CompareDiff(ctx context.Context, repo, ref1, ref2 string) (*scm.Diff, *scm.Response, error) {
+	f := s.data
+	if f.Commits[ref1] == nil || f.Commits[ref2] == nil {
+		return nil, nil, scm.ErrNotFound
+	}
+	changes := []*scm.Change{}
+	for _, c := range s.commitsExcluding(ref2, s.ancestors(ref1)) {
+		changes = append(changes, f.CommitChanges[c.Sha]...)
+	}
+	diff, err := scm.ParseDiff(scm.FormatChanges(changes))
+	return diff, nil, err
+}
+
 func (s *gitService) ListTags(ctx context.Context, repo string, opts *scm.ListOptions) ([]*scm.Reference, *scm.Response, error) {
 	panic("implement me")
 }
@@ -70,3 +172,101 @@ func (s *gitService) ListTags(ctx context.Context, repo string, opts *scm.ListOp
 func (s *gitService) GetDefaultBranch(ctx context.Context, repo string) (*scm.Reference, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
+
+// FindTree lists the files beneath the repository folder in data.ContentDir
+func (s *gitService) FindTree(ctx context.Context, repo, ref string, recursive bool) (*scm.Tree, *scm.Response, error) {
+	c := contentService{client: s.client, data: s.data}
+	dir, err := c.path(repo, "", ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	tree := &scm.Tree{Sha: ref, Entries: []*scm.TreeEntry{}}
+	err = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || path == dir {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		sha, err := objectSha(path, d.IsDir())
+		if err != nil {
+			return err
+		}
+		entry := &scm.TreeEntry{
+			Path: filepath.ToSlash(rel),
+			Mode: "100644",
+			Type: scm.TreeEntryBlob,
+			Sha:  sha,
+			Size: info.Size(),
+		}
+		if d.IsDir() {
+			entry.Mode = "040000"
+			entry.Type = scm.TreeEntryTree
+			entry.Size = 0
+		}
+		tree.Entries = append(tree.Entries, entry)
+		if d.IsDir() && !recursive {
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return tree, nil, nil
+}
+
+// objectSha returns the git object id of the file or directory, so
+// that tree entries have the sha git would give them.
+func objectSha(path string, dir bool) (string, error) {
+	if !dir {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return hashObject("blob", data), nil
+	}
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return "", err
+	}
+	// git orders tree entries by name, directories sorting as if
+	// their name ended with a slash.
+	sortName := func(e fs.DirEntry) string {
+		if e.IsDir() {
+			return e.Name() + "/"
+		}
+		return e.Name()
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return sortName(entries[i]) < sortName(entries[j])
+	})
+	buf := new(bytes.Buffer)
+	for _, e := range entries {
+		sha, err := objectSha(filepath.Join(path, e.Name()), e.IsDir())
+		if err != nil {
+			return "", err
+		}
+		raw, _ := hex.DecodeString(sha)
+		mode := "100644"
+		if e.IsDir() {
+			mode = "40000"
+		}
+		fmt.Fprintf(buf, "%s %s\x00", mode, e.Name())
+		buf.Write(raw)
+	}
+	return hashObject("tree", buf.Bytes()), nil
+}
+
+// hashObject returns the git object id of the contents.
+func hashObject(kind string, data []byte) string {
+	h := sha1.New() // #nosec
+	fmt.Fprintf(h, "%s %d\x00", kind, len(data))
+	h.Write(data)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,138 @@
+package fake_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindTree(t *testing.T) {
+	client, _ := fake.NewDefault()
+	ctx := context.Background()
+
+	tree, _, err := client.Git.FindTree(ctx, "myorg/myrepo", "master", true)
+	require.NoError(t, err)
+
+	var paths []string
+	shas := map[string]string{}
+	for _, e := range tree.Entries {
+		paths = append(paths, e.Path)
+		shas[e.Path] = e.Sha
+	}
+	assert.ElementsMatch(t, []string{"README.md", "somedir", "somedir/something.txt"}, paths)
+	assert.Equal(t, map[string]string{
+		"README.md":             "a25ceb60c5efdb683dfe09acb414c8a970cda06e",
+		"somedir":               "06e4d4a34f730b2c88f0ff0a50af31b4c4794eeb",
+		"somedir/something.txt": "95d09f2b10159347eece71399a7e2e907ea3df4f",
+	}, shas)
+
+	tree, _, err = client.Git.FindTree(ctx, "myorg/myrepo", "master", false)
+	require.NoError(t, err)
+	require.Len(t, tree.Entries, 2)
+	for _, e := range tree.Entries {
+		if e.Path == "somedir" {
+			assert.Equal(t, scm.TreeEntryTree, e.Type)
+		} else {
+			assert.Equal(t, scm.TreeEntryBlob, e.Type)
+		}
+	}
+}
+
+func TestArchive(t *testing.T) {
+	client, _ := fake.NewDefault()
+
+	rc, _, err := client.Repositories.Archive(context.Background(), "myorg/myrepo", "master", scm.ArchiveFormatTarball)
+	require.NoError(t, err)
+	defer rc.Close()
+
+	gr, err := gzip.NewReader(rc)
+	require.NoError(t, err)
+	tr := tar.NewReader(gr)
+
+	var names []string
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		names = append(names, hdr.Name)
+	}
+	assert.ElementsMatch(t, []string{"README.md", "somedir/something.txt"}, names)
+}
+
+func TestCompare(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+
+	// a - b - c  master
+	//      \
+	//       d - e  feature
+	for sha, parents := range map[string][]string{
+		"a": nil,
+		"b": {"a"},
+		"c": {"b"},
+		"d": {"b"},
+		"e": {"d"},
+	} {
+		data.Commits[sha] = &scm.Commit{Sha: sha, Parents: parents}
+	}
+
+	got, _, err := client.Git.Compare(ctx, "myorg/myrepo", "c", "e", &scm.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, scm.ComparisonDiverged, got.Status)
+	assert.Equal(t, 2, got.AheadBy)
+	assert.Equal(t, 1, got.BehindBy)
+	assert.Equal(t, "b", got.MergeBase)
+	require.Len(t, got.Commits, 2)
+	assert.Equal(t, "d", got.Commits[0].Sha)
+	assert.Equal(t, "e", got.Commits[1].Sha)
+
+	got, _, err = client.Git.Compare(ctx, "myorg/myrepo", "a", "c", &scm.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, scm.ComparisonAhead, got.Status)
+	assert.Equal(t, "a", got.MergeBase)
+
+	got, _, err = client.Git.Compare(ctx, "myorg/myrepo", "c", "c", &scm.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, scm.ComparisonIdentical, got.Status)
+	assert.Empty(t, got.Commits)
+
+	_, _, err = client.Git.Compare(ctx, "myorg/myrepo", "c", "missing", &scm.ListOptions{})
+	assert.Equal(t, scm.ErrNotFound, err)
+}
+
+func TestCompareDiff(t *testing.T) {
+	client, data := fake.NewDefault()
+	ctx := context.Background()
+
+	// a - b  master
+	//  \
+	//   c  feature
+	for sha, parents := range map[string][]string{
+		"a": nil,
+		"b": {"a"},
+		"c": {"a"},
+	} {
+		data.Commits[sha] = &scm.Commit{Sha: sha, Parents: parents}
+	}
+	data.CommitChanges["b"] = []*scm.Change{{Path: "master.txt", Added: true, Patch: "@@ -0,0 +1 @@\n+master\n"}}
+	data.CommitChanges["c"] = []*scm.Change{{Path: "feature.txt", Added: true, Patch: "@@ -0,0 +1 @@\n+feature\n"}}
+
+	got, _, err := client.Git.CompareDiff(ctx, "myorg/myrepo", "b", "c")
+	require.NoError(t, err)
+	require.Len(t, got.Files, 1)
+	assert.Equal(t, "feature.txt", got.Files[0].NewPath)
+	assert.True(t, got.Files[0].Added)
+	require.Len(t, got.Files[0].Hunks, 1)
+
+	_, _, err = client.Git.CompareDiff(ctx, "myorg/myrepo", "b", "missing")
+	assert.Equal(t, scm.ErrNotFound, err)
+}
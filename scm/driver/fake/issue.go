@@ -130,8 +130,53 @@ func (s *issueService) ListComments(ctx context.Context, repo string, number int
 	return append([]*scm.Comment{}, f.IssueComments[number]...), nil, nil
 }
 
-func (s *issueService) Create(context.Context, string, *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
-	panic("implement me")
+func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	f := s.data
+	number := 1
+	for _, slice := range f.Issues {
+		for _, issue := range slice {
+			if issue.Number >= number {
+				number = issue.Number + 1
+			}
+		}
+	}
+	issue := &scm.Issue{
+		Number: number,
+		State:  "open",
+		Author: scm.User{Login: botName},
+	}
+	updateIssue(issue, input)
+	f.Issues[number] = append(f.Issues[number], issue)
+	return issue, nil, nil
+}
+
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	issue, _, _ := s.Find(ctx, repo, number)
+	if issue == nil {
+		return nil, nil, scm.ErrNotFound
+	}
+	updateIssue(issue, input)
+	return issue, nil, nil
+}
+
+// updateIssue sets the fields of the input on the issue, leaving empty
+// fields unchanged.
+func updateIssue(issue *scm.Issue, input *scm.IssueInput) {
+	if input.Title != "" {
+		issue.Title = input.Title
+	}
+	if input.Body != "" {
+		issue.Body = input.Body
+	}
+	if len(input.Labels) > 0 {
+		issue.Labels = append([]string{}, input.Labels...)
+	}
+	if len(input.Assignees) > 0 {
+		issue.Assignees = nil
+		for _, login := range input.Assignees {
+			issue.Assignees = append(issue.Assignees, scm.User{Login: login})
+		}
+	}
 }
 
 func (s *issueService) CreateComment(ctx context.Context, repo string, number int, comment *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
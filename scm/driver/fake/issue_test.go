@@ -0,0 +1,43 @@
+package fake
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+func TestIssueCreateUpdate(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+	data.Issues[4] = []*scm.Issue{{Number: 4, Title: "existing"}}
+
+	issue, _, err := client.Issues.Create(ctx, "test/test", &scm.IssueInput{
+		Title:     "Found a bug",
+		Body:      "I'm having a problem with this.",
+		Labels:    []string{"bug"},
+		Assignees: []string{"jcitizen"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.Number != 5 {
+		t.Errorf("Want issue number 5, got %d", issue.Number)
+	}
+
+	updated, _, err := client.Issues.Update(ctx, "test/test", 5, &scm.IssueInput{Labels: []string{"bug", "triaged"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Title != "Found a bug" {
+		t.Errorf("Want the title unchanged, got %q", updated.Title)
+	}
+	if want := []string{"bug", "triaged"}; !reflect.DeepEqual(updated.Labels, want) {
+		t.Errorf("Want labels %v, got %v", want, updated.Labels)
+	}
+
+	if _, _, err := client.Issues.Update(ctx, "test/test", 6, &scm.IssueInput{}); err != scm.ErrNotFound {
+		t.Errorf("Want not found error, got %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package fake
 import (
 	"context"
 	"fmt"
+	"math/rand"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -141,3 +142,54 @@ func (s *organizationService) AcceptOrganizationInvitation(_ context.Context, or
 	}
 	return nil, scm.ErrNotFound
 }
+
+func (s *organizationService) FindHook(ctx context.Context, org, id string) (*scm.Hook, *scm.Response, error) {
+	for _, h := range s.data.OrganizationHooks[org] {
+		if h.ID == id {
+			return h, nil, nil
+		}
+	}
+	return nil, nil, scm.ErrNotFound
+}
+
+func (s *organizationService) ListHooks(ctx context.Context, org string, opts *scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
+	return s.data.OrganizationHooks[org], nil, nil
+}
+
+func (s *organizationService) CreateHook(ctx context.Context, org string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	hook := &scm.Hook{
+		//nolint:gosec
+		ID:         fmt.Sprintf("%d", rand.Int()),
+		Name:       input.Name,
+		Target:     input.Target,
+		Events:     convertHookEvents(input),
+		Active:     true,
+		SkipVerify: input.SkipVerify,
+	}
+	s.data.OrganizationHooks[org] = append(s.data.OrganizationHooks[org], hook)
+	s.data.HookSecrets[hook.ID] = input.Secret
+	return hook, nil, nil
+}
+
+func (s *organizationService) UpdateHook(ctx context.Context, org string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	hook, _, err := s.FindHook(ctx, org, input.Name)
+	if err != nil {
+		return nil, nil, err
+	}
+	hook.Target = input.Target
+	hook.Events = convertHookEvents(input)
+	hook.SkipVerify = input.SkipVerify
+	s.data.HookSecrets[hook.ID] = input.Secret
+	return hook, nil, nil
+}
+
+func (s *organizationService) DeleteHook(ctx context.Context, org, id string) (*scm.Response, error) {
+	hooks := s.data.OrganizationHooks[org]
+	for i, h := range hooks {
+		if h.ID == id {
+			s.data.OrganizationHooks[org] = append(hooks[0:i], hooks[i+1:]...)
+			return nil, nil
+		}
+	}
+	return nil, scm.ErrNotFound
+}
@@ -0,0 +1,85 @@
+package fake_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganizationHooks(t *testing.T) {
+	ctx := context.Background()
+	client, data := fake.NewDefault()
+
+	hook, _, err := client.Organizations.CreateHook(ctx, "myorg", &scm.HookInput{
+		Name:         "ci",
+		Target:       "https://example.com",
+		NativeEvents: []string{"push"},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, hook.ID)
+	require.Len(t, data.OrganizationHooks["myorg"], 1)
+	assert.Empty(t, data.Hooks, "organization hooks must not be stored as repository hooks")
+
+	hooks, _, err := client.Organizations.ListHooks(ctx, "myorg", &scm.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, hooks, 1)
+
+	_, _, err = client.Organizations.UpdateHook(ctx, "myorg", &scm.HookInput{
+		Name:         hook.ID,
+		Target:       "https://example.com/hook",
+		NativeEvents: []string{"push", "member"},
+	})
+	require.NoError(t, err)
+
+	got, _, err := client.Organizations.FindHook(ctx, "myorg", hook.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/hook", got.Target)
+	assert.Equal(t, []string{"push", "member"}, got.Events)
+	assert.Equal(t, got, data.OrganizationHooks["myorg"][0])
+
+	repoHooks, _, err := client.Repositories.ListHooks(ctx, "myorg/repo", &scm.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, repoHooks, "organization hooks must not be listed as repository hooks")
+
+	_, err = client.Organizations.DeleteHook(ctx, "myorg", hook.ID)
+	require.NoError(t, err)
+	assert.Empty(t, data.OrganizationHooks["myorg"])
+
+	_, _, err = client.Organizations.FindHook(ctx, "myorg", hook.ID)
+	assert.Equal(t, scm.ErrNotFound, err)
+}
+
+func TestOrganizationHooksReconcile(t *testing.T) {
+	ctx := context.Background()
+	client, data := fake.NewDefault()
+	data.OrganizationHooks["myorg"] = []*scm.Hook{
+		{ID: "1", Target: "https://example.com/hook", Events: []string{"push"}, Active: true},
+	}
+
+	// find the existing hook by target and update it in place,
+	// as a hook reconciler would.
+	hooks, _, err := client.Organizations.ListHooks(ctx, "myorg", &scm.ListOptions{})
+	require.NoError(t, err)
+	var existing *scm.Hook
+	for _, h := range hooks {
+		if h.Target == "https://example.com/hook" {
+			existing = h
+		}
+	}
+	require.NotNil(t, existing)
+
+	_, _, err = client.Organizations.UpdateHook(ctx, "myorg", &scm.HookInput{
+		Name:         existing.ID,
+		Target:       existing.Target,
+		NativeEvents: []string{"push", "pull_request"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, data.OrganizationHooks["myorg"], 1)
+	assert.Equal(t, "1", data.OrganizationHooks["myorg"][0].ID)
+	assert.Equal(t, []string{"push", "pull_request"}, data.OrganizationHooks["myorg"][0].Events)
+}
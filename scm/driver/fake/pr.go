@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/jenkins-x/go-scm/scm"
@@ -50,7 +51,7 @@ func (s *pullService) List(ctx context.Context, fullName string, opts *scm.PullR
 		}
 	}
 
-	filteredPullRequests := filterPullRequests(allPullRequests, opts)
+	filteredPullRequests := scm.FilterPullRequests(filterPullRequests(allPullRequests, opts), opts)
 
 	returnStart, returnEnd := paginated(opts.Page, opts.Size, len(filteredPullRequests))
 	return filteredPullRequests[returnStart:returnEnd], nil, nil
@@ -133,12 +134,53 @@ func filterPullRequests(requests []*scm.PullRequest, opts *scm.PullRequestListOp
 	return returnRequests
 }
 
+// ListForCommit returns the pull requests whose head, merge commit or
+// commits in data.CommitMap match the sha.
+func (s *pullService) ListForCommit(ctx context.Context, fullName, sha string) ([]*scm.PullRequest, *scm.Response, error) {
+	f := s.data
+	keys := make([]int, 0, len(f.PullRequests))
+	for prKey := range f.PullRequests {
+		keys = append(keys, prKey)
+	}
+	sort.Ints(keys)
+	prs := []*scm.PullRequest{}
+	for _, prKey := range keys {
+		pr := f.PullRequests[prKey]
+		repo := pr.Repository()
+		fn := repo.FullName
+		if fn == "" {
+			fn = scm.Join(repo.Namespace, repo.Name)
+		}
+		if fn != fullName {
+			continue
+		}
+		found := pr.Sha == sha || pr.Head.Sha == sha || pr.MergeSha == sha
+		for _, c := range f.CommitMap[fmt.Sprintf("%s#%d", fullName, pr.Number)] {
+			found = found || c.Sha == sha
+		}
+		if found {
+			prs = append(prs, pr)
+		}
+	}
+	return prs, nil, nil
+}
+
 func (s *pullService) ListChanges(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
 	f := s.data
 	returnStart, returnEnd := paginated(opts.Page, opts.Size, len(f.PullRequestChanges[number]))
 	return f.PullRequestChanges[number][returnStart:returnEnd], nil, nil
 }
 
+func (s *pullService) FindDiff(ctx context.Context, repo string, number int) (*scm.Diff, *scm.Response, error) {
+	f := s.data
+	changes, ok := f.PullRequestChanges[number]
+	if !ok {
+		return nil, nil, scm.ErrNotFound
+	}
+	diff, err := scm.ParseDiff(scm.FormatChanges(changes))
+	return diff, nil, err
+}
+
 func (s *pullService) ListCommits(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Commit, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
@@ -359,6 +401,7 @@ func (s *pullService) Create(_ context.Context, fullName string, input *scm.Pull
 			},
 		},
 		Source: input.Head,
+		Draft:  input.Draft,
 		Link:   fmt.Sprintf("https://api.fake.com/pull/%d", f.PullRequestID),
 		Head: scm.PullRequestBranch{
 			Ref: input.Head,
@@ -380,3 +423,84 @@ func (s *pullService) ClearMilestone(ctx context.Context, repo string, prID int)
 func (s *pullService) DeletePullRequest(ctx context.Context, repo string, prID int) (*scm.Response, error) {
 	return nil, scm.ErrNotSupported
 }
+
+func (s *pullService) MarkReady(_ context.Context, fullName string, number int) (*scm.Response, error) {
+	return s.setDraft(number, false)
+}
+
+func (s *pullService) ConvertToDraft(_ context.Context, fullName string, number int) (*scm.Response, error) {
+	return s.setDraft(number, true)
+}
+
+func (s *pullService) EnableAutoMerge(_ context.Context, fullName string, number int, opts *scm.AutoMergeOptions) (*scm.Response, error) {
+	if _, ok := s.data.PullRequests[number]; !ok {
+		return nil, fmt.Errorf("pull request %d not found", number)
+	}
+	if opts == nil {
+		opts = &scm.AutoMergeOptions{}
+	}
+	s.data.AutoMerge[number] = opts
+	return nil, nil
+}
+
+func (s *pullService) DisableAutoMerge(_ context.Context, fullName string, number int) (*scm.Response, error) {
+	delete(s.data.AutoMerge, number)
+	return nil, nil
+}
+
+func (s *pullService) EnqueueMerge(ctx context.Context, fullName string, number int, opts *scm.AutoMergeOptions) (*scm.MergeQueueEntry, *scm.Response, error) {
+	if _, ok := s.data.PullRequests[number]; !ok {
+		return nil, nil, fmt.Errorf("pull request %d not found", number)
+	}
+	if entry, _, err := s.FindMergeQueueEntry(ctx, fullName, number); err == nil {
+		return entry, nil, nil
+	}
+	s.data.MergeQueue = append(s.data.MergeQueue, number)
+	return s.FindMergeQueueEntry(ctx, fullName, number)
+}
+
+func (s *pullService) DequeueMerge(_ context.Context, fullName string, number int) (*scm.Response, error) {
+	for i, n := range s.data.MergeQueue {
+		if n == number {
+			s.data.MergeQueue = append(s.data.MergeQueue[:i], s.data.MergeQueue[i+1:]...)
+			return nil, nil
+		}
+	}
+	return nil, scm.ErrNotFound
+}
+
+func (s *pullService) FindMergeQueueEntry(_ context.Context, fullName string, number int) (*scm.MergeQueueEntry, *scm.Response, error) {
+	for i, n := range s.data.MergeQueue {
+		if n != number {
+			continue
+		}
+		entry := &scm.MergeQueueEntry{
+			ID:       strconv.Itoa(number),
+			Number:   number,
+			Position: i + 1,
+			State:    "queued",
+		}
+		if pr := s.data.PullRequests[number]; pr != nil {
+			entry.Sha = pr.Head.Sha
+		}
+		return entry, nil, nil
+	}
+	return nil, nil, scm.ErrNotFound
+}
+
+func (s *pullService) UpdateBranch(_ context.Context, fullName string, number int, method string) (string, *scm.Response, error) {
+	pr, ok := s.data.PullRequests[number]
+	if !ok {
+		return "", nil, scm.ErrNotFound
+	}
+	return pr.Head.Sha, nil, nil
+}
+
+func (s *pullService) setDraft(number int, draft bool) (*scm.Response, error) {
+	pr, ok := s.data.PullRequests[number]
+	if !ok || pr == nil {
+		return nil, fmt.Errorf("pull request %d not found", number)
+	}
+	pr.Draft = draft
+	return nil, nil
+}
@@ -276,3 +276,167 @@ func TestPullServiceClose(t *testing.T) {
 		}
 	}
 }
+
+func TestFindDiff(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+	data.PullRequestChanges[1] = []*scm.Change{
+		{
+			Path:  "README.md",
+			Patch: "@@ -1,2 +1,2 @@\n # Hello World\n-Old line.\n+New line.\n",
+		},
+	}
+
+	diff, _, err := client.PullRequests.FindDiff(ctx, "test/test", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pos, err := diff.Position("README.md", 2, scm.DiffSideRight)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pos.Position != 3 {
+		t.Errorf("FindDiff() position got %d, want 3", pos.Position)
+	}
+
+	if _, _, err := client.PullRequests.FindDiff(ctx, "test/test", 2); err != scm.ErrNotFound {
+		t.Errorf("FindDiff() error got %v, want %v", err, scm.ErrNotFound)
+	}
+}
+
+func TestListFilters(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+	repo := scm.Repository{Namespace: "test", Name: "test", FullName: "test/test"}
+	data.PullRequests[1] = &scm.PullRequest{
+		Number: 1,
+		Base:   scm.PullRequestBranch{Ref: "master", Repo: repo},
+		Head:   scm.PullRequestBranch{Ref: "feature", Sha: "abc", Repo: repo},
+		Author: scm.User{Login: "alice"},
+	}
+	data.PullRequests[2] = &scm.PullRequest{
+		Number:   2,
+		Base:     scm.PullRequestBranch{Ref: "release", Repo: repo},
+		Head:     scm.PullRequestBranch{Ref: "fix", Sha: "def", Repo: repo},
+		Author:   scm.User{Login: "bob"},
+		MergeSha: "123",
+	}
+	data.CommitMap["test/test#1"] = []scm.Commit{{Sha: "456"}}
+
+	tests := []struct {
+		opts scm.PullRequestListOptions
+		want []int
+	}{
+		{scm.PullRequestListOptions{Open: true}, []int{1, 2}},
+		{scm.PullRequestListOptions{Open: true, Head: "feature"}, []int{1}},
+		{scm.PullRequestListOptions{Open: true, Head: "test:fix"}, []int{2}},
+		{scm.PullRequestListOptions{Open: true, Base: "refs/heads/release"}, []int{2}},
+		{scm.PullRequestListOptions{Open: true, Author: "alice"}, []int{1}},
+		{scm.PullRequestListOptions{Open: true, Head: "feature", Base: "release"}, nil},
+	}
+	for i, tt := range tests {
+		prs, _, err := client.PullRequests.List(ctx, "test/test", &tt.opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := extractNumbers(prs); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("[%d] List() got %v, want %v", i, got, tt.want)
+		}
+	}
+
+	for sha, want := range map[string][]int{"abc": {1}, "456": {1}, "123": {2}, "789": nil} {
+		prs, _, err := client.PullRequests.ListForCommit(ctx, "test/test", sha)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := extractNumbers(prs); !reflect.DeepEqual(got, want) {
+			t.Errorf("ListForCommit(%s) got %v, want %v", sha, got, want)
+		}
+	}
+}
+
+func extractNumbers(prs []*scm.PullRequest) []int {
+	var numbers []int
+	for _, pr := range prs {
+		numbers = append(numbers, pr.Number)
+	}
+	return numbers
+}
+
+func TestDraft(t *testing.T) {
+	ctx := context.Background()
+	client, _ := NewDefault()
+
+	pr, _, err := client.PullRequests.Create(ctx, "test/test", &scm.PullRequestInput{Title: "draft", Head: "feature", Base: "master", Draft: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pr.Draft {
+		t.Errorf("Create() draft got false, want true")
+	}
+
+	if _, err := client.PullRequests.MarkReady(ctx, "test/test", pr.Number); err != nil {
+		t.Fatal(err)
+	}
+	if pr.Draft {
+		t.Errorf("MarkReady() draft got true, want false")
+	}
+
+	if _, err := client.PullRequests.ConvertToDraft(ctx, "test/test", pr.Number); err != nil {
+		t.Fatal(err)
+	}
+	if !pr.Draft {
+		t.Errorf("ConvertToDraft() draft got false, want true")
+	}
+
+	if _, err := client.PullRequests.MarkReady(ctx, "test/test", 99); err == nil {
+		t.Errorf("MarkReady() expected an error for a missing pull request")
+	}
+}
+
+func TestMergeQueue(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+	data.PullRequests[1] = &scm.PullRequest{Number: 1, Head: scm.PullRequestBranch{Sha: "abc"}}
+	data.PullRequests[2] = &scm.PullRequest{Number: 2}
+
+	if _, err := client.PullRequests.EnableAutoMerge(ctx, "test/test", 1, &scm.AutoMergeOptions{MergeMethod: "squash"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := data.AutoMerge[1]; got == nil || got.MergeMethod != "squash" {
+		t.Errorf("EnableAutoMerge() options got %v", got)
+	}
+	if _, err := client.PullRequests.DisableAutoMerge(ctx, "test/test", 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := data.AutoMerge[1]; ok {
+		t.Errorf("DisableAutoMerge() did not remove the options")
+	}
+
+	for _, number := range []int{2, 1} {
+		if _, _, err := client.PullRequests.EnqueueMerge(ctx, "test/test", number, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	entry, _, err := client.PullRequests.FindMergeQueueEntry(ctx, "test/test", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Position != 2 || entry.Sha != "abc" {
+		t.Errorf("FindMergeQueueEntry() got %+v", entry)
+	}
+
+	if _, err := client.PullRequests.DequeueMerge(ctx, "test/test", 2); err != nil {
+		t.Fatal(err)
+	}
+	entry, _, err = client.PullRequests.FindMergeQueueEntry(ctx, "test/test", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entry.Position != 1 {
+		t.Errorf("FindMergeQueueEntry() position got %d, want 1", entry.Position)
+	}
+	if _, _, err := client.PullRequests.FindMergeQueueEntry(ctx, "test/test", 2); err != scm.ErrNotFound {
+		t.Errorf("FindMergeQueueEntry() error got %v, want %v", err, scm.ErrNotFound)
+	}
+}
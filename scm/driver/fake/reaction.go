@@ -0,0 +1,99 @@
+package fake
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type reactionService struct {
+	client *wrapper
+	data   *Data
+}
+
+// List returns the reactions recorded for the target. The fake
+// data does not record who reacted, so reactions are reported
+// as made by the current user.
+func (s *reactionService) List(ctx context.Context, repo string, target scm.ReactionTarget, opts *scm.ListOptions) ([]*scm.Reaction, *scm.Response, error) {
+	prefix := reactionKey(repo, target) + ":"
+	var to []*scm.Reaction
+	for _, v := range s.data.ReactionsAdded {
+		if strings.HasPrefix(v, prefix) {
+			to = append(to, &scm.Reaction{
+				Content: scm.ReactionContent(strings.TrimPrefix(v, prefix)),
+				User:    s.data.CurrentUser,
+			})
+		}
+	}
+	return to, nil, nil
+}
+
+func (s *reactionService) Create(ctx context.Context, repo string, target scm.ReactionTarget, content scm.ReactionContent) (*scm.Reaction, *scm.Response, error) {
+	s.data.ReactionsAdded = append(s.data.ReactionsAdded, fmt.Sprintf("%s:%s", reactionKey(repo, target), content))
+	legacy := s.legacyReactions(target)
+	*legacy = append(*legacy, fmt.Sprintf("%s:%s", legacyReactionKey(repo, target), content))
+	return &scm.Reaction{Content: content, User: s.data.CurrentUser}, nil, nil
+}
+
+func (s *reactionService) Delete(ctx context.Context, repo string, target scm.ReactionTarget, content scm.ReactionContent) (*scm.Response, error) {
+	if !removeReaction(&s.data.ReactionsAdded, fmt.Sprintf("%s:%s", reactionKey(repo, target), content)) {
+		return nil, scm.ErrNotFound
+	}
+	removeReaction(s.legacyReactions(target), fmt.Sprintf("%s:%s", legacyReactionKey(repo, target), content))
+	return nil, nil
+}
+
+// removeReaction removes the first reaction with the key and
+// returns true if one was found.
+func removeReaction(reactions *[]string, key string) bool {
+	for i, v := range *reactions {
+		if v == key {
+			*reactions = append((*reactions)[:i], (*reactions)[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// legacyReactions returns the reactions recorded for the kind of
+// target in the IssueReactionsAdded and CommentReactionsAdded
+// format, which are keyed by the issue number or comment id only.
+func (s *reactionService) legacyReactions(target scm.ReactionTarget) *[]string {
+	switch target.Type {
+	case scm.ReactionOnIssue, scm.ReactionOnPullRequest:
+		return &s.data.IssueReactionsAdded
+	default:
+		return &s.data.CommentReactionsAdded
+	}
+}
+
+func legacyReactionKey(repo string, target scm.ReactionTarget) string {
+	switch target.Type {
+	case scm.ReactionOnIssue, scm.ReactionOnPullRequest:
+		return fmt.Sprintf("%s#%d", repo, target.Number)
+	default:
+		return fmt.Sprintf("%s#%d", repo, target.Comment)
+	}
+}
+
+// reactionTargetKinds names the kind of each reaction target,
+// so that targets with the same number or id do not collide.
+var reactionTargetKinds = map[scm.ReactionTargetType]string{
+	scm.ReactionOnIssue:              "issue",
+	scm.ReactionOnPullRequest:        "pull",
+	scm.ReactionOnIssueComment:       "issue_comment",
+	scm.ReactionOnPullRequestComment: "pull_comment",
+	scm.ReactionOnReviewComment:      "review_comment",
+}
+
+func reactionKey(repo string, target scm.ReactionTarget) string {
+	kind := reactionTargetKinds[target.Type]
+	switch target.Type {
+	case scm.ReactionOnIssue, scm.ReactionOnPullRequest:
+		return fmt.Sprintf("%s#%s/%d", repo, kind, target.Number)
+	default:
+		return fmt.Sprintf("%s#%s/%d", repo, kind, target.Comment)
+	}
+}
@@ -0,0 +1,65 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+func TestReactions(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+
+	issue := scm.ReactionTarget{Type: scm.ReactionOnIssue, Number: 1}
+	comment := scm.ReactionTarget{Type: scm.ReactionOnIssueComment, Number: 1, Comment: 5}
+	if _, _, err := client.Reactions.Create(ctx, "myorg/myrepo", issue, scm.ReactionHeart); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := client.Reactions.Create(ctx, "myorg/myrepo", comment, scm.ReactionPlusOne); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := data.IssueReactionsAdded, []string{"myorg/myrepo#1:heart"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("Want issue reactions %v, got %v", want, got)
+	}
+	if got, want := data.CommentReactionsAdded, []string{"myorg/myrepo#5:+1"}; len(got) != 1 || got[0] != want[0] {
+		t.Errorf("Want comment reactions %v, got %v", want, got)
+	}
+	if got, want := data.ReactionsAdded, []string{"myorg/myrepo#issue/1:heart", "myorg/myrepo#issue_comment/5:+1"}; len(got) != 2 || got[0] != want[0] || got[1] != want[1] {
+		t.Errorf("Want reactions %v, got %v", want, got)
+	}
+
+	got, _, err := client.Reactions.List(ctx, "myorg/myrepo", comment, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Content != scm.ReactionPlusOne {
+		t.Errorf("Want one +1 reaction, got %v", got)
+	}
+
+	// a review comment with the same id has its own reactions.
+	reviewComment := scm.ReactionTarget{Type: scm.ReactionOnReviewComment, Number: 1, Comment: 5}
+	got, _, err = client.Reactions.List(ctx, "myorg/myrepo", reviewComment, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Want no review comment reactions, got %v", got)
+	}
+	if _, err := client.Reactions.Delete(ctx, "myorg/myrepo", reviewComment, scm.ReactionPlusOne); err != scm.ErrNotFound {
+		t.Errorf("Want not found error, got %v", err)
+	}
+
+	if _, err := client.Reactions.Delete(ctx, "myorg/myrepo", issue, scm.ReactionHeart); err != nil {
+		t.Fatal(err)
+	}
+	if len(data.IssueReactionsAdded) != 0 {
+		t.Errorf("Want no issue reactions, got %v", data.IssueReactionsAdded)
+	}
+	if len(data.ReactionsAdded) != 1 {
+		t.Errorf("Want only the comment reaction, got %v", data.ReactionsAdded)
+	}
+	if _, err := client.Reactions.Delete(ctx, "myorg/myrepo", issue, scm.ReactionHeart); err != scm.ErrNotFound {
+		t.Errorf("Want not found error, got %v", err)
+	}
+}
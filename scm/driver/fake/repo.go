@@ -1,9 +1,17 @@
 package fake
 
 import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"fmt"
+	"io"
+	"io/fs"
 	"math/rand"
+	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -181,17 +189,71 @@ func (s *repositoryService) ListHooks(ctx context.Context, fullName string, opts
 func (s *repositoryService) CreateHook(ctx context.Context, fullName string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
 	hook := &scm.Hook{
 		//nolint:gosec
-		ID:     fmt.Sprintf("%d", rand.Int()),
-		Name:   input.Name,
-		Target: input.Target,
-		Active: true,
+		ID:         fmt.Sprintf("%d", rand.Int()),
+		Name:       input.Name,
+		Target:     input.Target,
+		Events:     convertHookEvents(input),
+		Active:     true,
+		SkipVerify: input.SkipVerify,
 	}
 	s.data.Hooks[fullName] = append(s.data.Hooks[fullName], hook)
+	s.data.HookSecrets[hook.ID] = input.Secret
 	return hook, nil, nil
 }
 
-func (s *repositoryService) UpdateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+func (s *repositoryService) UpdateHook(ctx context.Context, fullName string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	for _, h := range s.data.Hooks[fullName] {
+		if h.ID == input.Name {
+			h.Target = input.Target
+			h.Events = convertHookEvents(input)
+			h.SkipVerify = input.SkipVerify
+			h.Active = true
+			s.data.HookSecrets[h.ID] = input.Secret
+			return h, nil, nil
+		}
+	}
+	return nil, nil, scm.ErrNotFound
+}
+
+// ConvertHookEvents returns the events reported for a webhook
+// created from the input.
+func (s *repositoryService) ConvertHookEvents(input *scm.HookInput) []string {
+	return convertHookEvents(input)
+}
+
+// convertHookEvents returns the native events of the input followed
+// by the github names of the events set in the input.
+func convertHookEvents(input *scm.HookInput) []string {
+	events := append([]string(nil), input.NativeEvents...)
+	from := input.Events
+	for _, e := range []struct {
+		set  bool
+		name string
+	}{
+		{from.Push, "push"},
+		{from.PullRequest, "pull_request"},
+		{from.Review, "pull_request_review"},
+		{from.PullRequestComment, "pull_request_review_comment"},
+		{from.ReviewThread, "pull_request_review_thread"},
+		{from.Issue, "issues"},
+		{from.IssueComment, "issue_comment"},
+		{from.Branch || from.Tag, "create"},
+		{from.Branch || from.Tag, "delete"},
+		{from.Deployment, "deployment"},
+		{from.DeploymentStatus, "deployment_status"},
+		{from.Release, "release"},
+		{from.Pipeline, "workflow_run"},
+		{from.Job, "workflow_job"},
+		{from.Member, "member"},
+		{from.Organization, "organization"},
+		{from.Team, "team"},
+		{from.Star, "star"},
+	} {
+		if e.set {
+			events = append(events, e.name)
+		}
+	}
+	return events
 }
 
 func (s *repositoryService) DeleteHook(ctx context.Context, fullName, hookID string) (*scm.Response, error) {
@@ -206,6 +268,52 @@ func (s *repositoryService) DeleteHook(ctx context.Context, fullName, hookID str
 	return nil, nil
 }
 
+func (s *repositoryService) ListHookDeliveries(ctx context.Context, fullName, hookID string, opts *scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	return s.data.HookDeliveries[hookID], nil, nil
+}
+
+func (s *repositoryService) FindHookDelivery(ctx context.Context, fullName, hookID, deliveryID string) (*scm.HookDelivery, *scm.Response, error) {
+	for _, d := range s.data.HookDeliveries[hookID] {
+		if d.ID == deliveryID {
+			return d, nil, nil
+		}
+	}
+	return nil, nil, scm.ErrNotFound
+}
+
+func (s *repositoryService) RedeliverHook(ctx context.Context, fullName, hookID, deliveryID string) (*scm.Response, error) {
+	d, _, err := s.FindHookDelivery(ctx, fullName, hookID, deliveryID)
+	if err != nil {
+		return nil, err
+	}
+	redelivery := *d
+	//nolint:gosec
+	redelivery.ID = fmt.Sprintf("%d", rand.Int())
+	redelivery.Redelivery = true
+	redelivery.Delivered = time.Now()
+	s.data.HookDeliveries[hookID] = append(s.data.HookDeliveries[hookID], &redelivery)
+	return nil, nil
+}
+
+func (s *repositoryService) TestHook(ctx context.Context, fullName, hookID string) (*scm.Response, error) {
+	for _, h := range s.data.Hooks[fullName] {
+		if h.ID == hookID {
+			s.data.HookDeliveries[hookID] = append(s.data.HookDeliveries[hookID], &scm.HookDelivery{
+				//nolint:gosec
+				ID:        fmt.Sprintf("%d", rand.Int()),
+				Event:     "ping",
+				Delivered: time.Now(),
+				Request: scm.HookDeliveryRequest{
+					URL:    h.Target,
+					Method: "POST",
+				},
+			})
+			return nil, nil
+		}
+	}
+	return nil, scm.ErrNotFound
+}
+
 func (s *repositoryService) CreateStatus(ctx context.Context, repo, ref string, in *scm.StatusInput) (*scm.Status, *scm.Response, error) {
 	statuses := s.data.Statuses[ref]
 	if statuses == nil {
@@ -226,3 +334,87 @@ func (s *repositoryService) CreateStatus(ctx context.Context, repo, ref string,
 func (s *repositoryService) Delete(context.Context, string) (*scm.Response, error) {
 	panic("implement me")
 }
+
+// Archive creates an archive of the repository folder in data.ContentDir
+func (s *repositoryService) Archive(ctx context.Context, repo, ref string, format scm.ArchiveFormat) (io.ReadCloser, *scm.Response, error) {
+	c := contentService{client: s.client, data: s.data}
+	dir, err := c.path(repo, "", ref)
+	if err != nil {
+		return nil, nil, err
+	}
+	buf := new(bytes.Buffer)
+	switch format {
+	case scm.ArchiveFormatTarball:
+		err = writeTarball(buf, dir)
+	case scm.ArchiveFormatZip:
+		err = writeZip(buf, dir)
+	default:
+		return nil, nil, scm.ErrNotSupported
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return io.NopCloser(buf), nil, nil
+}
+
+func writeTarball(w io.Writer, dir string) error {
+	gw := gzip.NewWriter(w)
+	tw := tar.NewWriter(gw)
+	err := walkFiles(dir, func(name string, info fs.FileInfo, data []byte) error {
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = name
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err = tw.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gw.Close()
+}
+
+func writeZip(w io.Writer, dir string) error {
+	zw := zip.NewWriter(w)
+	err := walkFiles(dir, func(name string, _ fs.FileInfo, data []byte) error {
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = f.Write(data)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// walkFiles invokes fn with the slash separated relative name and contents of each file beneath dir
+func walkFiles(dir string, fn func(name string, info fs.FileInfo, data []byte) error) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path) // #nosec
+		if err != nil {
+			return err
+		}
+		return fn(filepath.ToSlash(rel), info, data)
+	})
+}
@@ -62,6 +62,38 @@ func TestHookCreateDelete(t *testing.T) {
 	}
 }
 
+func TestHookDeliveries(t *testing.T) {
+	ctx := context.Background()
+	client, _ := fake.NewDefault()
+
+	hook, _, err := client.Repositories.CreateHook(ctx, "foo/repo", &scm.HookInput{Target: "https://example.com"})
+	require.NoError(t, err)
+
+	_, err = client.Repositories.TestHook(ctx, "foo/repo", hook.ID)
+	require.NoError(t, err)
+
+	deliveries, _, err := client.Repositories.ListHookDeliveries(ctx, "foo/repo", hook.ID, &scm.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, "ping", deliveries[0].Event)
+	assert.Equal(t, "https://example.com", deliveries[0].Request.URL)
+
+	_, err = client.Repositories.RedeliverHook(ctx, "foo/repo", hook.ID, deliveries[0].ID)
+	require.NoError(t, err)
+
+	deliveries, _, err = client.Repositories.ListHookDeliveries(ctx, "foo/repo", hook.ID, &scm.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, deliveries, 2)
+	assert.True(t, deliveries[1].Redelivery)
+
+	got, _, err := client.Repositories.FindHookDelivery(ctx, "foo/repo", hook.ID, deliveries[1].ID)
+	require.NoError(t, err)
+	assert.Equal(t, deliveries[1], got)
+
+	_, err = client.Repositories.TestHook(ctx, "foo/repo", "unknown")
+	assert.Equal(t, scm.ErrNotFound, err)
+}
+
 func TestForkRepository(t *testing.T) {
 	client, _ := fake.NewDefault()
 
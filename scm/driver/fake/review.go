@@ -60,3 +60,19 @@ func (s *reviewService) Submit(ctx context.Context, repo string, prID, reviewID
 func (s *reviewService) Dismiss(ctx context.Context, repo string, prID, reviewID int, msg string) (*scm.Review, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
+
+func (s *reviewService) CreateComment(ctx context.Context, repo string, number int, input *scm.ReviewCommentInput) (*scm.ReviewComment, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *reviewService) ListThreads(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.ReviewThread, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *reviewService) ResolveThread(ctx context.Context, repo string, number int, id string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *reviewService) UnresolveThread(ctx context.Context, repo string, number int, id string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
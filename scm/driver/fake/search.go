@@ -0,0 +1,101 @@
+package fake
+
+import (
+	"context"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type searchService struct {
+	client *wrapper
+	data   *Data
+}
+
+func (s *searchService) Code(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchCode, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+// Repositories returns the repositories whose name contains
+// the query text, in the organization when one is set.
+func (s *searchService) Repositories(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	f := s.data
+	var to []*scm.Repository
+	for _, repo := range f.Repositories {
+		if q.Org != "" && repo.Namespace != q.Org {
+			continue
+		}
+		if q.Repo != "" && repo.FullName != q.Repo {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(repo.Name), strings.ToLower(q.Text)) {
+			continue
+		}
+		to = append(to, repo)
+	}
+	return to, nil, nil
+}
+
+// Issues returns the issues whose title or body contains the
+// query text and which match the state, label and author
+// filters.
+func (s *searchService) Issues(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchIssue, *scm.Response, error) {
+	f := s.data
+	var to []*scm.SearchIssue
+	for _, issues := range f.Issues {
+		for _, issue := range issues {
+			if !matchSearchIssue(issue, q) {
+				continue
+			}
+			to = append(to, &scm.SearchIssue{Issue: *issue})
+		}
+	}
+	return to, nil, nil
+}
+
+func (s *searchService) Commits(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchCommit, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func matchSearchIssue(issue *scm.Issue, q *scm.SearchQuery) bool {
+	text := strings.ToLower(q.Text)
+	if !strings.Contains(strings.ToLower(issue.Title), text) && !strings.Contains(strings.ToLower(issue.Body), text) {
+		return false
+	}
+	switch q.State {
+	case "open":
+		if issue.Closed {
+			return false
+		}
+	case "closed":
+		if !issue.Closed {
+			return false
+		}
+	}
+	switch q.Type {
+	case scm.SearchTypeIssue:
+		if issue.PullRequest != nil {
+			return false
+		}
+	case scm.SearchTypePullRequest:
+		if issue.PullRequest == nil {
+			return false
+		}
+	}
+	if q.Author != "" && !strings.EqualFold(issue.Author.Login, q.Author) {
+		return false
+	}
+	for _, label := range q.Labels {
+		found := false
+		for _, l := range issue.Labels {
+			if l == label {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,48 @@
+package fake
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+func TestSearchIssues(t *testing.T) {
+	ctx := context.Background()
+	client, data := NewDefault()
+	data.Issues[1] = []*scm.Issue{{Number: 1, Title: "Crash on start", Labels: []string{"bug"}, Author: scm.User{Login: "jcitizen"}}}
+	data.Issues[2] = []*scm.Issue{{Number: 2, Title: "Crash on exit", Closed: true}}
+	data.Issues[3] = []*scm.Issue{{Number: 3, Title: "Add docs"}}
+
+	got, _, err := client.Search.Issues(ctx, &scm.SearchQuery{Text: "crash"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Errorf("Want 2 issues, got %d", len(got))
+	}
+
+	got, _, err = client.Search.Issues(ctx, &scm.SearchQuery{Text: "crash", State: "open", Labels: []string{"bug"}, Author: "jcitizen"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].Number != 1 {
+		t.Errorf("Want issue 1, got %v", got)
+	}
+}
+
+func TestSearchRepositories(t *testing.T) {
+	client, data := NewDefault()
+	data.Repositories = []*scm.Repository{
+		{Namespace: "myorg", Name: "go-scm", FullName: "myorg/go-scm"},
+		{Namespace: "other", Name: "go-scm", FullName: "other/go-scm"},
+	}
+
+	got, _, err := client.Search.Repositories(context.Background(), &scm.SearchQuery{Text: "SCM", Org: "myorg"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 || got[0].FullName != "myorg/go-scm" {
+		t.Errorf("Want myorg/go-scm, got %v", got)
+	}
+}
@@ -5,6 +5,7 @@
 package gitea
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -98,8 +99,167 @@ func (s *gitService) ListChanges(ctx context.Context, repo, ref string, _ *scm.L
 	return nil, nil, scm.ErrNotSupported
 }
 
-func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string, _ *scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+// CompareCommits returns the changes of the comparison of ref2 to
+// ref1, see Compare.
+func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string, opts *scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	out, res, err := s.Compare(ctx, repo, ref1, ref2, opts)
+	if err != nil {
+		return nil, res, err
+	}
+	return out.Changes, res, nil
+}
+
+// Compare returns the comparison of the head commit to the base
+// commit. Gitea only lists the commits between two refs, so the
+// merge base is the first ancestor of base that is not behind head,
+// and the changes are read from the diff of the comparison.
+func (s *gitService) Compare(ctx context.Context, repo, base, head string, _ *scm.ListOptions) (*scm.Comparison, *scm.Response, error) {
+	ahead, res, err := s.compare(ctx, repo, base, head)
+	if err != nil {
+		return nil, res, err
+	}
+	behind, res, err := s.compare(ctx, repo, head, base)
+	if err != nil {
+		return nil, res, err
+	}
+	mergeBase, res, err := s.mergeBase(ctx, repo, base, behind)
+	if err != nil {
+		return nil, res, err
+	}
+	diff, res, err := s.CompareDiff(ctx, repo, base, head)
+	if err != nil {
+		return nil, res, err
+	}
+	return &scm.Comparison{
+		Status:    scm.ComparisonStatusOf(len(ahead), len(behind)),
+		AheadBy:   len(ahead),
+		BehindBy:  len(behind),
+		MergeBase: mergeBase,
+		Commits:   ahead,
+		Changes:   convertDiffChanges(diff),
+	}, res, nil
+}
+
+// mergeBase walks the ancestry of base and returns the first commit
+// that is not one of the commits behind head. The ancestry is listed
+// in the commit log order, which includes the parents of merge
+// commits, so the result is the most recent common ancestor. When
+// there are several merge bases, for example after criss-cross
+// merges, it may differ from the one picked by git merge-base.
+func (s *gitService) mergeBase(ctx context.Context, repo, base string, behind []*scm.Commit) (string, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	skip := map[string]bool{}
+	for _, c := range behind {
+		skip[c.Sha] = true
+	}
+	opts := gitea.ListCommitOptions{
+		ListOptions: gitea.ListOptions{Page: 1, PageSize: 50},
+		SHA:         base,
+	}
+	for {
+		out, resp, err := s.client.GiteaClient.ListRepoCommits(namespace, name, opts)
+		if err != nil {
+			return "", toSCMResponse(resp), err
+		}
+		for _, c := range out {
+			if !skip[c.SHA] {
+				return c.SHA, toSCMResponse(resp), nil
+			}
+		}
+		if len(out) < opts.PageSize {
+			return "", toSCMResponse(resp), scm.ErrNotFound
+		}
+		opts.Page++
+	}
+}
+
+// compare returns the commits reachable from head but not from
+// base, oldest first.
+func (s *gitService) compare(ctx context.Context, repo, base, head string) ([]*scm.Commit, *scm.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/compare/%s...%s", repo, base, head)
+	out := new(gitea.Compare)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	commits := convertCommitList(out.Commits)
+	for i, j := 0, len(commits)-1; i < j; i, j = i+1, j-1 {
+		commits[i], commits[j] = commits[j], commits[i]
+	}
+	return commits, res, nil
+}
+
+// CompareDiff returns the diff of ref2 against its merge base with
+// ref1. The api does not return the patch of a comparison, so the
+// raw diff of the web compare page is requested.
+func (s *gitService) CompareDiff(ctx context.Context, repo, ref1, ref2 string) (*scm.Diff, *scm.Response, error) {
+	path := fmt.Sprintf("%s/compare/%s...%s.diff", repo, ref1, ref2)
+	buf := new(bytes.Buffer)
+	res, err := s.client.do(ctx, "GET", path, nil, buf)
+	if err != nil {
+		return nil, res, err
+	}
+	diff, err := scm.ParseDiff(buf.String())
+	return diff, res, err
+}
+
+// convertDiffChanges returns the changed files of the diff.
+func convertDiffChanges(from *scm.Diff) []*scm.Change {
+	to := []*scm.Change{}
+	for _, f := range from.Files {
+		change := &scm.Change{
+			Path:     f.NewPath,
+			Added:    f.Added,
+			Deleted:  f.Deleted,
+			Renamed:  f.Renamed,
+			Modified: !f.Added && !f.Deleted && !f.Renamed,
+		}
+		// the patch of a change starts at the first hunk
+		if i := strings.Index(f.Patch, "\n@@"); i >= 0 {
+			change.Patch = f.Patch[i+1:]
+		}
+		if f.Deleted {
+			change.Path = f.OldPath
+		}
+		if f.Renamed {
+			change.PreviousPath = f.OldPath
+		}
+		for _, h := range f.Hunks {
+			for _, l := range h.Lines {
+				switch l.Type {
+				case scm.DiffLineAdded:
+					change.Additions++
+				case scm.DiffLineDeleted:
+					change.Deletions++
+				}
+			}
+		}
+		change.Changes = change.Additions + change.Deletions
+		to = append(to, change)
+	}
+	return to
+}
+
+func (s *gitService) FindTree(ctx context.Context, repo, ref string, recursive bool) (*scm.Tree, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	to := &scm.Tree{Entries: []*scm.TreeEntry{}}
+	for page := 1; ; page++ {
+		opts := gitea.ListTreeOptions{
+			ListOptions: gitea.ListOptions{Page: page},
+			Ref:         ref,
+			Recursive:   recursive,
+		}
+		out, resp, err := s.client.GiteaClient.GetTrees(namespace, name, opts)
+		if err != nil {
+			return nil, toSCMResponse(resp), err
+		}
+		to.Sha = out.SHA
+		to.Entries = append(to.Entries, convertTreeEntryList(out.Entries)...)
+		// gitea marks the tree as truncated while further pages remain
+		if !out.Truncated || len(out.Entries) == 0 {
+			return to, toSCMResponse(resp), nil
+		}
+	}
 }
 
 func (s *gitService) GetDefaultBranch(ctx context.Context, repo string) (*scm.Reference, *scm.Response, error) {
@@ -176,6 +336,21 @@ func convertTag(src *gitea.Tag) *scm.Reference {
 	}
 }
 
+func convertTreeEntryList(src []gitea.GitEntry) []*scm.TreeEntry {
+	dst := []*scm.TreeEntry{}
+	for _, v := range src {
+		dst = append(dst, &scm.TreeEntry{
+			Path: v.Path,
+			Mode: v.Mode,
+			Type: v.Type,
+			Sha:  v.SHA,
+			Size: v.Size,
+			Link: v.URL,
+		})
+	}
+	return dst
+}
+
 func convertCommitList(src []*gitea.Commit) []*scm.Commit {
 	dst := []*scm.Commit{}
 	for _, v := range src {
@@ -188,12 +363,17 @@ func convertCommit(src *gitea.Commit) *scm.Commit {
 	if src == nil || src.RepoCommit == nil {
 		return nil
 	}
+	var parents []string
+	for _, p := range src.Parents {
+		parents = append(parents, p.SHA)
+	}
 	return &scm.Commit{
 		Sha:       src.SHA,
 		Link:      src.URL,
 		Message:   src.RepoCommit.Message,
 		Author:    convertUserSignature(src.Author),
 		Committer: convertUserSignature(src.Committer),
+		Parents:   parents,
 	}
 }
 
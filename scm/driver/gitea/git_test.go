@@ -98,17 +98,61 @@ func TestCompareCommits(t *testing.T) {
 	defer gock.Off()
 
 	mockServerVersion()
+	mockCompare()
+
 	client, _ := New("https://demo.gitea.com")
-	_, _, err := client.Git.CompareCommits(context.Background(), "go-gitea/gitea", "21cf205dc770d637a9ba636644cf8bf690cc100d", "63aeb0a859499623becc1d1e7c8a2ad57439e139", &scm.ListOptions{})
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	got, _, err := client.Git.CompareCommits(context.Background(), "gitea/gitea", "master", "feature", &scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
 	}
+
+	want := new(scm.Comparison)
+	raw, _ := os.ReadFile("testdata/compare.json.golden")
+	err = json.Unmarshal(raw, want)
+	assert.NoError(t, err)
+
+	if diff := cmp.Diff(want.Changes, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	assert.True(t, gock.IsDone())
 }
 
 //
 // branch sub-tests
 //
 
+func TestTreeFind(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/go-gitea/gitea/git/trees/master").
+		MatchParam("recursive", "1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tree.json")
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.Git.FindTree(context.Background(), "go-gitea/gitea", "master", true)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Tree)
+	raw, _ := os.ReadFile("testdata/tree.json.golden")
+	err = json.Unmarshal(raw, &want)
+	assert.NoError(t, err)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestBranchFind(t *testing.T) {
 	defer gock.Off()
 
@@ -255,3 +299,80 @@ func TestGitGetDefaultBranch(t *testing.T) {
 		t.Log(diff)
 	}
 }
+
+func TestGitCompare(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+	mockCompare()
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.Git.Compare(context.Background(), "gitea/gitea", "master", "feature", &scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := os.ReadFile("testdata/compare.json.golden")
+	err = json.Unmarshal(raw, want)
+	assert.NoError(t, err)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	assert.True(t, gock.IsDone())
+}
+
+func mockCompare() {
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/gitea/gitea/compare/master...feature").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare.json")
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/gitea/gitea/compare/feature...master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare_behind.json")
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/gitea/gitea/commits").
+		MatchParam("sha", "master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare_base_commits.json")
+
+	gock.New("https://demo.gitea.com").
+		Get("/gitea/gitea/compare/master...feature.diff").
+		Reply(200).
+		Type("text/plain").
+		File("testdata/pr.diff")
+}
+
+func TestGitCompareDiff(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/go-gitea/gitea/compare/master...feature.diff").
+		Reply(200).
+		Type("text/plain").
+		File("testdata/pr.diff")
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.Git.CompareDiff(context.Background(), "go-gitea/gitea", "master", "feature")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := len(got.Files), 2; got != want {
+		t.Errorf("Want %d files, got %d", want, got)
+	}
+	if got, want := len(got.Files[0].Hunks), 2; got != want {
+		t.Errorf("Want %d hunks, got %d", want, got)
+	}
+}
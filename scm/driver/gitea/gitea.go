@@ -54,7 +54,9 @@ func NewWithToken(uri, token string) (*scm.Client, error) {
 	client.Organizations = &organizationService{client}
 	client.PullRequests = &pullService{&issueService{client}}
 	client.Repositories = &repositoryService{client}
+	client.Reactions = &reactionService{client}
 	client.Reviews = &reviewService{client}
+	client.Search = &searchService{client}
 	client.Releases = &releaseService{client}
 	client.Users = &userService{client}
 	client.Webhooks = &webhookService{client}
@@ -86,7 +88,9 @@ func NewWithBasicAuth(uri, user, password string) (*scm.Client, error) {
 	client.Organizations = &organizationService{client}
 	client.PullRequests = &pullService{&issueService{client}}
 	client.Repositories = &repositoryService{client}
+	client.Reactions = &reactionService{client}
 	client.Reviews = &reviewService{client}
+	client.Search = &searchService{client}
 	client.Users = &userService{client}
 	client.Webhooks = &webhookService{client}
 	return client.Client, nil
@@ -170,6 +174,9 @@ func toSCMResponse(r *gitea.Response) *scm.Response {
 }
 
 func toGiteaListOptions(in *scm.ListOptions) gitea.ListOptions {
+	if in == nil {
+		return gitea.ListOptions{}
+	}
 	return gitea.ListOptions{
 		Page:     in.Page,
 		PageSize: in.Size,
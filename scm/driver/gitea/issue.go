@@ -107,25 +107,44 @@ func (s *issueService) lookupLabel(ctx context.Context, repo, lbl string) (int64
 	return labelID, res, nil
 }
 
-func (s *issueService) AddLabel(ctx context.Context, repo string, number int, lbl string) (*scm.Response, error) {
+// ensureLabel returns the id of the label, creating the label in the
+// repository if it does not exist.
+func (s *issueService) ensureLabel(ctx context.Context, repo, lbl string) (int64, *scm.Response, error) {
 	labelID, res, err := s.lookupLabel(ctx, repo, lbl)
-	if err != nil {
-		return res, err
+	if err != nil || labelID != -1 {
+		return labelID, res, err
 	}
 	namespace, name := scm.Split(repo)
+	lblInput := gitea.CreateLabelOption{
+		Color:       "#00aabb",
+		Description: "",
+		Name:        lbl,
+	}
+	newLabel, giteaResp, err := s.client.GiteaClient.CreateLabel(namespace, name, lblInput)
+	if err != nil {
+		return -1, toSCMResponse(giteaResp), errors.Wrapf(err, "failed to create label %s in repository %s", lbl, repo)
+	}
+	return newLabel.ID, toSCMResponse(giteaResp), nil
+}
 
-	if labelID == -1 {
-		lblInput := gitea.CreateLabelOption{
-			Color:       "#00aabb",
-			Description: "",
-			Name:        lbl,
-		}
-		newLabel, giteaResp, err := s.client.GiteaClient.CreateLabel(namespace, name, lblInput)
+func (s *issueService) ensureLabels(ctx context.Context, repo string, labels []string) ([]int64, *scm.Response, error) {
+	var ids []int64
+	for _, lbl := range labels {
+		id, res, err := s.ensureLabel(ctx, repo, lbl)
 		if err != nil {
-			return toSCMResponse(giteaResp), errors.Wrapf(err, "failed to create label %s in repository %s", lbl, repo)
+			return nil, res, err
 		}
-		labelID = newLabel.ID
+		ids = append(ids, id)
 	}
+	return ids, nil, nil
+}
+
+func (s *issueService) AddLabel(ctx context.Context, repo string, number int, lbl string) (*scm.Response, error) {
+	labelID, res, err := s.ensureLabel(ctx, repo, lbl)
+	if err != nil {
+		return res, err
+	}
+	namespace, name := scm.Split(repo)
 
 	in := gitea.IssueLabelsOption{Labels: []int64{labelID}}
 	_, giteaResp, err := s.client.GiteaClient.AddIssueLabels(namespace, name, int64(number), in)
@@ -185,13 +204,22 @@ func (s *issueService) List(ctx context.Context, repo string, opts scm.IssueList
 			Page:     opts.Page,
 			PageSize: opts.Size,
 		},
-		Type: gitea.IssueTypeIssue,
+		Type:       gitea.IssueTypeIssue,
+		Labels:     opts.Labels,
+		CreatedBy:  opts.Author,
+		AssignedBy: opts.Assignee,
 	}
 	if opts.Open && !opts.Closed {
 		in.State = gitea.StateOpen
 	} else if opts.Closed && !opts.Open {
 		in.State = gitea.StateClosed
 	}
+	if opts.Milestone != "" {
+		in.Milestones = []string{opts.Milestone}
+	}
+	if opts.Since != nil {
+		in.Since = *opts.Since
+	}
 	out, resp, err := s.client.GiteaClient.ListRepoIssues(namespace, name, in)
 	return convertIssueList(out), toSCMResponse(resp), err
 }
@@ -205,14 +233,57 @@ func (s *issueService) ListComments(ctx context.Context, repo string, index int,
 func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 
+	labels, res, err := s.ensureLabels(ctx, repo, input.Labels)
+	if err != nil {
+		return nil, res, err
+	}
 	in := gitea.CreateIssueOption{
-		Title: input.Title,
-		Body:  input.Body,
+		Title:     input.Title,
+		Body:      input.Body,
+		Assignees: input.Assignees,
+		Deadline:  input.DueDate,
+		Milestone: int64(input.Milestone),
+		Labels:    labels,
 	}
 	out, resp, err := s.client.GiteaClient.CreateIssue(namespace, name, in)
 	return convertIssue(out), toSCMResponse(resp), err
 }
 
+// Update edits the issue and then replaces its labels when labels are
+// set, Gitea does not edit labels together with the issue.
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	in := gitea.EditIssueOption{
+		Title:     input.Title,
+		Assignees: input.Assignees,
+		Deadline:  input.DueDate,
+	}
+	if input.Body != "" {
+		in.Body = &input.Body
+	}
+	if input.Milestone != 0 {
+		milestone := int64(input.Milestone)
+		in.Milestone = &milestone
+	}
+	out, resp, err := s.client.GiteaClient.EditIssue(namespace, name, int64(number), in)
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	if len(input.Labels) == 0 {
+		return convertIssue(out), toSCMResponse(resp), nil
+	}
+	labels, res, err := s.ensureLabels(ctx, repo, input.Labels)
+	if err != nil {
+		return nil, res, err
+	}
+	replaced, resp, err := s.client.GiteaClient.ReplaceIssueLabels(namespace, name, int64(number), gitea.IssueLabelsOption{Labels: labels})
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	out.Labels = replaced
+	return convertIssue(out), toSCMResponse(resp), nil
+}
+
 func (s *issueService) CreateComment(ctx context.Context, repo string, index int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	in := gitea.CreateIssueCommentOption{Body: input.Body}
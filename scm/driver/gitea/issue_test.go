@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/jenkins-x/go-scm/scm"
@@ -83,6 +84,40 @@ func TestIssueList(t *testing.T) {
 	t.Run("Page", testPage(res))
 }
 
+func TestIssueListFilters(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/go-gitea/gitea/issues").
+		MatchParam("type", "issues").
+		MatchParam("labels", "bug,ui").
+		MatchParam("created_by", "jdoe").
+		MatchParam("assigned_by", "jsmith").
+		MatchParam("milestones", "v1.0").
+		MatchParam("since", "2020-01-02T03:04:05Z").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issues.json")
+
+	since := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	client, _ := New("https://demo.gitea.com")
+	_, _, err := client.Issues.List(context.Background(), "go-gitea/gitea", scm.IssueListOptions{
+		Labels:    []string{"bug", "ui"},
+		Author:    "jdoe",
+		Assignee:  "jsmith",
+		Milestone: "v1.0",
+		Since:     &since,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected the filters to be sent as query parameters")
+	}
+}
+
 func TestIssueCreate(t *testing.T) {
 	defer gock.Off()
 
@@ -118,6 +153,95 @@ func TestIssueCreate(t *testing.T) {
 	}
 }
 
+func TestIssueCreateTriaged(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/go-gitea/gitea/labels").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue_labels.json")
+
+	gock.New("https://demo.gitea.com").
+		Post("/api/v1/repos/go-gitea/gitea/issues").
+		File("testdata/create_issue.json").
+		Reply(201).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	due := time.Date(2020, time.September, 30, 0, 0, 0, 0, time.UTC)
+	input := scm.IssueInput{
+		Title:     "Bug found",
+		Body:      "I'm having a problem with this.",
+		Labels:    []string{"string"},
+		Assignees: []string{"jcitizen"},
+		Milestone: 1,
+		DueDate:   &due,
+	}
+
+	client, _ := New("https://demo.gitea.com")
+	_, _, err := client.Issues.Create(context.Background(), "go-gitea/gitea", &input)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestIssueUpdate(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Patch("/api/v1/repos/go-gitea/gitea/issues/1").
+		File("testdata/update_issue.json").
+		Reply(201).
+		Type("application/json").
+		File("testdata/issue.json")
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/go-gitea/gitea/labels").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue_labels.json")
+
+	gock.New("https://demo.gitea.com").
+		Put("/api/v1/repos/go-gitea/gitea/issues/1/labels").
+		File("testdata/update_issue_labels.json").
+		Reply(200).
+		Type("application/json").
+		File("testdata/issue_labels.json")
+
+	input := scm.IssueInput{
+		Title:     "Bug found",
+		Labels:    []string{"string"},
+		Assignees: []string{"jcitizen"},
+	}
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.Issues.Update(context.Background(), "go-gitea/gitea", 1, &input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Issue)
+	raw, _ := os.ReadFile("testdata/issue.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
 func TestIssueClose(t *testing.T) {
 	defer gock.Off()
 
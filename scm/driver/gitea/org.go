@@ -6,6 +6,7 @@ package gitea
 
 import (
 	"context"
+	"strconv"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/jenkins-x/go-scm/scm"
@@ -105,6 +106,59 @@ func (s *organizationService) ListMemberships(ctx context.Context, opts *scm.Lis
 	return nil, nil, scm.ErrNotSupported
 }
 
+func (s *organizationService) FindHook(_ context.Context, org, id string) (*scm.Hook, *scm.Response, error) {
+	idInt, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, nil, err
+	}
+	out, resp, err := s.client.GiteaClient.GetOrgHook(org, idInt)
+	return convertHook(out), toSCMResponse(resp), err
+}
+
+func (s *organizationService) ListHooks(_ context.Context, org string, opts *scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
+	out, resp, err := s.client.GiteaClient.ListOrgHooks(org, gitea.ListHooksOptions{ListOptions: toGiteaListOptions(opts)})
+	return convertHookList(out), toSCMResponse(resp), err
+}
+
+func (s *organizationService) CreateHook(_ context.Context, org string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	in, err := convertHookInput(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	out, resp, err := s.client.GiteaClient.CreateOrgHook(org, in)
+	return convertHook(out), toSCMResponse(resp), err
+}
+
+func (s *organizationService) UpdateHook(ctx context.Context, org string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	idInt, err := strconv.ParseInt(input.Name, 10, 64)
+	if err != nil {
+		return nil, nil, err
+	}
+	in, err := convertHookInput(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.client.GiteaClient.EditOrgHook(org, idInt, gitea.EditHookOption{
+		Config: in.Config,
+		Events: in.Events,
+		Active: &in.Active,
+	})
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	// the edit endpoint does not return the hook.
+	return s.FindHook(ctx, org, input.Name)
+}
+
+func (s *organizationService) DeleteHook(_ context.Context, org, id string) (*scm.Response, error) {
+	idInt, err := strconv.ParseInt(id, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.GiteaClient.DeleteOrgHook(org, idInt)
+	return toSCMResponse(resp), err
+}
+
 //
 // native data structure conversion
 //
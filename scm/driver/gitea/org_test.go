@@ -78,3 +78,157 @@ func TestOrgList(t *testing.T) {
 
 	t.Run("Page", testPage(res))
 }
+
+func TestOrgHookFind(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/orgs/gogits/hooks/20").
+		Reply(200).
+		Type("application/json").
+		File("testdata/hook.json")
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.Organizations.FindHook(context.Background(), "gogits", "20")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(scm.Hook)
+	raw, _ := os.ReadFile("testdata/hook.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestOrgHookList(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/orgs/gogits/hooks").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockPageHeaders).
+		File("testdata/hooks.json")
+
+	client, _ := New("https://demo.gitea.com")
+	got, res, err := client.Organizations.ListHooks(context.Background(), "gogits", &scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*scm.Hook{}
+	raw, _ := os.ReadFile("testdata/hooks.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Page", testPage(res))
+}
+
+func TestOrgHookCreate(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Post("/api/v1/orgs/gogits/hooks").
+		Reply(201).
+		Type("application/json").
+		File("testdata/hook.json")
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.Organizations.CreateHook(context.Background(), "gogits", &scm.HookInput{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(scm.Hook)
+	raw, _ := os.ReadFile("testdata/hook.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestOrgHookUpdate(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Patch("/api/v1/orgs/gogits/hooks/20").
+		Reply(200).
+		Type("application/json").
+		File("testdata/hook.json")
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/orgs/gogits/hooks/20").
+		Reply(200).
+		Type("application/json").
+		File("testdata/hook.json")
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.Organizations.UpdateHook(context.Background(), "gogits", &scm.HookInput{
+		Name:   "20",
+		Target: "http://example.com/webhook",
+		Events: scm.HookEvents{Push: true},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(scm.Hook)
+	raw, _ := os.ReadFile("testdata/hook.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if !gock.IsDone() {
+		t.Errorf("Expected the hook to be edited and then fetched")
+	}
+}
+
+func TestOrgHookDelete(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Delete("/api/v1/orgs/gogits/hooks/20").
+		Reply(204).
+		Type("application/json")
+
+	client, _ := New("https://demo.gitea.com")
+	_, err := client.Organizations.DeleteHook(context.Background(), "gogits", "20")
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 
 	"code.gitea.io/sdk/gitea"
 	"github.com/bluekeyes/go-gitdiff/gitdiff"
@@ -31,17 +33,46 @@ func (s *pullService) List(ctx context.Context, repo string, opts *scm.PullReque
 			Page:     opts.Page,
 			PageSize: opts.Size,
 		},
+		Sort: convertSortOptions(opts.Sort, opts.Ascending),
 	}
 	if opts.Open && !opts.Closed {
 		in.State = gitea.StateOpen
 	} else if opts.Closed && !opts.Open {
 		in.State = gitea.StateClosed
 	}
+	// the milestone can only be filtered on by id and the
+	// labels by label id, so the remaining filters are emulated.
 	out, resp, err := s.client.GiteaClient.ListRepoPullRequests(namespace, name, in)
-	return convertPullRequests(out), toSCMResponse(resp), err
+	return scm.FilterPullRequests(convertPullRequests(out), opts), toSCMResponse(resp), err
+}
+
+// ListForCommit returns the pull request that introduced the commit.
+// Gitea associates a commit with at most one pull request.
+func (s *pullService) ListForCommit(ctx context.Context, repo, sha string) ([]*scm.PullRequest, *scm.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/commits/%s/pull", repo, sha)
+	out := new(gitea.PullRequest)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if res != nil && res.Status == http.StatusNotFound {
+		return []*scm.PullRequest{}, res, nil
+	}
+	if err != nil {
+		return nil, res, err
+	}
+	return []*scm.PullRequest{convertPullRequest(out)}, res, nil
 }
 
 // TODO: Maybe contribute to gitea/go-sdk with .patch function?
+func (s *pullService) FindDiff(ctx context.Context, repo string, number int) (*scm.Diff, *scm.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/pulls/%d.diff", repo, number)
+	buf := new(bytes.Buffer)
+	res, err := s.client.do(ctx, "GET", path, nil, buf)
+	if err != nil {
+		return nil, res, err
+	}
+	diff, err := scm.ParseDiff(buf.String())
+	return diff, res, err
+}
+
 func (s *pullService) ListChanges(ctx context.Context, repo string, number int, _ *scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
 	// Get the patch and then parse it.
 	path := fmt.Sprintf("api/v1/repos/%s/pulls/%d.patch", repo, number)
@@ -126,10 +157,14 @@ func (s *pullService) Reopen(ctx context.Context, repo string, number int) (*scm
 
 func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
+	title := input.Title
+	if input.Draft {
+		title = draftTitle(title)
+	}
 	in := gitea.CreatePullRequestOption{
 		Head:  input.Head,
 		Base:  input.Base,
-		Title: input.Title,
+		Title: title,
 		Body:  input.Body,
 	}
 	out, resp, err := s.client.GiteaClient.CreatePullRequest(namespace, name, in)
@@ -148,10 +183,130 @@ func (s *pullService) DeletePullRequest(ctx context.Context, repo string, prID i
 	return nil, scm.ErrNotSupported
 }
 
+// MarkReady removes the work in progress prefix from the pull
+// request title.
+func (s *pullService) MarkReady(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(ctx, repo, number, false)
+}
+
+// ConvertToDraft adds the work in progress prefix to the pull
+// request title.
+func (s *pullService) ConvertToDraft(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(ctx, repo, number, true)
+}
+
+func (s *pullService) EnableAutoMerge(ctx context.Context, repo string, number int, opts *scm.AutoMergeOptions) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) DisableAutoMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) EnqueueMerge(ctx context.Context, repo string, number int, opts *scm.AutoMergeOptions) (*scm.MergeQueueEntry, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *pullService) DequeueMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) FindMergeQueueEntry(ctx context.Context, repo string, number int) (*scm.MergeQueueEntry, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+// UpdateBranch updates the pull request branch with the base branch
+// and returns the new head sha.
+func (s *pullService) UpdateBranch(ctx context.Context, repo string, number int, method string) (string, *scm.Response, error) {
+	if method == "" {
+		method = scm.UpdateBranchMerge
+	}
+	path := fmt.Sprintf("api/v1/repos/%s/pulls/%d/update?style=%s", repo, number, method)
+	res, err := s.client.do(ctx, "POST", path, nil, nil)
+	if res != nil && res.Status == http.StatusConflict {
+		return "", res, scm.MergeConflict{Number: number}
+	}
+	if err != nil {
+		return "", res, err
+	}
+	namespace, name := scm.Split(repo)
+	out, resp, err := s.client.GiteaClient.GetPullRequest(namespace, name, int64(number))
+	if err != nil {
+		return "", toSCMResponse(resp), err
+	}
+	return out.Head.Sha, toSCMResponse(resp), nil
+}
+
+func (s *pullService) setDraft(ctx context.Context, repo string, number int, draft bool) (*scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	out, resp, err := s.client.GiteaClient.GetPullRequest(namespace, name, int64(number))
+	if err != nil {
+		return toSCMResponse(resp), err
+	}
+	title := trimDraftTitle(out.Title)
+	if draft {
+		title = draftTitle(title)
+	}
+	if title == out.Title {
+		return toSCMResponse(resp), nil
+	}
+	in := gitea.EditPullRequestOption{
+		Title: title,
+	}
+	_, resp, err = s.client.GiteaClient.EditPullRequest(namespace, name, int64(number), in)
+	return toSCMResponse(resp), err
+}
+
+// draftPrefixes are the default title prefixes Gitea uses to mark a
+// pull request as work in progress, in lower case.
+var draftPrefixes = []string{"wip:", "[wip]"}
+
+// isDraftTitle returns true if the title marks the pull request as
+// work in progress.
+func isDraftTitle(title string) bool {
+	return trimDraftTitle(title) != title
+}
+
+// trimDraftTitle returns the title without any work in progress
+// prefix.
+func trimDraftTitle(title string) string {
+	lower := strings.ToLower(title)
+	for _, prefix := range draftPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return strings.TrimSpace(title[len(prefix):])
+		}
+	}
+	return title
+}
+
+// draftTitle returns the title with the work in progress prefix
+// applied.
+func draftTitle(title string) string {
+	if isDraftTitle(title) {
+		return title
+	}
+	return "WIP: " + title
+}
+
 //
 // native data structure conversion
 //
 
+// convertSortOptions returns the pull request sort order
+// accepted by Gitea. Gitea sorts by newest first by default.
+func convertSortOptions(sort string, ascending bool) string {
+	switch {
+	case sort == scm.SortUpdated && ascending:
+		return "leastupdate"
+	case sort == scm.SortUpdated:
+		return "recentupdate"
+	case ascending:
+		return "oldest"
+	default:
+		return ""
+	}
+}
+
 func convertPullRequests(src []*gitea.PullRequest) []*scm.PullRequest {
 	dst := []*scm.PullRequest{}
 	for _, v := range src {
@@ -180,6 +335,7 @@ func convertPullRequest(src *gitea.PullRequest) *scm.PullRequest {
 		DiffLink:  src.DiffURL,
 		Link:      src.HTMLURL,
 		Closed:    src.State == gitea.StateClosed,
+		Draft:     src.Draft,
 		Author:    *convertUser(src.Poster),
 		Assignees: convertUsers(src.Assignees),
 		Merged:    src.HasMerged,
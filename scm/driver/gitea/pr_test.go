@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
@@ -84,6 +85,93 @@ func TestPullRequestList(t *testing.T) {
 	t.Run("Page", testPage(res))
 }
 
+func TestPullRequestListFilters(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls").
+		Times(2).
+		Reply(200).
+		Type("application/json").
+		File("testdata/prs.json")
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.PullRequests.List(context.Background(), "jcitizen/my-repo", &scm.PullRequestListOptions{Head: "feature", Base: "master", Author: "jcitizen"})
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+
+	got, _, err = client.PullRequests.List(context.Background(), "jcitizen/my-repo", &scm.PullRequestListOptions{Head: "other"})
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestPullRequestListSort(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls").
+		MatchParam("sort", "leastupdate").
+		Reply(200).
+		Type("application/json").
+		File("testdata/prs.json")
+
+	client, _ := New("https://demo.gitea.com")
+	since := time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)
+	got, _, err := client.PullRequests.List(context.Background(), "jcitizen/my-repo", &scm.PullRequestListOptions{Sort: scm.SortUpdated, Ascending: true, UpdatedAfter: &since})
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.True(t, gock.IsDone())
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls").
+		Reply(200).
+		Type("application/json").
+		File("testdata/prs.json")
+
+	got, _, err = client.PullRequests.List(context.Background(), "jcitizen/my-repo", &scm.PullRequestListOptions{Labels: []string{"bug"}})
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestPullRequestListForCommit(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/jcitizen/my-repo/commits/4f5e7d8f15cf79387cfd8a0d30c58855ab61e138/pull").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.PullRequests.ListForCommit(context.Background(), "jcitizen/my-repo", "4f5e7d8f15cf79387cfd8a0d30c58855ab61e138")
+	assert.NoError(t, err)
+
+	want := new(scm.PullRequest)
+	raw, _ := os.ReadFile("testdata/pr.json.golden")
+	err = json.Unmarshal(raw, want)
+	assert.NoError(t, err)
+
+	if diff := cmp.Diff([]*scm.PullRequest{want}, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/jcitizen/my-repo/commits/39af58f1eff02aa308e16913e887c8d50362b474/pull").
+		Reply(404).
+		Type("application/json")
+
+	got, _, err = client.PullRequests.ListForCommit(context.Background(), "jcitizen/my-repo", "39af58f1eff02aa308e16913e887c8d50362b474")
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
+
 func TestPullClose(t *testing.T) {
 	defer gock.Off()
 
@@ -208,3 +296,122 @@ func TestPullCreate(t *testing.T) {
 		t.Log(diff)
 	}
 }
+
+func TestPullMarkReady(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_draft.json")
+
+	gock.New("https://demo.gitea.com").
+		Patch("/api/v1/repos/jcitizen/my-repo/pulls/1").
+		BodyString(`"title":"Add License File"`).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	client, _ := New("https://demo.gitea.com")
+	_, err := client.PullRequests.MarkReady(context.Background(), "jcitizen/my-repo", 1)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestPullConvertToDraft(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	gock.New("https://demo.gitea.com").
+		Patch("/api/v1/repos/jcitizen/my-repo/pulls/1").
+		BodyString(`"title":"WIP: Add License File"`).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr_draft.json")
+
+	client, _ := New("https://demo.gitea.com")
+	_, err := client.PullRequests.ConvertToDraft(context.Background(), "jcitizen/my-repo", 1)
+	assert.NoError(t, err)
+	assert.True(t, gock.IsDone())
+}
+
+func TestPullUpdateBranch(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Post("/api/v1/repos/jcitizen/my-repo/pulls/1/update").
+		MatchParam("style", "rebase").
+		Reply(200)
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.PullRequests.UpdateBranch(context.Background(), "jcitizen/my-repo", 1, scm.UpdateBranchRebase)
+	assert.NoError(t, err)
+	assert.Equal(t, "4f5e7d8f15cf79387cfd8a0d30c58855ab61e138", got)
+}
+
+func TestPullUpdateBranchConflict(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Post("/api/v1/repos/jcitizen/my-repo/pulls/1/update").
+		MatchParam("style", "merge").
+		Reply(409).
+		Type("application/json").
+		BodyString(`{"message":"merge conflict","url":"https://demo.gitea.com/api/swagger"}`)
+
+	client, _ := New("https://demo.gitea.com")
+	_, _, err := client.PullRequests.UpdateBranch(context.Background(), "jcitizen/my-repo", 1, scm.UpdateBranchMerge)
+	assert.True(t, scm.IsMergeConflict(err))
+}
+
+func TestPullFindDiff(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/go-gitea/gitea/pulls/1.diff").
+		Reply(200).
+		Type("text/plain").
+		File("testdata/pr.diff")
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.PullRequests.FindDiff(context.Background(), "go-gitea/gitea", 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := len(got.Files), 2; got != want {
+		t.Fatalf("Want %d files, got %d", want, got)
+	}
+	if got, want := got.Files[0].NewPath, "README.md"; got != want {
+		t.Errorf("Want file %q, got %q", want, got)
+	}
+	if got, want := len(got.Files[0].Hunks), 2; got != want {
+		t.Errorf("Want %d hunks, got %d", want, got)
+	}
+	if !got.Files[1].Added || !got.Files[1].Binary {
+		t.Errorf("Want added binary file %s", got.Files[1].NewPath)
+	}
+}
@@ -0,0 +1,88 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitea
+
+import (
+	"context"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// reactionService implements reactions on issues and comments.
+// Gitea reactions have no id and the reaction list is not
+// paginated.
+type reactionService struct {
+	client *wrapper
+}
+
+func (s *reactionService) List(ctx context.Context, repo string, target scm.ReactionTarget, opts *scm.ListOptions) ([]*scm.Reaction, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	var out []*gitea.Reaction
+	var resp *gitea.Response
+	var err error
+	if isCommentReaction(target) {
+		out, resp, err = s.client.GiteaClient.GetIssueCommentReactions(namespace, name, int64(target.Comment))
+	} else {
+		out, resp, err = s.client.GiteaClient.GetIssueReactions(namespace, name, int64(target.Number))
+	}
+	return convertReactionList(out), toSCMResponse(resp), err
+}
+
+func (s *reactionService) Create(ctx context.Context, repo string, target scm.ReactionTarget, content scm.ReactionContent) (*scm.Reaction, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	var out *gitea.Reaction
+	var resp *gitea.Response
+	var err error
+	if isCommentReaction(target) {
+		out, resp, err = s.client.GiteaClient.PostIssueCommentReaction(namespace, name, int64(target.Comment), string(content))
+	} else {
+		out, resp, err = s.client.GiteaClient.PostIssueReaction(namespace, name, int64(target.Number), string(content))
+	}
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	return convertReaction(out), toSCMResponse(resp), nil
+}
+
+func (s *reactionService) Delete(ctx context.Context, repo string, target scm.ReactionTarget, content scm.ReactionContent) (*scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	var resp *gitea.Response
+	var err error
+	if isCommentReaction(target) {
+		resp, err = s.client.GiteaClient.DeleteIssueCommentReaction(namespace, name, int64(target.Comment), string(content))
+	} else {
+		resp, err = s.client.GiteaClient.DeleteIssueReaction(namespace, name, int64(target.Number), string(content))
+	}
+	return toSCMResponse(resp), err
+}
+
+func isCommentReaction(target scm.ReactionTarget) bool {
+	switch target.Type {
+	case scm.ReactionOnIssueComment, scm.ReactionOnPullRequestComment, scm.ReactionOnReviewComment:
+		return true
+	default:
+		return false
+	}
+}
+
+func convertReactionList(from []*gitea.Reaction) []*scm.Reaction {
+	to := []*scm.Reaction{}
+	for _, v := range from {
+		to = append(to, convertReaction(v))
+	}
+	return to
+}
+
+func convertReaction(from *gitea.Reaction) *scm.Reaction {
+	to := &scm.Reaction{
+		Content: scm.ReactionContent(from.Reaction),
+		Created: from.Created,
+	}
+	if user := convertUser(from.User); user != nil {
+		to.User = *user
+	}
+	return to
+}
@@ -0,0 +1,86 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestReactionList(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/go-gitea/gitea/issues/1/reactions").
+		Reply(200).
+		Type("application/json").
+		File("testdata/reactions.json")
+
+	client, _ := New("https://demo.gitea.com")
+	target := scm.ReactionTarget{Type: scm.ReactionOnIssue, Number: 1}
+	got, _, err := client.Reactions.List(context.Background(), "go-gitea/gitea", target, &scm.ListOptions{})
+	assert.NoError(t, err)
+
+	want := []*scm.Reaction{}
+	raw, _ := os.ReadFile("testdata/reactions.json.golden")
+	assert.NoError(t, json.Unmarshal(raw, &want))
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReactionCreate(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Post("/api/v1/repos/go-gitea/gitea/issues/comments/74/reactions").
+		BodyString(`{"content":"heart"}`).
+		Reply(201).
+		Type("application/json").
+		File("testdata/reaction.json")
+
+	client, _ := New("https://demo.gitea.com")
+	target := scm.ReactionTarget{Type: scm.ReactionOnPullRequestComment, Number: 1, Comment: 74}
+	got, _, err := client.Reactions.Create(context.Background(), "go-gitea/gitea", target, scm.ReactionHeart)
+	assert.NoError(t, err)
+
+	want := new(scm.Reaction)
+	raw, _ := os.ReadFile("testdata/reaction.json.golden")
+	assert.NoError(t, json.Unmarshal(raw, want))
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReactionDelete(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Delete("/api/v1/repos/go-gitea/gitea/issues/1/reactions").
+		BodyString(`{"content":"heart"}`).
+		Reply(200)
+
+	client, _ := New("https://demo.gitea.com")
+	target := scm.ReactionTarget{Type: scm.ReactionOnPullRequest, Number: 1}
+	_, err := client.Reactions.Delete(context.Background(), "go-gitea/gitea", target, scm.ReactionHeart)
+	assert.NoError(t, err)
+}
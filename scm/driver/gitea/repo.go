@@ -6,6 +6,8 @@ package gitea
 
 import (
 	"context"
+	"fmt"
+	"io"
 	"net/url"
 	"strconv"
 
@@ -171,34 +173,41 @@ func (s *repositoryService) ListStatus(_ context.Context, repo, ref string, opts
 }
 
 func (s *repositoryService) CreateHook(_ context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
-	target, err := url.Parse(input.Target)
+	in, err := convertHookInput(input)
 	if err != nil {
 		return nil, nil, err
 	}
-	params := target.Query()
-	params.Set("secret", input.Secret)
-	target.RawQuery = params.Encode()
-
 	namespace, name := scm.Split(repo)
-	in := gitea.CreateHookOption{
-		Type: "gitea",
-		Config: map[string]string{
-			"secret":       input.Secret,
-			"content_type": "json",
-			"url":          target.String(),
-		},
-		Events: append(
-			input.NativeEvents,
-			convertHookEvent(input.Events)...,
-		),
-		Active: true,
-	}
 	out, resp, err := s.client.GiteaClient.CreateRepoHook(namespace, name, in)
 	return convertHook(out), toSCMResponse(resp), err
 }
 
 func (s *repositoryService) UpdateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	idInt, err := strconv.ParseInt(input.Name, 10, 64)
+	if err != nil {
+		return nil, nil, err
+	}
+	in, err := convertHookInput(input)
+	if err != nil {
+		return nil, nil, err
+	}
+	namespace, name := scm.Split(repo)
+	resp, err := s.client.GiteaClient.EditRepoHook(namespace, name, idInt, gitea.EditHookOption{
+		Config: in.Config,
+		Events: in.Events,
+		Active: &in.Active,
+	})
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	// the edit endpoint does not return the hook.
+	return s.FindHook(ctx, repo, input.Name)
+}
+
+// ConvertHookEvents returns the events reported for a webhook
+// created from the input.
+func (s *repositoryService) ConvertHookEvents(input *scm.HookInput) []string {
+	return append(append([]string(nil), input.NativeEvents...), convertHookEvent(input.Events)...)
 }
 
 func (s *repositoryService) CreateStatus(_ context.Context, repo, ref string, input *scm.StatusInput) (*scm.Status, *scm.Response, error) {
@@ -213,6 +222,32 @@ func (s *repositoryService) CreateStatus(_ context.Context, repo, ref string, in
 	return convertStatus(out), toSCMResponse(resp), err
 }
 
+// ListHookDeliveries is not supported. Gitea records the deliveries
+// of a webhook as hook tasks, but no release up to Gitea 1.22 exposes
+// them through the api: they are only shown on the webhook settings
+// page.
+func (s *repositoryService) ListHookDeliveries(context.Context, string, string, *scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+// FindHookDelivery is not supported, see ListHookDeliveries.
+func (s *repositoryService) FindHookDelivery(context.Context, string, string, string) (*scm.HookDelivery, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+// RedeliverHook is not supported. Gitea 1.22 and earlier can only
+// replay a hook task from the webhook settings page, which requires a
+// web session rather than an api token.
+func (s *repositoryService) RedeliverHook(context.Context, string, string, string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+// TestHook sends a test push event to a repository webhook.
+func (s *repositoryService) TestHook(ctx context.Context, repo, id string) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v1/repos/%s/hooks/%s/tests", repo, id)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
 func (s *repositoryService) DeleteHook(_ context.Context, repo, id string) (*scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	idInt, err := strconv.ParseInt(id, 10, 64)
@@ -229,6 +264,21 @@ func (s *repositoryService) Delete(_ context.Context, repo string) (*scm.Respons
 	return toSCMResponse(resp), err
 }
 
+func (s *repositoryService) Archive(_ context.Context, repo, ref string, format scm.ArchiveFormat) (io.ReadCloser, *scm.Response, error) {
+	var ext gitea.ArchiveType
+	switch format {
+	case scm.ArchiveFormatTarball:
+		ext = gitea.TarGZArchive
+	case scm.ArchiveFormatZip:
+		ext = gitea.ZipArchive
+	default:
+		return nil, nil, scm.ErrNotSupported
+	}
+	namespace, name := scm.Split(repo)
+	out, resp, err := s.client.GiteaClient.GetArchiveReader(namespace, name, ref, ext)
+	return out, toSCMResponse(resp), err
+}
+
 //
 // native data structure conversion
 //
@@ -289,6 +339,32 @@ func convertHook(from *gitea.Hook) *scm.Hook {
 	}
 }
 
+// convertHookInput returns the options used to create a
+// repository or organization webhook.
+func convertHookInput(input *scm.HookInput) (gitea.CreateHookOption, error) {
+	target, err := url.Parse(input.Target)
+	if err != nil {
+		return gitea.CreateHookOption{}, err
+	}
+	params := target.Query()
+	params.Set("secret", input.Secret)
+	target.RawQuery = params.Encode()
+
+	return gitea.CreateHookOption{
+		Type: "gitea",
+		Config: map[string]string{
+			"secret":       input.Secret,
+			"content_type": "json",
+			"url":          target.String(),
+		},
+		Events: append(
+			input.NativeEvents,
+			convertHookEvent(input.Events)...,
+		),
+		Active: true,
+	}, nil
+}
+
 func convertHookEvent(from scm.HookEvents) []string {
 	var events []string
 	if from.PullRequest {
@@ -7,6 +7,7 @@ package gitea
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"os"
 	"strings"
 	"testing"
@@ -132,6 +133,35 @@ func TestRepoNotFound(t *testing.T) {
 	}
 }
 
+func TestRepoArchive(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/go-gitea/gitea/archive/master.tar.gz").
+		Reply(200).
+		Type("application/octet-stream").
+		BodyString("archive")
+
+	client, _ := New("https://demo.gitea.com")
+	rc, _, err := client.Repositories.Archive(context.Background(), "go-gitea/gitea", "master", scm.ArchiveFormatTarball)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := string(data), "archive"; got != want {
+		t.Errorf("Want archive contents %q, got %q", want, got)
+	}
+}
+
 //
 // hook sub-tests
 //
@@ -229,6 +259,51 @@ func TestHookCreate(t *testing.T) {
 	}
 }
 
+func TestHookUpdate(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Patch("/api/v1/repos/go-gitea/gitea/hooks/20").
+		Reply(200).
+		Type("application/json").
+		File("testdata/hook.json")
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/go-gitea/gitea/hooks/20").
+		Reply(200).
+		Type("application/json").
+		File("testdata/hook.json")
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.Repositories.UpdateHook(context.Background(), "go-gitea/gitea", &scm.HookInput{
+		Name:   "20",
+		Target: "http://example.com/webhook",
+		Secret: "topsecret",
+		Events: scm.HookEvents{Push: true},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(scm.Hook)
+	raw, _ := os.ReadFile("testdata/hook.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if !gock.IsDone() {
+		t.Errorf("Expected the hook to be edited and then fetched")
+	}
+}
+
 func TestHookDelete(t *testing.T) {
 	defer gock.Off()
 
@@ -246,6 +321,43 @@ func TestHookDelete(t *testing.T) {
 	}
 }
 
+func TestHookTest(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Post("/api/v1/repos/go-gitea/gitea/hooks/20/tests").
+		Reply(204).
+		Type("application/json")
+
+	client, _ := New("https://demo.gitea.com")
+	res, err := client.Repositories.TestHook(context.Background(), "go-gitea/gitea", "20")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := res.Status, 204; got != want {
+		t.Errorf("Want response status %d, got %d", want, got)
+	}
+}
+
+func TestHookDeliveries(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	client, _ := New("https://demo.gitea.com")
+	_, _, err := client.Repositories.ListHookDeliveries(context.Background(), "go-gitea/gitea", "20", &scm.ListOptions{})
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+	_, err = client.Repositories.RedeliverHook(context.Background(), "go-gitea/gitea", "20", "1")
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
+
 func TestHookEvents(t *testing.T) {
 	tests := []struct {
 		in  scm.HookEvents
@@ -6,6 +6,8 @@ package gitea
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 
 	"code.gitea.io/sdk/gitea"
 	"fortio.org/safecast"
@@ -29,7 +31,14 @@ func (s *reviewService) List(ctx context.Context, repo string, number int, opts
 	return convertReviewList(reviews), toSCMResponse(resp), err
 }
 
+// Create creates the review on the pull request. Gitea comments apply
+// to a single line so multi-line comments are not supported.
 func (s *reviewService) Create(ctx context.Context, repo string, number int, input *scm.ReviewInput) (*scm.Review, *scm.Response, error) {
+	for _, c := range input.Comments {
+		if isMultiLine(c) {
+			return nil, nil, scm.ErrNotSupported
+		}
+	}
 	namespace, name := scm.Split(repo)
 
 	in := gitea.CreatePullReviewOptions{
@@ -73,6 +82,85 @@ func (s *reviewService) Submit(ctx context.Context, repo string, prID, reviewID
 	return convertReview(review), toSCMResponse(resp), err
 }
 
+// CreateComment creates a single comment review on the pull request.
+// Gitea has no API to reply to an existing comment or to comment on a
+// range of lines.
+func (s *reviewService) CreateComment(ctx context.Context, repo string, number int, input *scm.ReviewCommentInput) (*scm.ReviewComment, *scm.Response, error) {
+	if input.InReplyTo != 0 || input.ThreadID != "" || isMultiLine(input) {
+		return nil, nil, scm.ErrNotSupported
+	}
+	namespace, name := scm.Split(repo)
+	in := gitea.CreatePullReviewOptions{
+		State:    gitea.ReviewStateComment,
+		CommitID: input.Sha,
+		Comments: toCreatePullRequestComments([]*scm.ReviewCommentInput{input}),
+	}
+	review, resp, err := s.client.GiteaClient.CreatePullReview(namespace, name, int64(number), in)
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	comments, resp, err := s.client.GiteaClient.ListPullReviewComments(namespace, name, int64(number), review.ID)
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	if len(comments) == 0 {
+		return nil, toSCMResponse(resp), scm.ErrNotFound
+	}
+	return convertReviewComment(comments[0]), toSCMResponse(resp), nil
+}
+
+// ListThreads groups the comments of the pull request reviews by the
+// line they are attached to. The thread id is the id of the first
+// comment on the line.
+func (s *reviewService) ListThreads(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.ReviewThread, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	reviews, resp, err := s.client.GiteaClient.ListPullReviews(namespace, name, int64(number), gitea.ListPullReviewsOptions{ListOptions: toGiteaListOptions(opts)})
+	if err != nil {
+		return nil, toSCMResponse(resp), err
+	}
+	res := toSCMResponse(resp)
+	threads := []*scm.ReviewThread{}
+	index := map[string]*scm.ReviewThread{}
+	for _, review := range reviews {
+		comments, resp, err := s.client.GiteaClient.ListPullReviewComments(namespace, name, int64(number), review.ID)
+		if err != nil {
+			return nil, toSCMResponse(resp), err
+		}
+		for _, c := range comments {
+			comment := convertReviewComment(c)
+			key := fmt.Sprintf("%s:%s:%d", comment.Path, comment.Side, comment.Line)
+			thread, ok := index[key]
+			if !ok {
+				thread = &scm.ReviewThread{
+					ID:       strconv.Itoa(comment.ID),
+					Path:     comment.Path,
+					Line:     comment.Line,
+					Side:     comment.Side,
+					Resolved: c.Resolver != nil,
+				}
+				index[key] = thread
+				threads = append(threads, thread)
+			}
+			comment.ThreadID = thread.ID
+			if len(thread.Comments) > 0 {
+				comment.InReplyTo = thread.Comments[0].ID
+			}
+			thread.Comments = append(thread.Comments, comment)
+		}
+	}
+	return threads, res, nil
+}
+
+// ResolveThread is not supported, Gitea has no API to resolve
+// conversations.
+func (s *reviewService) ResolveThread(ctx context.Context, repo string, number int, id string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *reviewService) UnresolveThread(ctx context.Context, repo string, number int, id string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
 // TODO: Figure out whether this actually is a _thing_ exactly in Gitea. I don't think it is.
 func (s *reviewService) Dismiss(ctx context.Context, repo string, prID, reviewID int, msg string) (*scm.Review, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
@@ -110,27 +198,44 @@ func convertReviewCommentList(src []*gitea.PullReviewComment) []*scm.ReviewComme
 }
 
 func convertReviewComment(src *gitea.PullReviewComment) *scm.ReviewComment {
-	return &scm.ReviewComment{
+	comment := &scm.ReviewComment{
 		ID:      int(src.ID),
 		Body:    src.Body,
 		Path:    src.Path,
 		Sha:     src.CommitID,
 		Line:    safecast.MustConvert[int](src.LineNum),
+		Side:    scm.DiffSideRight,
 		Link:    src.HTMLURL,
 		Author:  *convertUser(src.Reviewer),
 		Created: src.Created,
 		Updated: src.Updated,
 	}
+	if src.LineNum == 0 && src.OldLineNum != 0 {
+		comment.Line = safecast.MustConvert[int](src.OldLineNum)
+		comment.Side = scm.DiffSideLeft
+	}
+	comment.FileLine = comment.Line
+	return comment
+}
+
+// isMultiLine returns true if the comment applies to a range of lines.
+func isMultiLine(c *scm.ReviewCommentInput) bool {
+	return c.StartLine != 0 && c.StartLine != c.Line
 }
 
 func toCreatePullRequestComments(src []*scm.ReviewCommentInput) []gitea.CreatePullReviewComment {
 	var out []gitea.CreatePullReviewComment
 	for _, c := range src {
-		out = append(out, gitea.CreatePullReviewComment{
-			Path:       c.Path,
-			Body:       c.Body,
-			NewLineNum: int64(c.Line),
-		})
+		comment := gitea.CreatePullReviewComment{
+			Path: c.Path,
+			Body: c.Body,
+		}
+		if c.Side == scm.DiffSideLeft {
+			comment.OldLineNum = int64(c.Line)
+		} else {
+			comment.NewLineNum = int64(c.Line)
+		}
+		out = append(out, comment)
 	}
 	return out
 }
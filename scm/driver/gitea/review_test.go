@@ -156,3 +156,78 @@ func TestReviewDelete(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestReviewListThreads(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1/reviews").
+		Reply(200).
+		Type("application/json").
+		File("testdata/reviews.json")
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1/reviews/1/comments").
+		Reply(200).
+		Type("application/json").
+		File("testdata/review_comments.json")
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.Reviews.ListThreads(context.Background(), "jcitizen/my-repo", 1, &scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*scm.ReviewThread{}
+	raw, _ := os.ReadFile("testdata/review_threads.json.golden")
+	err = json.Unmarshal(raw, &want)
+	assert.NoError(t, err)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReviewCreateComment(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Post("/api/v1/repos/jcitizen/my-repo/pulls/1/reviews").
+		File("testdata/review_comment_create.json").
+		Reply(200).
+		Type("application/json").
+		File("testdata/review.json")
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/jcitizen/my-repo/pulls/1/reviews/1/comments").
+		Reply(200).
+		Type("application/json").
+		File("testdata/review_comments.json")
+
+	client, _ := New("https://demo.gitea.com")
+	in := &scm.ReviewCommentInput{
+		Body: "Why was this removed?",
+		Path: "some/file",
+		Line: 3,
+		Side: scm.DiffSideLeft,
+		Sha:  "5c23b301e7eb47aa83de90cf08e0a75b4c0906c8",
+	}
+	got, _, err := client.Reviews.CreateComment(context.Background(), "jcitizen/my-repo", 1, in)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	assert.Equal(t, 11, got.ID)
+	assert.True(t, gock.IsDone())
+
+	_, _, err = client.Reviews.CreateComment(context.Background(), "jcitizen/my-repo", 1, &scm.ReviewCommentInput{Body: "+1", InReplyTo: 11})
+	assert.Equal(t, scm.ErrNotSupported, err)
+
+	_, _, err = client.Reviews.CreateComment(context.Background(), "jcitizen/my-repo", 1, &scm.ReviewCommentInput{Body: "+1", Path: "some/file", StartLine: 1, Line: 3})
+	assert.Equal(t, scm.ErrNotSupported, err)
+}
@@ -0,0 +1,117 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitea
+
+import (
+	"context"
+	"strconv"
+
+	"code.gitea.io/sdk/gitea"
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// searchService implements the search API on top of the
+// repository and issue search endpoints. Gitea has no code
+// or commit search API and repositories cannot be searched by
+// language.
+type searchService struct {
+	client *wrapper
+}
+
+func (s *searchService) Code(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchCode, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *searchService) Repositories(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	if q.Language != "" {
+		return nil, nil, scm.ErrNotSupported
+	}
+	if q.Repo != "" {
+		namespace, name := scm.Split(q.Repo)
+		out, resp, err := s.client.GiteaClient.GetRepo(namespace, name)
+		if err != nil {
+			return nil, toSCMResponse(resp), err
+		}
+		return []*scm.Repository{convertRepository(out)}, toSCMResponse(resp), nil
+	}
+	in := gitea.SearchRepoOptions{
+		ListOptions: toGiteaListOptions(opts),
+		Keyword:     q.Text,
+	}
+	if q.Org != "" {
+		// organizations are users in gitea, so this resolves
+		// the owner id of both user and organization namespaces.
+		owner, resp, err := s.client.GiteaClient.GetUserInfo(q.Org)
+		if err != nil {
+			return nil, toSCMResponse(resp), err
+		}
+		in.OwnerID = owner.ID
+	}
+	out, resp, err := s.client.GiteaClient.SearchRepos(in)
+	return convertRepositoryList(out), toSCMResponse(resp), err
+}
+
+func (s *searchService) Issues(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchIssue, *scm.Response, error) {
+	in := gitea.ListIssueOption{
+		ListOptions: toGiteaListOptions(opts),
+		KeyWord:     q.Text,
+		Labels:      q.Labels,
+		CreatedBy:   q.Author,
+		State:       gitea.StateAll,
+	}
+	switch q.State {
+	case "open":
+		in.State = gitea.StateOpen
+	case "closed":
+		in.State = gitea.StateClosed
+	}
+	switch q.Type {
+	case scm.SearchTypeIssue:
+		in.Type = gitea.IssueTypeIssue
+	case scm.SearchTypePullRequest:
+		in.Type = gitea.IssueTypePull
+	}
+	var out []*gitea.Issue
+	var resp *gitea.Response
+	var err error
+	if q.Repo != "" {
+		namespace, name := scm.Split(q.Repo)
+		out, resp, err = s.client.GiteaClient.ListRepoIssues(namespace, name, in)
+	} else {
+		in.Owner = q.Org
+		out, resp, err = s.client.GiteaClient.ListIssues(in)
+	}
+	return convertSearchIssueList(out), toSCMResponse(resp), err
+}
+
+func (s *searchService) Commits(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchCommit, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func convertSearchIssueList(from []*gitea.Issue) []*scm.SearchIssue {
+	to := []*scm.SearchIssue{}
+	for _, v := range from {
+		result := &scm.SearchIssue{
+			Issue: *convertIssue(v),
+		}
+		if v.PullRequest != nil {
+			result.PullRequest = &scm.PullRequest{
+				Number: int(v.Index),
+				Link:   v.HTMLURL,
+				Merged: v.PullRequest.HasMerged,
+			}
+		}
+		if v.Repository != nil {
+			result.Repository = scm.Repository{
+				ID:        strconv.FormatInt(v.Repository.ID, 10),
+				Namespace: v.Repository.Owner,
+				Name:      v.Repository.Name,
+				FullName:  v.Repository.FullName,
+			}
+		}
+		to = append(to, result)
+	}
+	return to
+}
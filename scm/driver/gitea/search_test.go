@@ -0,0 +1,174 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestSearchCode(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	client, _ := New("https://demo.gitea.com")
+	_, _, err := client.Search.Code(context.Background(), &scm.SearchQuery{Text: "main"}, nil)
+	assert.Equal(t, scm.ErrNotSupported, err)
+}
+
+func TestSearchRepositories(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/users/gitea").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/search").
+		MatchParam("q", "hello").
+		MatchParam("uid", "1").
+		Reply(200).
+		Type("application/json").
+		BodyString(searchReposBody(t))
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.Search.Repositories(context.Background(), &scm.SearchQuery{Text: "hello", Org: "gitea"}, &scm.ListOptions{})
+	assert.NoError(t, err)
+
+	want := []*scm.Repository{}
+	raw, _ := os.ReadFile("testdata/repos.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchRepositoriesRepo(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/go-gitea/gitea").
+		Reply(200).
+		Type("application/json").
+		File("testdata/repo.json")
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.Search.Repositories(context.Background(), &scm.SearchQuery{Text: "hello", Repo: "go-gitea/gitea"}, &scm.ListOptions{})
+	assert.NoError(t, err)
+
+	want := new(scm.Repository)
+	raw, _ := os.ReadFile("testdata/repo.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff([]*scm.Repository{want}, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchRepositoriesLanguage(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	client, _ := New("https://demo.gitea.com")
+	_, _, err := client.Search.Repositories(context.Background(), &scm.SearchQuery{Language: "go"}, &scm.ListOptions{})
+	assert.Equal(t, scm.ErrNotSupported, err)
+}
+
+// searchReposBody wraps the repositories fixture in the
+// envelope of the repository search response.
+func searchReposBody(t *testing.T) string {
+	raw, err := os.ReadFile("testdata/repos.json")
+	assert.NoError(t, err)
+	return `{"ok":true,"data":` + string(raw) + `}`
+}
+
+func TestSearchIssues(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/issues/search").
+		MatchParam("q", "bug").
+		MatchParam("state", "open").
+		MatchParam("labels", "bug").
+		MatchParam("created_by", "janedoe").
+		MatchParam("owner", "go-gitea").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockPageHeaders).
+		File("testdata/search_issues.json")
+
+	q := &scm.SearchQuery{
+		Text:   "bug",
+		Org:    "go-gitea",
+		State:  "open",
+		Labels: []string{"bug"},
+		Author: "janedoe",
+	}
+	client, _ := New("https://demo.gitea.com")
+	got, res, err := client.Search.Issues(context.Background(), q, nil)
+	assert.NoError(t, err)
+
+	want := []*scm.SearchIssue{}
+	raw, _ := os.ReadFile("testdata/search_issues.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Page", testPage(res))
+}
+
+func TestSearchRepoPullRequests(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	gock.New("https://demo.gitea.com").
+		Get("/api/v1/repos/go-gitea/gitea/issues").
+		MatchParam("q", "bug").
+		MatchParam("type", "pulls").
+		MatchParam("state", "all").
+		Reply(200).
+		Type("application/json").
+		File("testdata/search_issues.json")
+
+	client, _ := New("https://demo.gitea.com")
+	got, _, err := client.Search.Issues(context.Background(), &scm.SearchQuery{Text: "bug", Repo: "go-gitea/gitea", Type: scm.SearchTypePullRequest}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, got, 2)
+	assert.True(t, gock.IsDone())
+}
+
+func TestSearchCommits(t *testing.T) {
+	defer gock.Off()
+
+	mockServerVersion()
+
+	client, _ := New("https://demo.gitea.com")
+	_, _, err := client.Search.Commits(context.Background(), &scm.SearchQuery{Text: "fix"}, nil)
+	assert.Equal(t, scm.ErrNotSupported, err)
+}
@@ -22,6 +22,10 @@ type webhookService struct {
 }
 
 func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
+	return s.ParseWithSecrets(req, fn.Secrets())
+}
+
+func (s *webhookService) ParseWithSecrets(req *http.Request, fn scm.SecretsFunc) (scm.Webhook, error) {
 	data, err := io.ReadAll(
 		io.LimitReader(req.Body, 10000000),
 	)
@@ -67,10 +71,12 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	// get the gitea signature key to verify the payload
 	// signature. If no key is provided, no validation
 	// is performed.
-	key, err := fn(hook)
+	keys, err := fn(hook)
 	if err != nil {
 		return hook, err
-	} else if key == "" {
+	}
+	keys = hmac.Keys(keys)
+	if len(keys) == 0 {
 		return hook, nil
 	}
 
@@ -82,12 +88,12 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	}
 
 	// test signature if header not set and secret is in payload
-	if signature == "" && secret != "" && secret != key {
+	if signature == "" && secret != "" && !hmac.ValidateToken(secret, keys) {
 		return hook, scm.ErrSignatureInvalid
 	}
 
 	// test signature using header
-	if signature != "" && !hmac.Validate(sha256.New, data, []byte(key), signature) {
+	if signature != "" && !hmac.ValidateAny(sha256.New, data, keys, signature) {
 		return hook, scm.ErrSignatureInvalid
 	}
 
@@ -213,6 +219,11 @@ type (
 		PullRequest gitea.PullRequest `json:"pull_request"`
 		Repository  gitea.Repository  `json:"repository"`
 		Sender      gitea.User        `json:"sender"`
+		Changes     struct {
+			Title *struct {
+				From string `json:"from"`
+			} `json:"title"`
+		} `json:"changes"`
 	}
 
 	// gitea pull request review webhook payload
@@ -320,8 +331,20 @@ func convertPushHook(src *pushHook) *scm.PushHook {
 }
 
 func convertPullRequestHook(dst *pullRequestHook) *scm.PullRequestHook {
+	action := convertAction(dst.Action)
+	// gitea marks drafts with a title prefix, so an edit which
+	// toggles the prefix converts the pull request.
+	if title := dst.Changes.Title; action == scm.ActionUpdate && title != nil {
+		previous, current := isDraftTitle(title.From), isDraftTitle(dst.PullRequest.Title)
+		switch {
+		case previous && !current:
+			action = scm.ActionReadyForReview
+		case !previous && current:
+			action = scm.ActionConvertedToDraft
+		}
+	}
 	return &scm.PullRequestHook{
-		Action:      convertAction(dst.Action),
+		Action:      action,
 		PullRequest: *convertPullRequest(&dst.PullRequest),
 		Repo:        *convertRepository(&dst.Repository),
 		Sender:      *convertUser(&dst.Sender),
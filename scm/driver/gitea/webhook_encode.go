@@ -0,0 +1,380 @@
+package gitea
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"strconv"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/jenkins-x/go-scm/pkg/hmac"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/hookenc"
+)
+
+// Encode returns the Gitea webhook request for the hook, signed
+// with the sha256 signature.
+//
+// Pull request comment webhooks are rendered as issue comments
+// and, like the webhooks sent by Gitea, are only parsed when
+// the pull request can be fetched from the server.
+func (s *webhookService) Encode(hook scm.Webhook, secret string) (*http.Request, error) {
+	var (
+		event   string
+		guid    string
+		payload interface{}
+	)
+	switch v := hook.(type) {
+	case *scm.PushHook:
+		event, guid, payload = "push", v.GUID, encodePushHook(v)
+	case *scm.BranchHook:
+		event, payload = encodeCreateDeleteEvent(v.Action), encodeCreateHook("branch", &v.Ref, &v.Repo, &v.Sender)
+	case *scm.TagHook:
+		event, payload = encodeCreateDeleteEvent(v.Action), encodeCreateHook("tag", &v.Ref, &v.Repo, &v.Sender)
+	case *scm.IssueHook:
+		event, payload = "issues", encodeIssueHook(v)
+	case *scm.IssueCommentHook:
+		event, guid, payload = "issue_comment", v.GUID, encodeIssueCommentHook(v)
+	case *scm.PullRequestHook:
+		event, payload = "pull_request", encodePullRequestHook(v)
+	case *scm.PullRequestCommentHook:
+		event, guid, payload = "issue_comment", v.GUID, encodePullRequestCommentHook(v)
+	case *scm.ReviewHook:
+		event, payload = "reviewed", encodePullRequestReviewHook(v)
+	default:
+		return nil, scm.ErrNotSupported
+	}
+
+	req, data, err := hookenc.NewRequest(payload)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Gitea-Event", event)
+	req.Header.Set("X-Gitea-Delivery", hookenc.DeliveryID(guid))
+	if secret != "" {
+		req.Header.Set("X-Gitea-Signature", hmac.Sign(sha256.New, data, []byte(secret)))
+	}
+	return req, nil
+}
+
+func encodeCreateDeleteEvent(action scm.Action) string {
+	if action == scm.ActionDelete {
+		return "delete"
+	}
+	return "create"
+}
+
+func encodePushHook(src *scm.PushHook) *pushHook {
+	dst := &pushHook{
+		Ref:        src.Ref,
+		Before:     src.Before,
+		After:      src.After,
+		Compare:    src.Compare,
+		Repository: *encodeRepository(&src.Repo),
+		Pusher:     *encodeUser(&src.Sender),
+		Sender:     *encodeUser(&src.Sender),
+	}
+	// gitea describes the head commit using the first commit
+	// of the push, or the pusher if no commits were pushed.
+	if src.Commit.Message != "" || !src.Commit.Author.Date.IsZero() {
+		dst.Commits = []commit{{
+			ID:        src.Commit.Sha,
+			Message:   src.Commit.Message,
+			URL:       src.Commit.Link,
+			Author:    encodeSignature(&src.Commit.Author),
+			Committer: encodeSignature(&src.Commit.Committer),
+			Timestamp: src.Commit.Author.Date,
+		}}
+	} else {
+		dst.Pusher = gitea.User{
+			UserName: src.Commit.Author.Login,
+			FullName: src.Commit.Author.Name,
+			Email:    src.Commit.Author.Email,
+		}
+	}
+	return dst
+}
+
+func encodeSignature(src *scm.Signature) signature {
+	return signature{
+		Name:     src.Name,
+		Email:    src.Email,
+		Username: src.Login,
+	}
+}
+
+func encodeCreateHook(refType string, ref *scm.Reference, repo *scm.Repository, sender *scm.User) *createHook {
+	return &createHook{
+		Ref:           ref.Name,
+		RefType:       refType,
+		Sha:           ref.Sha,
+		DefaultBranch: repo.Branch,
+		Repository:    *encodeRepository(repo),
+		Sender:        *encodeUser(sender),
+	}
+}
+
+func encodeIssueHook(src *scm.IssueHook) *issueHook {
+	return &issueHook{
+		Action:     encodeAction(src.Action),
+		Issue:      *encodeIssue(&src.Issue),
+		Repository: *encodeRepository(&src.Repo),
+		Sender:     *encodeUser(&src.Sender),
+	}
+}
+
+func encodeIssueCommentHook(src *scm.IssueCommentHook) *issueHook {
+	return &issueHook{
+		Action:     encodeAction(src.Action),
+		Issue:      *encodeIssue(&src.Issue),
+		Comment:    *encodeComment(&src.Comment),
+		Repository: *encodeRepository(&src.Repo),
+		Sender:     *encodeUser(&src.Sender),
+	}
+}
+
+func encodePullRequestCommentHook(src *scm.PullRequestCommentHook) *issueHook {
+	pr := &src.PullRequest
+	return &issueHook{
+		Action: encodeAction(src.Action),
+		Issue: gitea.Issue{
+			Index:     int64(pr.Number),
+			Title:     pr.Title,
+			Body:      pr.Body,
+			URL:       pr.Link,
+			State:     encodeState(pr.Closed),
+			Poster:    encodeUser(&pr.Author),
+			Assignees: encodeUsers(pr.Assignees),
+			Created:   pr.Created,
+			Updated:   pr.Updated,
+			PullRequest: &gitea.PullRequestMeta{
+				HasMerged: pr.Merged,
+			},
+		},
+		Comment:    *encodeComment(&src.Comment),
+		Repository: *encodeRepository(&src.Repo),
+		Sender:     *encodeUser(&src.Sender),
+	}
+}
+
+func encodePullRequestHook(src *scm.PullRequestHook) *pullRequestHook {
+	dst := &pullRequestHook{
+		Action:      encodePullRequestAction(src.Action),
+		Number:      src.PullRequest.Number,
+		PullRequest: *encodePullRequest(&src.PullRequest),
+		Repository:  *encodeRepository(&src.Repo),
+		Sender:      *encodeUser(&src.Sender),
+	}
+	// gitea marks drafts with a title prefix, so the previous
+	// title tells whether the pull request was converted.
+	switch src.Action {
+	case scm.ActionReadyForReview:
+		dst.Changes.Title = &struct {
+			From string `json:"from"`
+		}{From: draftTitle(src.PullRequest.Title)}
+	case scm.ActionConvertedToDraft:
+		dst.Changes.Title = &struct {
+			From string `json:"from"`
+		}{From: trimDraftTitle(src.PullRequest.Title)}
+	}
+	return dst
+}
+
+func encodePullRequestReviewHook(src *scm.ReviewHook) *pullRequestReviewHook {
+	return &pullRequestReviewHook{
+		Action:      "reviewed",
+		Number:      src.PullRequest.Number,
+		PullRequest: *encodePullRequest(&src.PullRequest),
+		Repository:  *encodeRepository(&src.Repo),
+		Sender:      *encodeUser(&src.Review.Author),
+		Review: pullRequestReviewPayload{
+			Type:    encodeReviewAction(src.Action),
+			Content: src.Review.Body,
+		},
+	}
+}
+
+func encodePullRequest(src *scm.PullRequest) *gitea.PullRequest {
+	dst := &gitea.PullRequest{
+		Index:     int64(src.Number),
+		Title:     src.Title,
+		Body:      src.Body,
+		Labels:    encodeLabels(src.Labels),
+		State:     gitea.StateType(src.State),
+		Draft:     src.Draft,
+		Poster:    encodeUser(&src.Author),
+		Assignees: encodeUsers(src.Assignees),
+		HTMLURL:   src.Link,
+		DiffURL:   src.DiffLink,
+		HasMerged: src.Merged,
+		Mergeable: src.Mergeable,
+		Base:      encodePullRequestBranch(&src.Base, src.Target),
+		Head:      encodePullRequestBranch(&src.Head, src.Source),
+		Created:   &src.Created,
+		Updated:   &src.Updated,
+	}
+	if dst.State == "" {
+		dst.State = encodeState(src.Closed)
+	}
+	if dst.Head.Sha == "" {
+		dst.Head.Sha = src.Sha
+	}
+	if src.MergeSha != "" {
+		dst.MergedCommitID = &src.MergeSha
+	}
+	return dst
+}
+
+func encodePullRequestBranch(src *scm.PullRequestBranch, name string) *gitea.PRBranchInfo {
+	return &gitea.PRBranchInfo{
+		Name:       name,
+		Ref:        src.Ref,
+		Sha:        src.Sha,
+		Repository: encodeRepository(&src.Repo),
+	}
+}
+
+func encodeIssue(src *scm.Issue) *gitea.Issue {
+	dst := &gitea.Issue{
+		Index:     int64(src.Number),
+		Title:     src.Title,
+		Body:      src.Body,
+		URL:       src.Link,
+		State:     encodeState(src.Closed),
+		Poster:    encodeUser(&src.Author),
+		Assignees: encodeUsers(src.Assignees),
+		Created:   src.Created,
+		Updated:   src.Updated,
+	}
+	for _, name := range src.Labels {
+		dst.Labels = append(dst.Labels, &gitea.Label{Name: name})
+	}
+	return dst
+}
+
+func encodeComment(src *scm.Comment) *gitea.Comment {
+	return &gitea.Comment{
+		ID:      int64(src.ID),
+		Body:    src.Body,
+		Poster:  encodeUser(&src.Author),
+		Created: src.Created,
+		Updated: src.Updated,
+	}
+}
+
+func encodeRepository(src *scm.Repository) *gitea.Repository {
+	id, _ := strconv.ParseInt(src.ID, 10, 64)
+	dst := &gitea.Repository{
+		ID:            id,
+		Owner:         &gitea.User{UserName: src.Namespace},
+		Name:          src.Name,
+		FullName:      src.FullName,
+		DefaultBranch: src.Branch,
+		Private:       src.Private,
+		CloneURL:      src.Clone,
+		SSHURL:        src.CloneSSH,
+		HTMLURL:       src.Link,
+		Created:       src.Created,
+		Updated:       src.Updated,
+	}
+	if src.Perm != nil {
+		dst.Permissions = &gitea.Permission{
+			Push:  src.Perm.Push,
+			Pull:  src.Perm.Pull,
+			Admin: src.Perm.Admin,
+		}
+	}
+	return dst
+}
+
+func encodeUsers(src []scm.User) []*gitea.User {
+	var dst []*gitea.User
+	for i := range src {
+		dst = append(dst, encodeUser(&src[i]))
+	}
+	return dst
+}
+
+func encodeUser(src *scm.User) *gitea.User {
+	return &gitea.User{
+		ID:        int64(src.ID),
+		UserName:  src.Login,
+		FullName:  src.Name,
+		Email:     src.Email,
+		AvatarURL: src.Avatar,
+		IsAdmin:   src.IsAdmin,
+	}
+}
+
+func encodeLabels(src []*scm.Label) []*gitea.Label {
+	var dst []*gitea.Label
+	for _, label := range src {
+		dst = append(dst, &gitea.Label{
+			ID:          label.ID,
+			Name:        label.Name,
+			Description: label.Description,
+			URL:         label.URL,
+			Color:       label.Color,
+		})
+	}
+	return dst
+}
+
+func encodeState(closed bool) gitea.StateType {
+	if closed {
+		return gitea.StateClosed
+	}
+	return gitea.StateOpen
+}
+
+func encodeReviewAction(action scm.Action) string {
+	switch action {
+	case scm.ActionEdited:
+		return "pull_request_review_comment"
+	case scm.ActionDismissed:
+		return "pull_request_review_rejected"
+	default:
+		return "pull_request_review_approved"
+	}
+}
+
+func encodePullRequestAction(action scm.Action) string {
+	switch action {
+	case scm.ActionMerge:
+		// gitea reports merged pull requests as closed.
+		return "closed"
+	case scm.ActionReadyForReview, scm.ActionConvertedToDraft:
+		return "edited"
+	default:
+		return encodeAction(action)
+	}
+}
+
+func encodeAction(action scm.Action) string {
+	switch action {
+	case scm.ActionCreate:
+		return "created"
+	case scm.ActionDelete:
+		return "deleted"
+	case scm.ActionUpdate, scm.ActionEdited:
+		return "edited"
+	case scm.ActionOpen:
+		return "opened"
+	case scm.ActionReopen:
+		return "reopened"
+	case scm.ActionClose:
+		return "closed"
+	case scm.ActionLabel:
+		return "label_updated"
+	case scm.ActionUnlabel:
+		return "label_cleared"
+	case scm.ActionSync:
+		return "synchronized"
+	case scm.ActionAssigned:
+		return "assigned"
+	case scm.ActionUnassigned:
+		return "unassigned"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,126 @@
+package gitea
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestWebhookEncode(t *testing.T) {
+	tests := []struct {
+		event string
+		file  string
+		setup func()
+	}{
+		{event: "push", file: "testdata/webhooks/push.json"},
+		{event: "create", file: "testdata/webhooks/branch_create.json"},
+		{event: "delete", file: "testdata/webhooks/branch_delete.json"},
+		{event: "create", file: "testdata/webhooks/tag_create.json"},
+		{event: "delete", file: "testdata/webhooks/tag_delete.json"},
+		{event: "issues", file: "testdata/webhooks/issues_opened.json"},
+		{event: "issues", file: "testdata/webhooks/issues_closed.json"},
+		{event: "issue_comment", file: "testdata/webhooks/issue_comment_created.json"},
+		{event: "pull_request", file: "testdata/webhooks/pull_request_opened.json"},
+		{event: "pull_request", file: "testdata/webhooks/pull_request_edited.json"},
+		{event: "pull_request", file: "testdata/webhooks/pull_request_ready.json"},
+		{event: "pull_request", file: "testdata/webhooks/pull_request_draft.json"},
+		{event: "pull_request", file: "testdata/webhooks/pull_request_synchronized.json"},
+		{event: "pull_request", file: "testdata/webhooks/pull_request_closed.json"},
+		{event: "pull_request", file: "testdata/webhooks/pull_request_reopened.json"},
+		{event: "pull_request", file: "testdata/webhooks/pull_request_merged.json"},
+		{
+			event: "issue_comment",
+			file:  "testdata/webhooks/pull_request_comment_created.json",
+			setup: func() {
+				gock.New("https://demo.gitea.com").
+					Get("/api/v1/repos/gogits/hello-world/pulls/2").
+					Times(2).
+					Reply(200).
+					Type("application/json").
+					File("testdata/webhooks/pull_request_comment_created_pr.json")
+			},
+		},
+		{event: "reviewed", file: "testdata/webhooks/review_approved.json"},
+	}
+
+	defer gock.Off()
+	mockServerVersion()
+
+	client, _ := New("https://demo.gitea.com")
+	s := client.Webhooks.(*webhookService)
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			if test.setup != nil {
+				test.setup()
+			}
+			data, err := os.ReadFile(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, _ := http.NewRequest("POST", "/", bytes.NewReader(data))
+			r.Header.Set("X-Gitea-Event", test.event)
+			r.Header.Set("X-Gitea-Delivery", "ee8d97b4-1479-43f1-9cac-fbbd1b80da55")
+			want, err := s.Parse(r, noSecretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req, err := s.Encode(want, "71295b197fa25f4356d2fb9965df3f2379d903d7")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := req.Header.Get("X-Gitea-Event"); got != test.event {
+				t.Errorf("Want event %s, got %s", test.event, got)
+			}
+
+			got, err := s.Parse(req, secretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Encoded webhook does not round trip")
+				t.Log(diff)
+			}
+		})
+	}
+}
+
+func TestWebhookEncode_Signature(t *testing.T) {
+	hook := &scm.BranchHook{
+		Action: scm.ActionCreate,
+		Ref:    scm.Reference{Name: "feature"},
+		Repo:   scm.Repository{ID: "6", Namespace: "gogits", Name: "hello-world", FullName: "gogits/hello-world"},
+		Sender: scm.User{ID: 1, Login: "gogits"},
+	}
+
+	s := new(webhookService)
+	req, err := s.Encode(hook, "71295b197fa25f4356d2fb9965df3f2379d903d7")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Header.Get("X-Gitea-Signature") == "" {
+		t.Errorf("Want signed webhook")
+	}
+	req.Header.Set("X-Gitea-Signature", "failfailfailfail")
+	if _, err := s.Parse(req, secretFunc); err != scm.ErrSignatureInvalid {
+		t.Errorf("Expect invalid signature error, got %v", err)
+	}
+}
+
+func TestWebhookEncode_NotSupported(t *testing.T) {
+	s := new(webhookService)
+	_, err := s.Encode(&scm.ReleaseHook{}, "71295b197fa25f4356d2fb9965df3f2379d903d7")
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect not supported error, got %v", err)
+	}
+}
+
+func noSecretFunc(scm.Webhook) (string, error) {
+	return "", nil
+}
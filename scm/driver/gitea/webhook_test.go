@@ -86,6 +86,18 @@ func TestWebhooks(t *testing.T) {
 			after:  "testdata/webhooks/pull_request_edited.json.golden",
 			obj:    new(scm.PullRequestHook),
 		},
+		{
+			event:  "pull_request",
+			before: "testdata/webhooks/pull_request_ready.json",
+			after:  "testdata/webhooks/pull_request_ready.json.golden",
+			obj:    new(scm.PullRequestHook),
+		},
+		{
+			event:  "pull_request",
+			before: "testdata/webhooks/pull_request_draft.json",
+			after:  "testdata/webhooks/pull_request_draft.json.golden",
+			obj:    new(scm.PullRequestHook),
+		},
 		{
 			event:  "pull_request",
 			before: "testdata/webhooks/pull_request_synchronized.json",
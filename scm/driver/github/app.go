@@ -91,6 +91,32 @@ func (s *appService) GetUserInstallation(ctx context.Context, user string) (*scm
 	return convertInstallation(out), res, err
 }
 
+// ListHookDeliveries returns the recent deliveries of the app webhook.
+//
+// See https://docs.github.com/en/rest/apps/webhooks#list-deliveries-for-an-app-webhook
+func (s *appService) ListHookDeliveries(ctx context.Context, opts *scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	path := fmt.Sprintf("app/hook/deliveries?%s", encodeListOptions(opts))
+	return listHookDeliveries(ctx, s.client, path, opts)
+}
+
+// FindHookDelivery returns an app webhook delivery.
+//
+// See https://docs.github.com/en/rest/apps/webhooks#get-a-delivery-for-an-app-webhook
+func (s *appService) FindHookDelivery(ctx context.Context, delivery string) (*scm.HookDelivery, *scm.Response, error) {
+	path := fmt.Sprintf("app/hook/deliveries/%s", delivery)
+	out := new(hookDelivery)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertHookDelivery(out), res, err
+}
+
+// RedeliverHook sends an app webhook delivery again.
+//
+// See https://docs.github.com/en/rest/apps/webhooks#redeliver-a-delivery-for-an-app-webhook
+func (s *appService) RedeliverHook(ctx context.Context, delivery string) (*scm.Response, error) {
+	path := fmt.Sprintf("app/hook/deliveries/%s/attempts", delivery)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
 func convertInstallationToken(src *installationToken) *scm.InstallationToken {
 	dst := &scm.InstallationToken{
 		ExpiresAt: src.ExpiresAt,
@@ -54,3 +54,85 @@ func TestAppRepositoryInstallation(t *testing.T) {
 		}
 	}
 }
+
+func TestAppHookDeliveryList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/app/hook/deliveries").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/hook_deliveries.json")
+
+	client := NewDefault()
+	got, _, err := client.Apps.ListHookDeliveries(context.Background(), &scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.HookDelivery{}
+	raw, _ := os.ReadFile("testdata/hook_deliveries.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestAppHookDeliveryFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/app/hook/deliveries/12345678").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/hook_delivery.json")
+
+	client := NewDefault()
+	got, _, err := client.Apps.FindHookDelivery(context.Background(), "12345678")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.HookDelivery)
+	raw, _ := os.ReadFile("testdata/hook_delivery.json.golden")
+	err = json.Unmarshal(raw, want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestAppHookRedeliver(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/app/hook/deliveries/12345678/attempts").
+		Reply(202).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString("{}")
+
+	client := NewDefault()
+	res, err := client.Apps.RedeliverHook(context.Background(), "12345678")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := res.Status, 202; got != want {
+		t.Errorf("Want response status %d, got %d", want, got)
+	}
+}
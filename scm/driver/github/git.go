@@ -115,6 +115,37 @@ func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string
 	return convertChangeList(out.Files), res, err
 }
 
+// Compare returns the comparison of the head commit to the base commit.
+//
+// See https://docs.github.com/en/rest/commits/commits#compare-two-commits
+func (s *gitService) Compare(ctx context.Context, repo, base, head string, opts *scm.ListOptions) (*scm.Comparison, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/compare/%s...%s?%s", repo, base, head, encodeListOptions(opts))
+	out := new(comparison)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertComparison(out), res, err
+}
+
+// FindTree returns the git tree for the given ref.
+//
+// See https://docs.github.com/en/rest/git/trees#get-a-tree
+func (s *gitService) FindTree(ctx context.Context, repo, ref string, recursive bool) (*scm.Tree, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/git/trees/%s", repo, ref)
+	if recursive {
+		path += "?recursive=1"
+	}
+	out := new(tree)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertTree(out), res, err
+}
+
+// CompareDiff returns the unified diff between two commits.
+//
+// See https://docs.github.com/en/rest/commits/commits#compare-two-commits
+func (s *gitService) CompareDiff(ctx context.Context, repo, ref1, ref2 string) (*scm.Diff, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/compare/%s...%s", repo, ref1, ref2)
+	return s.client.doDiff(ctx, path)
+}
+
 func (s *gitService) GetDefaultBranch(ctx context.Context, repo string) (*scm.Reference, *scm.Response, error) {
 	repository, res, err := s.client.Repositories.Find(ctx, repo)
 	if err != nil {
@@ -123,6 +154,30 @@ func (s *gitService) GetDefaultBranch(ctx context.Context, repo string) (*scm.Re
 	return s.FindBranch(ctx, repo, repository.Branch)
 }
 
+type comparison struct {
+	Status          string    `json:"status"`
+	AheadBy         int       `json:"ahead_by"`
+	BehindBy        int       `json:"behind_by"`
+	MergeBaseCommit commit    `json:"merge_base_commit"`
+	Commits         []*commit `json:"commits"`
+	Files           []*file   `json:"files"`
+}
+
+type tree struct {
+	Sha       string       `json:"sha"`
+	Tree      []*treeEntry `json:"tree"`
+	Truncated bool         `json:"truncated"`
+}
+
+type treeEntry struct {
+	Path string `json:"path"`
+	Mode string `json:"mode"`
+	Type string `json:"type"`
+	Sha  string `json:"sha"`
+	Size int64  `json:"size"`
+	URL  string `json:"url"`
+}
+
 type branch struct {
 	Name      string `json:"name"`
 	Commit    commit `json:"commit"`
@@ -157,6 +212,9 @@ type commit struct {
 		AvatarURL string `json:"avatar_url"`
 		Login     string `json:"login"`
 	} `json:"committer"`
+	Parents []struct {
+		Sha string `json:"sha"`
+	} `json:"parents"`
 	Files []*file `json:"files"`
 }
 
@@ -169,6 +227,10 @@ func convertCommitList(from []*commit) []*scm.Commit {
 }
 
 func convertCommit(from *commit) *scm.Commit {
+	var parents []string
+	for _, p := range from.Parents {
+		parents = append(parents, p.Sha)
+	}
 	return &scm.Commit{
 		Message: from.Commit.Message,
 		Sha:     from.Sha,
@@ -191,6 +253,7 @@ func convertCommit(from *commit) *scm.Commit {
 			Login:  from.Committer.Login,
 			Avatar: from.Committer.AvatarURL,
 		},
+		Parents: parents,
 	}
 }
 
@@ -225,3 +288,33 @@ func convertTag(from *branch) *scm.Reference {
 		Sha:  from.Commit.Sha,
 	}
 }
+
+func convertComparison(from *comparison) *scm.Comparison {
+	return &scm.Comparison{
+		Status:    scm.ComparisonStatus(from.Status),
+		AheadBy:   from.AheadBy,
+		BehindBy:  from.BehindBy,
+		MergeBase: from.MergeBaseCommit.Sha,
+		Commits:   convertCommitList(from.Commits),
+		Changes:   convertChangeList(from.Files),
+	}
+}
+
+func convertTree(from *tree) *scm.Tree {
+	to := &scm.Tree{
+		Sha:       from.Sha,
+		Truncated: from.Truncated,
+		Entries:   []*scm.TreeEntry{},
+	}
+	for _, v := range from.Tree {
+		to.Entries = append(to.Entries, &scm.TreeEntry{
+			Path: v.Path,
+			Mode: v.Mode,
+			Type: v.Type,
+			Sha:  v.Sha,
+			Size: v.Size,
+			Link: v.URL,
+		})
+	}
+	return to
+}
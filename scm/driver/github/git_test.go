@@ -83,6 +83,40 @@ func TestGitFindBranch(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestGitFindTree(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/git/trees/master").
+		MatchParam("recursive", "1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/tree.json")
+
+	client := NewDefault()
+	got, res, err := client.Git.FindTree(context.Background(), "octocat/hello-world", "master", true)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Tree)
+	raw, _ := os.ReadFile("testdata/tree.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
 func TestGitFindTag(t *testing.T) {
 	git := new(gitService)
 	_, _, err := git.FindTag(context.Background(), "octocat/hello-world", "v1.0")
@@ -341,3 +375,72 @@ func TestGitGetDefaultBranch(t *testing.T) {
 	t.Run("Request", testRequest(res))
 	t.Run("Rate", testRate(res))
 }
+
+func TestGitCompareDiff(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/compare/master...topic").
+		MatchHeader("Accept", "application/vnd.github.v3.diff").
+		Reply(200).
+		Type("text/plain").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.diff")
+
+	client := NewDefault()
+	got, res, err := client.Git.CompareDiff(context.Background(), "octocat/hello-world", "master", "topic")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := len(got.Files), 2; got != want {
+		t.Fatalf("Want %d files, got %d", want, got)
+	}
+	if got, want := got.Files[0].NewPath, "README.md"; got != want {
+		t.Errorf("Want file %q, got %q", want, got)
+	}
+	if got, want := len(got.Files[0].Hunks), 2; got != want {
+		t.Errorf("Want %d hunks, got %d", want, got)
+	}
+	if !got.Files[1].Added || !got.Files[1].Binary {
+		t.Errorf("Want added binary file %s", got.Files[1].NewPath)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestGitCompare(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/compare/master...topic").
+		MatchParam("page", "1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/compare.json")
+
+	client := NewDefault()
+	got, res, err := client.Git.Compare(context.Background(), "octocat/hello-world", "master", "topic", &scm.ListOptions{Page: 1})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := os.ReadFile("testdata/compare.json.golden")
+	err = json.Unmarshal(raw, want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
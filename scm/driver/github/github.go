@@ -52,11 +52,13 @@ func New(uri string) (*scm.Client, error) {
 	client.Git = &gitService{client}
 	client.Issues = &issueService{client}
 	client.Milestones = &milestoneService{client}
+	client.Reactions = &reactionService{client}
 	client.Releases = &releaseService{client}
 	client.Organizations = &organizationService{client}
 	client.PullRequests = &pullService{&issueService{client}}
 	client.Repositories = &repositoryService{client}
 	client.Reviews = &reviewService{client}
+	client.Search = &searchService{client}
 	client.Users = &userService{client}
 	client.Webhooks = &webhookService{client}
 	client.Apps = &appService{client}
@@ -167,6 +169,7 @@ func (c *wrapper) doRequest(ctx context.Context, req *scm.Request, in, out inter
 		if res.Status == 404 {
 			return res, scm.ErrNotFound
 		}
+		statusErr := &statusError{Status: http.StatusText(res.Status)}
 		if res.Body != nil {
 			if b, err := io.ReadAll(res.Body); err == nil {
 				logrus.WithFields(logrus.Fields{
@@ -177,22 +180,105 @@ func (c *wrapper) doRequest(ctx context.Context, req *scm.Request, in, out inter
 					"rate":           res.Rate,
 					"requestID":      res.ID,
 				}).Warn("GitHub responded with error")
+				_ = json.Unmarshal(b, &statusErr.Response)
 			}
 		}
-		return res, errors.New(
-			http.StatusText(res.Status),
-		)
+		return res, statusErr
 	}
 
 	if out == nil {
 		return res, nil
 	}
 
+	// if raw output is expected, copy to the provided
+	// buffer and exit.
+	if w, ok := out.(io.Writer); ok {
+		_, err := io.Copy(w, res.Body)
+		return res, err
+	}
+
 	// if a json response is expected, parse and return
 	// the json response.
 	return res, json.NewDecoder(res.Body).Decode(out)
 }
 
+// doDiff requests the path using the diff media type and
+// parses the unified diff returned.
+func (c *wrapper) doDiff(ctx context.Context, path string) (*scm.Diff, *scm.Response, error) {
+	req := &scm.Request{
+		Method: http.MethodGet,
+		Path:   path,
+		Header: map[string][]string{
+			"Accept": {"application/vnd.github.v3.diff"},
+		},
+	}
+	buf := new(bytes.Buffer)
+	res, err := c.doRequest(ctx, req, nil, buf)
+	if err != nil {
+		return nil, res, err
+	}
+	diff, err := scm.ParseDiff(buf.String())
+	return diff, res, err
+}
+
+// doGraphQL posts the GraphQL query to the GraphQL endpoint and
+// unmarshals the response data into out. GraphQL reports failures
+// in the response body, so these are returned as an error.
+func (c *wrapper) doGraphQL(ctx context.Context, query string, vars map[string]interface{}, out interface{}) (*scm.Response, error) {
+	in := &graphQLRequest{
+		Query:     query,
+		Variables: vars,
+	}
+	resp := &graphQLResponse{Data: out}
+	res, err := c.do(ctx, http.MethodPost, c.GraphQLURL.String(), in, resp)
+	if err != nil {
+		return res, err
+	}
+	if len(resp.Errors) > 0 {
+		return res, errors.New(resp.Errors[0].Message)
+	}
+	return res, nil
+}
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   interface{} `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// stream wraps the Client.Do function by creating the Request and
+// returning the raw response body. The caller must close the body.
+func (c *wrapper) stream(ctx context.Context, method, path string) (io.ReadCloser, *scm.Response, error) {
+	req := &scm.Request{
+		Method: method,
+		Path:   path,
+	}
+	res, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// parse the github request id.
+	res.ID = res.Header.Get("X-GitHub-Request-Id")
+
+	if res.Status > 300 {
+		res.Body.Close()
+		if res.Status == 404 {
+			return nil, res, scm.ErrNotFound
+		}
+		return nil, res, errors.New(
+			http.StatusText(res.Status),
+		)
+	}
+	return res.Body, res, nil
+}
+
 // Error represents a Github error.
 type Error struct {
 	Message string `json:"message"`
@@ -201,3 +287,15 @@ type Error struct {
 func (e *Error) Error() string {
 	return e.Message
 }
+
+// statusError is returned for failed requests. Its message is the
+// http status text, the error response is kept for callers that
+// need to tell failures with the same status apart.
+type statusError struct {
+	Status   string
+	Response Error
+}
+
+func (e *statusError) Error() string {
+	return e.Status
+}
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
@@ -118,13 +119,47 @@ func (s *issueService) FindComment(ctx context.Context, repo string, index, id i
 	return convertIssueComment(out), res, err
 }
 
+// List returns the issues of the repository. GitHub filters issues
+// by milestone number, so the milestone title is resolved first.
 func (s *issueService) List(ctx context.Context, repo string, opts scm.IssueListOptions) ([]*scm.Issue, *scm.Response, error) {
+	if opts.Milestone != "" {
+		number, res, err := s.findMilestone(ctx, repo, opts.Milestone)
+		if err == scm.ErrNotFound {
+			return []*scm.Issue{}, res, nil
+		}
+		if err != nil {
+			return nil, res, err
+		}
+		opts.Milestone = strconv.Itoa(number)
+	}
 	path := fmt.Sprintf("repos/%s/issues?%s", repo, encodeIssueListOptions(opts))
 	out := []*issue{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
 	return convertIssueList(out), res, err
 }
 
+// findMilestone returns the number of the milestone with the title.
+func (s *issueService) findMilestone(ctx context.Context, repo, title string) (int, *scm.Response, error) {
+	opts := scm.MilestoneListOptions{Page: 1, Size: 100, Open: true, Closed: true}
+	for {
+		path := fmt.Sprintf("repos/%s/milestones?%s", repo, encodeMilestoneListOptions(opts))
+		out := []*milestone{}
+		res, err := s.client.do(ctx, "GET", path, nil, &out)
+		if err != nil {
+			return 0, res, err
+		}
+		for _, m := range out {
+			if m.Title == title {
+				return m.Number, res, nil
+			}
+		}
+		if res.Page.Next <= opts.Page {
+			return 0, res, scm.ErrNotFound
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
 func (s *issueService) ListComments(ctx context.Context, repo string, index int, opts *scm.ListOptions) ([]*scm.Comment, *scm.Response, error) {
 	path := fmt.Sprintf("repos/%s/issues/%d/comments?%s", repo, index, encodeListOptions(opts))
 	out := []*issueComment{}
@@ -134,12 +169,15 @@ func (s *issueService) ListComments(ctx context.Context, repo string, index int,
 
 func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
 	path := fmt.Sprintf("repos/%s/issues", repo)
-	in := &issueInput{
-		Title: input.Title,
-		Body:  input.Body,
-	}
 	out := new(issue)
-	res, err := s.client.do(ctx, "POST", path, in, out)
+	res, err := s.client.do(ctx, "POST", path, convertIssueInput(input), out)
+	return convertIssue(out), res, err
+}
+
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/issues/%d", repo, number)
+	out := new(issue)
+	res, err := s.client.do(ctx, "PATCH", path, convertIssueInput(input), out)
 	return convertIssue(out), res, err
 }
 
@@ -270,8 +308,23 @@ type issue struct {
 }
 
 type issueInput struct {
-	Title string `json:"title"`
-	Body  string `json:"body"`
+	Title     string   `json:"title,omitempty"`
+	Body      string   `json:"body,omitempty"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignees []string `json:"assignees,omitempty"`
+	Milestone int      `json:"milestone,omitempty"`
+}
+
+// convertIssueInput returns the issue input. GitHub issues have no
+// due date or confidentiality.
+func convertIssueInput(from *scm.IssueInput) *issueInput {
+	return &issueInput{
+		Title:     from.Title,
+		Body:      from.Body,
+		Labels:    from.Labels,
+		Assignees: from.Assignees,
+		Milestone: from.Milestone,
+	}
 }
 
 type issueComment struct {
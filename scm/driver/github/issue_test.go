@@ -120,6 +120,36 @@ func TestIssueList(t *testing.T) {
 	t.Run("Page", testPage(res))
 }
 
+func TestIssueListMilestone(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/milestones").
+		MatchParam("state", "all").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/milestones.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/issues").
+		MatchParam("milestone", "1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/issues.json")
+
+	client := NewDefault()
+	_, _, err := client.Issues.List(context.Background(), "octocat/hello-world", scm.IssueListOptions{Milestone: "v1.0"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
 func TestIssueSearch(t *testing.T) {
 	defer gock.Off()
 
@@ -280,6 +310,81 @@ func TestIssueCreate(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestIssueCreateTriaged(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/issues").
+		JSON(map[string]interface{}{
+			"title":     "Found a bug",
+			"body":      "I'm having a problem with this.",
+			"labels":    []string{"bug"},
+			"assignees": []string{"octocat"},
+			"milestone": 1,
+		}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/issue.json")
+
+	input := scm.IssueInput{
+		Title:     "Found a bug",
+		Body:      "I'm having a problem with this.",
+		Labels:    []string{"bug"},
+		Assignees: []string{"octocat"},
+		Milestone: 1,
+	}
+
+	client := NewDefault()
+	_, _, err := client.Issues.Create(context.Background(), "octocat/hello-world", &input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestIssueUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Patch("/repos/octocat/hello-world/issues/1347").
+		JSON(map[string]interface{}{
+			"title":  "Found a bug",
+			"labels": []string{"bug", "triaged"},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/issue.json")
+
+	input := scm.IssueInput{
+		Title:  "Found a bug",
+		Labels: []string{"bug", "triaged"},
+	}
+
+	client := NewDefault()
+	got, res, err := client.Issues.Update(context.Background(), "octocat/hello-world", 1347, &input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Issue)
+	raw, _ := os.ReadFile("testdata/issue.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
 func TestIssueCreateComment(t *testing.T) {
 	defer gock.Off()
 
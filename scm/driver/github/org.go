@@ -201,6 +201,51 @@ func (s *organizationService) AcceptOrganizationInvitation(ctx context.Context,
 	return s.client.doRequest(ctx, req, values, nil)
 }
 
+// FindHook returns an organization webhook.
+// see https://docs.github.com/en/rest/orgs/webhooks#get-an-organization-webhook
+func (s *organizationService) FindHook(ctx context.Context, org, id string) (*scm.Hook, *scm.Response, error) {
+	path := fmt.Sprintf("orgs/%s/hooks/%s", org, id)
+	out := new(hook)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertHook(out), res, err
+}
+
+// ListHooks returns the organization webhooks.
+// see https://docs.github.com/en/rest/orgs/webhooks#list-organization-webhooks
+func (s *organizationService) ListHooks(ctx context.Context, org string, opts *scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
+	path := fmt.Sprintf("orgs/%s/hooks?%s", org, encodeListOptions(opts))
+	out := []*hook{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertHookList(out), res, err
+}
+
+// CreateHook creates an organization webhook.
+// see https://docs.github.com/en/rest/orgs/webhooks#create-an-organization-webhook
+func (s *organizationService) CreateHook(ctx context.Context, org string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	path := fmt.Sprintf("orgs/%s/hooks", org)
+	in := convertOrgHookInput(input)
+	out := new(hook)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertHook(out), res, err
+}
+
+// UpdateHook edits an organization webhook.
+// see https://docs.github.com/en/rest/orgs/webhooks#update-an-organization-webhook
+func (s *organizationService) UpdateHook(ctx context.Context, org string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	path := fmt.Sprintf("orgs/%s/hooks/%s", org, input.Name)
+	in := convertOrgHookInput(input)
+	out := new(hook)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertHook(out), res, err
+}
+
+// DeleteHook deletes an organization webhook.
+// see https://docs.github.com/en/rest/orgs/webhooks#delete-an-organization-webhook
+func (s *organizationService) DeleteHook(ctx context.Context, org, id string) (*scm.Response, error) {
+	path := fmt.Sprintf("orgs/%s/hooks/%s", org, id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
 func convertOrganisationPendingInvites(from []*pendingInvitations) []*scm.OrganizationPendingInvite {
 	to := []*scm.OrganizationPendingInvite{}
 	for _, v := range from {
@@ -6,6 +6,7 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"regexp"
@@ -31,7 +32,24 @@ func (s *pullService) Find(ctx context.Context, repo string, number int) (*scm.P
 }
 
 func (s *pullService) List(ctx context.Context, repo string, opts *scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
-	path := fmt.Sprintf("repos/%s/pulls?%s", repo, encodePullRequestListOptions(opts))
+	// the head filter requires the user or organization
+	// of the branch.
+	in := *opts
+	if in.Head != "" && !strings.Contains(in.Head, ":") {
+		namespace, _ := scm.Split(repo)
+		in.Head = namespace + ":" + in.Head
+	}
+	path := fmt.Sprintf("repos/%s/pulls?%s", repo, encodePullRequestListOptions(&in))
+	out := []*pr{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return scm.FilterPullRequests(convertPullRequestList(out), opts), res, err
+}
+
+// ListForCommit returns the pull requests associated with the commit.
+//
+// See https://docs.github.com/en/rest/commits/commits#list-pull-requests-associated-with-a-commit
+func (s *pullService) ListForCommit(ctx context.Context, repo, sha string) ([]*scm.PullRequest, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/commits/%s/pulls", repo, sha)
 	out := []*pr{}
 	res, err := s.client.do(ctx, "GET", path, nil, &out)
 	return convertPullRequestList(out), res, err
@@ -44,6 +62,14 @@ func (s *pullService) ListChanges(ctx context.Context, repo string, number int,
 	return convertChangeList(out), res, err
 }
 
+// FindDiff returns the unified diff of the pull request.
+//
+// See https://docs.github.com/en/rest/pulls/pulls#get-a-pull-request
+func (s *pullService) FindDiff(ctx context.Context, repo string, number int) (*scm.Diff, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d", repo, number)
+	return s.client.doDiff(ctx, path)
+}
+
 func (s *pullService) ListCommits(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Commit, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
@@ -75,6 +101,7 @@ func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRe
 		Head:  input.Head,
 		Base:  input.Base,
 		Body:  input.Body,
+		Draft: input.Draft,
 	}
 
 	out := new(pr)
@@ -162,6 +189,215 @@ func (s *pullService) DeletePullRequest(ctx context.Context, repo string, prID i
 	return nil, scm.ErrNotSupported
 }
 
+func (s *pullService) MarkReady(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(ctx, repo, number, "markPullRequestReadyForReview")
+}
+
+func (s *pullService) ConvertToDraft(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(ctx, repo, number, "convertPullRequestToDraft")
+}
+
+// setDraft runs the named draft mutation against the pull request.
+// The REST API cannot change the draft state, so the pull request
+// node id is looked up and passed to the GraphQL mutation.
+func (s *pullService) setDraft(ctx context.Context, repo string, number int, mutation string) (*scm.Response, error) {
+	id, res, err := s.nodeID(ctx, repo, number)
+	if err != nil {
+		return res, err
+	}
+	query := fmt.Sprintf("mutation($id: ID!) { %s(input: {pullRequestId: $id}) { clientMutationId } }", mutation)
+	vars := map[string]interface{}{"id": id}
+	return s.client.doGraphQL(ctx, query, vars, nil)
+}
+
+// nodeID returns the GraphQL node id of the pull request.
+func (s *pullService) nodeID(ctx context.Context, repo string, number int) (string, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/pulls/%d", repo, number)
+	out := new(pr)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out.NodeID, res, err
+}
+
+func (s *pullService) EnableAutoMerge(ctx context.Context, repo string, number int, opts *scm.AutoMergeOptions) (*scm.Response, error) {
+	id, res, err := s.nodeID(ctx, repo, number)
+	if err != nil {
+		return res, err
+	}
+	input := map[string]interface{}{"pullRequestId": id}
+	if opts != nil {
+		if opts.MergeMethod != "" {
+			input["mergeMethod"] = strings.ToUpper(opts.MergeMethod)
+		}
+		if opts.CommitTitle != "" {
+			input["commitHeadline"] = opts.CommitTitle
+		}
+		if opts.CommitMessage != "" {
+			input["commitBody"] = opts.CommitMessage
+		}
+		if opts.SHA != "" {
+			input["expectedHeadOid"] = opts.SHA
+		}
+	}
+	query := "mutation($input: EnablePullRequestAutoMergeInput!) { enablePullRequestAutoMerge(input: $input) { clientMutationId } }"
+	return s.client.doGraphQL(ctx, query, map[string]interface{}{"input": input}, nil)
+}
+
+func (s *pullService) DisableAutoMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	id, res, err := s.nodeID(ctx, repo, number)
+	if err != nil {
+		return res, err
+	}
+	input := map[string]interface{}{"pullRequestId": id}
+	query := "mutation($input: DisablePullRequestAutoMergeInput!) { disablePullRequestAutoMerge(input: $input) { clientMutationId } }"
+	return s.client.doGraphQL(ctx, query, map[string]interface{}{"input": input}, nil)
+}
+
+func (s *pullService) EnqueueMerge(ctx context.Context, repo string, number int, opts *scm.AutoMergeOptions) (*scm.MergeQueueEntry, *scm.Response, error) {
+	id, res, err := s.nodeID(ctx, repo, number)
+	if err != nil {
+		return nil, res, err
+	}
+	input := map[string]interface{}{"pullRequestId": id}
+	if opts != nil && opts.SHA != "" {
+		input["expectedHeadOid"] = opts.SHA
+	}
+	query := "mutation($input: EnqueuePullRequestInput!) { enqueuePullRequest(input: $input) { mergeQueueEntry { " + mergeQueueEntryFields + " } } }"
+	out := new(struct {
+		EnqueuePullRequest struct {
+			MergeQueueEntry *mergeQueueEntry `json:"mergeQueueEntry"`
+		} `json:"enqueuePullRequest"`
+	})
+	res, err = s.client.doGraphQL(ctx, query, map[string]interface{}{"input": input}, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertMergeQueueEntry(out.EnqueuePullRequest.MergeQueueEntry), res, nil
+}
+
+func (s *pullService) DequeueMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	id, res, err := s.nodeID(ctx, repo, number)
+	if err != nil {
+		return res, err
+	}
+	input := map[string]interface{}{"id": id}
+	query := "mutation($input: DequeuePullRequestInput!) { dequeuePullRequest(input: $input) { clientMutationId } }"
+	return s.client.doGraphQL(ctx, query, map[string]interface{}{"input": input}, nil)
+}
+
+func (s *pullService) FindMergeQueueEntry(ctx context.Context, repo string, number int) (*scm.MergeQueueEntry, *scm.Response, error) {
+	id, res, err := s.nodeID(ctx, repo, number)
+	if err != nil {
+		return nil, res, err
+	}
+	query := "query($id: ID!) { node(id: $id) { ... on PullRequest { mergeQueueEntry { " + mergeQueueEntryFields + " } } } }"
+	out := new(struct {
+		Node struct {
+			MergeQueueEntry *mergeQueueEntry `json:"mergeQueueEntry"`
+		} `json:"node"`
+	})
+	res, err = s.client.doGraphQL(ctx, query, map[string]interface{}{"id": id}, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if out.Node.MergeQueueEntry == nil {
+		return nil, res, scm.ErrNotFound
+	}
+	return convertMergeQueueEntry(out.Node.MergeQueueEntry), res, nil
+}
+
+// updateBranchPollInterval is how often the pull request is checked
+// while GitHub updates its branch.
+var updateBranchPollInterval = time.Second
+
+// updateBranchPollAttempts is how many times the pull request is
+// checked before giving up waiting for the branch update.
+var updateBranchPollAttempts = 60
+
+// UpdateBranch brings the pull request branch up to date with its base
+// branch, merging with the REST API or rebasing with the GraphQL API.
+// GitHub updates the branch asynchronously, so the pull request is
+// polled until its head moves.
+func (s *pullService) UpdateBranch(ctx context.Context, repo string, number int, method string) (string, *scm.Response, error) {
+	switch method {
+	case "", scm.UpdateBranchMerge, scm.UpdateBranchRebase:
+	default:
+		return "", nil, scm.ErrNotSupported
+	}
+	path := fmt.Sprintf("repos/%s/pulls/%d", repo, number)
+	out := new(pr)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return "", res, err
+	}
+	head := out.Head.Sha
+
+	// the update fails when there is nothing to merge.
+	compare := new(struct {
+		BehindBy int `json:"behind_by"`
+	})
+	res, err = s.client.do(ctx, "GET", fmt.Sprintf("repos/%s/compare/%s...%s", repo, out.Base.Ref, head), nil, compare)
+	if err != nil {
+		return "", res, err
+	}
+	if compare.BehindBy == 0 {
+		return head, res, nil
+	}
+
+	if method == scm.UpdateBranchRebase {
+		input := map[string]interface{}{
+			"pullRequestId":   out.NodeID,
+			"expectedHeadOid": head,
+			"updateMethod":    "REBASE",
+		}
+		query := "mutation($input: UpdatePullRequestBranchInput!) { updatePullRequestBranch(input: $input) { clientMutationId } }"
+		res, err = s.client.doGraphQL(ctx, query, map[string]interface{}{"input": input}, nil)
+	} else {
+		in := &updateBranchInput{ExpectedHeadSha: head}
+		res, err = s.client.do(ctx, "PUT", path+"/update-branch", in, nil)
+	}
+	if message, ok := updateBranchConflict(err); ok {
+		return "", res, scm.MergeConflict{Number: number, Message: message}
+	}
+	if err != nil {
+		return "", res, err
+	}
+	for i := 0; i < updateBranchPollAttempts; i++ {
+		select {
+		case <-ctx.Done():
+			return "", res, ctx.Err()
+		case <-time.After(updateBranchPollInterval):
+		}
+		out := new(pr)
+		res, err = s.client.do(ctx, "GET", path, nil, out)
+		if err != nil {
+			return "", res, err
+		}
+		if out.Head.Sha != head {
+			return out.Head.Sha, res, nil
+		}
+	}
+	return "", res, fmt.Errorf("timed out waiting for the branch of pull request %d to be updated", number)
+}
+
+// updateBranchConflict returns the error message and true if the
+// branch update failed because of a merge conflict, rather than a
+// stale head or an invalid request.
+func updateBranchConflict(err error) (string, bool) {
+	if err == nil {
+		return "", false
+	}
+	message := err.Error()
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		message = statusErr.Response.Message
+	}
+	return message, strings.Contains(strings.ToLower(message), "conflict")
+}
+
+type updateBranchInput struct {
+	ExpectedHeadSha string `json:"expected_head_sha,omitempty"`
+}
+
 func prepareReviewersBody(logins []string, org string) (prReviewers, error) {
 	body := prReviewers{}
 	var errors []error
@@ -214,6 +450,7 @@ type prBranch struct {
 
 type pr struct {
 	Number             int         `json:"number"`
+	NodeID             string      `json:"node_id"`
 	State              string      `json:"state"`
 	Title              string      `json:"title"`
 	Body               string      `json:"body"`
@@ -237,6 +474,23 @@ type pr struct {
 	UpdatedAt          time.Time   `json:"updated_at"`
 }
 
+// mergeQueueEntryFields are the GraphQL fields selected for a
+// merge queue entry.
+const mergeQueueEntryFields = "id position state enqueuedAt headCommit { oid } pullRequest { number }"
+
+type mergeQueueEntry struct {
+	ID         string    `json:"id"`
+	Position   int       `json:"position"`
+	State      string    `json:"state"`
+	EnqueuedAt time.Time `json:"enqueuedAt"`
+	HeadCommit struct {
+		Oid string `json:"oid"`
+	} `json:"headCommit"`
+	PullRequest struct {
+		Number int `json:"number"`
+	} `json:"pullRequest"`
+}
+
 type file struct {
 	Sha              string `json:"sha"`
 	Filename         string `json:"filename"`
@@ -254,6 +508,21 @@ type prInput struct {
 	Body  string `json:"body,omitempty"`
 	Head  string `json:"head,omitempty"`
 	Base  string `json:"base,omitempty"`
+	Draft bool   `json:"draft,omitempty"`
+}
+
+func convertMergeQueueEntry(from *mergeQueueEntry) *scm.MergeQueueEntry {
+	if from == nil {
+		return nil
+	}
+	return &scm.MergeQueueEntry{
+		ID:       from.ID,
+		Number:   from.PullRequest.Number,
+		Position: from.Position,
+		State:    strings.ToLower(from.State),
+		Sha:      from.HeadCommit.Oid,
+		Created:  from.EnqueuedAt,
+	}
 }
 
 func convertPullRequestList(from []*pr) []*scm.PullRequest {
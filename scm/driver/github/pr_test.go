@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 
@@ -87,6 +88,82 @@ func TestPullList(t *testing.T) {
 	t.Run("Page", testPage(res))
 }
 
+func TestPullListFilters(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls").
+		MatchParam("head", "octocat:new-topic").
+		MatchParam("base", "master").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pulls.json")
+
+	client := NewDefault()
+	opts := &scm.PullRequestListOptions{Head: "new-topic", Base: "master", Author: "octocat"}
+	got, _, err := client.PullRequests.List(context.Background(), "octocat/hello-world", opts)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(got) != 1 {
+		t.Errorf("Want 1 pull request, got %d", len(got))
+	}
+	if opts.Head != "new-topic" {
+		t.Errorf("Want options unchanged, got head %q", opts.Head)
+	}
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pulls.json")
+
+	got, _, err = client.PullRequests.List(context.Background(), "octocat/hello-world", &scm.PullRequestListOptions{Author: "someone-else"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(got) != 0 {
+		t.Errorf("Want pull requests filtered by author, got %d", len(got))
+	}
+}
+
+func TestPullListForCommit(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/commits/6dcb09b5b57875f334f61aebed695e2e4193db5e/pulls").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pulls.json")
+
+	client := NewDefault()
+	got, res, err := client.PullRequests.ListForCommit(context.Background(), "octocat/hello-world", "6dcb09b5b57875f334f61aebed695e2e4193db5e")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.PullRequest{}
+	raw, _ := os.ReadFile("testdata/pulls.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
 func TestPullListChanges(t *testing.T) {
 	gock.New("https://api.github.com").
 		Get("/repos/octocat/hello-world/pulls/1347/files").
@@ -231,6 +308,538 @@ func TestPullCreate(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestPullCreateDraft(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/pulls").
+		JSON(map[string]interface{}{
+			"title": "Amazing new feature",
+			"head":  "octocat:new-feature",
+			"base":  "master",
+			"draft": true,
+		}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_create.json")
+
+	input := &scm.PullRequestInput{
+		Title: "Amazing new feature",
+		Head:  "octocat:new-feature",
+		Base:  "master",
+		Draft: true,
+	}
+
+	client := NewDefault()
+	_, _, err := client.PullRequests.Create(context.Background(), "octocat/hello-world", input)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPullMarkReady(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		JSON(map[string]interface{}{
+			"query":     "mutation($id: ID!) { markPullRequestReadyForReview(input: {pullRequestId: $id}) { clientMutationId } }",
+			"variables": map[string]interface{}{"id": "MDExOlB1bGxSZXF1ZXN0MQ=="},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_ready.json")
+
+	client := NewDefault()
+	res, err := client.PullRequests.MarkReady(context.Background(), "octocat/hello-world", 1347)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestPullConvertToDraft(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		JSON(map[string]interface{}{
+			"query":     "mutation($id: ID!) { convertPullRequestToDraft(input: {pullRequestId: $id}) { clientMutationId } }",
+			"variables": map[string]interface{}{"id": "MDExOlB1bGxSZXF1ZXN0MQ=="},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_draft.json")
+
+	client := NewDefault()
+	res, err := client.PullRequests.ConvertToDraft(context.Background(), "octocat/hello-world", 1347)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestPullMarkReadyError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/graphql_error.json")
+
+	client := NewDefault()
+	_, err := client.PullRequests.MarkReady(context.Background(), "octocat/hello-world", 1347)
+	if err == nil || err.Error() != "Pull request is not in draft state" {
+		t.Errorf("Want GraphQL error, got %v", err)
+	}
+}
+
+func TestPullEnableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		JSON(map[string]interface{}{
+			"query": "mutation($input: EnablePullRequestAutoMergeInput!) { enablePullRequestAutoMerge(input: $input) { clientMutationId } }",
+			"variables": map[string]interface{}{
+				"input": map[string]interface{}{
+					"pullRequestId":   "MDExOlB1bGxSZXF1ZXN0MQ==",
+					"mergeMethod":     "SQUASH",
+					"commitHeadline":  "Amazing new feature (#1347)",
+					"expectedHeadOid": "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+				},
+			},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"data":{"enablePullRequestAutoMerge":{"clientMutationId":null}}}`)
+
+	client := NewDefault()
+	res, err := client.PullRequests.EnableAutoMerge(context.Background(), "octocat/hello-world", 1347, &scm.AutoMergeOptions{
+		MergeMethod: "squash",
+		CommitTitle: "Amazing new feature (#1347)",
+		SHA:         "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestPullDisableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		JSON(map[string]interface{}{
+			"query": "mutation($input: DisablePullRequestAutoMergeInput!) { disablePullRequestAutoMerge(input: $input) { clientMutationId } }",
+			"variables": map[string]interface{}{
+				"input": map[string]interface{}{"pullRequestId": "MDExOlB1bGxSZXF1ZXN0MQ=="},
+			},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"data":{"disablePullRequestAutoMerge":{"clientMutationId":null}}}`)
+
+	client := NewDefault()
+	_, err := client.PullRequests.DisableAutoMerge(context.Background(), "octocat/hello-world", 1347)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPullEnqueueMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`enqueuePullRequest`).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_enqueue.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.EnqueueMerge(context.Background(), "octocat/hello-world", 1347, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.MergeQueueEntry)
+	raw, _ := os.ReadFile("testdata/pr_enqueue.json.golden")
+	err = json.Unmarshal(raw, want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullDequeueMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		JSON(map[string]interface{}{
+			"query": "mutation($input: DequeuePullRequestInput!) { dequeuePullRequest(input: $input) { clientMutationId } }",
+			"variables": map[string]interface{}{
+				"input": map[string]interface{}{"id": "MDExOlB1bGxSZXF1ZXN0MQ=="},
+			},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"data":{"dequeuePullRequest":{"clientMutationId":null}}}`)
+
+	client := NewDefault()
+	_, err := client.PullRequests.DequeueMerge(context.Background(), "octocat/hello-world", 1347)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPullFindMergeQueueEntry(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Times(2).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_merge_queue_entry.json")
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr_merge_queue_entry_missing.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.FindMergeQueueEntry(context.Background(), "octocat/hello-world", 1347)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Position != 1 || got.State != "mergeable" || got.Number != 1347 {
+		t.Errorf("Unexpected merge queue entry %+v", got)
+	}
+
+	_, _, err = client.PullRequests.FindMergeQueueEntry(context.Background(), "octocat/hello-world", 1347)
+	if err != scm.ErrNotFound {
+		t.Errorf("Want ErrNotFound for a pull request which is not queued, got %v", err)
+	}
+}
+
+func TestPullUpdateBranch(t *testing.T) {
+	defer gock.Off()
+	defer func(interval time.Duration) { updateBranchPollInterval = interval }(updateBranchPollInterval)
+	updateBranchPollInterval = 0
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/compare/master...6dcb09b5b57875f334f61aebed695e2e4193db5e").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"status":"diverged","ahead_by":1,"behind_by":2}`)
+
+	gock.New("https://api.github.com").
+		Put("/repos/octocat/hello-world/pulls/1347/update-branch").
+		JSON(map[string]interface{}{
+			"expected_head_sha": "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		}).
+		Reply(202).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"message":"Updating pull request branch.","url":"https://github.com/octocat/hello-world/pull/1347"}`)
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"number":1347,"head":{"sha":"e5bd3914e2e596debea16f433f57875b5b90bcd6"}}`)
+
+	client := NewDefault()
+	got, res, err := client.PullRequests.UpdateBranch(context.Background(), "octocat/hello-world", 1347, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "e5bd3914e2e596debea16f433f57875b5b90bcd6"; got != want {
+		t.Errorf("Want head sha %s, got %s", want, got)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+
+	_, _, err = client.PullRequests.UpdateBranch(context.Background(), "octocat/hello-world", 1347, "squash")
+	if err != scm.ErrNotSupported {
+		t.Errorf("Want not supported error, got %v", err)
+	}
+}
+
+func TestPullUpdateBranchConflict(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/compare/master...6dcb09b5b57875f334f61aebed695e2e4193db5e").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"status":"diverged","ahead_by":1,"behind_by":2}`)
+
+	gock.New("https://api.github.com").
+		Put("/repos/octocat/hello-world/pulls/1347/update-branch").
+		Reply(422).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"message":"merge conflict between base and head"}`)
+
+	client := NewDefault()
+	_, _, err := client.PullRequests.UpdateBranch(context.Background(), "octocat/hello-world", 1347, scm.UpdateBranchMerge)
+	if !scm.IsMergeConflict(err) {
+		t.Errorf("Want merge conflict error, got %v", err)
+	}
+}
+
+// mockUpdateBranchPull mocks the pull request and its comparison
+// with the base branch, two commits behind.
+func mockUpdateBranchPull() {
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/compare/master...6dcb09b5b57875f334f61aebed695e2e4193db5e").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"status":"diverged","ahead_by":1,"behind_by":2}`)
+}
+
+func TestPullUpdateBranchStaleHead(t *testing.T) {
+	defer gock.Off()
+
+	mockUpdateBranchPull()
+
+	gock.New("https://api.github.com").
+		Put("/repos/octocat/hello-world/pulls/1347/update-branch").
+		Reply(422).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"message":"expected head sha didn't match current head ref."}`)
+
+	client := NewDefault()
+	_, _, err := client.PullRequests.UpdateBranch(context.Background(), "octocat/hello-world", 1347, scm.UpdateBranchMerge)
+	if err == nil {
+		t.Fatal("Want an error for a stale head")
+	}
+	if scm.IsMergeConflict(err) {
+		t.Errorf("Want the stale head error, got merge conflict %v", err)
+	}
+}
+
+func TestPullUpdateBranchRebase(t *testing.T) {
+	defer gock.Off()
+	defer func(interval time.Duration) { updateBranchPollInterval = interval }(updateBranchPollInterval)
+	updateBranchPollInterval = 0
+
+	mockUpdateBranchPull()
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		JSON(map[string]interface{}{
+			"query": "mutation($input: UpdatePullRequestBranchInput!) { updatePullRequestBranch(input: $input) { clientMutationId } }",
+			"variables": map[string]interface{}{"input": map[string]interface{}{
+				"pullRequestId":   "MDExOlB1bGxSZXF1ZXN0MQ==",
+				"expectedHeadOid": "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+				"updateMethod":    "REBASE",
+			}},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"data":{"updatePullRequestBranch":{"clientMutationId":null}}}`)
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"number":1347,"head":{"sha":"e5bd3914e2e596debea16f433f57875b5b90bcd6"}}`)
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.UpdateBranch(context.Background(), "octocat/hello-world", 1347, scm.UpdateBranchRebase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "e5bd3914e2e596debea16f433f57875b5b90bcd6"; got != want {
+		t.Errorf("Want head sha %s, got %s", want, got)
+	}
+}
+
+func TestPullUpdateBranchTimeout(t *testing.T) {
+	defer gock.Off()
+	defer func(interval time.Duration, attempts int) {
+		updateBranchPollInterval = interval
+		updateBranchPollAttempts = attempts
+	}(updateBranchPollInterval, updateBranchPollAttempts)
+	updateBranchPollInterval = 0
+	updateBranchPollAttempts = 2
+
+	mockUpdateBranchPull()
+
+	gock.New("https://api.github.com").
+		Put("/repos/octocat/hello-world/pulls/1347/update-branch").
+		Reply(202).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"message":"Updating pull request branch."}`)
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Times(2).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	client := NewDefault()
+	_, _, err := client.PullRequests.UpdateBranch(context.Background(), "octocat/hello-world", 1347, scm.UpdateBranchMerge)
+	if err == nil {
+		t.Error("Want an error when the branch is not updated")
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestPullUpdateBranchUpToDate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/compare/master...6dcb09b5b57875f334f61aebed695e2e4193db5e").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"status":"ahead","ahead_by":1,"behind_by":0}`)
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.UpdateBranch(context.Background(), "octocat/hello-world", 1347, scm.UpdateBranchMerge)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "6dcb09b5b57875f334f61aebed695e2e4193db5e"; got != want {
+		t.Errorf("Want head sha %s, got %s", want, got)
+	}
+}
+
 func TestPullUpdate(t *testing.T) {
 	defer gock.Off()
 
@@ -320,3 +929,38 @@ func TestPullService_UnrequestReview(t *testing.T) {
 	t.Run("Rate", testRate(res))
 
 }
+
+func TestPullFindDiff(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1347").
+		MatchHeader("Accept", "application/vnd.github.v3.diff").
+		Reply(200).
+		Type("text/plain").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.diff")
+
+	client := NewDefault()
+	got, res, err := client.PullRequests.FindDiff(context.Background(), "octocat/hello-world", 1347)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := len(got.Files), 2; got != want {
+		t.Fatalf("Want %d files, got %d", want, got)
+	}
+	if got, want := got.Files[0].NewPath, "README.md"; got != want {
+		t.Errorf("Want file %q, got %q", want, got)
+	}
+	if got, want := len(got.Files[0].Hunks), 2; got != want {
+		t.Errorf("Want %d hunks, got %d", want, got)
+	}
+	if !got.Files[1].Added || !got.Files[1].Binary {
+		t.Errorf("Want added binary file %s", got.Files[1].NewPath)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
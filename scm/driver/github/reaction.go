@@ -0,0 +1,101 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type reactionService struct {
+	client *wrapper
+}
+
+type reaction struct {
+	ID      int       `json:"id"`
+	Content string    `json:"content"`
+	User    user      `json:"user"`
+	Created time.Time `json:"created_at"`
+}
+
+type reactionInput struct {
+	Content string `json:"content"`
+}
+
+func (s *reactionService) List(ctx context.Context, repo string, target scm.ReactionTarget, opts *scm.ListOptions) ([]*scm.Reaction, *scm.Response, error) {
+	path := fmt.Sprintf("%s?%s", reactionsPath(repo, target), encodeListOptions(opts))
+	out := []*reaction{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertReactionList(out), res, err
+}
+
+func (s *reactionService) Create(ctx context.Context, repo string, target scm.ReactionTarget, content scm.ReactionContent) (*scm.Reaction, *scm.Response, error) {
+	in := &reactionInput{Content: string(content)}
+	out := new(reaction)
+	res, err := s.client.do(ctx, "POST", reactionsPath(repo, target), in, out)
+	return convertReaction(out), res, err
+}
+
+// Delete looks up the reaction of the authenticated user, as
+// GitHub deletes reactions by id. The reactions are paged
+// through, as the reaction may not be on the first page.
+func (s *reactionService) Delete(ctx context.Context, repo string, target scm.ReactionTarget, content scm.ReactionContent) (*scm.Response, error) {
+	me, res, err := s.client.Users.Find(ctx)
+	if err != nil {
+		return res, err
+	}
+	opts := &scm.ListOptions{Page: 1, Size: 100}
+	for {
+		params := url.Values{"content": {string(content)}}
+		path := fmt.Sprintf("%s?%s", reactionsPath(repo, target), encodeListOptionsWith(opts, params))
+		out := []*reaction{}
+		res, err = s.client.do(ctx, "GET", path, nil, &out)
+		if err != nil {
+			return res, err
+		}
+		for _, v := range out {
+			if v.Content == string(content) && NormLogin(v.User.Login) == NormLogin(me.Login) {
+				path := fmt.Sprintf("%s/%d", reactionsPath(repo, target), v.ID)
+				return s.client.do(ctx, "DELETE", path, nil, nil)
+			}
+		}
+		if res.Page.Next <= opts.Page {
+			return res, scm.ErrNotFound
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+func reactionsPath(repo string, target scm.ReactionTarget) string {
+	switch target.Type {
+	case scm.ReactionOnIssueComment, scm.ReactionOnPullRequestComment:
+		return fmt.Sprintf("repos/%s/issues/comments/%d/reactions", repo, target.Comment)
+	case scm.ReactionOnReviewComment:
+		return fmt.Sprintf("repos/%s/pulls/comments/%d/reactions", repo, target.Comment)
+	default:
+		return fmt.Sprintf("repos/%s/issues/%d/reactions", repo, target.Number)
+	}
+}
+
+func convertReactionList(from []*reaction) []*scm.Reaction {
+	to := []*scm.Reaction{}
+	for _, v := range from {
+		to = append(to, convertReaction(v))
+	}
+	return to
+}
+
+func convertReaction(from *reaction) *scm.Reaction {
+	return &scm.Reaction{
+		ID:      from.ID,
+		Content: scm.ReactionContent(from.Content),
+		User:    *convertUser(&from.User),
+		Created: from.Created,
+	}
+}
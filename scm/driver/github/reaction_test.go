@@ -0,0 +1,156 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestReactionList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/issues/comments/1/reactions").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/reactions.json")
+
+	client := NewDefault()
+	target := scm.ReactionTarget{Type: scm.ReactionOnIssueComment, Number: 2, Comment: 1}
+	got, res, err := client.Reactions.List(context.Background(), "octocat/hello-world", target, &scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Reaction{}
+	raw, _ := os.ReadFile("testdata/reactions.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+	t.Run("Page", testPage(res))
+}
+
+func TestReactionCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/pulls/comments/1/reactions").
+		JSON(map[string]string{"content": "heart"}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/reaction.json")
+
+	client := NewDefault()
+	target := scm.ReactionTarget{Type: scm.ReactionOnReviewComment, Number: 2, Comment: 1}
+	got, _, err := client.Reactions.Create(context.Background(), "octocat/hello-world", target, scm.ReactionHeart)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Reaction)
+	raw, _ := os.ReadFile("testdata/reaction.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReactionDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/user").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/issues/1/reactions").
+		MatchParam("content", "heart").
+		MatchParam("page", "1").
+		MatchParam("per_page", "100").
+		Reply(200).
+		Type("application/json").
+		File("testdata/reactions.json")
+
+	gock.New("https://api.github.com").
+		Delete("/repos/octocat/hello-world/issues/1/reactions/1").
+		Reply(204).
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	target := scm.ReactionTarget{Type: scm.ReactionOnPullRequest, Number: 1}
+	_, err := client.Reactions.Delete(context.Background(), "octocat/hello-world", target, scm.ReactionHeart)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected the reaction to be deleted")
+	}
+}
+
+func TestReactionDeletePlusOne(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/user").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/issues/1/reactions").
+		MatchParam("content", `^\+1$`).
+		MatchParam("page", "1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockPageHeaders).
+		File("testdata/reactions.json")
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/issues/1/reactions").
+		MatchParam("content", `^\+1$`).
+		MatchParam("page", "2").
+		Reply(200).
+		Type("application/json").
+		File("testdata/reactions_plus_one.json")
+
+	gock.New("https://api.github.com").
+		Delete("/repos/octocat/hello-world/issues/1/reactions/3").
+		Reply(204).
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	target := scm.ReactionTarget{Type: scm.ReactionOnIssue, Number: 1}
+	_, err := client.Reactions.Delete(context.Background(), "octocat/hello-world", target, scm.ReactionPlusOne)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected the reaction on the second page to be deleted")
+	}
+}
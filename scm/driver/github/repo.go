@@ -5,10 +5,14 @@
 package github
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
@@ -59,6 +63,27 @@ type hook struct {
 	} `json:"config"`
 }
 
+type hookDelivery struct {
+	ID          int64     `json:"id"`
+	GUID        string    `json:"guid"`
+	DeliveredAt time.Time `json:"delivered_at"`
+	Redelivery  bool      `json:"redelivery"`
+	Duration    float64   `json:"duration"`
+	Status      string    `json:"status"`
+	StatusCode  int       `json:"status_code"`
+	Event       string    `json:"event"`
+	Action      string    `json:"action"`
+	URL         string    `json:"url"`
+	Request     struct {
+		Headers map[string]string `json:"headers"`
+		Payload json.RawMessage   `json:"payload"`
+	} `json:"request"`
+	Response struct {
+		Headers map[string]string `json:"headers"`
+		Payload string            `json:"payload"`
+	} `json:"response"`
+}
+
 type collaboratorBody struct {
 	Permission string `json:"permission"`
 }
@@ -290,22 +315,7 @@ func (s *repositoryService) Fork(ctx context.Context, input *scm.RepositoryInput
 // CreateHook creates a new repository webhook.
 func (s *repositoryService) CreateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
 	path := fmt.Sprintf("repos/%s/hooks", repo)
-	in := new(hook)
-	in.Active = true
-	in.Name = "web"
-	in.Config.Secret = input.Secret
-	in.Config.ContentType = "json"
-	in.Config.URL = input.Target
-	if input.SkipVerify {
-		in.Config.InsecureSSL = "1"
-	} else {
-		in.Config.InsecureSSL = "0"
-	}
-	input.NativeEvents = append(
-		input.NativeEvents,
-		convertHookEvents(input.Events)...,
-	)
-	in.Events = input.NativeEvents
+	in := convertHookInput(input)
 	out := new(hook)
 	res, err := s.client.do(ctx, "POST", path, in, out)
 	return convertHook(out), res, err
@@ -313,27 +323,18 @@ func (s *repositoryService) CreateHook(ctx context.Context, repo string, input *
 
 func (s *repositoryService) UpdateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
 	path := fmt.Sprintf("repos/%s/hooks/%s", repo, input.Name)
-	in := new(hook)
-	in.Active = true
-	in.Name = "web"
-	in.Config.Secret = input.Secret
-	in.Config.ContentType = "json"
-	in.Config.URL = input.Target
-	if input.SkipVerify {
-		in.Config.InsecureSSL = "1"
-	} else {
-		in.Config.InsecureSSL = "0"
-	}
-	input.NativeEvents = append(
-		input.NativeEvents,
-		convertHookEvents(input.Events)...,
-	)
-	in.Events = input.NativeEvents
+	in := convertHookInput(input)
 	out := new(hook)
 	res, err := s.client.do(ctx, "PATCH", path, in, out)
 	return convertHook(out), res, err
 }
 
+// ConvertHookEvents returns the events reported for a webhook
+// created from the input.
+func (s *repositoryService) ConvertHookEvents(input *scm.HookInput) []string {
+	return append(append([]string(nil), input.NativeEvents...), convertHookEvents(input.Events)...)
+}
+
 // CreateStatus creates a new commit status.
 func (s *repositoryService) CreateStatus(ctx context.Context, repo, ref string, input *scm.StatusInput) (*scm.Status, *scm.Response, error) {
 	path := fmt.Sprintf("repos/%s/statuses/%s", repo, ref)
@@ -354,11 +355,64 @@ func (s *repositoryService) DeleteHook(ctx context.Context, repo, id string) (*s
 	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
+// ListHookDeliveries returns the recent deliveries of a repository webhook.
+//
+// GitHub paginates deliveries with a cursor, so the next page is
+// returned as Response.Page.NextURL and requested with ListOptions.URL.
+//
+// See https://docs.github.com/en/rest/repos/webhooks#list-deliveries-for-a-repository-webhook
+func (s *repositoryService) ListHookDeliveries(ctx context.Context, repo, id string, opts *scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/hooks/%s/deliveries?%s", repo, id, encodeListOptions(opts))
+	return listHookDeliveries(ctx, s.client, path, opts)
+}
+
+// FindHookDelivery returns a repository webhook delivery.
+//
+// See https://docs.github.com/en/rest/repos/webhooks#get-a-delivery-for-a-repository-webhook
+func (s *repositoryService) FindHookDelivery(ctx context.Context, repo, id, delivery string) (*scm.HookDelivery, *scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/hooks/%s/deliveries/%s", repo, id, delivery)
+	out := new(hookDelivery)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertHookDelivery(out), res, err
+}
+
+// RedeliverHook sends a repository webhook delivery again.
+//
+// See https://docs.github.com/en/rest/repos/webhooks#redeliver-a-delivery-for-a-repository-webhook
+func (s *repositoryService) RedeliverHook(ctx context.Context, repo, id, delivery string) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/hooks/%s/deliveries/%s/attempts", repo, id, delivery)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
+// TestHook sends a ping event to a repository webhook.
+//
+// See https://docs.github.com/en/rest/repos/webhooks#ping-a-repository-webhook
+func (s *repositoryService) TestHook(ctx context.Context, repo, id string) (*scm.Response, error) {
+	path := fmt.Sprintf("repos/%s/hooks/%s/pings", repo, id)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
 func (s *repositoryService) Delete(ctx context.Context, repo string) (*scm.Response, error) {
 	path := fmt.Sprintf("repos/%s", repo)
 	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
+// Archive downloads a tarball or zipball of the repository at the given ref.
+//
+// See https://docs.github.com/en/rest/repos/contents#download-a-repository-archive-tar
+func (s *repositoryService) Archive(ctx context.Context, repo, ref string, format scm.ArchiveFormat) (io.ReadCloser, *scm.Response, error) {
+	var path string
+	switch format {
+	case scm.ArchiveFormatTarball:
+		path = fmt.Sprintf("repos/%s/tarball/%s", repo, ref)
+	case scm.ArchiveFormatZip:
+		path = fmt.Sprintf("repos/%s/zipball/%s", repo, ref)
+	default:
+		return nil, nil, scm.ErrNotSupported
+	}
+	return s.client.stream(ctx, "GET", path)
+}
+
 // helper function to convert from the gogs repository list to
 // the common repository structure.
 func convertRepositoryList(from []*repository) []*scm.Repository {
@@ -416,6 +470,110 @@ func convertHook(from *hook) *scm.Hook {
 	}
 }
 
+// convertHookInput returns the request body used to create
+// or edit a repository webhook.
+func convertHookInput(input *scm.HookInput) *hook {
+	return convertHookInputEvents(input, convertHookEvents(input.Events))
+}
+
+// convertOrgHookInput returns the request body used to create
+// or edit an organization webhook, which can also subscribe to
+// the organization only events.
+func convertOrgHookInput(input *scm.HookInput) *hook {
+	return convertHookInputEvents(input, convertOrgHookEvents(input.Events))
+}
+
+func convertHookInputEvents(input *scm.HookInput, events []string) *hook {
+	in := new(hook)
+	in.Active = true
+	in.Name = "web"
+	in.Config.Secret = input.Secret
+	in.Config.ContentType = "json"
+	in.Config.URL = input.Target
+	if input.SkipVerify {
+		in.Config.InsecureSSL = "1"
+	} else {
+		in.Config.InsecureSSL = "0"
+	}
+	input.NativeEvents = append(
+		input.NativeEvents,
+		events...,
+	)
+	in.Events = input.NativeEvents
+	return in
+}
+
+func listHookDeliveries(ctx context.Context, client *wrapper, path string, opts *scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	if opts.URL != "" {
+		path = opts.URL
+	}
+	out := []*hookDelivery{}
+	res, err := client.do(ctx, "GET", path, nil, &out)
+	if res != nil {
+		res.Page.NextURL = nextLink(res.Header.Get("Link"))
+	}
+	return convertHookDeliveryList(out), res, err
+}
+
+// nextLink returns the url of the next page from the Link
+// header, which is used by endpoints with cursor pagination.
+func nextLink(header string) string {
+	for _, link := range strings.Split(header, ",") {
+		segments := strings.Split(strings.TrimSpace(link), ";")
+		if len(segments) < 2 {
+			continue
+		}
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		for _, segment := range segments[1:] {
+			if strings.TrimSpace(segment) == `rel="next"` {
+				return target[1 : len(target)-1]
+			}
+		}
+	}
+	return ""
+}
+
+func convertHookDeliveryList(from []*hookDelivery) []*scm.HookDelivery {
+	to := []*scm.HookDelivery{}
+	for _, v := range from {
+		to = append(to, convertHookDelivery(v))
+	}
+	return to
+}
+
+func convertHookDelivery(from *hookDelivery) *scm.HookDelivery {
+	to := &scm.HookDelivery{
+		ID:         strconv.FormatInt(from.ID, 10),
+		GUID:       from.GUID,
+		Event:      from.Event,
+		Action:     from.Action,
+		Status:     from.Status,
+		StatusCode: from.StatusCode,
+		Redelivery: from.Redelivery,
+		Duration:   time.Duration(from.Duration * float64(time.Second)),
+		Delivered:  from.DeliveredAt,
+		Request: scm.HookDeliveryRequest{
+			URL:    from.URL,
+			Header: from.Request.Headers,
+		},
+		Response: scm.HookDeliveryResponse{
+			Header: from.Response.Headers,
+			Body:   from.Response.Payload,
+		},
+	}
+	if len(from.Request.Payload) != 0 && string(from.Request.Payload) != "null" {
+		buf := new(bytes.Buffer)
+		if err := json.Compact(buf, from.Request.Payload); err == nil {
+			to.Request.Method = http.MethodPost
+			to.Request.Body = buf.String()
+		}
+	}
+	return to
+}
+
 func convertHookEvents(from scm.HookEvents) []string {
 	var events []string
 	if from.Push {
@@ -448,6 +606,38 @@ func convertHookEvents(from scm.HookEvents) []string {
 	if from.Release {
 		events = append(events, "release")
 	}
+	if from.ReviewThread {
+		events = append(events, "pull_request_review_thread")
+	}
+	if from.Pipeline {
+		events = append(events, "workflow_run")
+	}
+	if from.Job {
+		events = append(events, "workflow_job")
+	}
+	if from.Member {
+		events = append(events, "member")
+	}
+	if from.Star {
+		events = append(events, "star")
+	}
+	return events
+}
+
+// convertOrgHookEvents returns the events of an organization
+// webhook. The membership, team and organization events are
+// only delivered to organization webhooks.
+func convertOrgHookEvents(from scm.HookEvents) []string {
+	events := convertHookEvents(from)
+	if from.Member {
+		events = append(events, "membership")
+	}
+	if from.Team {
+		events = append(events, "team")
+	}
+	if from.Organization {
+		events = append(events, "organization")
+	}
 	return events
 }
 
@@ -7,6 +7,8 @@ package github
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
 	"os"
 	"testing"
 
@@ -378,6 +380,145 @@ func TestRepositoryHookDelete(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestRepositoryHookDeliveryList(t *testing.T) {
+	defer gock.Off()
+
+	next := "https://api.github.com/repos/octocat/hello-world/hooks/1/deliveries?cursor=v1_12345678&per_page=2"
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/hooks/1/deliveries").
+		MatchParam("per_page", "2").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeader("Link", fmt.Sprintf(`<%s>; rel="next"`, next)).
+		File("testdata/hook_deliveries.json")
+
+	client := NewDefault()
+	got, res, err := client.Repositories.ListHookDeliveries(context.Background(), "octocat/hello-world", "1", &scm.ListOptions{Size: 2})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.HookDelivery{}
+	raw, _ := os.ReadFile("testdata/hook_deliveries.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	if got, want := res.Page.NextURL, next; got != want {
+		t.Errorf("Want next page url %q, got %q", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/hooks/1/deliveries").
+		MatchParam("cursor", "v1_12345678").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString("[]")
+
+	got, _, err = client.Repositories.ListHookDeliveries(context.Background(), "octocat/hello-world", "1", &scm.ListOptions{URL: res.Page.NextURL})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(got) != 0 {
+		t.Errorf("Want no deliveries on the last page, got %d", len(got))
+	}
+}
+
+func TestRepositoryHookDeliveryFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/hooks/1/deliveries/12345678").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/hook_delivery.json")
+
+	client := NewDefault()
+	got, res, err := client.Repositories.FindHookDelivery(context.Background(), "octocat/hello-world", "1", "12345678")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.HookDelivery)
+	raw, _ := os.ReadFile("testdata/hook_delivery.json.golden")
+	err = json.Unmarshal(raw, want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestRepositoryHookRedeliver(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/hooks/1/deliveries/12345678/attempts").
+		Reply(202).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString("{}")
+
+	client := NewDefault()
+	res, err := client.Repositories.RedeliverHook(context.Background(), "octocat/hello-world", "1", "12345678")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := res.Status, 202; got != want {
+		t.Errorf("Want response status %d, got %d", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestRepositoryHookTest(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/hooks/1/pings").
+		Reply(204).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	res, err := client.Repositories.TestHook(context.Background(), "octocat/hello-world", "1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := res.Status, 204; got != want {
+		t.Errorf("Want response status %d, got %d", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
 func TestRepositoryHookCreate(t *testing.T) {
 	defer gock.Off()
 
@@ -616,6 +757,51 @@ func TestConvertFromState(t *testing.T) {
 	}
 }
 
+func TestRepositoryArchive(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/tarball/master").
+		Reply(200).
+		Type("application/x-gzip").
+		SetHeaders(mockHeaders).
+		BodyString("archive")
+
+	client := NewDefault()
+	rc, res, err := client.Repositories.Archive(context.Background(), "octocat/hello-world", "master", scm.ArchiveFormatTarball)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := string(data), "archive"; got != want {
+		t.Errorf("Want archive contents %q, got %q", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+}
+
+func TestRepositoryArchive_NotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/zipball/missing").
+		Reply(404).
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	_, _, err := client.Repositories.Archive(context.Background(), "octocat/hello-world", "missing", scm.ArchiveFormatZip)
+	if err != scm.ErrNotFound {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
 func TestHookEvents(t *testing.T) {
 	tests := []struct {
 		in  scm.HookEvents
@@ -657,6 +843,18 @@ func TestHookEvents(t *testing.T) {
 			in:  scm.HookEvents{Release: true},
 			out: []string{"release"},
 		},
+		{
+			in:  scm.HookEvents{Pipeline: true, Job: true},
+			out: []string{"workflow_run", "workflow_job"},
+		},
+		{
+			in:  scm.HookEvents{Member: true, Team: true, Organization: true},
+			out: []string{"member"},
+		},
+		{
+			in:  scm.HookEvents{ReviewThread: true, Star: true},
+			out: []string{"pull_request_review_thread", "star"},
+		},
 		{
 			in: scm.HookEvents{
 				Branch:             true,
@@ -683,6 +881,15 @@ func TestHookEvents(t *testing.T) {
 	}
 }
 
+func TestOrgHookEvents(t *testing.T) {
+	in := scm.HookEvents{Push: true, Member: true, Team: true, Organization: true}
+	want := []string{"push", "member", "membership", "team", "organization"}
+	if diff := cmp.Diff(want, convertOrgHookEvents(in)); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestAddCollaborator(t *testing.T) {
 	defer gock.Off()
 
@@ -38,11 +38,7 @@ func (s *reviewService) Create(ctx context.Context, repo string, number int, inp
 		Event:    input.Event,
 	}
 	for _, c := range input.Comments {
-		in.Comments = append(in.Comments, &reviewCommentInput{
-			Body:     c.Body,
-			Path:     c.Path,
-			Position: c.Line,
-		})
+		in.Comments = append(in.Comments, convertReviewCommentInput(c))
 	}
 	out := new(review)
 	res, err := s.client.do(ctx, "POST", path, in, out)
@@ -96,12 +92,177 @@ func (s *reviewService) Dismiss(ctx context.Context, repo string, prID, reviewID
 	return convertReview(out), res, err
 }
 
+// CreateComment creates a review comment on the pull request. A reply
+// is posted to the comment with the InReplyTo id or, failing that, to
+// the thread with the ThreadID node id.
+func (s *reviewService) CreateComment(ctx context.Context, repo string, number int, input *scm.ReviewCommentInput) (*scm.ReviewComment, *scm.Response, error) {
+	switch {
+	case input.InReplyTo != 0:
+		path := fmt.Sprintf("repos/%s/pulls/%d/comments/%d/replies", repo, number, input.InReplyTo)
+		in := &reviewReplyInput{Body: input.Body}
+		out := new(reviewComment)
+		res, err := s.client.do(ctx, "POST", path, in, out)
+		return convertReviewComment(out), res, err
+	case input.ThreadID != "":
+		vars := map[string]interface{}{
+			"input": map[string]interface{}{
+				"pullRequestReviewThreadId": input.ThreadID,
+				"body":                      input.Body,
+			},
+		}
+		out := &struct {
+			AddPullRequestReviewThreadReply struct {
+				Comment reviewThreadComment `json:"comment"`
+			} `json:"addPullRequestReviewThreadReply"`
+		}{}
+		res, err := s.client.doGraphQL(ctx, reviewThreadReplyMutation, vars, out)
+		if err != nil {
+			return nil, res, err
+		}
+		comment := convertReviewThreadComment(&out.AddPullRequestReviewThreadReply.Comment)
+		comment.ThreadID = input.ThreadID
+		return comment, res, nil
+	}
+
+	in := convertReviewCommentInput(input)
+	in.CommitID = input.Sha
+	if in.CommitID == "" {
+		head := new(pr)
+		res, err := s.client.do(ctx, "GET", fmt.Sprintf("repos/%s/pulls/%d", repo, number), nil, head)
+		if err != nil {
+			return nil, res, err
+		}
+		in.CommitID = head.Head.Sha
+	}
+	path := fmt.Sprintf("repos/%s/pulls/%d/comments", repo, number)
+	out := new(reviewComment)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertReviewComment(out), res, err
+}
+
+// ListThreads returns every review thread of the pull request. The
+// GraphQL API is paged by cursor, so the list options are ignored.
+func (s *reviewService) ListThreads(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.ReviewThread, *scm.Response, error) {
+	owner, name := scm.Split(repo)
+	vars := map[string]interface{}{
+		"owner":  owner,
+		"name":   name,
+		"number": number,
+	}
+	to := []*scm.ReviewThread{}
+	for {
+		out := new(reviewThreadsQuery)
+		res, err := s.client.doGraphQL(ctx, reviewThreadsQueryString, vars, out)
+		if err != nil {
+			return nil, res, err
+		}
+		threads := out.Repository.PullRequest.ReviewThreads
+		for _, v := range threads.Nodes {
+			to = append(to, convertReviewThread(v))
+		}
+		if !threads.PageInfo.HasNextPage {
+			return to, res, nil
+		}
+		vars["cursor"] = threads.PageInfo.EndCursor
+	}
+}
+
+func (s *reviewService) ResolveThread(ctx context.Context, repo string, number int, id string) (*scm.Response, error) {
+	return s.setThreadResolved(ctx, "resolveReviewThread", id)
+}
+
+func (s *reviewService) UnresolveThread(ctx context.Context, repo string, number int, id string) (*scm.Response, error) {
+	return s.setThreadResolved(ctx, "unresolveReviewThread", id)
+}
+
+func (s *reviewService) setThreadResolved(ctx context.Context, mutation, id string) (*scm.Response, error) {
+	query := fmt.Sprintf("mutation($id: ID!) { %s(input: {threadId: $id}) { thread { isResolved } } }", mutation)
+	vars := map[string]interface{}{"id": id}
+	return s.client.doGraphQL(ctx, query, vars, nil)
+}
+
+const reviewThreadCommentFields = `databaseId body path position line startLine originalLine url createdAt updatedAt
+author { login avatarUrl } commit { oid } replyTo { databaseId }`
+
+const reviewThreadReplyMutation = `mutation($input: AddPullRequestReviewThreadReplyInput!) {
+  addPullRequestReviewThreadReply(input: $input) { comment { ` + reviewThreadCommentFields + ` } }
+}`
+
+const reviewThreadsQueryString = `query($owner: String!, $name: String!, $number: Int!, $cursor: String) {
+  repository(owner: $owner, name: $name) {
+    pullRequest(number: $number) {
+      reviewThreads(first: 100, after: $cursor) {
+        pageInfo { hasNextPage endCursor }
+        nodes {
+          id isResolved isOutdated path line startLine diffSide
+          comments(first: 100) { nodes { ` + reviewThreadCommentFields + ` } }
+        }
+      }
+    }
+  }
+}`
+
+type reviewThreadsQuery struct {
+	Repository struct {
+		PullRequest struct {
+			ReviewThreads struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []*reviewThread `json:"nodes"`
+			} `json:"reviewThreads"`
+		} `json:"pullRequest"`
+	} `json:"repository"`
+}
+
+type reviewThread struct {
+	ID         string `json:"id"`
+	IsResolved bool   `json:"isResolved"`
+	IsOutdated bool   `json:"isOutdated"`
+	Path       string `json:"path"`
+	Line       int    `json:"line"`
+	StartLine  int    `json:"startLine"`
+	DiffSide   string `json:"diffSide"`
+	Comments   struct {
+		Nodes []*reviewThreadComment `json:"nodes"`
+	} `json:"comments"`
+}
+
+type reviewThreadComment struct {
+	DatabaseID   int       `json:"databaseId"`
+	Body         string    `json:"body"`
+	Path         string    `json:"path"`
+	Position     int       `json:"position"`
+	Line         int       `json:"line"`
+	StartLine    int       `json:"startLine"`
+	OriginalLine int       `json:"originalLine"`
+	URL          string    `json:"url"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+	Author       struct {
+		Login     string `json:"login"`
+		AvatarURL string `json:"avatarUrl"`
+	} `json:"author"`
+	Commit struct {
+		Oid string `json:"oid"`
+	} `json:"commit"`
+	ReplyTo *struct {
+		DatabaseID int `json:"databaseId"`
+	} `json:"replyTo"`
+}
+
 type reviewComment struct {
-	ID       int    `json:"id"`
-	CommitID string `json:"commit_id"`
-	Position int    `json:"position"`
-	Path     string `json:"path"`
-	User     struct {
+	ID           int    `json:"id"`
+	CommitID     string `json:"commit_id"`
+	Position     int    `json:"position"`
+	Line         int    `json:"line"`
+	OriginalLine int    `json:"original_line"`
+	StartLine    int    `json:"start_line"`
+	Side         string `json:"side"`
+	InReplyToID  int    `json:"in_reply_to_id"`
+	Path         string `json:"path"`
+	User         struct {
 		ID        int    `json:"id"`
 		Login     string `json:"login"`
 		AvatarURL string `json:"avatar_url"`
@@ -120,9 +281,18 @@ type reviewInput struct {
 }
 
 type reviewCommentInput struct {
-	Body     string `json:"body"`
-	Path     string `json:"path"`
-	Position int    `json:"position"`
+	Body      string `json:"body"`
+	CommitID  string `json:"commit_id,omitempty"`
+	Path      string `json:"path"`
+	Position  int    `json:"position,omitempty"`
+	Line      int    `json:"line,omitempty"`
+	Side      string `json:"side,omitempty"`
+	StartLine int    `json:"start_line,omitempty"`
+	StartSide string `json:"start_side,omitempty"`
+}
+
+type reviewReplyInput struct {
+	Body string `json:"body"`
 }
 
 type reviewUpdateInput struct {
@@ -183,14 +353,25 @@ func convertReviewCommentList(from []*reviewComment) []*scm.ReviewComment {
 	return to
 }
 
+// convertReviewComment reports the diff position as the line, as
+// GitHub always has, and the file line separately. The file line
+// of an outdated comment is the line it was made on.
 func convertReviewComment(from *reviewComment) *scm.ReviewComment {
+	line := from.Line
+	if line == 0 {
+		line = from.OriginalLine
+	}
 	return &scm.ReviewComment{
-		ID:   0,
-		Body: from.Body,
-		Path: from.Path,
-		Sha:  from.CommitID,
-		Line: from.Position,
-		Link: from.HTMLURL,
+		ID:        from.ID,
+		Body:      from.Body,
+		Path:      from.Path,
+		Sha:       from.CommitID,
+		Line:      from.Position,
+		FileLine:  line,
+		StartLine: from.StartLine,
+		Side:      scm.DiffSide(from.Side),
+		InReplyTo: from.InReplyToID,
+		Link:      from.HTMLURL,
 		Author: scm.User{
 			Login:  from.User.Login,
 			Avatar: from.User.AvatarURL,
@@ -199,3 +380,74 @@ func convertReviewComment(from *reviewComment) *scm.ReviewComment {
 		Updated: from.UpdatedAt,
 	}
 }
+
+// convertReviewCommentInput addresses the comment by file line when a
+// side or start line is given and by diff position otherwise.
+func convertReviewCommentInput(from *scm.ReviewCommentInput) *reviewCommentInput {
+	to := &reviewCommentInput{
+		Body: from.Body,
+		Path: from.Path,
+	}
+	if from.Side == "" && from.StartLine == 0 {
+		to.Position = from.Line
+		return to
+	}
+	side := from.Side
+	if side == "" {
+		side = scm.DiffSideRight
+	}
+	to.Line = from.Line
+	to.Side = string(side)
+	if from.StartLine != 0 && from.StartLine != from.Line {
+		to.StartLine = from.StartLine
+		to.StartSide = string(side)
+	}
+	return to
+}
+
+func convertReviewThread(from *reviewThread) *scm.ReviewThread {
+	to := &scm.ReviewThread{
+		ID:        from.ID,
+		Path:      from.Path,
+		Line:      from.Line,
+		StartLine: from.StartLine,
+		Side:      scm.DiffSide(from.DiffSide),
+		Resolved:  from.IsResolved,
+		Outdated:  from.IsOutdated,
+		Comments:  []*scm.ReviewComment{},
+	}
+	for _, c := range from.Comments.Nodes {
+		comment := convertReviewThreadComment(c)
+		comment.ThreadID = from.ID
+		comment.Side = to.Side
+		to.Comments = append(to.Comments, comment)
+	}
+	return to
+}
+
+func convertReviewThreadComment(from *reviewThreadComment) *scm.ReviewComment {
+	line := from.Line
+	if line == 0 {
+		line = from.OriginalLine
+	}
+	to := &scm.ReviewComment{
+		ID:        from.DatabaseID,
+		Body:      from.Body,
+		Path:      from.Path,
+		Sha:       from.Commit.Oid,
+		Line:      from.Position,
+		FileLine:  line,
+		StartLine: from.StartLine,
+		Link:      from.URL,
+		Author: scm.User{
+			Login:  from.Author.Login,
+			Avatar: from.Author.AvatarURL,
+		},
+		Created: from.CreatedAt,
+		Updated: from.UpdatedAt,
+	}
+	if from.ReplyTo != nil {
+		to.InReplyTo = from.ReplyTo.DatabaseID
+	}
+	return to
+}
@@ -294,3 +294,159 @@ func TestReviewDismiss(t *testing.T) {
 	t.Run("Request", testRequest(res))
 	t.Run("Rate", testRate(res))
 }
+
+func TestReviewCreateComment(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/repos/octocat/hello-world/pulls/1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/pr.json")
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/pulls/1/comments").
+		JSON(map[string]interface{}{
+			"body":       "```suggestion\nreturn nil\n```",
+			"commit_id":  "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+			"path":       "file1.txt",
+			"line":       20,
+			"side":       "RIGHT",
+			"start_line": 18,
+			"start_side": "RIGHT",
+		}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/review_comment_create.json")
+
+	input := &scm.ReviewCommentInput{
+		Body:      "```suggestion\nreturn nil\n```",
+		Path:      "file1.txt",
+		StartLine: 18,
+		Line:      20,
+	}
+
+	client := NewDefault()
+	got, res, err := client.Reviews.CreateComment(context.Background(), "octocat/hello-world", 1, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.ReviewComment)
+	raw, _ := os.ReadFile("testdata/review_comment_create.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestReviewCreateCommentReply(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/repos/octocat/hello-world/pulls/1/comments/10/replies").
+		JSON(map[string]string{"body": "Done."}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/review_comment_create.json")
+
+	client := NewDefault()
+	_, _, err := client.Reviews.CreateComment(context.Background(), "octocat/hello-world", 1, &scm.ReviewCommentInput{
+		Body:      "Done.",
+		InReplyTo: 10,
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewListThreads(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		BodyString(`"variables":\{"name":"hello-world","number":1,"owner":"octocat"\}`).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/review_threads.json")
+
+	client := NewDefault()
+	got, res, err := client.Reviews.ListThreads(context.Background(), "octocat/hello-world", 1, &scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.ReviewThread{}
+	raw, _ := os.ReadFile("testdata/review_threads.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestReviewResolveThread(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		JSON(map[string]interface{}{
+			"query":     "mutation($id: ID!) { resolveReviewThread(input: {threadId: $id}) { thread { isResolved } } }",
+			"variables": map[string]interface{}{"id": "PRRT_kwDOAHz1OX4-NVQd"},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"data": {"resolveReviewThread": {"thread": {"isResolved": true}}}}`)
+
+	client := NewDefault()
+	_, err := client.Reviews.ResolveThread(context.Background(), "octocat/hello-world", 1, "PRRT_kwDOAHz1OX4-NVQd")
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewUnresolveThread(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Post("/graphql").
+		JSON(map[string]interface{}{
+			"query":     "mutation($id: ID!) { unresolveReviewThread(input: {threadId: $id}) { thread { isResolved } } }",
+			"variables": map[string]interface{}{"id": "PRRT_kwDOAHz1OX4-NVQd"},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"data": {"unresolveReviewThread": {"thread": {"isResolved": false}}}}`)
+
+	client := NewDefault()
+	_, err := client.Reviews.UnresolveThread(context.Background(), "octocat/hello-world", 1, "PRRT_kwDOAHz1OX4-NVQd")
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
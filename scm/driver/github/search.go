@@ -0,0 +1,205 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+type searchService struct {
+	client *wrapper
+}
+
+type codeSearchResults struct {
+	TotalCount int           `json:"total_count"`
+	Items      []*codeResult `json:"items"`
+}
+
+type codeResult struct {
+	Name        string     `json:"name"`
+	Path        string     `json:"path"`
+	Sha         string     `json:"sha"`
+	HTMLURL     string     `json:"html_url"`
+	Repository  repository `json:"repository"`
+	TextMatches []struct {
+		Fragment string `json:"fragment"`
+	} `json:"text_matches"`
+}
+
+type repositorySearchResults struct {
+	TotalCount int           `json:"total_count"`
+	Items      []*repository `json:"items"`
+}
+
+type commitSearchResults struct {
+	TotalCount int             `json:"total_count"`
+	Items      []*commitResult `json:"items"`
+}
+
+type commitResult struct {
+	commit
+	Repository repository `json:"repository"`
+}
+
+func (s *searchService) Code(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchCode, *scm.Response, error) {
+	out := new(codeSearchResults)
+	req := &scm.Request{
+		Method: http.MethodGet,
+		Path:   "search/code?" + encodeSearchQuery(q, opts, codeQualifiers),
+		Header: map[string][]string{
+			// This accept header adds the matching fragments
+			"Accept": {"application/vnd.github.text-match+json"},
+		},
+	}
+	res, err := s.client.doRequest(ctx, req, nil, out)
+	return convertCodeResults(out), res, err
+}
+
+func (s *searchService) Repositories(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	path := "search/repositories?" + encodeSearchQuery(q, opts, repositoryQualifiers)
+	out := new(repositorySearchResults)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertRepositoryList(out.Items), res, err
+}
+
+func (s *searchService) Issues(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchIssue, *scm.Response, error) {
+	path := "search/issues?" + encodeSearchQuery(q, opts, issueQualifiers)
+	out := new(searchResults)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertSearchIssueList(out.Items), res, err
+}
+
+func (s *searchService) Commits(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchCommit, *scm.Response, error) {
+	path := "search/commits?" + encodeSearchQuery(q, opts, commitQualifiers)
+	out := new(commitSearchResults)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertCommitResults(out), res, err
+}
+
+// qualifiers returns the search qualifiers supported by an
+// endpoint for the query.
+type qualifiers func(q *scm.SearchQuery) []string
+
+func scopeQualifiers(q *scm.SearchQuery) []string {
+	if q.Repo != "" {
+		return []string{"repo:" + q.Repo}
+	}
+	if q.Org != "" {
+		return []string{"org:" + q.Org}
+	}
+	return nil
+}
+
+func codeQualifiers(q *scm.SearchQuery) []string {
+	to := scopeQualifiers(q)
+	if q.Path != "" {
+		to = append(to, "path:"+quoteQualifier(q.Path))
+	}
+	if q.Language != "" {
+		to = append(to, "language:"+quoteQualifier(q.Language))
+	}
+	return to
+}
+
+func repositoryQualifiers(q *scm.SearchQuery) []string {
+	to := scopeQualifiers(q)
+	if q.Language != "" {
+		to = append(to, "language:"+quoteQualifier(q.Language))
+	}
+	return to
+}
+
+func issueQualifiers(q *scm.SearchQuery) []string {
+	to := scopeQualifiers(q)
+	switch q.Type {
+	case scm.SearchTypeIssue:
+		to = append(to, "is:issue")
+	case scm.SearchTypePullRequest:
+		to = append(to, "is:pr")
+	}
+	if q.State != "" {
+		to = append(to, "state:"+q.State)
+	}
+	for _, label := range q.Labels {
+		to = append(to, "label:"+quoteQualifier(label))
+	}
+	if q.Author != "" {
+		to = append(to, "author:"+q.Author)
+	}
+	return to
+}
+
+func commitQualifiers(q *scm.SearchQuery) []string {
+	to := scopeQualifiers(q)
+	if q.Author != "" {
+		to = append(to, "author:"+q.Author)
+	}
+	return to
+}
+
+func quoteQualifier(s string) string {
+	if strings.ContainsAny(s, " \t") {
+		return strconv.Quote(s)
+	}
+	return s
+}
+
+// encodeSearchQuery compiles the query to the GitHub search
+// syntax, which combines the text with qualifiers.
+func encodeSearchQuery(q *scm.SearchQuery, opts *scm.ListOptions, fn qualifiers) string {
+	terms := fn(q)
+	if q.Text != "" {
+		terms = append([]string{q.Text}, terms...)
+	}
+	params := url.Values{}
+	params.Set("q", strings.Join(terms, " "))
+	if opts != nil {
+		if opts.Page != 0 {
+			params.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.Size != 0 {
+			params.Set("per_page", strconv.Itoa(opts.Size))
+		}
+		if opts.Sort != "" {
+			params.Set("sort", opts.Sort)
+		}
+	}
+	return params.Encode()
+}
+
+func convertCodeResults(from *codeSearchResults) []*scm.SearchCode {
+	to := []*scm.SearchCode{}
+	for _, v := range from.Items {
+		code := &scm.SearchCode{
+			Name:       v.Name,
+			Path:       v.Path,
+			Sha:        v.Sha,
+			Link:       v.HTMLURL,
+			Repository: *convertRepository(&v.Repository),
+		}
+		for _, match := range v.TextMatches {
+			code.Fragments = append(code.Fragments, match.Fragment)
+		}
+		to = append(to, code)
+	}
+	return to
+}
+
+func convertCommitResults(from *commitSearchResults) []*scm.SearchCommit {
+	to := []*scm.SearchCommit{}
+	for _, v := range from.Items {
+		to = append(to, &scm.SearchCommit{
+			Commit:     *convertCommit(&v.commit),
+			Repository: *convertRepository(&v.Repository),
+		})
+	}
+	return to
+}
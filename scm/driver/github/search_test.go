@@ -0,0 +1,150 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestSearchCode(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/search/code").
+		MatchParam("q", `^hello repo:octocat/Hello-World path:docs language:"Visual Basic"$`).
+		MatchParam("per_page", "10").
+		MatchHeader("Accept", "application/vnd.github.text-match\\+json").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/search_code.json")
+
+	q := &scm.SearchQuery{
+		Text:     "hello",
+		Repo:     "octocat/Hello-World",
+		Path:     "docs",
+		Language: "Visual Basic",
+		Author:   "ignored",
+	}
+	client := NewDefault()
+	got, res, err := client.Search.Code(context.Background(), q, &scm.ListOptions{Size: 10})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.SearchCode{}
+	raw, _ := os.ReadFile("testdata/search_code.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+	t.Run("Page", testPage(res))
+}
+
+func TestSearchRepositories(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/search/repositories").
+		MatchParam("q", `^hello org:octocat language:go$`).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/search_repositories.json")
+
+	client := NewDefault()
+	got, _, err := client.Search.Repositories(context.Background(), &scm.SearchQuery{Text: "hello", Org: "octocat", Language: "go"}, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Repository{}
+	raw, _ := os.ReadFile("testdata/search_repositories.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchIssues(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/search/issues").
+		MatchParam("q", `^windows repo:octocat/Hello-World is:issue state:open label:bug label:"help wanted" author:octocat$`).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/issue_search.json")
+
+	q := &scm.SearchQuery{
+		Text:   "windows",
+		Repo:   "octocat/Hello-World",
+		Type:   scm.SearchTypeIssue,
+		State:  "open",
+		Labels: []string{"bug", "help wanted"},
+		Author: "octocat",
+	}
+	client := NewDefault()
+	got, _, err := client.Search.Issues(context.Background(), q, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.SearchIssue{}
+	raw, _ := os.ReadFile("testdata/issue_search.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchCommits(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://api.github.com").
+		Get("/search/commits").
+		MatchParam("q", `^fix repo:octocat/Hello-World author:octocat$`).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/search_commits.json")
+
+	client := NewDefault()
+	got, _, err := client.Search.Commits(context.Background(), &scm.SearchQuery{Text: "fix", Repo: "octocat/Hello-World", Author: "octocat"}, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.SearchCommit{}
+	raw, _ := os.ReadFile("testdata/search_commits.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
@@ -59,9 +59,42 @@ func encodeIssueListOptions(opts scm.IssueListOptions) string {
 	} else if opts.Closed {
 		params.Set("state", "closed")
 	}
+	if len(opts.Labels) > 0 {
+		params.Set("labels", strings.Join(opts.Labels, ","))
+	}
+	if opts.Author != "" {
+		params.Set("creator", opts.Author)
+	}
+	if opts.Assignee != "" {
+		params.Set("assignee", opts.Assignee)
+	}
+	if opts.Milestone != "" {
+		params.Set("milestone", opts.Milestone)
+	}
+	if opts.Since != nil {
+		params.Set("since", opts.Since.Format(scm.SearchTimeFormat))
+	}
+	encodeSortOptions(params, opts.Sort, opts.Ascending)
 	return params.Encode()
 }
 
+// encodeSortOptions sets the sort and direction parameters
+// accepted by the issue and pull request list endpoints.
+func encodeSortOptions(params url.Values, sort string, ascending bool) {
+	if sort == "" && !ascending {
+		return
+	}
+	if sort == "" {
+		sort = scm.SortCreated
+	}
+	params.Set("sort", sort)
+	if ascending {
+		params.Set("direction", "asc")
+	} else {
+		params.Set("direction", "desc")
+	}
+}
+
 func encodeIssueSearchOptions(opts scm.SearchOptions) string {
 	params := url.Values{}
 	if opts.Sort == "" {
@@ -89,6 +122,13 @@ func encodePullRequestListOptions(opts *scm.PullRequestListOptions) string {
 	} else if opts.Closed {
 		params.Set("state", "closed")
 	}
+	if opts.Head != "" {
+		params.Set("head", opts.Head)
+	}
+	if opts.Base != "" {
+		params.Set("base", opts.Base)
+	}
+	encodeSortOptions(params, opts.Sort, opts.Ascending)
 	return params.Encode()
 }
 
@@ -6,6 +6,7 @@ package github
 
 import (
 	"testing"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -63,6 +64,24 @@ func Test_encodeIssueListOptions_Closed(t *testing.T) {
 	}
 }
 
+func Test_encodeIssueListOptions_Filters(t *testing.T) {
+	since := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	opts := scm.IssueListOptions{
+		Labels:    []string{"bug", "help wanted"},
+		Author:    "octocat",
+		Assignee:  "hubot",
+		Milestone: "3",
+		Since:     &since,
+		Sort:      scm.SortUpdated,
+		Ascending: true,
+	}
+	want := "assignee=hubot&creator=octocat&direction=asc&labels=bug%2Chelp+wanted&milestone=3&since=2020-01-02T03%3A04%3A05Z&sort=updated"
+	got := encodeIssueListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded issue list options %q, got %q", want, got)
+	}
+}
+
 func Test_encodePullRequestListOptions(t *testing.T) {
 	t.Parallel()
 	opts := &scm.PullRequestListOptions{
@@ -92,3 +111,17 @@ func Test_encodePullRequestListOptions_Closed(t *testing.T) {
 		t.Errorf("Want encoded pr list options %q, got %q", want, got)
 	}
 }
+
+func Test_encodePullRequestListOptions_Sort(t *testing.T) {
+	t.Parallel()
+	opts := &scm.PullRequestListOptions{
+		Head: "octocat:feature",
+		Base: "master",
+		Sort: scm.SortUpdated,
+	}
+	want := "base=master&direction=desc&head=octocat%3Afeature&sort=updated"
+	got := encodePullRequestListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded pr list options %q, got %q", want, got)
+	}
+}
@@ -25,6 +25,10 @@ type webhookService struct {
 }
 
 func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
+	return s.ParseWithSecrets(req, fn.Secrets())
+}
+
+func (s *webhookService) ParseWithSecrets(req *http.Request, fn scm.SecretsFunc) (scm.Webhook, error) {
 	data, err := io.ReadAll(
 		io.LimitReader(req.Body, 10000000),
 	)
@@ -61,6 +65,8 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 		hook, err = s.parseDeploymentHook(data)
 	case "deployment_status":
 		hook, err = s.parseDeploymentStatusHook(data)
+	case "deployment_protection_rule":
+		hook, err = s.parseDeploymentProtectionRuleHook(data)
 	case "fork":
 		hook, err = s.parseForkHook(data)
 	case "issues":
@@ -73,6 +79,14 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 		hook, err = s.parseInstallationRepositoryHook(data)
 	case "label":
 		hook, err = s.parseLabelHook(data)
+	case "member":
+		hook, err = s.parseMemberHook(data)
+	case "membership":
+		hook, err = s.parseMembershipHook(data)
+	case "merge_group":
+		hook, err = s.parseMergeGroupHook(data)
+	case "organization":
+		hook, err = s.parseOrganizationHook(data)
 	case "ping":
 		hook, err = s.parsePingHook(data, guid)
 	case "push":
@@ -83,14 +97,24 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 		hook, err = s.parsePullRequestReviewHook(data, guid)
 	case "pull_request_review_comment":
 		hook, err = s.parsePullRequestReviewCommentHook(data, guid)
+	case "pull_request_review_thread":
+		hook, err = s.parsePullRequestReviewThreadHook(data)
 	case "release":
 		hook, err = s.parseReleaseHook(data)
 	case "repository":
 		hook, err = s.parseRepositoryHook(data)
+	case "star":
+		hook, err = s.parseStarHook(data)
 	case "status":
 		hook, err = s.parseStatusHook(data)
+	case "team":
+		hook, err = s.parseTeamHook(data)
 	case "watch":
 		hook, err = s.parseWatchHook(data)
+	case "workflow_job":
+		hook, err = s.parseWorkflowJobHook(data)
+	case "workflow_run":
+		hook, err = s.parseWorkflowRunHook(data)
 	default:
 		log.WithField("Event", event).Warnf("unknown webhook")
 		return nil, scm.UnknownWebhook{Event: event}
@@ -102,19 +126,27 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	// get the gogs signature key to verify the payload
 	// signature. If no key is provided, no validation
 	// is performed.
-	key, err := fn(hook)
+	keys, err := fn(hook)
 	if err != nil {
 		return hook, err
-	} else if key == "" {
+	}
+	keys = hmac.Keys(keys)
+	if len(keys) == 0 {
 		return hook, nil
 	}
 
 	if logWebHooks {
-		log.Infof("Webhook HMAC token: %s", key)
+		log.Infof("Webhook HMAC tokens: %v", keys)
 	}
 
-	sig := req.Header.Get("X-Hub-Signature")
-	if !hmac.ValidatePrefix(data, []byte(key), sig) {
+	// prefer the sha256 signature, falling back to the
+	// legacy sha1 signature for older GitHub Enterprise
+	// servers that do not send it.
+	sig := req.Header.Get("X-Hub-Signature-256")
+	if sig == "" {
+		sig = req.Header.Get("X-Hub-Signature")
+	}
+	if !hmac.ValidatePrefixAny(data, keys, sig) {
 		return hook, scm.ErrSignatureInvalid
 	}
 
@@ -213,6 +245,16 @@ func (s *webhookService) parseCheckSuiteHook(data []byte) (scm.Webhook, error) {
 	return to, err
 }
 
+func (s *webhookService) parseMergeGroupHook(data []byte) (scm.Webhook, error) {
+	src := new(mergeGroupHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	to := convertMergeGroupHook(src)
+	return to, err
+}
+
 func (s *webhookService) parseDeploymentStatusHook(data []byte) (scm.Webhook, error) {
 	src := new(deploymentStatusHook)
 	err := json.Unmarshal(data, src)
@@ -320,6 +362,14 @@ func (s *webhookService) parsePullRequestHook(data []byte, guid string) (scm.Web
 		dst.Action = scm.ActionReadyForReview
 	case "converted_to_draft":
 		dst.Action = scm.ActionConvertedToDraft
+	case "auto_merge_enabled":
+		dst.Action = scm.ActionAutoMergeEnabled
+	case "auto_merge_disabled":
+		dst.Action = scm.ActionAutoMergeDisabled
+	case "enqueued":
+		dst.Action = scm.ActionEnqueued
+	case "dequeued":
+		dst.Action = scm.ActionDequeued
 	}
 	return dst, nil
 }
@@ -360,6 +410,96 @@ func (s *webhookService) parseIssueCommentHook(data []byte, guid string) (*scm.I
 	return dst, nil
 }
 
+func (s *webhookService) parseDeploymentProtectionRuleHook(data []byte) (scm.Webhook, error) {
+	src := new(deploymentProtectionRuleHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	return convertDeploymentProtectionRuleHook(src), nil
+}
+
+func (s *webhookService) parseMemberHook(data []byte) (scm.Webhook, error) {
+	src := new(memberHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	return convertMemberHook(src), nil
+}
+
+func (s *webhookService) parseMembershipHook(data []byte) (scm.Webhook, error) {
+	src := new(membershipHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	return convertMembershipHook(src), nil
+}
+
+// parseOrganizationHook returns a member hook for members
+// being added or removed and an organization hook otherwise.
+func (s *webhookService) parseOrganizationHook(data []byte) (scm.Webhook, error) {
+	src := new(organizationHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	switch src.Action {
+	case "member_added", "member_removed":
+		return convertOrganizationMemberHook(src), nil
+	case "renamed", "deleted":
+		return convertOrganizationHook(src), nil
+	default:
+		return nil, scm.UnknownWebhook{Event: "organization." + src.Action}
+	}
+}
+
+func (s *webhookService) parsePullRequestReviewThreadHook(data []byte) (scm.Webhook, error) {
+	src := new(pullRequestReviewThreadHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	return convertPullRequestReviewThreadHook(src), nil
+}
+
+func (s *webhookService) parseStarHook(data []byte) (scm.Webhook, error) {
+	src := new(starHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	return convertStarHook(src), nil
+}
+
+func (s *webhookService) parseTeamHook(data []byte) (scm.Webhook, error) {
+	src := new(teamHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	return convertTeamHook(src), nil
+}
+
+func (s *webhookService) parseWorkflowJobHook(data []byte) (scm.Webhook, error) {
+	src := new(workflowJobHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	return convertWorkflowJobHook(src), nil
+}
+
+func (s *webhookService) parseWorkflowRunHook(data []byte) (scm.Webhook, error) {
+	src := new(workflowRunHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	return convertWorkflowRunHook(src), nil
+}
+
 func (s *webhookService) parseInstallationHook(data []byte) (*scm.InstallationHook, error) {
 	src := new(installationHook)
 	err := json.Unmarshal(data, src)
@@ -396,7 +536,8 @@ type (
 
 	// github ping payload
 	pingHook struct {
-		Repository   repository       `json:"repository"`
+		Repository   *repository      `json:"repository"`
+		Organization *organization    `json:"organization"`
 		Sender       user             `json:"sender"`
 		Installation *installationRef `json:"installation"`
 	}
@@ -427,6 +568,35 @@ type (
 		Installation *installationRef `json:"installation"`
 	}
 
+	// github merge_group payload
+	mergeGroupHook struct {
+		Action     string `json:"action"`
+		Reason     string `json:"reason"`
+		MergeGroup struct {
+			HeadSha    string `json:"head_sha"`
+			HeadRef    string `json:"head_ref"`
+			BaseSha    string `json:"base_sha"`
+			BaseRef    string `json:"base_ref"`
+			HeadCommit struct {
+				ID        string    `json:"id"`
+				TreeID    string    `json:"tree_id"`
+				Message   string    `json:"message"`
+				Timestamp time.Time `json:"timestamp"`
+				Author    struct {
+					Name  string `json:"name"`
+					Email string `json:"email"`
+				} `json:"author"`
+				Committer struct {
+					Name  string `json:"name"`
+					Email string `json:"email"`
+				} `json:"committer"`
+			} `json:"head_commit"`
+		} `json:"merge_group"`
+		Repository   repository       `json:"repository"`
+		Sender       user             `json:"sender"`
+		Installation *installationRef `json:"installation"`
+	}
+
 	// github deployment webhook payload
 	deploymentHook struct {
 		Deployment   deployment       `json:"deployment"`
@@ -698,6 +868,135 @@ type (
 		ID     int64  `json:"id"`
 		NodeID string `json:"node_id"`
 	}
+
+	// github deployment_protection_rule payload
+	deploymentProtectionRuleHook struct {
+		Action                string           `json:"action"`
+		Environment           string           `json:"environment"`
+		Event                 string           `json:"event"`
+		DeploymentCallbackURL string           `json:"deployment_callback_url"`
+		Deployment            *deployment      `json:"deployment"`
+		Repository            repository       `json:"repository"`
+		Sender                user             `json:"sender"`
+		Installation          *installationRef `json:"installation"`
+	}
+
+	// github member payload
+	memberHook struct {
+		Action  string `json:"action"`
+		Member  user   `json:"member"`
+		Changes struct {
+			Permission *struct {
+				To string `json:"to"`
+			} `json:"permission"`
+			RoleName *struct {
+				To string `json:"to"`
+			} `json:"role_name"`
+		} `json:"changes"`
+		Repository   repository       `json:"repository"`
+		Organization *organization    `json:"organization"`
+		Sender       user             `json:"sender"`
+		Installation *installationRef `json:"installation"`
+	}
+
+	// github membership payload
+	membershipHook struct {
+		Action       string           `json:"action"`
+		Scope        string           `json:"scope"`
+		Member       user             `json:"member"`
+		Team         team             `json:"team"`
+		Organization organization     `json:"organization"`
+		Sender       user             `json:"sender"`
+		Installation *installationRef `json:"installation"`
+	}
+
+	// github organization payload
+	organizationHook struct {
+		Action     string `json:"action"`
+		Membership *struct {
+			Role  string `json:"role"`
+			State string `json:"state"`
+			User  user   `json:"user"`
+		} `json:"membership"`
+		Organization organization     `json:"organization"`
+		Sender       user             `json:"sender"`
+		Installation *installationRef `json:"installation"`
+	}
+
+	// github pull_request_review_thread payload
+	pullRequestReviewThreadHook struct {
+		Action string `json:"action"`
+		Thread struct {
+			NodeID   string           `json:"node_id"`
+			Comments []*reviewComment `json:"comments"`
+		} `json:"thread"`
+		PullRequest  pr               `json:"pull_request"`
+		Repository   repository       `json:"repository"`
+		Sender       user             `json:"sender"`
+		Installation *installationRef `json:"installation"`
+	}
+
+	// github star payload
+	starHook struct {
+		Action     string     `json:"action"`
+		StarredAt  time.Time  `json:"starred_at"`
+		Repository repository `json:"repository"`
+		Sender     user       `json:"sender"`
+	}
+
+	// github team payload
+	teamHook struct {
+		Action       string           `json:"action"`
+		Team         team             `json:"team"`
+		Organization organization     `json:"organization"`
+		Repository   *repository      `json:"repository"`
+		Sender       user             `json:"sender"`
+		Installation *installationRef `json:"installation"`
+	}
+
+	// github workflow_job payload
+	workflowJobHook struct {
+		Action      string `json:"action"`
+		WorkflowJob struct {
+			ID          int       `json:"id"`
+			RunID       int       `json:"run_id"`
+			Name        string    `json:"name"`
+			Status      string    `json:"status"`
+			Conclusion  string    `json:"conclusion"`
+			HeadBranch  string    `json:"head_branch"`
+			HeadSha     string    `json:"head_sha"`
+			HTMLURL     string    `json:"html_url"`
+			RunnerName  string    `json:"runner_name"`
+			CreatedAt   time.Time `json:"created_at"`
+			StartedAt   time.Time `json:"started_at"`
+			CompletedAt time.Time `json:"completed_at"`
+		} `json:"workflow_job"`
+		Repository   repository       `json:"repository"`
+		Sender       user             `json:"sender"`
+		Installation *installationRef `json:"installation"`
+	}
+
+	// github workflow_run payload
+	workflowRunHook struct {
+		Action      string `json:"action"`
+		WorkflowRun struct {
+			ID         int       `json:"id"`
+			Name       string    `json:"name"`
+			RunNumber  int       `json:"run_number"`
+			Event      string    `json:"event"`
+			Status     string    `json:"status"`
+			Conclusion string    `json:"conclusion"`
+			HeadBranch string    `json:"head_branch"`
+			HeadSha    string    `json:"head_sha"`
+			HTMLURL    string    `json:"html_url"`
+			Actor      user      `json:"actor"`
+			CreatedAt  time.Time `json:"created_at"`
+			UpdatedAt  time.Time `json:"updated_at"`
+		} `json:"workflow_run"`
+		Repository   repository       `json:"repository"`
+		Sender       user             `json:"sender"`
+		Installation *installationRef `json:"installation"`
+	}
 )
 
 //
@@ -784,12 +1083,19 @@ func convertInstallationRef(dst *installationRef) *scm.InstallationRef {
 	}
 }
 
-func convertPingHook(dst *pingHook) *scm.PingHook {
-	return &scm.PingHook{
-		Repo:         *convertRepository(&dst.Repository),
-		Sender:       *convertUser(&dst.Sender),
-		Installation: convertInstallationRef(dst.Installation),
+func convertPingHook(src *pingHook) *scm.PingHook {
+	dst := &scm.PingHook{
+		Sender:       *convertUser(&src.Sender),
+		Installation: convertInstallationRef(src.Installation),
+	}
+	// organization webhooks are pinged without a repository.
+	if src.Repository != nil {
+		dst.Repo = *convertRepository(src.Repository)
+	}
+	if src.Organization != nil {
+		dst.Org = *convertOrganization(src.Organization)
 	}
+	return dst
 }
 
 func convertWatchHook(dst *watchHook) *scm.WatchHook {
@@ -821,6 +1127,40 @@ func convertCheckSuiteHook(dst *checkSuiteHook) *scm.CheckSuiteHook {
 	}
 }
 
+func convertMergeGroupHook(src *mergeGroupHook) *scm.MergeGroupHook {
+	commit := src.MergeGroup.HeadCommit
+	return &scm.MergeGroupHook{
+		Action: convertAction(src.Action),
+		Reason: src.Reason,
+		MergeGroup: scm.MergeGroup{
+			HeadSha: src.MergeGroup.HeadSha,
+			HeadRef: src.MergeGroup.HeadRef,
+			BaseSha: src.MergeGroup.BaseSha,
+			BaseRef: src.MergeGroup.BaseRef,
+			HeadCommit: scm.Commit{
+				Sha:     commit.ID,
+				Message: commit.Message,
+				Tree: scm.CommitTree{
+					Sha: commit.TreeID,
+				},
+				Author: scm.Signature{
+					Name:  commit.Author.Name,
+					Email: commit.Author.Email,
+					Date:  commit.Timestamp,
+				},
+				Committer: scm.Signature{
+					Name:  commit.Committer.Name,
+					Email: commit.Committer.Email,
+					Date:  commit.Timestamp,
+				},
+			},
+		},
+		Repo:         *convertRepository(&src.Repository),
+		Sender:       *convertUser(&src.Sender),
+		Installation: convertInstallationRef(src.Installation),
+	}
+}
+
 func convertDeploymentHook(src *deploymentHook) *scm.DeployHook {
 	dst := &scm.DeployHook{
 		Deployment: *convertDeployment(&src.Deployment, src.Repository.FullName),
@@ -855,6 +1195,242 @@ func convertDeploymentStatusHook(dst *deploymentStatusHook) *scm.DeploymentStatu
 	}
 }
 
+func convertDeploymentProtectionRuleHook(src *deploymentProtectionRuleHook) *scm.DeployHook {
+	dst := &scm.DeployHook{
+		Action:       scm.ActionRequested,
+		Repo:         *convertRepository(&src.Repository),
+		Sender:       *convertUser(&src.Sender),
+		Installation: convertInstallationRef(src.Installation),
+		CallbackURL:  src.DeploymentCallbackURL,
+	}
+	if src.Deployment != nil {
+		dst.Deployment = *convertDeployment(src.Deployment, src.Repository.FullName)
+		dst.Ref = scm.Reference{
+			Name: src.Deployment.Ref,
+			Sha:  src.Deployment.Sha,
+		}
+	}
+	if dst.Deployment.Environment == "" {
+		dst.Deployment.Environment = src.Environment
+	}
+	return dst
+}
+
+func convertMemberHook(src *memberHook) *scm.MemberHook {
+	dst := &scm.MemberHook{
+		Action:       convertMemberAction(src.Action),
+		Member:       *convertUser(&src.Member),
+		Repo:         *convertRepository(&src.Repository),
+		Sender:       *convertUser(&src.Sender),
+		Installation: convertInstallationRef(src.Installation),
+	}
+	if c := src.Changes.Permission; c != nil {
+		dst.Role = c.To
+	}
+	if c := src.Changes.RoleName; c != nil {
+		dst.Role = c.To
+	}
+	if src.Organization != nil {
+		dst.Org = *convertOrganization(src.Organization)
+	}
+	return dst
+}
+
+func convertMembershipHook(src *membershipHook) *scm.MemberHook {
+	return &scm.MemberHook{
+		Action:       convertMemberAction(src.Action),
+		Member:       *convertUser(&src.Member),
+		Org:          *convertOrganization(&src.Organization),
+		Team:         convertTeam(&src.Team),
+		Sender:       *convertUser(&src.Sender),
+		Installation: convertInstallationRef(src.Installation),
+	}
+}
+
+func convertOrganizationMemberHook(src *organizationHook) *scm.MemberHook {
+	dst := &scm.MemberHook{
+		Action:       convertMemberAction(src.Action),
+		Org:          *convertOrganization(&src.Organization),
+		Sender:       *convertUser(&src.Sender),
+		Installation: convertInstallationRef(src.Installation),
+	}
+	if src.Membership != nil {
+		dst.Member = *convertUser(&src.Membership.User)
+		dst.Role = src.Membership.Role
+	}
+	return dst
+}
+
+func convertOrganizationHook(src *organizationHook) *scm.OrganizationHook {
+	action := scm.ActionUpdate
+	if src.Action == "deleted" {
+		action = scm.ActionDelete
+	}
+	return &scm.OrganizationHook{
+		Action:       action,
+		Org:          *convertOrganization(&src.Organization),
+		Sender:       *convertUser(&src.Sender),
+		Installation: convertInstallationRef(src.Installation),
+	}
+}
+
+func convertMemberAction(src string) scm.Action {
+	switch src {
+	case "added", "member_added":
+		return scm.ActionCreate
+	case "removed", "member_removed":
+		return scm.ActionDelete
+	default:
+		return convertAction(src)
+	}
+}
+
+func convertPullRequestReviewThreadHook(src *pullRequestReviewThreadHook) *scm.ReviewThreadHook {
+	thread := scm.ReviewThread{
+		ID:       src.Thread.NodeID,
+		Resolved: src.Action == "resolved",
+	}
+	for _, c := range src.Thread.Comments {
+		thread.Comments = append(thread.Comments, convertReviewComment(c))
+	}
+	if len(thread.Comments) > 0 {
+		first := thread.Comments[0]
+		thread.Path = first.Path
+		thread.Line = first.Line
+		thread.StartLine = first.StartLine
+		thread.Side = first.Side
+	}
+	action := scm.ActionUnresolved
+	if thread.Resolved {
+		action = scm.ActionResolved
+	}
+	return &scm.ReviewThreadHook{
+		Action:       action,
+		Repo:         *convertRepository(&src.Repository),
+		PullRequest:  *convertPullRequest(&src.PullRequest),
+		Thread:       thread,
+		Sender:       *convertUser(&src.Sender),
+		Installation: convertInstallationRef(src.Installation),
+	}
+}
+
+func convertStarHook(src *starHook) *scm.StarHook {
+	return &scm.StarHook{
+		Action:    convertAction(src.Action),
+		StarredAt: src.StarredAt,
+		Repo:      *convertRepository(&src.Repository),
+		Sender:    *convertUser(&src.Sender),
+	}
+}
+
+func convertTeamHook(src *teamHook) *scm.TeamHook {
+	var action scm.Action
+	switch src.Action {
+	case "added_to_repository":
+		action = scm.ActionCreate
+	case "removed_from_repository":
+		action = scm.ActionDelete
+	default:
+		action = convertAction(src.Action)
+	}
+	dst := &scm.TeamHook{
+		Action:       action,
+		Team:         *convertTeam(&src.Team),
+		Org:          *convertOrganization(&src.Organization),
+		Sender:       *convertUser(&src.Sender),
+		Installation: convertInstallationRef(src.Installation),
+	}
+	if src.Repository != nil {
+		dst.Repo = *convertRepository(src.Repository)
+	}
+	return dst
+}
+
+func convertWorkflowJobHook(src *workflowJobHook) *scm.JobHook {
+	job := src.WorkflowJob
+	return &scm.JobHook{
+		Action: convertWorkflowAction(src.Action),
+		Job: scm.Job{
+			ID:         job.ID,
+			PipelineID: job.RunID,
+			Name:       job.Name,
+			Status:     convertWorkflowState(job.Status, job.Conclusion),
+			Ref:        job.HeadBranch,
+			Sha:        job.HeadSha,
+			Link:       job.HTMLURL,
+			Runner:     job.RunnerName,
+			Created:    job.CreatedAt,
+			Started:    job.StartedAt,
+			Finished:   job.CompletedAt,
+		},
+		Repo:         *convertRepository(&src.Repository),
+		Sender:       *convertUser(&src.Sender),
+		Installation: convertInstallationRef(src.Installation),
+	}
+}
+
+func convertWorkflowRunHook(src *workflowRunHook) *scm.PipelineHook {
+	run := src.WorkflowRun
+	dst := &scm.PipelineHook{
+		Action: convertWorkflowAction(src.Action),
+		Pipeline: scm.Pipeline{
+			ID:      run.ID,
+			Number:  run.RunNumber,
+			Name:    run.Name,
+			Status:  convertWorkflowState(run.Status, run.Conclusion),
+			Ref:     run.HeadBranch,
+			Sha:     run.HeadSha,
+			Source:  run.Event,
+			Link:    run.HTMLURL,
+			Author:  *convertUser(&run.Actor),
+			Created: run.CreatedAt,
+		},
+		Repo:         *convertRepository(&src.Repository),
+		Sender:       *convertUser(&src.Sender),
+		Installation: convertInstallationRef(src.Installation),
+	}
+	if run.Status == "completed" {
+		dst.Pipeline.Finished = run.UpdatedAt
+	}
+	return dst
+}
+
+// convertWorkflowAction maps the workflow run and job actions
+// to the actions used for pipeline and job hooks on GitLab.
+func convertWorkflowAction(src string) scm.Action {
+	switch src {
+	case "requested", "queued":
+		return scm.ActionCreate
+	case "completed":
+		return scm.ActionCompleted
+	default:
+		return scm.ActionUpdate
+	}
+}
+
+// convertWorkflowState returns the state of a workflow run or
+// job from its status and, once completed, its conclusion.
+func convertWorkflowState(status, conclusion string) scm.State {
+	switch status {
+	case "completed":
+		// handled below
+	case "in_progress":
+		return scm.StateRunning
+	default:
+		return scm.StatePending
+	}
+	switch conclusion {
+	case "success", "neutral", "skipped":
+		return scm.StateSuccess
+	case "cancelled":
+		return scm.StateCanceled
+	case "action_required", "stale":
+		return scm.StateError
+	default:
+		return scm.StateFailure
+	}
+}
+
 func convertForkHook(dst *forkHook) *scm.ForkHook {
 	return &scm.ForkHook{
 		Repo:         *convertRepository(&dst.Repository),
@@ -1156,6 +1732,10 @@ func convertAction(src string) (action scm.Action) {
 		return scm.ActionSync
 	case "complete", "completed":
 		return scm.ActionCompleted
+	case "checks_requested":
+		return scm.ActionChecksRequested
+	case "destroyed":
+		return scm.ActionDestroyed
 	default:
 		return
 	}
@@ -0,0 +1,502 @@
+package github
+
+import (
+	"crypto/sha1" // #nosec
+	"crypto/sha256"
+	"net/http"
+	"strconv"
+
+	"github.com/jenkins-x/go-scm/pkg/hmac"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/hookenc"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/null"
+)
+
+// Encode returns the GitHub webhook request for the hook,
+// signed with both the sha256 and legacy sha1 signatures.
+func (s *webhookService) Encode(hook scm.Webhook, secret string) (*http.Request, error) {
+	var (
+		event   string
+		guid    string
+		payload interface{}
+	)
+	switch v := hook.(type) {
+	case *scm.PushHook:
+		event, guid, payload = "push", v.GUID, encodePushHook(v)
+	case *scm.BranchHook:
+		event, payload = encodeCreateDeleteEvent(v.Action), encodeBranchHook(v)
+	case *scm.TagHook:
+		event, payload = encodeCreateDeleteEvent(v.Action), encodeTagHook(v)
+	case *scm.PullRequestHook:
+		event, guid, payload = "pull_request", v.GUID, encodePullRequestHook(v)
+	case *scm.PullRequestCommentHook:
+		event, guid, payload = "pull_request_review_comment", v.GUID, encodePullRequestReviewCommentHook(v)
+	case *scm.ReviewHook:
+		event, guid, payload = "pull_request_review", v.GUID, encodePullRequestReviewHook(v)
+	case *scm.IssueHook:
+		event, payload = "issues", encodeIssueHook(v)
+	case *scm.IssueCommentHook:
+		event, guid, payload = "issue_comment", v.GUID, encodeIssueCommentHook(v)
+	case *scm.ReleaseHook:
+		event, payload = "release", encodeReleaseHook(v)
+	case *scm.StatusHook:
+		event, payload = "status", encodeStatusHook(v)
+	default:
+		return nil, scm.ErrNotSupported
+	}
+
+	req, data, err := hookenc.NewRequest(payload)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", hookenc.DeliveryID(guid))
+	if secret != "" {
+		req.Header.Set("X-Hub-Signature-256", "sha256="+hmac.Sign(sha256.New, data, []byte(secret)))
+		req.Header.Set("X-Hub-Signature", "sha1="+hmac.Sign(sha1.New, data, []byte(secret)))
+	}
+	return req, nil
+}
+
+func encodeCreateDeleteEvent(action scm.Action) string {
+	if action == scm.ActionDelete {
+		return "delete"
+	}
+	return "create"
+}
+
+func encodePushHook(src *scm.PushHook) *pushHook {
+	dst := &pushHook{
+		Ref:     src.Ref,
+		BaseRef: src.BaseRef,
+		Before:  src.Before,
+		After:   src.After,
+		Compare: src.Compare,
+		Created: src.Created,
+		Deleted: src.Deleted,
+		Forced:  src.Forced,
+		Commits: encodePushCommits(&src.Repo, src.Commits),
+		Sender:  *encodeUser(&src.Sender),
+
+		Installation: encodeInstallationRef(src.Installation),
+	}
+	dst.Head.ID = src.Commit.Sha
+	dst.Head.Message = src.Commit.Message
+	dst.Head.URL = src.Commit.Link
+	dst.Head.Author.Name = src.Commit.Author.Name
+	dst.Head.Author.Email = src.Commit.Author.Email
+	dst.Head.Author.Username = src.Commit.Author.Login
+	dst.Head.Committer.Name = src.Commit.Committer.Name
+	dst.Head.Committer.Email = src.Commit.Committer.Email
+	dst.Head.Committer.Username = src.Commit.Committer.Login
+
+	repo := encodeRepository(&src.Repo)
+	dst.Repository.ID = int64(repo.ID)
+	dst.Repository.Owner.Login = repo.Owner.Login
+	dst.Repository.Name = repo.Name
+	dst.Repository.FullName = repo.FullName
+	dst.Repository.Private = repo.Private
+	dst.Repository.HTMLURL = repo.HTMLURL
+	dst.Repository.SSHURL = repo.SSHURL
+	dst.Repository.CloneURL = repo.CloneURL
+	dst.Repository.DefaultBranch = repo.DefaultBranch
+	return dst
+}
+
+// encodePushCommits links the commits to their page on the
+// repository, if the repository link is known.
+func encodePushCommits(repo *scm.Repository, src []scm.PushCommit) []pushCommit {
+	var dst []pushCommit
+	for _, v := range src {
+		c := pushCommit{
+			ID:       v.ID,
+			Message:  v.Message,
+			Added:    v.Added,
+			Removed:  v.Removed,
+			Modified: v.Modified,
+		}
+		if repo.Link != "" {
+			c.URL = repo.Link + "/commit/" + v.ID
+		}
+		dst = append(dst, c)
+	}
+	return dst
+}
+
+func encodeBranchHook(src *scm.BranchHook) *createDeleteHook {
+	return &createDeleteHook{
+		Ref:          src.Ref.Name,
+		RefType:      "branch",
+		Repository:   *encodeRepository(&src.Repo),
+		Sender:       *encodeUser(&src.Sender),
+		Installation: encodeInstallationRef(src.Installation),
+	}
+}
+
+func encodeTagHook(src *scm.TagHook) *createDeleteHook {
+	return &createDeleteHook{
+		Ref:          src.Ref.Name,
+		RefType:      "tag",
+		Repository:   *encodeRepository(&src.Repo),
+		Sender:       *encodeUser(&src.Sender),
+		Installation: encodeInstallationRef(src.Installation),
+	}
+}
+
+func encodePullRequestHook(src *scm.PullRequestHook) *pullRequestHook {
+	dst := &pullRequestHook{
+		Action:       encodePullRequestAction(src.Action),
+		Number:       src.PullRequest.Number,
+		PullRequest:  *encodePullRequest(&src.PullRequest),
+		Repository:   *encodeRepository(&src.Repo),
+		Label:        encodeLabel(&src.Label),
+		Sender:       *encodeUser(&src.Sender),
+		Installation: encodeInstallationRef(src.Installation),
+	}
+	dst.Changes.Base.Ref.From = src.Changes.Base.Ref.From
+	dst.Changes.Base.Sha.From = src.Changes.Base.Sha.From
+	return dst
+}
+
+func encodePullRequestAction(src scm.Action) string {
+	switch src {
+	case scm.ActionAssigned:
+		return "assigned"
+	case scm.ActionUnassigned:
+		return "unassigned"
+	case scm.ActionReviewRequested:
+		return "review_requested"
+	case scm.ActionReviewRequestRemoved:
+		return "review_request_removed"
+	case scm.ActionLabel:
+		return "labeled"
+	case scm.ActionUnlabel:
+		return "unlabeled"
+	case scm.ActionOpen:
+		return "opened"
+	case scm.ActionUpdate:
+		return "edited"
+	case scm.ActionClose, scm.ActionMerge:
+		return "closed"
+	case scm.ActionReopen:
+		return "reopened"
+	case scm.ActionSync:
+		return "synchronize"
+	case scm.ActionReadyForReview:
+		return "ready_for_review"
+	case scm.ActionConvertedToDraft:
+		return "converted_to_draft"
+	case scm.ActionAutoMergeEnabled:
+		return "auto_merge_enabled"
+	case scm.ActionAutoMergeDisabled:
+		return "auto_merge_disabled"
+	case scm.ActionEnqueued:
+		return "enqueued"
+	case scm.ActionDequeued:
+		return "dequeued"
+	default:
+		return src.String()
+	}
+}
+
+func encodePullRequestReviewCommentHook(src *scm.PullRequestCommentHook) *pullRequestReviewCommentHook {
+	return &pullRequestReviewCommentHook{
+		Action:      "created",
+		PullRequest: *encodePullRequest(&src.PullRequest),
+		Repository:  *encodeRepository(&src.Repo),
+		Comment: reviewCommentFromHook{
+			ID:        src.Comment.ID,
+			User:      *encodeUser(&src.Comment.Author),
+			Body:      src.Comment.Body,
+			HTMLURL:   src.Comment.Link,
+			CreatedAt: src.Comment.Created,
+			UpdatedAt: src.Comment.Updated,
+		},
+		Installation: encodeInstallationRef(src.Installation),
+	}
+}
+
+func encodePullRequestReviewHook(src *scm.ReviewHook) *pullRequestReviewHook {
+	dst := &pullRequestReviewHook{
+		Action:       encodeReviewAction(src.Action),
+		PullRequest:  *encodePullRequest(&src.PullRequest),
+		Repository:   *encodeRepository(&src.Repo),
+		Installation: encodeInstallationRef(src.Installation),
+		Review: review{
+			ID:          src.Review.ID,
+			Body:        src.Review.Body,
+			SubmittedAt: src.Review.Created,
+			CommitID:    src.Review.Sha,
+			State:       src.Review.State,
+			HTMLURL:     src.Review.Link,
+		},
+	}
+	dst.Review.User.Login = src.Review.Author.Login
+	dst.Review.User.AvatarURL = src.Review.Author.Avatar
+	return dst
+}
+
+// encodeReviewAction is the inverse of convertReviewAction.
+func encodeReviewAction(src scm.Action) string {
+	switch src {
+	case scm.ActionSubmitted:
+		return "submitted"
+	case scm.ActionEdited:
+		return "edited"
+	case scm.ActionDismissed:
+		return "dismissed"
+	default:
+		return src.String()
+	}
+}
+
+func encodeReleaseHook(src *scm.ReleaseHook) *releaseHook {
+	return &releaseHook{
+		Action:     encodeAction(src.Action),
+		Repository: *encodeRepository(&src.Repo),
+		Release: release{
+			ID:          src.Release.ID,
+			Title:       src.Release.Title,
+			Description: src.Release.Description,
+			Link:        src.Release.Link,
+			Tag:         src.Release.Tag,
+			Commitish:   src.Release.Commitish,
+			Draft:       src.Release.Draft,
+			Prerelease:  src.Release.Prerelease,
+			Created:     src.Release.Created,
+			Published:   src.Release.Published,
+		},
+		Sender:       *encodeUser(&src.Sender),
+		Label:        encodeLabel(&src.Label),
+		Installation: encodeInstallationRef(src.Installation),
+	}
+}
+
+func encodeStatusHook(src *scm.StatusHook) *statusHook {
+	return &statusHook{
+		Repository:   *encodeRepository(&src.Repo),
+		Sender:       *encodeUser(&src.Sender),
+		Label:        encodeLabel(&src.Label),
+		Installation: encodeInstallationRef(src.Installation),
+	}
+}
+
+func encodeIssueHook(src *scm.IssueHook) *issueHook {
+	return &issueHook{
+		Action:       encodeAction(src.Action),
+		Issue:        *encodeIssue(&src.Issue),
+		Repository:   *encodeRepository(&src.Repo),
+		Sender:       *encodeUser(&src.Sender),
+		Installation: encodeInstallationRef(src.Installation),
+	}
+}
+
+func encodeIssueCommentHook(src *scm.IssueCommentHook) *issueCommentHook {
+	dst := &issueCommentHook{
+		Action:       encodeAction(src.Action),
+		Issue:        *encodeIssue(&src.Issue),
+		Repository:   *encodeRepository(&src.Repo),
+		Sender:       *encodeUser(&src.Sender),
+		Installation: encodeInstallationRef(src.Installation),
+	}
+	dst.Comment.ID = src.Comment.ID
+	dst.Comment.HTMLURL = src.Comment.Link
+	dst.Comment.User.Login = src.Comment.Author.Login
+	dst.Comment.User.AvatarURL = src.Comment.Author.Avatar
+	dst.Comment.Body = src.Comment.Body
+	dst.Comment.CreatedAt = src.Comment.Created
+	dst.Comment.UpdatedAt = src.Comment.Updated
+	return dst
+}
+
+// encodeAction is the inverse of convertAction.
+func encodeAction(src scm.Action) string {
+	switch src {
+	case scm.ActionCreate:
+		return "created"
+	case scm.ActionDelete:
+		return "deleted"
+	case scm.ActionUpdate:
+		return "edited"
+	case scm.ActionOpen:
+		return "opened"
+	case scm.ActionReopen:
+		return "reopened"
+	case scm.ActionClose:
+		return "closed"
+	case scm.ActionLabel:
+		return "labeled"
+	case scm.ActionUnlabel:
+		return "unlabeled"
+	case scm.ActionMerge:
+		return "merged"
+	case scm.ActionSync:
+		return "synchronize"
+	default:
+		return src.String()
+	}
+}
+
+func encodeRepository(src *scm.Repository) *repository {
+	id, _ := strconv.Atoi(src.ID)
+	dst := &repository{
+		ID:            id,
+		Name:          src.Name,
+		FullName:      src.FullName,
+		Private:       src.Private,
+		Archived:      src.Archived,
+		HTMLURL:       src.Link,
+		SSHURL:        src.CloneSSH,
+		CloneURL:      src.Clone,
+		DefaultBranch: src.Branch,
+		CreatedAt:     src.Created,
+		UpdatedAt:     src.Updated,
+	}
+	dst.Owner.Login = src.Namespace
+	if src.Perm != nil {
+		dst.Permissions.Admin = src.Perm.Admin
+		dst.Permissions.Push = src.Perm.Push
+		dst.Permissions.Pull = src.Perm.Pull
+	}
+	return dst
+}
+
+func encodeUser(src *scm.User) *user {
+	return &user{
+		ID:      src.ID,
+		Login:   src.Login,
+		Name:    src.Name,
+		Email:   null.StringFrom(src.Email),
+		Avatar:  src.Avatar,
+		HTMLURL: src.Link,
+		Created: src.Created,
+		Updated: src.Updated,
+	}
+}
+
+func encodeUsers(src []scm.User) []user {
+	var dst []user
+	for k := range src {
+		dst = append(dst, *encodeUser(&src[k]))
+	}
+	return dst
+}
+
+func encodeLabel(src *scm.Label) label {
+	return label{
+		URL:         src.URL,
+		Name:        src.Name,
+		Description: src.Description,
+		Color:       src.Color,
+	}
+}
+
+func encodePullRequest(src *scm.PullRequest) *pr {
+	dst := &pr{
+		Number:             src.Number,
+		State:              src.State,
+		Title:              src.Title,
+		Body:               src.Body,
+		DiffURL:            src.DiffLink,
+		HTMLURL:            src.Link,
+		User:               *encodeUser(&src.Author),
+		RequestedReviewers: encodeUsers(src.Reviewers),
+		Assignees:          encodeUsers(src.Assignees),
+		Head:               *encodePullRequestBranch(&src.Head),
+		Base:               *encodePullRequestBranch(&src.Base),
+		Draft:              src.Draft,
+		Merged:             src.Merged,
+		Mergeable:          src.Mergeable,
+		MergeableState:     src.MergeableState.String(),
+		Rebaseable:         src.Rebaseable,
+		MergeSha:           src.MergeSha,
+		CreatedAt:          src.Created,
+		UpdatedAt:          src.Updated,
+	}
+	if dst.State == "" {
+		dst.State = "open"
+		if src.Closed {
+			dst.State = "closed"
+		}
+	}
+	for _, l := range src.Labels {
+		v := encodeLabel(l)
+		dst.Labels = append(dst.Labels, &v)
+	}
+	// fall back to the flattened fields for hooks that
+	// were not produced by the parser.
+	if dst.Head.Ref == "" {
+		dst.Head.Ref = src.Source
+	}
+	if dst.Head.Sha == "" {
+		dst.Head.Sha = src.Sha
+	}
+	if dst.Base.Ref == "" {
+		dst.Base.Ref = src.Target
+	}
+	if dst.Head.Repo.FullName == "" {
+		dst.Head.Repo.FullName = src.Fork
+	}
+	return dst
+}
+
+func encodePullRequestBranch(src *scm.PullRequestBranch) *prBranch {
+	return &prBranch{
+		Ref:  src.Ref,
+		Sha:  src.Sha,
+		Repo: *encodeRepository(&src.Repo),
+	}
+}
+
+func encodeIssue(src *scm.Issue) *issue {
+	dst := &issue{
+		HTMLURL:   src.Link,
+		Number:    src.Number,
+		State:     src.State,
+		Title:     src.Title,
+		Body:      src.Body,
+		Assignees: encodeUsers(src.Assignees),
+		Locked:    src.Locked,
+		CreatedAt: src.Created,
+		UpdatedAt: src.Updated,
+	}
+	if dst.State == "" {
+		dst.State = "open"
+		if src.Closed {
+			dst.State = "closed"
+		}
+	}
+	dst.User.Login = src.Author.Login
+	dst.User.AvatarURL = src.Author.Avatar
+	if src.ClosedBy != nil {
+		dst.ClosedBy = &struct {
+			Login     string `json:"login"`
+			AvatarURL string `json:"avatar_url"`
+		}{
+			Login:     src.ClosedBy.Login,
+			AvatarURL: src.ClosedBy.Avatar,
+		}
+	}
+	for _, name := range src.Labels {
+		dst.Labels = append(dst.Labels, struct {
+			Name string `json:"name"`
+		}{Name: name})
+	}
+	if src.PullRequest != nil {
+		dst.PullRequest = &pr{
+			DiffURL: src.PullRequest.DiffLink,
+			HTMLURL: src.PullRequest.Link,
+		}
+	}
+	return dst
+}
+
+func encodeInstallationRef(src *scm.InstallationRef) *installationRef {
+	if src == nil {
+		return nil
+	}
+	return &installationRef{
+		ID:     src.ID,
+		NodeID: src.NodeID,
+	}
+}
@@ -0,0 +1,137 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+func TestWebhookEncode(t *testing.T) {
+	tests := []struct {
+		event string
+		file  string
+	}{
+		{event: "push", file: "testdata/webhooks/push.json"},
+		{event: "push", file: "testdata/webhooks/push_tag.json"},
+		{event: "push", file: "testdata/webhooks/push_branch_delete.json"},
+		{event: "create", file: "testdata/webhooks/branch_create.json"},
+		{event: "delete", file: "testdata/webhooks/branch_delete.json"},
+		{event: "create", file: "testdata/webhooks/tag_create.json"},
+		{event: "delete", file: "testdata/webhooks/tag_delete.json"},
+		{event: "pull_request", file: "testdata/webhooks/pr_opened.json"},
+		{event: "pull_request", file: "testdata/webhooks/pr_sync.json"},
+		{event: "pull_request", file: "testdata/webhooks/pr_closed.json"},
+		{event: "pull_request", file: "testdata/webhooks/pr_edited.json"},
+		{event: "pull_request", file: "testdata/webhooks/pr_labeled.json"},
+		{event: "pull_request", file: "testdata/webhooks/pr_ready_for_review.json"},
+		{event: "pull_request_review_comment", file: "testdata/webhooks/pr_comment.json"},
+		{event: "pull_request_review", file: "testdata/webhooks/pr_review_submitted.json"},
+		{event: "issue_comment", file: "testdata/webhooks/issue_comment.json"},
+		{event: "release", file: "testdata/webhooks/release.json"},
+		{event: "status", file: "testdata/webhooks/status.json"},
+	}
+
+	s := new(webhookService)
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			data, err := os.ReadFile(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, _ := http.NewRequest("POST", "/", bytes.NewReader(data))
+			r.Header.Set("X-GitHub-Event", test.event)
+			r.Header.Set("X-GitHub-Delivery", "f2467dea-70d6-11e8-8955-3c83993e0aef")
+			want, err := s.Parse(r, noSecretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req, err := s.Encode(want, "topsecret")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := req.Header.Get("X-GitHub-Event"); got != test.event {
+				t.Errorf("Want event %s, got %s", test.event, got)
+			}
+
+			got, err := s.Parse(req, secretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Encoded webhook does not round trip")
+				t.Log(diff)
+			}
+		})
+	}
+}
+
+func TestWebhookEncode_IssueHook(t *testing.T) {
+	want := &scm.IssueHook{
+		Action: scm.ActionOpen,
+		Issue: scm.Issue{
+			Number: 1,
+			Title:  "Spelling error in the README file",
+			State:  "open",
+			Labels: []string{"bug"},
+			Author: scm.User{Login: "octocat"},
+		},
+		Repo:   scm.Repository{ID: "1296269", Namespace: "octocat", Name: "hello-world", FullName: "octocat/hello-world", Perm: &scm.Perm{}},
+		Sender: scm.User{Login: "octocat"},
+	}
+
+	s := new(webhookService)
+	req, err := s.Encode(want, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Header.Get("X-Hub-Signature-256") != "" {
+		t.Errorf("Want unsigned webhook without a secret")
+	}
+	got, err := s.Parse(req, noSecretFunc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Encoded webhook does not round trip")
+		t.Log(diff)
+	}
+}
+
+func TestWebhookEncode_PushCommitURL(t *testing.T) {
+	hook := &scm.PushHook{
+		Ref:     "refs/heads/main",
+		Repo:    scm.Repository{Namespace: "octocat", Name: "hello-world", Link: "https://github.com/octocat/hello-world"},
+		Commits: []scm.PushCommit{{ID: "6dcb09b5b57875f334f61aebed695e2e4193db5e"}},
+	}
+
+	s := new(webhookService)
+	req, err := s.Encode(hook, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := new(pushHook)
+	if err := json.NewDecoder(req.Body).Decode(out); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.Commits[0].URL, "https://github.com/octocat/hello-world/commit/6dcb09b5b57875f334f61aebed695e2e4193db5e"; got != want {
+		t.Errorf("Want commit url %s, got %s", want, got)
+	}
+}
+
+func TestWebhookEncode_NotSupported(t *testing.T) {
+	s := new(webhookService)
+	_, err := s.Encode(&scm.StarHook{}, "topsecret")
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect not supported error, got %v", err)
+	}
+}
+
+func noSecretFunc(scm.Webhook) (string, error) {
+	return "", nil
+}
@@ -110,6 +110,13 @@ func TestWebhooks(t *testing.T) {
 			after:  "testdata/webhooks/ping.json.golden",
 			obj:    new(scm.PingHook),
 		},
+		{
+			name:   "ping_org",
+			event:  "ping",
+			before: "testdata/webhooks/ping_org.json",
+			after:  "testdata/webhooks/ping_org.json.golden",
+			obj:    new(scm.PingHook),
+		},
 
 		// push hooks
 		{
@@ -238,6 +245,30 @@ func TestWebhooks(t *testing.T) {
 			after:  "testdata/webhooks/pr_ready_for_review.json.golden",
 			obj:    new(scm.PullRequestHook),
 		},
+		// pull request added to the merge queue
+		{
+			name:   "pr_enqueued",
+			event:  "pull_request",
+			before: "testdata/webhooks/pr_enqueued.json",
+			after:  "testdata/webhooks/pr_enqueued.json.golden",
+			obj:    new(scm.PullRequestHook),
+		},
+		// merge group checks requested
+		{
+			name:   "merge_group_checks_requested",
+			event:  "merge_group",
+			before: "testdata/webhooks/merge_group_checks_requested.json",
+			after:  "testdata/webhooks/merge_group_checks_requested.json.golden",
+			obj:    new(scm.MergeGroupHook),
+		},
+		// merge group destroyed
+		{
+			name:   "merge_group_destroyed",
+			event:  "merge_group",
+			before: "testdata/webhooks/merge_group_destroyed.json",
+			after:  "testdata/webhooks/merge_group_destroyed.json.golden",
+			obj:    new(scm.MergeGroupHook),
+		},
 		// pull request converted to draft
 		{
 			name:   "pr_converted_to_draft",
@@ -319,6 +350,78 @@ func TestWebhooks(t *testing.T) {
 			after:  "testdata/webhooks/installation_delete.json.golden",
 			obj:    new(scm.InstallationHook),
 		},
+		// workflow run completed
+		{
+			name:   "workflow_run",
+			event:  "workflow_run",
+			before: "testdata/webhooks/workflow_run.json",
+			after:  "testdata/webhooks/workflow_run.json.golden",
+			obj:    new(scm.PipelineHook),
+		},
+		// workflow job in progress
+		{
+			name:   "workflow_job",
+			event:  "workflow_job",
+			before: "testdata/webhooks/workflow_job.json",
+			after:  "testdata/webhooks/workflow_job.json.golden",
+			obj:    new(scm.JobHook),
+		},
+		// collaborator added to repository
+		{
+			name:   "member",
+			event:  "member",
+			before: "testdata/webhooks/member.json",
+			after:  "testdata/webhooks/member.json.golden",
+			obj:    new(scm.MemberHook),
+		},
+		// member added to team
+		{
+			name:   "membership",
+			event:  "membership",
+			before: "testdata/webhooks/membership.json",
+			after:  "testdata/webhooks/membership.json.golden",
+			obj:    new(scm.MemberHook),
+		},
+		// member added to organization
+		{
+			name:   "organization",
+			event:  "organization",
+			before: "testdata/webhooks/organization.json",
+			after:  "testdata/webhooks/organization.json.golden",
+			obj:    new(scm.MemberHook),
+		},
+		// team added to repository
+		{
+			name:   "team",
+			event:  "team",
+			before: "testdata/webhooks/team.json",
+			after:  "testdata/webhooks/team.json.golden",
+			obj:    new(scm.TeamHook),
+		},
+		// pull request review thread resolved
+		{
+			name:   "pr_review_thread_resolved",
+			event:  "pull_request_review_thread",
+			before: "testdata/webhooks/pr_review_thread_resolved.json",
+			after:  "testdata/webhooks/pr_review_thread_resolved.json.golden",
+			obj:    new(scm.ReviewThreadHook),
+		},
+		// repository starred
+		{
+			name:   "star",
+			event:  "star",
+			before: "testdata/webhooks/star.json",
+			after:  "testdata/webhooks/star.json.golden",
+			obj:    new(scm.StarHook),
+		},
+		// deployment protection rule requested
+		{
+			name:   "deployment_protection_rule",
+			event:  "deployment_protection_rule",
+			before: "testdata/webhooks/deployment_protection_rule.json",
+			after:  "testdata/webhooks/deployment_protection_rule.json.golden",
+			obj:    new(scm.DeployHook),
+		},
 	}
 
 	for _, test := range tests {
@@ -427,6 +530,54 @@ func TestWebhookValid(t *testing.T) {
 	}
 }
 
+func TestWebhookValidSHA256(t *testing.T) {
+	// the sha can be recalculated with the below command
+	// openssl dgst -sha256 -hmac <secret> <file>
+
+	f, _ := os.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-GitHub-Event", "push")
+	r.Header.Set("X-GitHub-Delivery", "ee8d97b4-1479-43f1-9cac-fbbd1b80da55")
+	r.Header.Set("X-Hub-Signature-256", "sha256=951ebeea37401e9f8519e45d66d1fe09cdbfb5fe09c0620a781b180d548dd6e1")
+
+	s := new(webhookService)
+	_, err := s.Parse(r, secretFunc)
+	if err != nil {
+		t.Errorf("Expect valid signature, got %v", err)
+	}
+}
+
+func TestWebhookPrefersSHA256(t *testing.T) {
+	f, _ := os.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-GitHub-Event", "push")
+	r.Header.Set("X-GitHub-Delivery", "ee8d97b4-1479-43f1-9cac-fbbd1b80da55")
+	r.Header.Set("X-Hub-Signature", "sha1=e9c4409d39729236fda483f22e7fb7513e5cd273")
+	r.Header.Set("X-Hub-Signature-256", "sha256=8ca57e2afbad9fea8860404575c2d61827995c62aacd4c514eae4c404896390b")
+
+	s := new(webhookService)
+	_, err := s.Parse(r, secretFunc)
+	if err != scm.ErrSignatureInvalid {
+		t.Errorf("Expect invalid signature error, got %v", err)
+	}
+}
+
+func TestWebhookRotatedSecret(t *testing.T) {
+	f, _ := os.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-GitHub-Event", "push")
+	r.Header.Set("X-GitHub-Delivery", "ee8d97b4-1479-43f1-9cac-fbbd1b80da55")
+	r.Header.Set("X-Hub-Signature-256", "sha256=951ebeea37401e9f8519e45d66d1fe09cdbfb5fe09c0620a781b180d548dd6e1")
+
+	s := new(webhookService)
+	_, err := s.ParseWithSecrets(r, func(scm.Webhook) ([]string, error) {
+		return []string{"newsecret", "topsecret"}, nil
+	})
+	if err != nil {
+		t.Errorf("Expect valid signature, got %v", err)
+	}
+}
+
 func secretFunc(scm.Webhook) (string, error) {
 	return "topsecret", nil
 }
@@ -8,6 +8,7 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
@@ -123,6 +124,84 @@ func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string
 	return convertChangeList(out.Diffs), res, err
 }
 
+// Compare returns the comparison of the head commit to the base
+// commit. GitLab does not report how far apart the commits are, so
+// the commits behind are counted by comparing in reverse and the
+// merge base is requested separately.
+func (s *gitService) Compare(ctx context.Context, repo, base, head string, opts *scm.ListOptions) (*scm.Comparison, *scm.Response, error) {
+	ahead, res, err := s.compare(ctx, repo, base, head)
+	if err != nil {
+		return nil, res, err
+	}
+	behind, res, err := s.compare(ctx, repo, head, base)
+	if err != nil {
+		return nil, res, err
+	}
+	params := url.Values{}
+	params.Add("refs[]", base)
+	params.Add("refs[]", head)
+	path := fmt.Sprintf("api/v4/projects/%s/repository/merge_base?%s", encode(repo), params.Encode())
+	mergeBase := new(commit)
+	res, err = s.client.do(ctx, "GET", path, nil, mergeBase)
+	if err != nil {
+		return nil, res, err
+	}
+	return &scm.Comparison{
+		Status:    scm.ComparisonStatusOf(len(ahead.Commits), len(behind.Commits)),
+		AheadBy:   len(ahead.Commits),
+		BehindBy:  len(behind.Commits),
+		MergeBase: mergeBase.ID,
+		Commits:   convertCommitList(ahead.Commits),
+		Changes:   convertChangeList(ahead.Diffs),
+	}, res, nil
+}
+
+func (s *gitService) compare(ctx context.Context, repo, from, to string) (*compare, *scm.Response, error) {
+	params := url.Values{}
+	params.Set("from", from)
+	params.Set("to", to)
+	path := fmt.Sprintf("api/v4/projects/%s/repository/compare?%s", encode(repo), params.Encode())
+	out := new(compare)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return out, res, err
+}
+
+// CompareDiff returns the unified diff between two commits.
+func (s *gitService) CompareDiff(ctx context.Context, repo, ref1, ref2 string) (*scm.Diff, *scm.Response, error) {
+	out, res, err := s.compare(ctx, repo, ref1, ref2)
+	if err != nil {
+		return nil, res, err
+	}
+	diff, err := scm.ParseDiff(scm.FormatChanges(convertChangeList(out.Diffs)))
+	return diff, res, err
+}
+
+// FindTree returns the git tree for the given ref. GitLab paginates
+// the tree so every page is requested until the listing is complete.
+func (s *gitService) FindTree(ctx context.Context, repo, ref string, recursive bool) (*scm.Tree, *scm.Response, error) {
+	params := url.Values{}
+	params.Set("ref", ref)
+	params.Set("per_page", "100")
+	if recursive {
+		params.Set("recursive", "true")
+	}
+	to := &scm.Tree{Entries: []*scm.TreeEntry{}}
+	for page := 1; ; {
+		params.Set("page", strconv.Itoa(page))
+		path := fmt.Sprintf("api/v4/projects/%s/repository/tree?%s", encode(repo), params.Encode())
+		out := []*treeEntry{}
+		res, err := s.client.do(ctx, "GET", path, nil, &out)
+		if err != nil {
+			return nil, res, err
+		}
+		to.Entries = append(to.Entries, convertTreeEntryList(out)...)
+		if res.Page.Next <= page {
+			return to, res, nil
+		}
+		page = res.Page.Next
+	}
+}
+
 func (s *gitService) GetDefaultBranch(ctx context.Context, repo string) (*scm.Reference, *scm.Response, error) {
 	repository, res, err := s.client.Repositories.Find(ctx, repo)
 	if err != nil {
@@ -132,7 +211,16 @@ func (s *gitService) GetDefaultBranch(ctx context.Context, repo string) (*scm.Re
 }
 
 type compare struct {
-	Diffs []*change `json:"diffs"`
+	Commits []*commit `json:"commits"`
+	Diffs   []*change `json:"diffs"`
+}
+
+type treeEntry struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Path string `json:"path"`
+	Mode string `json:"mode"`
 }
 
 type branch struct {
@@ -154,6 +242,7 @@ type commit struct {
 	CommitterEmail string    `json:"committer_email"`
 	Created        time.Time `json:"created_at"`
 	URL            string    `json:"web_url"`
+	ParentIDs      []string  `json:"parent_ids"`
 }
 
 func convertCommitList(from []*commit) []*scm.Commit {
@@ -181,6 +270,7 @@ func convertCommit(from *commit) *scm.Commit {
 			Email: from.CommitterEmail,
 			Date:  from.CommittedDate,
 		},
+		Parents: from.ParentIDs,
 	}
 }
 
@@ -215,3 +305,16 @@ func convertTag(from *branch) *scm.Reference {
 		Sha:  from.Commit.ID,
 	}
 }
+
+func convertTreeEntryList(from []*treeEntry) []*scm.TreeEntry {
+	to := []*scm.TreeEntry{}
+	for _, v := range from {
+		to = append(to, &scm.TreeEntry{
+			Path: v.Path,
+			Mode: v.Mode,
+			Type: v.Type,
+			Sha:  v.ID,
+		})
+	}
+	return to
+}
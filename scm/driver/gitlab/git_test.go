@@ -120,6 +120,43 @@ func TestGitFindTag(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestGitFindTree(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/tree").
+		MatchParam("ref", "master").
+		MatchParam("recursive", "true").
+		MatchParam("page", "1").
+		MatchParam("per_page", "100").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/tree.json")
+
+	client := NewDefault()
+	got, res, err := client.Git.FindTree(context.Background(), "diaspora/diaspora", "master", true)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Tree)
+	raw, _ := os.ReadFile("testdata/tree.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
 func TestGitListCommits(t *testing.T) {
 	defer gock.Off()
 
@@ -373,3 +410,92 @@ func TestGitGetDefaultBranch(t *testing.T) {
 	t.Run("Request", testRequest(res))
 	t.Run("Rate", testRate(res))
 }
+
+func TestGitCompareDiff(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/gitterHQ/webapp/repository/compare").
+		MatchParam("from", "6da006adb7cafe15b8495e3b7811fc318e485553").
+		MatchParam("to", "c5895070235cadd2d839136dad79e01838ee2de1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/compare.json")
+
+	client := NewDefault()
+	got, res, err := client.Git.CompareDiff(context.Background(), "gitterHQ/webapp", "6da006adb7cafe15b8495e3b7811fc318e485553", "c5895070235cadd2d839136dad79e01838ee2de1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := len(got.Files), 1; got != want {
+		t.Fatalf("Want %d files, got %d", want, got)
+	}
+	file := got.Files[0]
+	if got, want := file.NewPath, "config/config.prod.json"; got != want {
+		t.Errorf("Want file %q, got %q", want, got)
+	}
+	if got, want := len(file.Hunks), 1; got != want {
+		t.Fatalf("Want %d hunks, got %d", want, got)
+	}
+	if got, want := file.Hunks[0].OldStart, 52; got != want {
+		t.Errorf("Want hunk starting at line %d, got %d", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestGitCompare(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/gitterHQ/webapp/repository/compare").
+		MatchParam("from", "6da006adb7cafe15b8495e3b7811fc318e485553").
+		MatchParam("to", "c5895070235cadd2d839136dad79e01838ee2de1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/compare.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/gitterHQ/webapp/repository/compare").
+		MatchParam("from", "c5895070235cadd2d839136dad79e01838ee2de1").
+		MatchParam("to", "6da006adb7cafe15b8495e3b7811fc318e485553").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/compare_behind.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/gitterHQ/webapp/repository/merge_base").
+		MatchParam("refs[]", "6da006adb7cafe15b8495e3b7811fc318e485553").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_base.json")
+
+	client := NewDefault()
+	got, res, err := client.Git.Compare(context.Background(), "gitterHQ/webapp", "6da006adb7cafe15b8495e3b7811fc318e485553", "c5895070235cadd2d839136dad79e01838ee2de1", &scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := os.ReadFile("testdata/comparison.json.golden")
+	err = json.Unmarshal(raw, want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
@@ -11,6 +11,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -42,12 +43,14 @@ func New(uri string) (*scm.Client, error) {
 	client.Contents = &contentService{client}
 	client.Git = &gitService{client}
 	client.Issues = &issueService{client}
+	client.Reactions = &reactionService{client}
 	client.Releases = &releaseService{client}
 	client.Milestones = &milestoneService{client}
 	client.Organizations = &organizationService{client}
 	client.PullRequests = &pullService{client}
 	client.Repositories = &repositoryService{client}
 	client.Reviews = &reviewService{client}
+	client.Search = &searchService{client}
 	client.Commits = &commitService{client}
 
 	// add the user service to the webhook service so it can be used for fetching users
@@ -189,6 +192,33 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	return res, json.NewDecoder(res.Body).Decode(out)
 }
 
+// stream wraps the Client.Do function by creating the Request and
+// returning the raw response body. The caller must close the body.
+func (c *wrapper) stream(ctx context.Context, method, path string) (io.ReadCloser, *scm.Response, error) {
+	req := &scm.Request{
+		Method: method,
+		Path:   path,
+	}
+	res, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// parse the gitlab request id.
+	res.ID = res.Header.Get("X-Request-Id")
+
+	if res.Status > 300 {
+		res.Body.Close()
+		if res.Status == http.StatusNotFound {
+			return nil, res, scm.ErrNotFound
+		}
+		return nil, res, errors.New(
+			http.StatusText(res.Status),
+		)
+	}
+	return res.Body, res, nil
+}
+
 // Error represents a GitLab error.
 type Error struct {
 	Message string `json:"message"`
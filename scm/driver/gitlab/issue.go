@@ -173,15 +173,57 @@ func (s *issueService) ListComments(ctx context.Context, repo string, index int,
 }
 
 func (s *issueService) Create(ctx context.Context, repo string, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
-	in := url.Values{}
-	in.Set("title", input.Title)
-	in.Set("description", input.Body)
-	path := fmt.Sprintf("api/v4/projects/%s/issues?%s", encode(repo), in.Encode())
+	in, res, err := s.issueOptions(ctx, input)
+	if err != nil {
+		return nil, res, err
+	}
+	path := fmt.Sprintf("api/v4/projects/%s/issues", encode(repo))
 	out := new(issue)
-	res, err := s.client.do(ctx, "POST", path, nil, out)
+	res, err = s.client.do(ctx, "POST", path, in, out)
+	return convertIssue(out), res, err
+}
+
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	in, res, err := s.issueOptions(ctx, input)
+	if err != nil {
+		return nil, res, err
+	}
+	path := fmt.Sprintf("api/v4/projects/%s/issues/%d", encode(repo), number)
+	out := new(issue)
+	res, err = s.client.do(ctx, "PUT", path, in, out)
 	return convertIssue(out), res, err
 }
 
+// issueOptions returns the issue options for the input, looking up
+// the ids of the assignees.
+func (s *issueService) issueOptions(ctx context.Context, input *scm.IssueInput) (*updateIssueOptions, *scm.Response, error) {
+	in := &updateIssueOptions{
+		Labels: input.Labels,
+	}
+	if input.Title != "" {
+		in.Title = &input.Title
+	}
+	if input.Body != "" {
+		in.Description = &input.Body
+	}
+	if input.Milestone != 0 {
+		in.MilestoneID = &input.Milestone
+	}
+	in.Confidential = input.Confidential
+	if input.DueDate != nil {
+		date := input.DueDate.Format("2006-01-02")
+		in.DueDate = &date
+	}
+	for _, login := range input.Assignees {
+		u, res, err := s.client.Users.FindLogin(ctx, login)
+		if err != nil {
+			return nil, res, err
+		}
+		in.AssigneeIDs = append(in.AssigneeIDs, u.ID)
+	}
+	return in, nil, nil
+}
+
 func (s *issueService) CreateComment(ctx context.Context, repo string, number int, input *scm.CommentInput) (*scm.Comment, *scm.Response, error) {
 	in := url.Values{}
 	in.Set("body", input.Body)
@@ -258,6 +300,7 @@ type updateIssueOptions struct {
 	UpdatedAt        *time.Time `json:"updated_at,omitempty"`
 	Weight           *int       `json:"weight,omitempty"`
 	DiscussionLocked *bool      `json:"discussion_locked,omitempty"`
+	DueDate          *string    `json:"due_date,omitempty"`
 }
 
 type issue struct {
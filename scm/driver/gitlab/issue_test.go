@@ -9,6 +9,7 @@ import (
 	"encoding/json"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 
@@ -232,6 +233,124 @@ func TestIssueCreate(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestIssueCreateTriaged(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/users").
+		MatchParam("search", "john_smith").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/user_search.json")
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/issues").
+		JSON(map[string]interface{}{
+			"title":        "Found a bug",
+			"description":  "I'm having a problem with this.",
+			"labels":       []string{"bug"},
+			"assignee_ids": []int{1},
+			"milestone_id": 2,
+			"confidential": true,
+			"due_date":     "2018-09-30",
+		}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/issue.json")
+
+	due := time.Date(2018, time.September, 30, 0, 0, 0, 0, time.UTC)
+	confidential := true
+	input := scm.IssueInput{
+		Title:        "Found a bug",
+		Body:         "I'm having a problem with this.",
+		Labels:       []string{"bug"},
+		Assignees:    []string{"john_smith"},
+		Milestone:    2,
+		Confidential: &confidential,
+		DueDate:      &due,
+	}
+
+	client := NewDefault()
+	_, _, err := client.Issues.Create(context.Background(), "diaspora/diaspora", &input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestIssueUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/issues/1").
+		JSON(map[string]interface{}{
+			"title":  "Found a bug",
+			"labels": []string{"bug", "triaged"},
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/issue.json")
+
+	input := scm.IssueInput{
+		Title:  "Found a bug",
+		Labels: []string{"bug", "triaged"},
+	}
+
+	client := NewDefault()
+	got, res, err := client.Issues.Update(context.Background(), "diaspora/diaspora", 1, &input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Issue)
+	raw, _ := os.ReadFile("testdata/issue.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestIssueUpdateConfidential(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/issues/1").
+		JSON(map[string]interface{}{
+			"confidential": false,
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/issue.json")
+
+	confidential := false
+	input := scm.IssueInput{
+		Confidential: &confidential,
+	}
+
+	client := NewDefault()
+	_, _, err := client.Issues.Update(context.Background(), "diaspora/diaspora", 1, &input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
 func TestIssueCreateComment(t *testing.T) {
 	defer gock.Off()
 
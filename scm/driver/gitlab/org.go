@@ -7,6 +7,8 @@ package gitlab
 import (
 	"context"
 	"fmt"
+	"net/url"
+	"strconv"
 
 	"github.com/jenkins-x/go-scm/scm"
 	"github.com/jenkins-x/go-scm/scm/driver/internal/null"
@@ -108,6 +110,60 @@ func (s *organizationService) ListMemberships(ctx context.Context, opts *scm.Lis
 	return nil, nil, scm.ErrNotSupported
 }
 
+func (s *organizationService) FindHook(ctx context.Context, org, id string) (*scm.Hook, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/groups/%s/hooks/%s", encode(org), id)
+	out := new(hook)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertHook(out), res, err
+}
+
+func (s *organizationService) ListHooks(ctx context.Context, org string, opts *scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/groups/%s/hooks?%s", encode(org), encodeListOptions(opts))
+	out := []*hook{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertHookList(out), res, err
+}
+
+func (s *organizationService) CreateHook(ctx context.Context, org string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	params := convertGroupHookInputToParams(input)
+
+	path := fmt.Sprintf("api/v4/groups/%s/hooks?%s", encode(org), params.Encode())
+	out := new(hook)
+	res, err := s.client.do(ctx, "POST", path, nil, out)
+	return convertHook(out), res, err
+}
+
+func (s *organizationService) UpdateHook(ctx context.Context, org string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	params := convertGroupHookInputToParams(input)
+	hookID := input.Name
+
+	path := fmt.Sprintf("api/v4/groups/%s/hooks/%s?%s", encode(org), hookID, params.Encode())
+	out := new(hook)
+	res, err := s.client.do(ctx, "PUT", path, nil, out)
+	return convertHook(out), res, err
+}
+
+func (s *organizationService) DeleteHook(ctx context.Context, org, id string) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v4/groups/%s/hooks/%s", encode(org), id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+// convertGroupHookInputToParams returns the group hook parameters,
+// which extend the project hook parameters with the group-only
+// member and subgroup events.
+func convertGroupHookInputToParams(input *scm.HookInput) url.Values {
+	params := convertHookInputToGenericParam(input)
+	hasStarEvents := false
+	for _, event := range input.NativeEvents {
+		if event == "*" {
+			hasStarEvents = true
+		}
+	}
+	params.Set("member_events", strconv.FormatBool(input.Events.Member || hasStarEvents))
+	params.Set("subgroup_events", strconv.FormatBool(input.Events.Organization || hasStarEvents))
+	return params
+}
+
 type organization struct {
 	ID     int         `json:"id"`
 	Name   string      `json:"name"`
@@ -85,3 +85,195 @@ func TestOrganizationList(t *testing.T) {
 	t.Run("Rate", testRate(res))
 	t.Run("Page", testPage(res))
 }
+
+func TestOrganizationHookFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/groups/twitter/hooks/1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/group_hook.json")
+
+	client := NewDefault()
+	got, res, err := client.Organizations.FindHook(context.Background(), "twitter", "1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Hook)
+	raw, _ := os.ReadFile("testdata/group_hook.json.golden")
+	err = json.Unmarshal(raw, want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestOrganizationHookList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/groups/twitter/hooks").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/group_hooks.json")
+
+	client := NewDefault()
+	got, res, err := client.Organizations.ListHooks(context.Background(), "twitter", &scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Hook{}
+	raw, _ := os.ReadFile("testdata/group_hooks.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+	t.Run("Page", testPage(res))
+}
+
+func TestOrganizationHookCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/groups/twitter/hooks").
+		MatchParams(map[string]string{
+			"enable_ssl_verification": "true",
+			"issues_events":           "false",
+			"merge_requests_events":   "true",
+			"note_events":             "false",
+			"push_events":             "true",
+			"tag_push_events":         "true",
+			"member_events":           "true",
+			"subgroup_events":         "true",
+			"token":                   "topsecret",
+			"url":                     "http://example.com/hook",
+		}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/group_hook.json")
+
+	in := &scm.HookInput{
+		Target: "http://example.com/hook",
+		Secret: "topsecret",
+		Events: scm.HookEvents{
+			Push:         true,
+			Tag:          true,
+			PullRequest:  true,
+			Member:       true,
+			Organization: true,
+		},
+	}
+
+	client := NewDefault()
+	got, res, err := client.Organizations.CreateHook(context.Background(), "twitter", in)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Hook)
+	raw, _ := os.ReadFile("testdata/group_hook.json.golden")
+	err = json.Unmarshal(raw, want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestOrganizationHookUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/groups/twitter/hooks/1").
+		MatchParam("member_events", "false").
+		MatchParam("push_events", "true").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/group_hook.json")
+
+	in := &scm.HookInput{
+		Name:   "1",
+		Target: "http://example.com/hook",
+		Events: scm.HookEvents{Push: true},
+	}
+
+	client := NewDefault()
+	got, res, err := client.Organizations.UpdateHook(context.Background(), "twitter", in)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Hook)
+	raw, _ := os.ReadFile("testdata/group_hook.json.golden")
+	err = json.Unmarshal(raw, want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestOrganizationHookDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Delete("/api/v4/groups/twitter/hooks/1").
+		Reply(204).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	res, err := client.Organizations.DeleteHook(context.Background(), "twitter", "1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := res.Status, 204; got != want {
+		t.Errorf("Want response status %d, got %d", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
@@ -7,8 +7,10 @@ package gitlab
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/mitchellh/copystructure"
@@ -55,6 +57,21 @@ func (s *pullService) List(ctx context.Context, repo string, opts *scm.PullReque
 	return convRepos, res, nil
 }
 
+// ListForCommit returns the merge requests associated with the commit.
+func (s *pullService) ListForCommit(ctx context.Context, repo, sha string) ([]*scm.PullRequest, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/repository/commits/%s/merge_requests", encode(repo), sha)
+	out := []*pr{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+	convRepos, convRes, err := s.convertPullRequestList(ctx, out)
+	if err != nil {
+		return nil, convRes, err
+	}
+	return convRepos, res, nil
+}
+
 func (s *pullService) ListChanges(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
 	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/changes?%s", encode(repo), number, encodeListOptions(opts))
 	out := new(changes)
@@ -62,6 +79,20 @@ func (s *pullService) ListChanges(ctx context.Context, repo string, number int,
 	return convertChangeList(out.Changes), res, err
 }
 
+// FindDiff returns the unified diff of the merge request. GitLab
+// returns the patch of each changed file, so the complete diff is
+// assembled from the changes.
+func (s *pullService) FindDiff(ctx context.Context, repo string, number int) (*scm.Diff, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/changes", encode(repo), number)
+	out := new(changes)
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+	diff, err := scm.ParseDiff(scm.FormatChanges(convertChangeList(out.Changes)))
+	return diff, res, err
+}
+
 func (s *pullService) ListCommits(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Commit, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
@@ -216,8 +247,12 @@ func (s *pullService) UnrequestReview(ctx context.Context, repo string, number i
 
 func (s *pullService) Create(ctx context.Context, repo string, input *scm.PullRequestInput) (*scm.PullRequest, *scm.Response, error) {
 	path := fmt.Sprintf("api/v4/projects/%s/merge_requests", encode(repo))
+	title := input.Title
+	if input.Draft {
+		title = draftTitle(title)
+	}
 	in := &prInput{
-		Title:        input.Title,
+		Title:        title,
 		SourceBranch: input.Head,
 		TargetBranch: input.Base,
 		Description:  input.Body,
@@ -270,6 +305,208 @@ func (s *pullService) DeletePullRequest(ctx context.Context, repo string, prID i
 	return nil, scm.ErrNotSupported
 }
 
+// MarkReady removes the draft prefix from the merge request title.
+func (s *pullService) MarkReady(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(ctx, repo, number, false)
+}
+
+// ConvertToDraft adds the draft prefix to the merge request title.
+func (s *pullService) ConvertToDraft(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.setDraft(ctx, repo, number, true)
+}
+
+func (s *pullService) setDraft(ctx context.Context, repo string, number int, draft bool) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d", encode(repo), number)
+	out := new(pr)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return res, err
+	}
+	title := trimDraftTitle(out.Title)
+	if draft {
+		title = draftTitle(title)
+	}
+	if title == out.Title {
+		return res, nil
+	}
+	in := &updateMergeRequestOptions{
+		Title: &title,
+	}
+	return s.client.do(ctx, "PUT", path, in, nil)
+}
+
+// EnableAutoMerge sets the merge request to merge when the
+// pipeline succeeds. GitLab takes whole commit messages: the
+// CommitTitle is the merge commit message and the CommitMessage
+// the squash commit message, which falls back to the CommitTitle.
+// The rebase method is not supported, GitLab takes the merge
+// method from the project settings and can only be asked to squash.
+func (s *pullService) EnableAutoMerge(ctx context.Context, repo string, number int, opts *scm.AutoMergeOptions) (*scm.Response, error) {
+	if !isAutoMergeMethod(opts) {
+		return nil, scm.ErrNotSupported
+	}
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/merge", encode(repo), number)
+	in := &pullRequestMergeRequest{
+		MergeWhenPipelineSucceeds: "true",
+	}
+	if opts != nil {
+		in.SHA = opts.SHA
+		in.CommitMessage = opts.CommitTitle
+		if opts.MergeMethod == "squash" {
+			in.Squash = "true"
+			in.SquashCommitMessage = opts.CommitMessage
+			if in.SquashCommitMessage == "" {
+				in.SquashCommitMessage = opts.CommitTitle
+			}
+		}
+		if opts.DeleteSourceBranch {
+			in.RemoveSourceBranch = "true"
+		}
+	}
+	return s.client.do(ctx, "PUT", path, in, nil)
+}
+
+// DisableAutoMerge cancels merge when pipeline succeeds. This also
+// removes the merge request from the merge train.
+func (s *pullService) DisableAutoMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/cancel_merge_when_pipeline_succeeds", encode(repo), number)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
+// EnqueueMerge adds the merge request to the merge train. As with
+// EnableAutoMerge, the rebase method is not supported.
+func (s *pullService) EnqueueMerge(ctx context.Context, repo string, number int, opts *scm.AutoMergeOptions) (*scm.MergeQueueEntry, *scm.Response, error) {
+	if !isAutoMergeMethod(opts) {
+		return nil, nil, scm.ErrNotSupported
+	}
+	path := fmt.Sprintf("api/v4/projects/%s/merge_trains/merge_requests/%d", encode(repo), number)
+	in := &mergeTrainInput{
+		WhenPipelineSucceeds: true,
+	}
+	if opts != nil {
+		in.SHA = opts.SHA
+		in.Squash = opts.MergeMethod == "squash"
+	}
+	out := []*mergeTrain{}
+	res, err := s.client.do(ctx, "POST", path, in, &out)
+	if err != nil {
+		return nil, res, err
+	}
+	for i, train := range out {
+		if train.MergeRequest.IID == number {
+			entry := convertMergeTrain(train)
+			entry.Position = i + 1
+			return entry, res, nil
+		}
+	}
+	return nil, res, scm.ErrNotFound
+}
+
+// DequeueMerge removes the merge request from the merge train.
+func (s *pullService) DequeueMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return s.DisableAutoMerge(ctx, repo, number)
+}
+
+// FindMergeQueueEntry returns the merge train entry of the merge
+// request.
+func (s *pullService) FindMergeQueueEntry(ctx context.Context, repo string, number int) (*scm.MergeQueueEntry, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_trains/merge_requests/%d", encode(repo), number)
+	out := new(mergeTrain)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if res != nil && res.Status == http.StatusNotFound {
+		return nil, res, scm.ErrNotFound
+	}
+	if err != nil {
+		return nil, res, err
+	}
+	return convertMergeTrain(out), res, nil
+}
+
+// isAutoMergeMethod reports whether the merge method of the options
+// can be honoured when merging automatically.
+func isAutoMergeMethod(opts *scm.AutoMergeOptions) bool {
+	if opts == nil {
+		return true
+	}
+	switch opts.MergeMethod {
+	case "", "merge", "squash":
+		return true
+	default:
+		return false
+	}
+}
+
+// rebasePollInterval is how often the merge request is checked
+// while a rebase is in progress.
+var rebasePollInterval = time.Second
+
+// UpdateBranch rebases the merge request onto its target branch.
+// GitLab rebases asynchronously, so the merge request is polled
+// until the rebase completes. Merging the target branch into the
+// source branch is not supported, rebase being the default.
+func (s *pullService) UpdateBranch(ctx context.Context, repo string, number int, method string) (string, *scm.Response, error) {
+	if method != "" && method != scm.UpdateBranchRebase {
+		return "", nil, scm.ErrNotSupported
+	}
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d", encode(repo), number)
+	res, err := s.client.do(ctx, "PUT", path+"/rebase", nil, nil)
+	if err != nil {
+		if res != nil && res.Status == http.StatusConflict {
+			return "", res, scm.MergeConflict{Number: number}
+		}
+		return "", res, err
+	}
+	for {
+		out := new(pr)
+		res, err = s.client.do(ctx, "GET", path+"?include_rebase_in_progress=true", nil, out)
+		if err != nil {
+			return "", res, err
+		}
+		if !out.Rebasing {
+			// the merge error is kept from earlier attempts, so
+			// the current merge status tells if the rebase failed.
+			if out.DetailedMergeStatus == "conflict" || out.MergeStatus == "cannot_be_merged" {
+				return "", res, scm.MergeConflict{Number: number, Message: out.MergeError}
+			}
+			return out.Sha, res, nil
+		}
+		select {
+		case <-ctx.Done():
+			return "", res, ctx.Err()
+		case <-time.After(rebasePollInterval):
+		}
+	}
+}
+
+// draftPrefixes are the title prefixes GitLab recognises as marking
+// a merge request as a draft, in lower case.
+var draftPrefixes = []string{"draft:", "[draft]", "(draft)", "wip:", "[wip]"}
+
+// isDraftTitle returns true if the title marks the merge request
+// as a draft.
+func isDraftTitle(title string) bool {
+	return trimDraftTitle(title) != title
+}
+
+// trimDraftTitle returns the title without any draft prefix.
+func trimDraftTitle(title string) string {
+	lower := strings.ToLower(title)
+	for _, prefix := range draftPrefixes {
+		if strings.HasPrefix(lower, prefix) {
+			return strings.TrimSpace(title[len(prefix):])
+		}
+	}
+	return title
+}
+
+// draftTitle returns the title with the draft prefix applied.
+func draftTitle(title string) string {
+	if isDraftTitle(title) {
+		return title
+	}
+	return "Draft: " + title
+}
+
 type updateMergeRequestOptions struct {
 	Title              *string `json:"title,omitempty"`
 	Description        *string `json:"description,omitempty"`
@@ -301,24 +538,27 @@ func (s *pullService) updateMergeRequestField(ctx context.Context, repo string,
 }
 
 type pr struct {
-	Number          int       `json:"iid"`
-	Sha             string    `json:"sha"`
-	Title           string    `json:"title"`
-	Desc            string    `json:"description"`
-	State           string    `json:"state"`
-	SourceProjectID int       `json:"source_project_id"`
-	TargetProjectID int       `json:"target_project_id"`
-	Labels          []*string `json:"labels"`
-	Link            string    `json:"web_url"`
-	WIP             bool      `json:"work_in_progress"`
-	Draft           bool      `json:"draft"`
-	Author          user      `json:"author"`
-	MergeStatus     string    `json:"merge_status"`
-	SourceBranch    string    `json:"source_branch"`
-	TargetBranch    string    `json:"target_branch"`
-	Created         time.Time `json:"created_at"`
-	Updated         time.Time `json:"updated_at"`
-	Closed          time.Time
+	Number              int       `json:"iid"`
+	Sha                 string    `json:"sha"`
+	Title               string    `json:"title"`
+	Desc                string    `json:"description"`
+	State               string    `json:"state"`
+	SourceProjectID     int       `json:"source_project_id"`
+	TargetProjectID     int       `json:"target_project_id"`
+	Labels              []*string `json:"labels"`
+	Link                string    `json:"web_url"`
+	WIP                 bool      `json:"work_in_progress"`
+	Draft               bool      `json:"draft"`
+	Author              user      `json:"author"`
+	MergeStatus         string    `json:"merge_status"`
+	MergeError          string    `json:"merge_error"`
+	DetailedMergeStatus string    `json:"detailed_merge_status"`
+	Rebasing            bool      `json:"rebase_in_progress"`
+	SourceBranch        string    `json:"source_branch"`
+	TargetBranch        string    `json:"target_branch"`
+	Created             time.Time `json:"created_at"`
+	Updated             time.Time `json:"updated_at"`
+	Closed              time.Time
 	// DiffRefs is only populated by GitLab on the single-MR endpoint
 	// (GET /merge_requests/:iid); the list endpoint omits it entirely.
 	//
@@ -366,6 +606,25 @@ type prInput struct {
 	TargetBranch string `json:"target_branch"`
 }
 
+type mergeTrainInput struct {
+	SHA                  string `json:"sha,omitempty"`
+	Squash               bool   `json:"squash,omitempty"`
+	WhenPipelineSucceeds bool   `json:"when_pipeline_succeeds,omitempty"`
+}
+
+type mergeTrain struct {
+	ID           int `json:"id"`
+	MergeRequest struct {
+		IID int `json:"iid"`
+	} `json:"merge_request"`
+	Pipeline struct {
+		Sha string `json:"sha"`
+	} `json:"pipeline"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 type pullRequestMergeRequest struct {
 	CommitMessage             string `json:"merge_commit_message,omitempty"`
 	SquashCommitMessage       string `json:"squash_commit_message,omitempty"`
@@ -375,6 +634,17 @@ type pullRequestMergeRequest struct {
 	MergeWhenPipelineSucceeds string `json:"merge_when_pipeline_succeeds,omitempty"`
 }
 
+func convertMergeTrain(from *mergeTrain) *scm.MergeQueueEntry {
+	return &scm.MergeQueueEntry{
+		ID:      strconv.Itoa(from.ID),
+		Number:  from.MergeRequest.IID,
+		State:   from.Status,
+		Sha:     from.Pipeline.Sha,
+		Created: from.CreatedAt,
+		Updated: from.UpdatedAt,
+	}
+}
+
 func (s *pullService) convertPullRequestList(ctx context.Context, from []*pr) ([]*scm.PullRequest, *scm.Response, error) {
 	to := []*scm.PullRequest{}
 	for _, v := range from {
@@ -166,6 +166,82 @@ func TestPullList(t *testing.T) {
 	t.Run("Page", testPage(res))
 }
 
+func TestPullListFilters(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/32732").
+		Times(2).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/repo.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests").
+		MatchParam("source_branch", "fix").
+		MatchParam("target_branch", "master").
+		MatchParam("author_username", "dblessing").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merges.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.List(context.Background(), "diaspora/diaspora", &scm.PullRequestListOptions{
+		Head:   "fix",
+		Base:   "master",
+		Author: "dblessing",
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(got) != 1 {
+		t.Errorf("Want 1 merge request, got %d", len(got))
+	}
+}
+
+func TestPullListForCommit(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/32732").
+		Times(2).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/repo.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/commits/12d65c8dd2b2676fa3ac47d955accc085a37a9c1/merge_requests").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merges.json")
+
+	client := NewDefault()
+	got, res, err := client.PullRequests.ListForCommit(context.Background(), "diaspora/diaspora", "12d65c8dd2b2676fa3ac47d955accc085a37a9c1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.PullRequest{}
+	raw, _ := os.ReadFile("testdata/merges.json.golden")
+	if err := json.Unmarshal(raw, &want); err != nil {
+		t.Fatalf("json.Unmarshal: %v", err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
 func TestPullListChanges(t *testing.T) {
 	gock.New("https://gitlab.com").
 		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1347/changes").
@@ -621,6 +697,294 @@ func TestPullSetMilestone(t *testing.T) {
 	}
 }
 
+func TestPullMarkReady(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_draft.json")
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/merge_requests/1").
+		JSON(map[string]string{"title": "JS fix"}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge.json")
+
+	client := NewDefault()
+	res, err := client.PullRequests.MarkReady(context.Background(), "diaspora/diaspora", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected the merge request title to be updated")
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestPullConvertToDraft(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge.json")
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/merge_requests/1").
+		JSON(map[string]string{"title": "Draft: JS fix"}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_draft.json")
+
+	client := NewDefault()
+	res, err := client.PullRequests.ConvertToDraft(context.Background(), "diaspora/diaspora", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected the merge request title to be updated")
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestPullEnableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/merge_requests/1/merge").
+		JSON(map[string]string{
+			"merge_commit_message":         "Merge JS fix",
+			"squash":                       "true",
+			"squash_commit_message":        "JS fix\n\nFixes the build.",
+			"sha":                          "b83d6e391c22777fca1ed3012fce84f633d7fed0",
+			"merge_when_pipeline_succeeds": "true",
+		}).
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge.json")
+
+	client := NewDefault()
+	res, err := client.PullRequests.EnableAutoMerge(context.Background(), "diaspora/diaspora", 1, &scm.AutoMergeOptions{
+		MergeMethod:   "squash",
+		CommitTitle:   "Merge JS fix",
+		CommitMessage: "JS fix\n\nFixes the build.",
+		SHA:           "b83d6e391c22777fca1ed3012fce84f633d7fed0",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestPullEnableAutoMergeRebase(t *testing.T) {
+	client := NewDefault()
+	_, err := client.PullRequests.EnableAutoMerge(context.Background(), "diaspora/diaspora", 1, &scm.AutoMergeOptions{
+		MergeMethod: "rebase",
+	})
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error, got %v", err)
+	}
+
+	_, _, err = client.PullRequests.EnqueueMerge(context.Background(), "diaspora/diaspora", 1, &scm.AutoMergeOptions{
+		MergeMethod: "rebase",
+	})
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error, got %v", err)
+	}
+}
+
+func TestPullDisableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/cancel_merge_when_pipeline_succeeds").
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge.json")
+
+	client := NewDefault()
+	_, err := client.PullRequests.DisableAutoMerge(context.Background(), "diaspora/diaspora", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPullEnqueueMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_trains/merge_requests/1").
+		JSON(map[string]interface{}{"when_pipeline_succeeds": true}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_trains.json")
+
+	client := NewDefault()
+	got, _, err := client.PullRequests.EnqueueMerge(context.Background(), "diaspora/diaspora", 1, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.MergeQueueEntry)
+	raw, _ := os.ReadFile("testdata/merge_train.json.golden")
+	_ = json.Unmarshal(raw, want)
+	want.Position = 2
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestPullFindMergeQueueEntry(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_trains/merge_requests/1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_train.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_trains/merge_requests/2").
+		Reply(404).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	got, res, err := client.PullRequests.FindMergeQueueEntry(context.Background(), "diaspora/diaspora", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.MergeQueueEntry)
+	raw, _ := os.ReadFile("testdata/merge_train.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+
+	_, _, err = client.PullRequests.FindMergeQueueEntry(context.Background(), "diaspora/diaspora", 2)
+	if err != scm.ErrNotFound {
+		t.Errorf("Want ErrNotFound for a merge request which is not queued, got %v", err)
+	}
+}
+
+func TestPullUpdateBranch(t *testing.T) {
+	defer gock.Off()
+	defer func(interval time.Duration) { rebasePollInterval = interval }(rebasePollInterval)
+	rebasePollInterval = 0
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/merge_requests/1/rebase").
+		Reply(202).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"rebase_in_progress": true}`)
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1").
+		MatchParam("include_rebase_in_progress", "true").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_rebasing.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1").
+		MatchParam("include_rebase_in_progress", "true").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_rebased.json")
+
+	client := NewDefault()
+	got, res, err := client.PullRequests.UpdateBranch(context.Background(), "diaspora/diaspora", 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "8fc6e1f8f9b9bd0d07c8d2f3e4a7a1c94d7a5d32"; got != want {
+		t.Errorf("Want head sha %s, got %s", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestPullUpdateBranchConflict(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/merge_requests/1/rebase").
+		Reply(202).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"rebase_in_progress": true}`)
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1").
+		MatchParam("include_rebase_in_progress", "true").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_rebase_failed.json")
+
+	client := NewDefault()
+	_, _, err := client.PullRequests.UpdateBranch(context.Background(), "diaspora/diaspora", 1, scm.UpdateBranchRebase)
+	if !scm.IsMergeConflict(err) {
+		t.Errorf("Want merge conflict error, got %v", err)
+	}
+
+	_, _, err = client.PullRequests.UpdateBranch(context.Background(), "diaspora/diaspora", 1, scm.UpdateBranchMerge)
+	if err != scm.ErrNotSupported {
+		t.Errorf("Want ErrNotSupported merging the target branch, got %v", err)
+	}
+}
+
+func TestDraftTitle(t *testing.T) {
+	tests := []struct {
+		title, ready, draft string
+	}{
+		{"JS fix", "JS fix", "Draft: JS fix"},
+		{"Draft: JS fix", "JS fix", "Draft: JS fix"},
+		{"[Draft] JS fix", "JS fix", "[Draft] JS fix"},
+		{"(draft) JS fix", "JS fix", "(draft) JS fix"},
+		{"WIP: JS fix", "JS fix", "WIP: JS fix"},
+		{"Drafting JS fix", "Drafting JS fix", "Draft: Drafting JS fix"},
+	}
+	for _, test := range tests {
+		if got, want := trimDraftTitle(test.title), test.ready; got != want {
+			t.Errorf("Want ready title %q, got %q", want, got)
+		}
+		if got, want := draftTitle(test.title), test.draft; got != want {
+			t.Errorf("Want draft title %q, got %q", want, got)
+		}
+	}
+}
+
 func TestPullClearMilestone(t *testing.T) {
 	defer gock.Off()
 
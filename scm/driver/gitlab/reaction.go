@@ -0,0 +1,131 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// reactionService implements reactions with award emoji.
+type reactionService struct {
+	client *wrapper
+}
+
+type awardEmoji struct {
+	ID      int       `json:"id"`
+	Name    string    `json:"name"`
+	User    user      `json:"user"`
+	Created time.Time `json:"created_at"`
+}
+
+type awardEmojiInput struct {
+	Name string `json:"name"`
+}
+
+// awardEmojiNames maps reactions to award emoji names.
+var awardEmojiNames = map[scm.ReactionContent]string{
+	scm.ReactionPlusOne:  "thumbsup",
+	scm.ReactionMinusOne: "thumbsdown",
+	scm.ReactionLaugh:    "laughing",
+	scm.ReactionConfused: "confused",
+	scm.ReactionHeart:    "heart",
+	scm.ReactionHooray:   "tada",
+	scm.ReactionRocket:   "rocket",
+	scm.ReactionEyes:     "eyes",
+}
+
+func (s *reactionService) List(ctx context.Context, repo string, target scm.ReactionTarget, opts *scm.ListOptions) ([]*scm.Reaction, *scm.Response, error) {
+	path := fmt.Sprintf("%s?%s", awardEmojiPath(repo, target), encodeListOptions(opts))
+	out := []*awardEmoji{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertAwardEmojiList(out), res, err
+}
+
+func (s *reactionService) Create(ctx context.Context, repo string, target scm.ReactionTarget, content scm.ReactionContent) (*scm.Reaction, *scm.Response, error) {
+	in := &awardEmojiInput{Name: encodeAwardEmoji(content)}
+	out := new(awardEmoji)
+	res, err := s.client.do(ctx, "POST", awardEmojiPath(repo, target), in, out)
+	return convertAwardEmoji(out), res, err
+}
+
+// Delete looks up the award emoji of the authenticated user,
+// as GitLab deletes award emoji by id. The award emoji are
+// paged through, as the emoji may not be on the first page.
+func (s *reactionService) Delete(ctx context.Context, repo string, target scm.ReactionTarget, content scm.ReactionContent) (*scm.Response, error) {
+	me, res, err := s.client.Users.Find(ctx)
+	if err != nil {
+		return res, err
+	}
+	name := encodeAwardEmoji(content)
+	opts := &scm.ListOptions{Page: 1, Size: 100}
+	for {
+		path := fmt.Sprintf("%s?%s", awardEmojiPath(repo, target), encodeListOptions(opts))
+		out := []*awardEmoji{}
+		res, err = s.client.do(ctx, "GET", path, nil, &out)
+		if err != nil {
+			return res, err
+		}
+		for _, v := range out {
+			if v.Name == name && v.User.Username == me.Login {
+				path := fmt.Sprintf("%s/%d", awardEmojiPath(repo, target), v.ID)
+				return s.client.do(ctx, "DELETE", path, nil, nil)
+			}
+		}
+		if res.Page.Next <= opts.Page {
+			return res, scm.ErrNotFound
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+func awardEmojiPath(repo string, target scm.ReactionTarget) string {
+	switch target.Type {
+	case scm.ReactionOnPullRequest:
+		return fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/award_emoji", encode(repo), target.Number)
+	case scm.ReactionOnIssueComment:
+		return fmt.Sprintf("api/v4/projects/%s/issues/%d/notes/%d/award_emoji", encode(repo), target.Number, target.Comment)
+	case scm.ReactionOnPullRequestComment, scm.ReactionOnReviewComment:
+		return fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/notes/%d/award_emoji", encode(repo), target.Number, target.Comment)
+	default:
+		return fmt.Sprintf("api/v4/projects/%s/issues/%d/award_emoji", encode(repo), target.Number)
+	}
+}
+
+func encodeAwardEmoji(content scm.ReactionContent) string {
+	if name, ok := awardEmojiNames[content]; ok {
+		return name
+	}
+	return string(content)
+}
+
+func decodeAwardEmoji(name string) scm.ReactionContent {
+	for content, v := range awardEmojiNames {
+		if v == name {
+			return content
+		}
+	}
+	return scm.ReactionContent(name)
+}
+
+func convertAwardEmojiList(from []*awardEmoji) []*scm.Reaction {
+	to := []*scm.Reaction{}
+	for _, v := range from {
+		to = append(to, convertAwardEmoji(v))
+	}
+	return to
+}
+
+func convertAwardEmoji(from *awardEmoji) *scm.Reaction {
+	return &scm.Reaction{
+		ID:      from.ID,
+		Content: decodeAwardEmoji(from.Name),
+		User:    *convertUser(&from.User),
+		Created: from.Created,
+	}
+}
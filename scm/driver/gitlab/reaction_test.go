@@ -0,0 +1,153 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestReactionList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/issues/1/award_emoji").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/award_emojis.json")
+
+	client := NewDefault()
+	got, res, err := client.Reactions.List(context.Background(), "diaspora/diaspora", scm.ReactionTarget{Number: 1}, &scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Reaction{}
+	raw, _ := os.ReadFile("testdata/award_emojis.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+	t.Run("Page", testPage(res))
+}
+
+func TestReactionCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/notes/2/award_emoji").
+		JSON(map[string]string{"name": "thumbsup"}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/award_emoji.json")
+
+	client := NewDefault()
+	target := scm.ReactionTarget{Type: scm.ReactionOnReviewComment, Number: 1, Comment: 2}
+	got, _, err := client.Reactions.Create(context.Background(), "diaspora/diaspora", target, scm.ReactionPlusOne)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Reaction)
+	raw, _ := os.ReadFile("testdata/award_emoji.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReactionDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/user").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/issues/1/notes/2/award_emoji").
+		MatchParam("page", "1").
+		MatchParam("per_page", "100").
+		Reply(200).
+		Type("application/json").
+		File("testdata/award_emojis.json")
+
+	gock.New("https://gitlab.com").
+		Delete("/api/v4/projects/diaspora/diaspora/issues/1/notes/2/award_emoji/4").
+		Reply(204).
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	target := scm.ReactionTarget{Type: scm.ReactionOnIssueComment, Number: 1, Comment: 2}
+	_, err := client.Reactions.Delete(context.Background(), "diaspora/diaspora", target, scm.ReactionPlusOne)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected the award emoji to be deleted")
+	}
+}
+
+func TestReactionDeletePaged(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/user").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/issues/1/award_emoji").
+		MatchParam("page", "1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockPageHeaders).
+		File("testdata/award_emojis_page1.json")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/issues/1/award_emoji").
+		MatchParam("page", "2").
+		Reply(200).
+		Type("application/json").
+		File("testdata/award_emojis.json")
+
+	gock.New("https://gitlab.com").
+		Delete("/api/v4/projects/diaspora/diaspora/issues/1/award_emoji/4").
+		Reply(204).
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	target := scm.ReactionTarget{Type: scm.ReactionOnIssue, Number: 1}
+	_, err := client.Reactions.Delete(context.Background(), "diaspora/diaspora", target, scm.ReactionPlusOne)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Expected the award emoji on the second page to be deleted")
+	}
+}
@@ -5,8 +5,11 @@
 package gitlab
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
 	"strconv"
 	"strings"
@@ -101,6 +104,8 @@ type hook struct {
 	JobEvents             bool      `json:"job_events"`
 	PipelineEvents        bool      `json:"pipeline_events"`
 	WikiPageEvents        bool      `json:"wiki_page_events"`
+	MemberEvents          bool      `json:"member_events"`
+	SubgroupEvents        bool      `json:"subgroup_events"`
 	EnableSslVerification bool      `json:"enable_ssl_verification"`
 	CreatedAt             time.Time `json:"created_at"`
 }
@@ -370,6 +375,19 @@ func (s *repositoryService) UpdateHook(ctx context.Context, repo string, input *
 	return convertHook(out), res, err
 }
 
+// ConvertHookEvents returns the events reported for a webhook
+// created from the input.
+func (s *repositoryService) ConvertHookEvents(input *scm.HookInput) []string {
+	params := convertHookInputToGenericParam(input)
+	return convertEvents(&hook{
+		IssuesEvents:        params.Get("issues_events") == "true",
+		NoteEvents:          params.Get("note_events") == "true",
+		MergeRequestsEvents: params.Get("merge_requests_events") == "true",
+		PushEvents:          params.Get("push_events") == "true",
+		TagPushEvents:       params.Get("tag_push_events") == "true",
+	})
+}
+
 func convertHookInputToGenericParam(input *scm.HookInput) url.Values {
 	params := url.Values{}
 	params.Set("url", input.Target)
@@ -436,12 +454,72 @@ func (s *repositoryService) DeleteHook(ctx context.Context, repo, id string) (*s
 	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
+// ListHookDeliveries returns the recent events of a project webhook.
+//
+// See https://docs.gitlab.com/ee/api/project_webhooks.html#list-project-webhook-events
+func (s *repositoryService) ListHookDeliveries(ctx context.Context, repo, id string, opts *scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/hooks/%s/events?%s", encode(repo), id, encodeListOptions(opts))
+	out := []*hookEvent{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertHookEventList(out), res, err
+}
+
+// FindHookDelivery returns a project webhook event. GitLab does not
+// provide an endpoint for a single event, so the events are listed
+// until the event is found.
+func (s *repositoryService) FindHookDelivery(ctx context.Context, repo, id, delivery string) (*scm.HookDelivery, *scm.Response, error) {
+	opts := &scm.ListOptions{Page: 1, Size: 100}
+	for {
+		deliveries, res, err := s.ListHookDeliveries(ctx, repo, id, opts)
+		if err != nil {
+			return nil, res, err
+		}
+		for _, v := range deliveries {
+			if v.ID == delivery {
+				return v, res, nil
+			}
+		}
+		if res.Page.Next == 0 {
+			return nil, res, scm.ErrNotFound
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+// RedeliverHook resends a project webhook event.
+//
+// See https://docs.gitlab.com/ee/api/project_webhooks.html#resend-a-project-webhook-event
+func (s *repositoryService) RedeliverHook(ctx context.Context, repo, id, delivery string) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/hooks/%s/events/%s/resend", encode(repo), id, delivery)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
+// TestHook triggers a test push event for a project webhook.
+//
+// See https://docs.gitlab.com/ee/api/project_webhooks.html#trigger-a-test-project-webhook
+func (s *repositoryService) TestHook(ctx context.Context, repo, id string) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/hooks/%s/test/push_events", encode(repo), id)
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
 // Delete a given repo by 'name' or 'namespace/name'
 func (s *repositoryService) Delete(ctx context.Context, repo string) (*scm.Response, error) {
 	path := fmt.Sprintf("api/v4/projects/%s", encode(repo))
 	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
+func (s *repositoryService) Archive(ctx context.Context, repo, ref string, format scm.ArchiveFormat) (io.ReadCloser, *scm.Response, error) {
+	switch format {
+	case scm.ArchiveFormatTarball, scm.ArchiveFormatZip:
+	default:
+		return nil, nil, scm.ErrNotSupported
+	}
+	params := url.Values{}
+	params.Set("sha", ref)
+	path := fmt.Sprintf("api/v4/projects/%s/repository/archive.%s?%s", encode(repo), format, params.Encode())
+	return s.client.stream(ctx, "GET", path)
+}
+
 // helper function to convert from the gogs repository list to
 // the common repository structure.
 func convertRepositoryList(from []*repository) []*scm.Repository {
@@ -506,6 +584,65 @@ func convertHook(from *hook) *scm.Hook {
 	}
 }
 
+type hookEvent struct {
+	ID                int               `json:"id"`
+	URL               string            `json:"url"`
+	Trigger           string            `json:"trigger"`
+	RequestHeaders    map[string]string `json:"request_headers"`
+	RequestData       json.RawMessage   `json:"request_data"`
+	ResponseHeaders   map[string]string `json:"response_headers"`
+	ResponseBody      string            `json:"response_body"`
+	ExecutionDuration float64           `json:"execution_duration"`
+	ResponseStatus    string            `json:"response_status"`
+}
+
+func convertHookEventList(from []*hookEvent) []*scm.HookDelivery {
+	to := []*scm.HookDelivery{}
+	for _, v := range from {
+		to = append(to, convertHookEvent(v))
+	}
+	return to
+}
+
+func convertHookEvent(from *hookEvent) *scm.HookDelivery {
+	to := &scm.HookDelivery{
+		ID:       strconv.Itoa(from.ID),
+		GUID:     from.RequestHeaders["X-Gitlab-Event-UUID"],
+		Event:    from.RequestHeaders["X-Gitlab-Event"],
+		Status:   from.ResponseStatus,
+		Duration: time.Duration(from.ExecutionDuration * float64(time.Second)),
+		Request: scm.HookDeliveryRequest{
+			URL:    from.URL,
+			Method: "POST",
+			Header: from.RequestHeaders,
+		},
+		Response: scm.HookDeliveryResponse{
+			Header: from.ResponseHeaders,
+			Body:   from.ResponseBody,
+		},
+	}
+	if to.Event == "" {
+		to.Event = from.Trigger
+	}
+	buf := new(bytes.Buffer)
+	if err := json.Compact(buf, from.RequestData); err == nil {
+		to.Request.Body = buf.String()
+	}
+	// the response status is the status code, or a description
+	// of the failure if no response was received.
+	to.StatusCode, _ = strconv.Atoi(from.ResponseStatus)
+
+	data := new(struct {
+		ObjectAttributes struct {
+			Action string `json:"action"`
+		} `json:"object_attributes"`
+	})
+	if err := json.Unmarshal(from.RequestData, data); err == nil {
+		to.Action = data.ObjectAttributes.Action
+	}
+	return to
+}
+
 type status struct {
 	Name    string      `json:"name"`
 	Desc    null.String `json:"description"`
@@ -551,6 +688,12 @@ func convertEvents(from *hook) []string {
 	if from.MergeRequestsEvents {
 		events = append(events, "merge")
 	}
+	if from.MemberEvents {
+		events = append(events, "member")
+	}
+	if from.SubgroupEvents {
+		events = append(events, "subgroup")
+	}
 	return events
 }
 
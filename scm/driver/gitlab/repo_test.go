@@ -7,6 +7,7 @@ package gitlab
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"os"
 	"testing"
 
@@ -86,6 +87,54 @@ func TestRepositoryDelete(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestRepositoryArchive(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/archive.zip").
+		MatchParam("sha", "master").
+		Reply(200).
+		Type("application/zip").
+		SetHeaders(mockHeaders).
+		BodyString("archive")
+
+	client := NewDefault()
+	rc, res, err := client.Repositories.Archive(context.Background(), "diaspora/diaspora", "master", scm.ArchiveFormatZip)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := string(data), "archive"; got != want {
+		t.Errorf("Want archive contents %q, got %q", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+}
+
+func TestRepositoryArchiveNotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/repository/archive.zip").
+		Reply(404).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"message":"404 Not Found"}`)
+
+	client := NewDefault()
+	_, _, err := client.Repositories.Archive(context.Background(), "diaspora/diaspora", "master", scm.ArchiveFormatZip)
+	if err != scm.ErrNotFound {
+		t.Errorf("Want error %v, got %v", scm.ErrNotFound, err)
+	}
+}
+
 func TestRepositoryFork(t *testing.T) {
 	defer gock.Off()
 
@@ -561,6 +610,153 @@ func TestRepositoryHookDelete(t *testing.T) {
 	t.Run("Rate", testRate(res))
 }
 
+func TestRepositoryHookDeliveryList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/hooks/1/events").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/hook_events.json")
+
+	client := NewDefault()
+	got, res, err := client.Repositories.ListHookDeliveries(context.Background(), "diaspora/diaspora", "1", &scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.HookDelivery{}
+	raw, _ := os.ReadFile("testdata/hook_events.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+	t.Run("Page", testPage(res))
+}
+
+func TestRepositoryHookDeliveryFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/hooks/1/events").
+		MatchParam("page", "1").
+		MatchParam("per_page", "100").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeader("Link", `<https://gitlab.com/resource?page=2>; rel="next"`).
+		BodyString("[]")
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/hooks/1/events").
+		MatchParam("page", "2").
+		MatchParam("per_page", "100").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/hook_events.json")
+
+	client := NewDefault()
+	got, _, err := client.Repositories.FindHookDelivery(context.Background(), "diaspora/diaspora", "1", "2")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.HookDelivery{}
+	raw, _ := os.ReadFile("testdata/hook_events.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want[1], got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRepositoryHookDeliveryFind_NotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/hooks/1/events").
+		MatchParam("page", "1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/hook_events.json")
+
+	client := NewDefault()
+	_, _, err := client.Repositories.FindHookDelivery(context.Background(), "diaspora/diaspora", "1", "3")
+	if err != scm.ErrNotFound {
+		t.Errorf("Want error %v, got %v", scm.ErrNotFound, err)
+	}
+}
+
+func TestRepositoryHookRedeliver(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/hooks/1/events/2/resend").
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"response_status": 200}`)
+
+	client := NewDefault()
+	res, err := client.Repositories.RedeliverHook(context.Background(), "diaspora/diaspora", "1", "2")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := res.Status, 201; got != want {
+		t.Errorf("Want response status %d, got %d", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
+func TestRepositoryHookTest(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/hooks/1/test/push_events").
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"message": "201 Created"}`)
+
+	client := NewDefault()
+	res, err := client.Repositories.TestHook(context.Background(), "diaspora/diaspora", "1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := res.Status, 201; got != want {
+		t.Errorf("Want response status %d, got %d", want, got)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+}
+
 func TestRepositoryHookCreate(t *testing.T) {
 	defer gock.Off()
 
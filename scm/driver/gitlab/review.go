@@ -6,42 +6,593 @@ package gitlab
 
 import (
 	"context"
+	"crypto/sha1"
+	"fmt"
+	"strings"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
 
+// GitLab has no review resource. Reviews are modelled on top of the
+// merge request discussions: an approval is the system note GitLab
+// records when a user approves, and a comment review is a positioned
+// diff discussion. The review id is the id of the first note of the
+// discussion. Pending reviews are draft notes of the current user,
+// identified by the id of their first draft note, which becomes the
+// id of the published note once the review is submitted. GitLab does
+// not group draft notes, so only the drafts created by the same call
+// are published together.
 type reviewService struct {
 	client *wrapper
 }
 
 func (s *reviewService) Find(ctx context.Context, repo string, number, id int) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/notes/%d", encode(repo), number, id)
+	out := new(reviewNote)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertReviewNote(out), res, nil
 }
 
 func (s *reviewService) List(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/discussions?%s", encode(repo), number, encodeListOptions(opts))
+	out := []*discussion{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertDiscussionReviewList(out), res, err
 }
 
 func (s *reviewService) Create(ctx context.Context, repo string, number int, input *scm.ReviewInput) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	switch input.Event {
+	case "", scm.ReviewStatePending, "COMMENT", "APPROVE", scm.ReviewStateApproved:
+	default:
+		// GitLab does not expose requesting changes through the API.
+		return nil, nil, scm.ErrNotSupported
+	}
+
+	var refs *diffRefs
+	if len(input.Comments) > 0 {
+		var res *scm.Response
+		var err error
+		refs, res, err = s.diffRefs(ctx, repo, number)
+		if err != nil {
+			return nil, res, err
+		}
+	}
+
+	review := &scm.Review{
+		Body:  input.Body,
+		Sha:   input.Sha,
+		State: scm.ReviewStatePending,
+	}
+	var res *scm.Response
+	var drafts []*draftNote
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/draft_notes", encode(repo), number)
+	for _, c := range input.Comments {
+		in := &draftNoteInput{
+			Note:                  c.Body,
+			InReplyToDiscussionID: c.ThreadID,
+		}
+		if c.ThreadID == "" {
+			in.Position = convertNotePosition(refs, c)
+		}
+		out := new(draftNote)
+		var err error
+		res, err = s.client.do(ctx, "POST", path, in, out)
+		if err != nil {
+			return nil, res, err
+		}
+		drafts = append(drafts, out)
+	}
+	if input.Body != "" {
+		out := new(draftNote)
+		var err error
+		res, err = s.client.do(ctx, "POST", path, &draftNoteInput{Note: input.Body}, out)
+		if err != nil {
+			return nil, res, err
+		}
+		drafts = append(drafts, out)
+	}
+	if len(drafts) > 0 {
+		review.ID = drafts[0].ID
+	}
+
+	switch input.Event {
+	case "", scm.ReviewStatePending:
+		return review, res, nil
+	}
+	return s.submit(ctx, repo, number, review, input.Event, drafts)
 }
 
+// Delete deletes the draft note when id is one of the current user's
+// draft notes, otherwise it deletes the note with the id. The other
+// draft notes of the user are left untouched.
 func (s *reviewService) Delete(ctx context.Context, repo string, number, id int) (*scm.Response, error) {
-	return nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/draft_notes", encode(repo), number)
+	out := []*draftNote{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	if err != nil {
+		return res, err
+	}
+	for _, d := range out {
+		if d.ID == id {
+			return s.client.do(ctx, "DELETE", fmt.Sprintf("%s/%d", path, id), nil, nil)
+		}
+	}
+	path = fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/notes/%d", encode(repo), number, id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
 func (s *reviewService) ListComments(ctx context.Context, repo string, prID, reviewID int, options *scm.ListOptions) ([]*scm.ReviewComment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	d, res, err := s.findDiscussion(ctx, repo, prID, func(d *discussion) bool {
+		return len(d.Notes) > 0 && d.Notes[0].ID == reviewID
+	})
+	if err != nil {
+		return nil, res, err
+	}
+	return convertDiscussionComments(d), res, nil
+}
+
+// CreateComment starts a new positioned discussion, or replies to the
+// discussion with the ThreadID or containing the InReplyTo note.
+func (s *reviewService) CreateComment(ctx context.Context, repo string, number int, input *scm.ReviewCommentInput) (*scm.ReviewComment, *scm.Response, error) {
+	thread := input.ThreadID
+	if thread == "" && input.InReplyTo != 0 {
+		d, res, err := s.findDiscussion(ctx, repo, number, func(d *discussion) bool {
+			for _, n := range d.Notes {
+				if n.ID == input.InReplyTo {
+					return true
+				}
+			}
+			return false
+		})
+		if err != nil {
+			return nil, res, err
+		}
+		thread = d.ID
+	}
+	if thread != "" {
+		path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/discussions/%s/notes", encode(repo), number, thread)
+		in := &updateNoteOptions{Body: input.Body}
+		out := new(reviewNote)
+		res, err := s.client.do(ctx, "POST", path, in, out)
+		if err != nil {
+			return nil, res, err
+		}
+		comment := convertReviewComment(out)
+		comment.ThreadID = thread
+		comment.InReplyTo = input.InReplyTo
+		return comment, res, nil
+	}
+
+	refs, res, err := s.diffRefs(ctx, repo, number)
+	if err != nil {
+		return nil, res, err
+	}
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/discussions", encode(repo), number)
+	in := &discussionInput{
+		Body:     input.Body,
+		Position: convertNotePosition(refs, input),
+	}
+	out := new(discussion)
+	res, err = s.client.do(ctx, "POST", path, in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	comments := convertDiscussionComments(out)
+	if len(comments) == 0 {
+		return nil, res, scm.ErrNotFound
+	}
+	return comments[0], res, nil
+}
+
+// ListThreads returns the resolvable diff discussions of the merge
+// request.
+func (s *reviewService) ListThreads(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.ReviewThread, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/discussions?%s", encode(repo), number, encodeListOptions(opts))
+	out := []*discussion{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	if err != nil {
+		return nil, res, err
+	}
+	to := []*scm.ReviewThread{}
+	for _, d := range out {
+		if len(d.Notes) == 0 || d.Notes[0].Position == nil {
+			continue
+		}
+		to = append(to, convertReviewThread(d))
+	}
+	return to, res, nil
+}
+
+func (s *reviewService) ResolveThread(ctx context.Context, repo string, number int, id string) (*scm.Response, error) {
+	return s.setThreadResolved(ctx, repo, number, id, true)
+}
+
+func (s *reviewService) UnresolveThread(ctx context.Context, repo string, number int, id string) (*scm.Response, error) {
+	return s.setThreadResolved(ctx, repo, number, id, false)
+}
+
+func (s *reviewService) setThreadResolved(ctx context.Context, repo string, number int, id string, resolved bool) (*scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/discussions/%s?resolved=%t", encode(repo), number, id, resolved)
+	return s.client.do(ctx, "PUT", path, nil, nil)
+}
+
+func (s *reviewService) diffRefs(ctx context.Context, repo string, number int) (*diffRefs, *scm.Response, error) {
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d", encode(repo), number)
+	out := new(mergeRequestDiffRefs)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return &out.DiffRefs, res, err
+}
+
+// findDiscussion pages through the discussions of the merge request
+// and returns the first one matching fn.
+func (s *reviewService) findDiscussion(ctx context.Context, repo string, number int, fn func(*discussion) bool) (*discussion, *scm.Response, error) {
+	opts := scm.ListOptions{Page: 1, Size: 100}
+	for {
+		path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/discussions?%s", encode(repo), number, encodeListOptions(&opts))
+		out := []*discussion{}
+		res, err := s.client.do(ctx, "GET", path, nil, &out)
+		if err != nil {
+			return nil, res, err
+		}
+		for _, d := range out {
+			if fn(d) {
+				return d, res, nil
+			}
+		}
+		if res.Page.Next == 0 {
+			return nil, res, scm.ErrNotFound
+		}
+		opts.Page = res.Page.Next
+	}
 }
 
 func (s *reviewService) Update(ctx context.Context, repo string, prID, reviewID int, body string) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/notes/%d", encode(repo), prID, reviewID)
+	in := &updateNoteOptions{Body: body}
+	out := new(reviewNote)
+	res, err := s.client.do(ctx, "PUT", path, in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertReviewNote(out), res, nil
 }
 
+// Submit publishes the draft note of the pending review, and the
+// body when set, and approves the merge request when the event
+// approves. The other draft notes of the user stay pending.
 func (s *reviewService) Submit(ctx context.Context, repo string, prID, reviewID int, input *scm.ReviewSubmitInput) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	switch input.Event {
+	case "COMMENT", "APPROVE", scm.ReviewStateApproved:
+	default:
+		return nil, nil, scm.ErrNotSupported
+	}
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/draft_notes", encode(repo), prID)
+	pending := []*draftNote{}
+	res, err := s.client.do(ctx, "GET", path, nil, &pending)
+	if err != nil {
+		return nil, res, err
+	}
+	var drafts []*draftNote
+	for _, d := range pending {
+		if d.ID == reviewID {
+			drafts = append(drafts, d)
+		}
+	}
+	if len(drafts) == 0 {
+		return nil, res, scm.ErrNotFound
+	}
+	if input.Body != "" {
+		out := new(draftNote)
+		res, err := s.client.do(ctx, "POST", path, &draftNoteInput{Note: input.Body}, out)
+		if err != nil {
+			return nil, res, err
+		}
+		drafts = append(drafts, out)
+	}
+	review := &scm.Review{
+		ID:   reviewID,
+		Body: input.Body,
+	}
+	return s.submit(ctx, repo, prID, review, input.Event, drafts)
 }
 
+// Dismiss removes the approval of the current user. GitLab does not
+// allow removing the approval of another user.
 func (s *reviewService) Dismiss(ctx context.Context, repo string, prID, reviewID int, msg string) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/unapprove", encode(repo), prID)
+	res, err := s.client.do(ctx, "POST", path, nil, nil)
+	if err != nil {
+		return nil, res, err
+	}
+	return &scm.Review{
+		ID:    reviewID,
+		Body:  msg,
+		State: scm.ReviewStateDismissed,
+	}, res, nil
+}
+
+// submit publishes the draft notes, rather than all of the user's
+// draft notes, and approves the merge request when the event
+// approves. The review id is set to the id of the note published
+// from the first draft.
+func (s *reviewService) submit(ctx context.Context, repo string, number int, review *scm.Review, event string, drafts []*draftNote) (*scm.Review, *scm.Response, error) {
+	var res *scm.Response
+	for _, d := range drafts {
+		path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/draft_notes/%d/publish", encode(repo), number, d.ID)
+		var err error
+		res, err = s.client.do(ctx, "PUT", path, nil, nil)
+		if err != nil {
+			return nil, res, err
+		}
+	}
+	if len(drafts) > 0 {
+		id, res, err := s.findPublishedNote(ctx, repo, number, drafts[0])
+		if err != nil {
+			return nil, res, err
+		}
+		review.ID = id
+	}
+	review.State = scm.ReviewStateCommented
+	if event == "APPROVE" || event == scm.ReviewStateApproved {
+		path := fmt.Sprintf("api/v4/projects/%s/merge_requests/%d/approve", encode(repo), number)
+		in := &approveInput{Sha: review.Sha}
+		var err error
+		res, err = s.client.do(ctx, "POST", path, in, nil)
+		if err != nil {
+			return nil, res, err
+		}
+		review.State = scm.ReviewStateApproved
+	}
+	return review, res, nil
+}
+
+// findPublishedNote returns the id of the note published from the
+// draft. Publishing does not return the note, so the discussions are
+// searched for the latest note of the draft author with its body.
+func (s *reviewService) findPublishedNote(ctx context.Context, repo string, number int, draft *draftNote) (int, *scm.Response, error) {
+	id := 0
+	_, res, err := s.findDiscussion(ctx, repo, number, func(d *discussion) bool {
+		for _, n := range d.Notes {
+			if !n.System && n.Author.ID == draft.AuthorID && n.Body == draft.Note {
+				id = n.ID
+			}
+		}
+		return false
+	})
+	if id != 0 {
+		return id, res, nil
+	}
+	if err == nil {
+		err = scm.ErrNotFound
+	}
+	return 0, res, err
+}
+
+type diffRefs struct {
+	BaseSha  string `json:"base_sha"`
+	StartSha string `json:"start_sha"`
+	HeadSha  string `json:"head_sha"`
+}
+
+type mergeRequestDiffRefs struct {
+	DiffRefs diffRefs `json:"diff_refs"`
+}
+
+type notePosition struct {
+	BaseSha      string         `json:"base_sha"`
+	StartSha     string         `json:"start_sha"`
+	HeadSha      string         `json:"head_sha"`
+	PositionType string         `json:"position_type"`
+	NewPath      string         `json:"new_path,omitempty"`
+	OldPath      string         `json:"old_path,omitempty"`
+	NewLine      int            `json:"new_line,omitempty"`
+	OldLine      int            `json:"old_line,omitempty"`
+	LineRange    *noteLineRange `json:"line_range,omitempty"`
+}
+
+type noteLineRange struct {
+	Start noteLine `json:"start"`
+	End   noteLine `json:"end"`
+}
+
+type noteLine struct {
+	LineCode string `json:"line_code"`
+	Type     string `json:"type"`
+	OldLine  int    `json:"old_line"`
+	NewLine  int    `json:"new_line"`
+}
+
+type draftNoteInput struct {
+	Note                  string        `json:"note"`
+	Position              *notePosition `json:"position,omitempty"`
+	InReplyToDiscussionID string        `json:"in_reply_to_discussion_id,omitempty"`
+}
+
+type discussionInput struct {
+	Body     string        `json:"body"`
+	Position *notePosition `json:"position,omitempty"`
+}
+
+type draftNote struct {
+	ID       int           `json:"id"`
+	AuthorID int           `json:"author_id"`
+	Note     string        `json:"note"`
+	Position *notePosition `json:"position"`
+}
+
+type approveInput struct {
+	Sha string `json:"sha,omitempty"`
+}
+
+type reviewNote struct {
+	ID        int           `json:"id"`
+	Type      string        `json:"type"`
+	Body      string        `json:"body"`
+	System    bool          `json:"system"`
+	Author    user          `json:"author"`
+	Position  *notePosition `json:"position"`
+	Resolved  bool          `json:"resolved"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+type discussion struct {
+	ID    string        `json:"id"`
+	Notes []*reviewNote `json:"notes"`
+}
+
+// convertNotePosition returns the diff position of the comment on the
+// merge request diff.
+func convertNotePosition(refs *diffRefs, from *scm.ReviewCommentInput) *notePosition {
+	to := &notePosition{
+		BaseSha:      refs.BaseSha,
+		StartSha:     refs.StartSha,
+		HeadSha:      refs.HeadSha,
+		PositionType: "text",
+		NewPath:      from.Path,
+		OldPath:      from.Path,
+	}
+	if from.Sha != "" {
+		to.HeadSha = from.Sha
+	}
+	if from.Side == scm.DiffSideLeft {
+		to.OldLine = from.Line
+	} else {
+		to.NewLine = from.Line
+	}
+	if from.StartLine != 0 && from.StartLine != from.Line {
+		to.LineRange = &noteLineRange{
+			Start: convertNoteLine(from.Path, from.Side, from.StartLine),
+			End:   convertNoteLine(from.Path, from.Side, from.Line),
+		}
+	}
+	return to
+}
+
+// convertNoteLine returns the line of a multi-line position. The line
+// code is the sha1 of the path followed by the old and the new line
+// numbers, the line number of the other side being unknown.
+func convertNoteLine(path string, side scm.DiffSide, line int) noteLine {
+	to := noteLine{Type: "new", NewLine: line}
+	if side == scm.DiffSideLeft {
+		to = noteLine{Type: "old", OldLine: line}
+	}
+	to.LineCode = fmt.Sprintf("%x_%d_%d", sha1.Sum([]byte(path)), to.OldLine, to.NewLine)
+	return to
+}
+
+// reviewState returns the review state recorded by the note, or
+// false if the note is not part of a review.
+func reviewState(from *reviewNote) (string, bool) {
+	if from.System {
+		switch strings.TrimSpace(from.Body) {
+		case "approved this merge request":
+			return scm.ReviewStateApproved, true
+		case "unapproved this merge request":
+			return scm.ReviewStateDismissed, true
+		case "requested changes":
+			return scm.ReviewStateChangesRequested, true
+		}
+		return "", false
+	}
+	if from.Type == "DiffNote" {
+		return scm.ReviewStateCommented, true
+	}
+	return "", false
+}
+
+func convertDiscussionReviewList(from []*discussion) []*scm.Review {
+	to := []*scm.Review{}
+	for _, d := range from {
+		if len(d.Notes) == 0 {
+			continue
+		}
+		if _, ok := reviewState(d.Notes[0]); !ok {
+			continue
+		}
+		to = append(to, convertReviewNote(d.Notes[0]))
+	}
+	return to
+}
+
+func convertReviewNote(from *reviewNote) *scm.Review {
+	state, ok := reviewState(from)
+	if !ok {
+		state = scm.ReviewStateCommented
+	}
+	review := &scm.Review{
+		ID:      from.ID,
+		Body:    from.Body,
+		State:   state,
+		Author:  *convertUser(&from.Author),
+		Created: from.CreatedAt,
+		Updated: from.UpdatedAt,
+	}
+	if from.Position != nil {
+		review.Sha = from.Position.HeadSha
+	}
+	return review
+}
+
+func convertDiscussionComments(from *discussion) []*scm.ReviewComment {
+	to := []*scm.ReviewComment{}
+	for i, v := range from.Notes {
+		comment := convertReviewComment(v)
+		comment.ThreadID = from.ID
+		if i > 0 {
+			comment.InReplyTo = from.Notes[0].ID
+		}
+		to = append(to, comment)
+	}
+	return to
+}
+
+func convertReviewThread(from *discussion) *scm.ReviewThread {
+	first := from.Notes[0]
+	comments := convertDiscussionComments(from)
+	return &scm.ReviewThread{
+		ID:        from.ID,
+		Path:      comments[0].Path,
+		Line:      comments[0].Line,
+		StartLine: comments[0].StartLine,
+		Side:      comments[0].Side,
+		Resolved:  first.Resolved,
+		Comments:  comments,
+	}
+}
+
+func convertReviewComment(from *reviewNote) *scm.ReviewComment {
+	comment := &scm.ReviewComment{
+		ID:      from.ID,
+		Body:    from.Body,
+		Author:  *convertUser(&from.Author),
+		Created: from.CreatedAt,
+		Updated: from.UpdatedAt,
+	}
+	if p := from.Position; p != nil {
+		comment.Sha = p.HeadSha
+		comment.Path = p.NewPath
+		comment.Line = p.NewLine
+		comment.Side = scm.DiffSideRight
+		if comment.Line == 0 {
+			comment.Path = p.OldPath
+			comment.Line = p.OldLine
+			comment.Side = scm.DiffSideLeft
+		}
+		comment.FileLine = comment.Line
+		if r := p.LineRange; r != nil {
+			comment.StartLine = r.Start.NewLine
+			if comment.Side == scm.DiffSideLeft {
+				comment.StartLine = r.Start.OldLine
+			}
+			if comment.StartLine == comment.Line {
+				comment.StartLine = 0
+			}
+		}
+	}
+	return comment
 }
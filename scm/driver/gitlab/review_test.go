@@ -6,39 +6,542 @@ package gitlab
 
 import (
 	"context"
+	"encoding/json"
+	"os"
 	"testing"
 
 	"github.com/jenkins-x/go-scm/scm"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestReviewFind(t *testing.T) {
-	service := new(reviewService)
-	_, _, err := service.Find(context.Background(), "diaspora/diaspora", 1, 1)
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/notes/1126").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_approval_note.json")
+
+	client := NewDefault()
+	got, res, err := client.Reviews.Find(context.Background(), "diaspora/diaspora", 1, 1126)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Review)
+	raw, _ := os.ReadFile("testdata/merge_approval_note.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
 }
 
 func TestReviewList(t *testing.T) {
-	service := new(reviewService)
-	_, _, err := service.List(context.Background(), "diaspora/diaspora", 1, &scm.ListOptions{})
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/merge_discussions.json")
+
+	client := NewDefault()
+	got, res, err := client.Reviews.List(context.Background(), "diaspora/diaspora", 1, &scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Review{}
+	raw, _ := os.ReadFile("testdata/merge_discussions.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+	t.Run("Page", testPage(res))
+}
+
+func TestReviewListComments(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions").
+		MatchParam("page", "1").
+		MatchParam("per_page", "100").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_discussions.json")
+
+	client := NewDefault()
+	got, _, err := client.Reviews.ListComments(context.Background(), "diaspora/diaspora", 1, 1128, &scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.ReviewComment{}
+	raw, _ := os.ReadFile("testdata/merge_discussion_comments.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
 }
 
 func TestReviewCreate(t *testing.T) {
-	service := new(reviewService)
-	_, _, err := service.Create(context.Background(), "diaspora/diaspora", 1, nil)
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge.json")
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/draft_notes").
+		JSON(map[string]interface{}{
+			"note": "Should this be configurable?",
+			"position": map[string]interface{}{
+				"base_sha":      "9c5dc8c4123abcdef0123456789abcdef0123456",
+				"start_sha":     "9c5dc8c4123abcdef0123456789abcdef0123456",
+				"head_sha":      "12d65c8dd2b2676fa3ac47d955accc085a37a9c1",
+				"position_type": "text",
+				"new_path":      "docs/README.md",
+				"old_path":      "docs/README.md",
+				"new_line":      24,
+			},
+		}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_draft_note.json")
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/merge_requests/1/draft_notes/37349978/publish").
+		Reply(204).
+		SetHeaders(mockHeaders)
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_discussions_published.json")
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/approve").
+		JSON(map[string]string{"sha": "12d65c8dd2b2676fa3ac47d955accc085a37a9c1"}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	input := &scm.ReviewInput{
+		Sha:   "12d65c8dd2b2676fa3ac47d955accc085a37a9c1",
+		Event: "APPROVE",
+		Comments: []*scm.ReviewCommentInput{
+			{Body: "Should this be configurable?", Path: "docs/README.md", Line: 24},
+		},
+	}
+	client := NewDefault()
+	got, _, err := client.Reviews.Create(context.Background(), "diaspora/diaspora", 1, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got.ID != 1131 {
+		t.Errorf("Want review id 1131, got %d", got.ID)
+	}
+	if got.State != scm.ReviewStateApproved {
+		t.Errorf("Want review state %s, got %s", scm.ReviewStateApproved, got.State)
+	}
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/notes/1131").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_note.json")
+
+	if _, _, err := client.Reviews.Find(context.Background(), "diaspora/diaspora", 1, got.ID); err != nil {
+		t.Errorf("Want the created review to be found, got %v", err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewCreatePending(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/draft_notes").
+		JSON(map[string]string{"note": "Looks good otherwise."}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_draft_note.json")
+
+	client := NewDefault()
+	got, _, err := client.Reviews.Create(context.Background(), "diaspora/diaspora", 1, &scm.ReviewInput{Body: "Looks good otherwise."})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got.State != scm.ReviewStatePending {
+		t.Errorf("Want review state %s, got %s", scm.ReviewStatePending, got.State)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewCreateRequestChanges(t *testing.T) {
+	client := NewDefault()
+	_, _, err := client.Reviews.Create(context.Background(), "diaspora/diaspora", 1, &scm.ReviewInput{Event: "REQUEST_CHANGES"})
 	if err != scm.ErrNotSupported {
 		t.Errorf("Expect Not Supported error")
 	}
 }
 
+func TestReviewSubmit(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/draft_notes").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_draft_notes.json")
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/draft_notes").
+		JSON(map[string]string{"note": "Looks good otherwise."}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString(`{"id": 37349979, "author_id": 1, "note": "Looks good otherwise."}`)
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/merge_requests/1/draft_notes/37349978/publish").
+		Reply(204).
+		SetHeaders(mockHeaders)
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/merge_requests/1/draft_notes/37349979/publish").
+		Reply(204).
+		SetHeaders(mockHeaders)
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_discussions_published.json")
+
+	client := NewDefault()
+	got, _, err := client.Reviews.Submit(context.Background(), "diaspora/diaspora", 1, 37349978, &scm.ReviewSubmitInput{Body: "Looks good otherwise.", Event: "COMMENT"})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got.ID != 1131 {
+		t.Errorf("Want review id 1131, got %d", got.ID)
+	}
+	if got.State != scm.ReviewStateCommented {
+		t.Errorf("Want review state %s, got %s", scm.ReviewStateCommented, got.State)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
 func TestReviewDelete(t *testing.T) {
-	service := new(reviewService)
-	_, err := service.Delete(context.Background(), "diaspora/diaspora", 1, 1)
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/draft_notes").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_draft_notes.json")
+
+	gock.New("https://gitlab.com").
+		Delete("/api/v4/projects/diaspora/diaspora/merge_requests/1/draft_notes/37349978").
+		Reply(204).
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	_, err := client.Reviews.Delete(context.Background(), "diaspora/diaspora", 1, 37349978)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewDeletePublished(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/draft_notes").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		BodyString("[]")
+
+	gock.New("https://gitlab.com").
+		Delete("/api/v4/projects/diaspora/diaspora/merge_requests/1/notes/1128").
+		Reply(204).
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	_, err := client.Reviews.Delete(context.Background(), "diaspora/diaspora", 1, 1128)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewDismiss(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/unapprove").
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	got, _, err := client.Reviews.Dismiss(context.Background(), "diaspora/diaspora", 1, 1126, "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got.State != scm.ReviewStateDismissed {
+		t.Errorf("Want review state %s, got %s", scm.ReviewStateDismissed, got.State)
+	}
+}
+
+func TestReviewCreateComment(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge.json")
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions").
+		JSON(map[string]interface{}{
+			"body": "Should this be configurable?",
+			"position": map[string]interface{}{
+				"base_sha":      "9c5dc8c4123abcdef0123456789abcdef0123456",
+				"start_sha":     "9c5dc8c4123abcdef0123456789abcdef0123456",
+				"head_sha":      "12d65c8dd2b2676fa3ac47d955accc085a37a9c1",
+				"position_type": "text",
+				"new_path":      "docs/README.md",
+				"old_path":      "docs/README.md",
+				"old_line":      12,
+			},
+		}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_discussion.json")
+
+	input := &scm.ReviewCommentInput{
+		Body: "Should this be configurable?",
+		Path: "docs/README.md",
+		Line: 12,
+		Side: scm.DiffSideLeft,
+	}
+	client := NewDefault()
+	got, _, err := client.Reviews.CreateComment(context.Background(), "diaspora/diaspora", 1, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got.ThreadID != "87805b7c09016a7058e91bdbe7b29d1f284a39e6" {
+		t.Errorf("Unexpected thread id %q", got.ThreadID)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewCreateCommentMultiLine(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge.json")
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions").
+		JSON(map[string]interface{}{
+			"body": "Should this be configurable?",
+			"position": map[string]interface{}{
+				"base_sha":      "9c5dc8c4123abcdef0123456789abcdef0123456",
+				"start_sha":     "9c5dc8c4123abcdef0123456789abcdef0123456",
+				"head_sha":      "12d65c8dd2b2676fa3ac47d955accc085a37a9c1",
+				"position_type": "text",
+				"new_path":      "docs/README.md",
+				"old_path":      "docs/README.md",
+				"new_line":      24,
+				"line_range": map[string]interface{}{
+					"start": map[string]interface{}{
+						"line_code": "9f7cbce127b94a94ee14460050fbff2ce58417d0_0_20",
+						"type":      "new",
+						"old_line":  0,
+						"new_line":  20,
+					},
+					"end": map[string]interface{}{
+						"line_code": "9f7cbce127b94a94ee14460050fbff2ce58417d0_0_24",
+						"type":      "new",
+						"old_line":  0,
+						"new_line":  24,
+					},
+				},
+			},
+		}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_discussion.json")
+
+	input := &scm.ReviewCommentInput{
+		Body:      "Should this be configurable?",
+		Path:      "docs/README.md",
+		StartLine: 20,
+		Line:      24,
+		Side:      scm.DiffSideRight,
+	}
+	client := NewDefault()
+	_, _, err := client.Reviews.CreateComment(context.Background(), "diaspora/diaspora", 1, input)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewCreateCommentReply(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions").
+		MatchParam("page", "1").
+		MatchParam("per_page", "100").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_discussions.json")
+
+	gock.New("https://gitlab.com").
+		Post("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions/87805b7c09016a7058e91bdbe7b29d1f284a39e6/notes").
+		JSON(map[string]string{"body": "Done."}).
+		Reply(201).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/merge_approval_note.json")
+
+	client := NewDefault()
+	got, _, err := client.Reviews.CreateComment(context.Background(), "diaspora/diaspora", 1, &scm.ReviewCommentInput{Body: "Done.", InReplyTo: 1129})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got.ThreadID != "87805b7c09016a7058e91bdbe7b29d1f284a39e6" {
+		t.Errorf("Unexpected thread id %q", got.ThreadID)
+	}
+	if got.InReplyTo != 1129 {
+		t.Errorf("Want in reply to 1129, got %d", got.InReplyTo)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewListThreads(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/merge_discussions.json")
+
+	client := NewDefault()
+	got, res, err := client.Reviews.ListThreads(context.Background(), "diaspora/diaspora", 1, &scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.ReviewThread{}
+	raw, _ := os.ReadFile("testdata/merge_threads.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Page", testPage(res))
+}
+
+func TestReviewResolveThread(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Put("/api/v4/projects/diaspora/diaspora/merge_requests/1/discussions/87805b7c09016a7058e91bdbe7b29d1f284a39e6").
+		MatchParam("resolved", "true").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders)
+
+	client := NewDefault()
+	_, err := client.Reviews.ResolveThread(context.Background(), "diaspora/diaspora", 1, "87805b7c09016a7058e91bdbe7b29d1f284a39e6")
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
 	}
 }
@@ -0,0 +1,208 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// searchService implements the search API. GitLab searches a
+// single scope at a time, in a project, a group or the whole
+// instance; labels and the issue author are filtered on the
+// returned page, the language and commit author are ignored.
+type searchService struct {
+	client *wrapper
+}
+
+type blob struct {
+	Basename  string `json:"basename"`
+	Data      string `json:"data"`
+	Path      string `json:"path"`
+	Filename  string `json:"filename"`
+	ID        string `json:"id"`
+	Ref       string `json:"ref"`
+	Startline int    `json:"startline"`
+	ProjectID int    `json:"project_id"`
+}
+
+type searchCommit struct {
+	commit
+	ProjectID int `json:"project_id"`
+}
+
+func (s *searchService) Code(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchCode, *scm.Response, error) {
+	text := q.Text
+	if q.Path != "" {
+		text = strings.TrimSpace(text + " path:" + q.Path)
+	}
+	path := encodeSearchPath(q, opts, "blobs", text)
+	out := []*blob{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertBlobList(out, q), res, err
+}
+
+func (s *searchService) Repositories(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	// projects can only be searched in a group or the instance
+	scoped := *q
+	scoped.Repo = ""
+	path := encodeSearchPath(&scoped, opts, "projects", q.Text)
+	out := []*repository{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertRepositoryList(out), res, err
+}
+
+func (s *searchService) Issues(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchIssue, *scm.Response, error) {
+	scope := "issues"
+	if q.Type == scm.SearchTypePullRequest {
+		scope = "merge_requests"
+	}
+	path := encodeSearchPath(q, opts, scope, q.Text)
+	out := []*issue{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertSearchIssueList(out, q, scope == "merge_requests"), res, err
+}
+
+func (s *searchService) Commits(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchCommit, *scm.Response, error) {
+	path := encodeSearchPath(q, opts, "commits", q.Text)
+	out := []*searchCommit{}
+	res, err := s.client.do(ctx, "GET", path, nil, &out)
+	return convertSearchCommitList(out, q), res, err
+}
+
+// encodeSearchPath returns the search endpoint of the query
+// scope with the search parameters.
+func encodeSearchPath(q *scm.SearchQuery, opts *scm.ListOptions, scope, text string) string {
+	params := url.Values{}
+	params.Set("scope", scope)
+	params.Set("search", text)
+	switch q.State {
+	case "open":
+		params.Set("state", "opened")
+	case "closed":
+		params.Set("state", "closed")
+	}
+	if opts != nil {
+		if opts.Page != 0 {
+			params.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.Size != 0 {
+			params.Set("per_page", strconv.Itoa(opts.Size))
+		}
+	}
+	switch {
+	case q.Repo != "":
+		return fmt.Sprintf("api/v4/projects/%s/search?%s", encode(q.Repo), params.Encode())
+	case q.Org != "":
+		return fmt.Sprintf("api/v4/groups/%s/search?%s", encode(q.Org), params.Encode())
+	default:
+		return fmt.Sprintf("api/v4/search?%s", params.Encode())
+	}
+}
+
+// searchRepository returns the repository of a search result,
+// which only holds the project id, or the path of the project
+// when the search is scoped to it.
+func searchRepository(q *scm.SearchQuery, projectID int) scm.Repository {
+	to := scm.Repository{ID: strconv.Itoa(projectID)}
+	if q.Repo != "" {
+		to.Namespace, to.Name = scm.Split(q.Repo)
+		to.FullName = q.Repo
+	}
+	return to
+}
+
+func convertBlobList(from []*blob, q *scm.SearchQuery) []*scm.SearchCode {
+	to := []*scm.SearchCode{}
+	for _, v := range from {
+		to = append(to, &scm.SearchCode{
+			Name:       v.Basename,
+			Path:       v.Path,
+			Sha:        v.ID,
+			Ref:        v.Ref,
+			Repository: searchRepository(q, v.ProjectID),
+			Fragments:  []string{v.Data},
+		})
+	}
+	return to
+}
+
+func convertSearchIssueList(from []*issue, q *scm.SearchQuery, mergeRequests bool) []*scm.SearchIssue {
+	to := []*scm.SearchIssue{}
+	for _, v := range from {
+		if q.Author != "" && !strings.EqualFold(v.Author.Username, q.Author) {
+			continue
+		}
+		if !containsLabels(v.Labels, q.Labels) {
+			continue
+		}
+		result := &scm.SearchIssue{
+			Issue: *convertIssue(v),
+		}
+		if mergeRequests {
+			result.PullRequest = &scm.PullRequest{
+				Number: v.Number,
+				Link:   v.Link,
+			}
+		}
+		populateRepositoryFromURL(&result.Repository, v.Link)
+		to = append(to, result)
+	}
+	return to
+}
+
+func convertSearchCommitList(from []*searchCommit, q *scm.SearchQuery) []*scm.SearchCommit {
+	to := []*scm.SearchCommit{}
+	for _, v := range from {
+		to = append(to, &scm.SearchCommit{
+			Commit:     *convertCommit(&v.commit),
+			Repository: searchRepository(q, v.ProjectID),
+		})
+	}
+	return to
+}
+
+func containsLabels(labels, names []string) bool {
+	for _, name := range names {
+		found := false
+		for _, label := range labels {
+			if label == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// populateRepositoryFromURL sets the repository path from the
+// web url of an issue or merge request.
+func populateRepositoryFromURL(repo *scm.Repository, link string) {
+	u, err := url.Parse(link)
+	if err != nil {
+		return
+	}
+	i := strings.Index(u.Path, "/-/")
+	if i == -1 {
+		i = strings.LastIndex(u.Path, "/issues/")
+	}
+	if i == -1 {
+		i = strings.LastIndex(u.Path, "/merge_requests/")
+	}
+	if i == -1 {
+		return
+	}
+	repo.FullName = strings.TrimPrefix(u.Path[:i], "/")
+	repo.Namespace, repo.Name = scm.Split(repo.FullName)
+	repo.Link = fmt.Sprintf("%s://%s/%s", u.Scheme, u.Host, repo.FullName)
+}
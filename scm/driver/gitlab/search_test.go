@@ -0,0 +1,173 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestSearchCode(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/search").
+		MatchParam("scope", "blobs").
+		MatchParam("search", "installation path:docs").
+		MatchParam("page", "1").
+		MatchParam("per_page", "30").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		SetHeaders(mockPageHeaders).
+		File("testdata/search_blobs.json")
+
+	q := &scm.SearchQuery{Text: "installation", Repo: "diaspora/diaspora", Path: "docs"}
+	client := NewDefault()
+	got, res, err := client.Search.Code(context.Background(), q, &scm.ListOptions{Page: 1, Size: 30})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.SearchCode{}
+	raw, _ := os.ReadFile("testdata/search_blobs.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	t.Run("Request", testRequest(res))
+	t.Run("Rate", testRate(res))
+	t.Run("Page", testPage(res))
+}
+
+func TestSearchRepositories(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/groups/diaspora/search").
+		MatchParam("scope", "projects").
+		MatchParam("search", "diaspora").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/repos.json")
+
+	client := NewDefault()
+	got, _, err := client.Search.Repositories(context.Background(), &scm.SearchQuery{Text: "diaspora", Org: "diaspora"}, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Repository{}
+	raw, _ := os.ReadFile("testdata/repos.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchIssues(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/search").
+		MatchParam("scope", "issues").
+		MatchParam("search", "crash").
+		MatchParam("state", "opened").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/search_issues.json")
+
+	q := &scm.SearchQuery{Text: "crash", Repo: "diaspora/diaspora", State: "open", Labels: []string{"bug"}}
+	client := NewDefault()
+	got, _, err := client.Search.Issues(context.Background(), q, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.SearchIssue{}
+	raw, _ := os.ReadFile("testdata/search_issues.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchMergeRequests(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/search").
+		MatchParam("scope", "merge_requests").
+		MatchParam("search", "crash").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/search_issues.json")
+
+	q := &scm.SearchQuery{Text: "crash", Type: scm.SearchTypePullRequest}
+	client := NewDefault()
+	got, _, err := client.Search.Issues(context.Background(), q, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(got) != 2 {
+		t.Fatalf("Want 2 merge requests, got %d", len(got))
+	}
+	if got[1].PullRequest == nil || got[1].PullRequest.Number != 2 {
+		t.Errorf("Want the merge request to be set on the result")
+	}
+	if got[1].Repository.FullName != "diaspora/diaspora" {
+		t.Errorf("Want repository diaspora/diaspora, got %q", got[1].Repository.FullName)
+	}
+}
+
+func TestSearchCommits(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://gitlab.com").
+		Get("/api/v4/projects/diaspora/diaspora/search").
+		MatchParam("scope", "commits").
+		MatchParam("search", "fix").
+		Reply(200).
+		Type("application/json").
+		SetHeaders(mockHeaders).
+		File("testdata/search_commits.json")
+
+	client := NewDefault()
+	got, _, err := client.Search.Commits(context.Background(), &scm.SearchQuery{Text: "fix", Repo: "diaspora/diaspora"}, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.SearchCommit{}
+	raw, _ := os.ReadFile("testdata/search_commits.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
@@ -77,9 +77,42 @@ func encodeIssueListOptions(opts scm.IssueListOptions) string {
 	} else if opts.Open {
 		params.Set("state", "opened")
 	}
+	if len(opts.Labels) > 0 {
+		params.Set("labels", strings.Join(opts.Labels, ","))
+	}
+	if opts.Author != "" {
+		params.Set("author_username", opts.Author)
+	}
+	if opts.Assignee != "" {
+		params.Set("assignee_username", opts.Assignee)
+	}
+	if opts.Milestone != "" {
+		params.Set("milestone", opts.Milestone)
+	}
+	if opts.Since != nil {
+		params.Set("updated_after", opts.Since.Format(scm.SearchTimeFormat))
+	}
+	encodeSortOptions(params, opts.Sort, opts.Ascending)
 	return params.Encode()
 }
 
+// encodeSortOptions sets the order_by and sort parameters
+// accepted by the issue and merge request list endpoints.
+func encodeSortOptions(params url.Values, sort string, ascending bool) {
+	switch sort {
+	case scm.SortCreated:
+		params.Set("order_by", "created_at")
+	case scm.SortUpdated:
+		params.Set("order_by", "updated_at")
+	case "":
+	default:
+		params.Set("order_by", sort)
+	}
+	if ascending {
+		params.Set("sort", "asc")
+	}
+}
+
 func encodeMilestoneListOptions(opts scm.MilestoneListOptions) string {
 	params := url.Values{}
 	if opts.Page != 0 {
@@ -126,6 +159,25 @@ func encodePullRequestListOptions(opts *scm.PullRequestListOptions) string {
 	if opts.UpdatedBefore != nil {
 		params.Set("updated_before", opts.UpdatedBefore.Format(scm.SearchTimeFormat))
 	}
+	if opts.Head != "" {
+		params.Set("source_branch", opts.Head)
+	}
+	if opts.Base != "" {
+		params.Set("target_branch", opts.Base)
+	}
+	if opts.Author != "" {
+		params.Set("author_username", opts.Author)
+	}
+	if opts.Assignee != "" {
+		params.Set("assignee_username", opts.Assignee)
+	}
+	if opts.Reviewer != "" {
+		params.Set("reviewer_username", opts.Reviewer)
+	}
+	if opts.Milestone != "" {
+		params.Set("milestone", opts.Milestone)
+	}
+	encodeSortOptions(params, opts.Sort, opts.Ascending)
 	return params.Encode()
 }
 
@@ -6,6 +6,7 @@ package gitlab
 
 import (
 	"testing"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -90,6 +91,24 @@ func Test_encodeIssueListOptions_Closed(t *testing.T) {
 	}
 }
 
+func Test_encodeIssueListOptions_Filters(t *testing.T) {
+	since := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	opts := scm.IssueListOptions{
+		Labels:    []string{"bug", "ui"},
+		Author:    "jdoe",
+		Assignee:  "jsmith",
+		Milestone: "v1.0",
+		Since:     &since,
+		Sort:      scm.SortUpdated,
+		Ascending: true,
+	}
+	want := "assignee_username=jsmith&author_username=jdoe&labels=bug%2Cui&milestone=v1.0&order_by=updated_at&sort=asc&updated_after=2020-01-02T03%3A04%3A05Z"
+	got := encodeIssueListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded issue list options %q, got %q", want, got)
+	}
+}
+
 func Test_encodePullRequestListOptions(t *testing.T) {
 	t.Parallel()
 	opts := scm.PullRequestListOptions{
@@ -134,3 +153,20 @@ func Test_encodePullRequestListOptions_Closed(t *testing.T) {
 		t.Errorf("Want encoded pr list options %q, got %q", want, got)
 	}
 }
+
+func Test_encodePullRequestListOptions_Filters(t *testing.T) {
+	t.Parallel()
+	since := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	opts := scm.PullRequestListOptions{
+		Assignee:     "jsmith",
+		Reviewer:     "jdoe",
+		Milestone:    "v1.0",
+		UpdatedAfter: &since,
+		Sort:         scm.SortCreated,
+	}
+	want := "assignee_username=jsmith&milestone=v1.0&order_by=created_at&reviewer_username=jdoe&updated_after=2020-01-02T03%3A04%3A05Z"
+	got := encodePullRequestListOptions(&opts)
+	if got != want {
+		t.Errorf("Want encoded pr list options %q, got %q", want, got)
+	}
+}
@@ -6,14 +6,15 @@ package gitlab
 
 import (
 	"context"
-	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"path"
 	"strconv"
 	"time"
 
+	"github.com/jenkins-x/go-scm/pkg/hmac"
 	"github.com/jenkins-x/go-scm/scm"
 )
 
@@ -29,6 +30,10 @@ type webhookUserService interface {
 }
 
 func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
+	return s.ParseWithSecrets(req, fn.Secrets())
+}
+
+func (s *webhookService) ParseWithSecrets(req *http.Request, fn scm.SecretsFunc) (scm.Webhook, error) {
 	data, err := io.ReadAll(
 		io.LimitReader(req.Body, 10000000),
 	)
@@ -41,14 +46,26 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	switch event {
 	case "Push Hook", "Tag Push Hook":
 		hook, err = parsePushHook(data)
-	case "Issue Hook":
-		return nil, scm.UnknownWebhook{Event: event}
+	case "Issue Hook", "Confidential Issue Hook":
+		hook, err = parseIssueHook(s, data)
 	case "Merge Request Hook":
 		hook, err = parsePullRequestHook(data)
 	case "Note Hook":
 		hook, err = parseCommentHook(s, data)
 	case "Release Hook":
 		hook, err = parseReleaseHook(data)
+	case "Pipeline Hook":
+		hook, err = parsePipelineHook(data)
+	case "Job Hook":
+		hook, err = parseJobHook(data)
+	case "Deployment Hook":
+		hook, err = parseDeploymentHook(data)
+	case "Wiki Page Hook":
+		hook, err = parseWikiPageHook(data)
+	case "Member Hook":
+		hook, err = parseMemberHook(data)
+	case "Subgroup Hook":
+		hook, err = parseSubgroupHook(data)
 	default:
 		return nil, scm.UnknownWebhook{Event: event}
 	}
@@ -59,14 +76,16 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	// get the gitlab shared token to verify the payload
 	// authenticity. If no key is provided, no validation
 	// is performed.
-	token, err := fn(hook)
+	tokens, err := fn(hook)
 	if err != nil {
 		return hook, err
-	} else if token == "" {
+	}
+	tokens = hmac.Keys(tokens)
+	if len(tokens) == 0 {
 		return hook, nil
 	}
 
-	if subtle.ConstantTimeCompare([]byte(req.Header.Get("X-Gitlab-Token")), []byte(token)) == 0 {
+	if !hmac.ValidateToken(req.Header.Get("X-Gitlab-Token"), tokens) {
 		return hook, scm.ErrSignatureInvalid
 	}
 	return hook, nil
@@ -150,6 +169,102 @@ func parseReleaseHook(data []byte) (scm.Webhook, error) {
 	return convertReleaseHook(src)
 }
 
+func parseIssueHook(s *webhookService, data []byte) (scm.Webhook, error) {
+	src := new(issueHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	switch src.ObjectAttributes.Action {
+	case "", "open", "close", "reopen", "update":
+		// no-op
+	default:
+		return nil, scm.UnknownWebhook{Event: src.ObjectAttributes.Action}
+	}
+	return convertIssueHook(s, src)
+}
+
+func parsePipelineHook(data []byte) (scm.Webhook, error) {
+	src := new(pipelineHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	return convertPipelineHook(src), nil
+}
+
+func parseJobHook(data []byte) (scm.Webhook, error) {
+	src := new(jobHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	return convertJobHook(src), nil
+}
+
+func parseDeploymentHook(data []byte) (scm.Webhook, error) {
+	src := new(deploymentHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	return convertDeploymentHook(src), nil
+}
+
+func parseWikiPageHook(data []byte) (scm.Webhook, error) {
+	src := new(wikiPageHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	return convertWikiPageHook(src), nil
+}
+
+func parseMemberHook(data []byte) (scm.Webhook, error) {
+	src := new(memberHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	var action scm.Action
+	switch src.EventName {
+	case "user_add_to_group":
+		action = scm.ActionCreate
+	case "user_update_for_group":
+		action = scm.ActionUpdate
+	case "user_remove_from_group":
+		action = scm.ActionDelete
+	default:
+		// access requests are not reported
+		return nil, scm.UnknownWebhook{Event: src.EventName}
+	}
+	return convertMemberHook(src, action), nil
+}
+
+func parseSubgroupHook(data []byte) (scm.Webhook, error) {
+	src := new(subgroupHook)
+	err := json.Unmarshal(data, src)
+	if err != nil {
+		return nil, err
+	}
+	var action scm.Action
+	switch src.EventName {
+	case "subgroup_create":
+		action = scm.ActionCreate
+	case "subgroup_destroy":
+		action = scm.ActionDelete
+	default:
+		return nil, scm.UnknownWebhook{Event: src.EventName}
+	}
+	return &scm.OrganizationHook{
+		Action: action,
+		Org: scm.Organization{
+			ID:   src.GroupID,
+			Name: src.FullPath,
+		},
+	}, nil
+}
+
 func convertPushHook(src *pushHook) *scm.PushHook {
 	repo := *convertRepositoryHook(&src.Project)
 	dst := &scm.PushHook{
@@ -245,6 +360,22 @@ func convertTagHook(src *pushHook) *scm.TagHook {
 	}
 }
 
+// draftChanged reports the new draft state if the merge request
+// update toggled it. Older GitLab versions only report the title
+// change so the draft prefix is compared as a fallback.
+func draftChanged(src *pullRequestHook) (draft, ok bool) {
+	if c := src.Changes.Draft; c != nil && c.Previous != c.Current {
+		return c.Current, true
+	}
+	if c := src.Changes.Title; c != nil {
+		previous, current := isDraftTitle(c.Previous), isDraftTitle(c.Current)
+		if previous != current {
+			return current, true
+		}
+	}
+	return false, false
+}
+
 func convertPullRequestHook(src *pullRequestHook) *scm.PullRequestHook {
 	action := scm.ActionUpdate
 	switch src.ObjectAttributes.Action {
@@ -258,6 +389,13 @@ func convertPullRequestHook(src *pullRequestHook) *scm.PullRequestHook {
 		action = scm.ActionMerge
 	case "update":
 		action = scm.ActionUpdate
+		if draft, ok := draftChanged(src); ok {
+			if draft {
+				action = scm.ActionConvertedToDraft
+			} else {
+				action = scm.ActionReadyForReview
+			}
+		}
 	}
 	fork := scm.Join(
 		src.ObjectAttributes.Source.Namespace,
@@ -286,6 +424,7 @@ func convertPullRequestHook(src *pullRequestHook) *scm.PullRequestHook {
 		Closed:   src.ObjectAttributes.State != "opened",
 		Merged:   src.ObjectAttributes.State == "merged",
 		MergeSha: src.ObjectAttributes.MergeCommitSha,
+		Draft:    src.ObjectAttributes.Draft || src.ObjectAttributes.WorkInProgress,
 		// Created   : src.ObjectAttributes.CreatedAt,
 		// Updated  : src.ObjectAttributes.UpdatedAt, // 2017-12-10 17:01:11 UTC
 		Author: scm.User{
@@ -318,8 +457,30 @@ func convertPullRequestHook(src *pullRequestHook) *scm.PullRequestHook {
 	}
 }
 
+// findUser returns the user with the id, looked up with the user
+// service. Webhook services created without a client have no user
+// service, so the fallback user from the payload is returned with
+// the id set instead.
+func (s *webhookService) findUser(id int, fallback scm.User) (*scm.User, error) {
+	if s.userService == nil {
+		fallback.ID = id
+		return &fallback, nil
+	}
+	return s.userService.FindLoginByID(context.TODO(), id)
+}
+
+// commentHookUser returns the user of the comment hook, who is the
+// author of the comment.
+func commentHookUser(src *commentHook) scm.User {
+	return scm.User{
+		Login:  src.User.Username,
+		Name:   src.User.Name,
+		Avatar: src.User.AvatarURL,
+	}
+}
+
 func convertIssueCommentHook(s *webhookService, src *commentHook) (*scm.IssueCommentHook, error) {
-	commentAuthor, err := s.userService.FindLoginByID(context.TODO(), src.ObjectAttributes.AuthorID)
+	commentAuthor, err := s.findUser(src.ObjectAttributes.AuthorID, commentHookUser(src))
 	if err != nil {
 		return nil, fmt.Errorf("unable to find comment author %w", err)
 	}
@@ -357,12 +518,12 @@ func convertIssueCommentHook(s *webhookService, src *commentHook) (*scm.IssueCom
 func convertMergeRequestCommentHook(s *webhookService, src *commentHook) (*scm.PullRequestCommentHook, error) {
 	// There are two users needed here: the comment author and the MergeRequest author.
 	// Since we only have the user name, we need to use the user service to fetch these.
-	commentAuthor, err := s.userService.FindLoginByID(context.TODO(), src.ObjectAttributes.AuthorID)
+	commentAuthor, err := s.findUser(src.ObjectAttributes.AuthorID, commentHookUser(src))
 	if err != nil {
 		return nil, fmt.Errorf("unable to find comment author %w", err)
 	}
 
-	mrAuthor, err := s.userService.FindLoginByID(context.TODO(), src.MergeRequest.AuthorID)
+	mrAuthor, err := s.findUser(src.MergeRequest.AuthorID, scm.User{})
 	if err != nil {
 		return nil, fmt.Errorf("unable to find mr author %w", err)
 	}
@@ -426,6 +587,226 @@ func convertMergeRequestCommentHook(s *webhookService, src *commentHook) (*scm.P
 	return hook, nil
 }
 
+func convertIssueHook(s *webhookService, src *issueHook) (*scm.IssueHook, error) {
+	// the user of the hook is the author when the issue is opened.
+	var fallback scm.User
+	if src.User.ID == src.ObjectAttributes.AuthorID || convertIssueAction(src) == scm.ActionOpen {
+		fallback = *convertHookUser(&src.User)
+	}
+	author, err := s.findUser(src.ObjectAttributes.AuthorID, fallback)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find issue author %w", err)
+	}
+	labels := []string{}
+	for _, l := range src.Labels {
+		labels = append(labels, l.Title)
+	}
+	var assignees []scm.User
+	for _, a := range src.Assignees {
+		assignees = append(assignees, *convertHookUser(&a))
+	}
+	issue := scm.Issue{
+		Number:    src.ObjectAttributes.Iid,
+		Title:     src.ObjectAttributes.Title,
+		Body:      src.ObjectAttributes.Description,
+		Link:      src.ObjectAttributes.URL,
+		State:     gitlabStateToSCMState(src.ObjectAttributes.State),
+		Labels:    labels,
+		Closed:    src.ObjectAttributes.State != "opened",
+		Author:    *author,
+		Assignees: assignees,
+		Created:   parseHookTime(src.ObjectAttributes.CreatedAt),
+		Updated:   parseHookTime(src.ObjectAttributes.UpdatedAt),
+	}
+	return &scm.IssueHook{
+		Action: convertIssueAction(src),
+		Repo:   *convertRepositoryHook(&src.Project),
+		Issue:  issue,
+		Sender: *convertHookUser(&src.User),
+	}, nil
+}
+
+// convertIssueAction returns the issue hook action. GitLab
+// reports label changes as updates, so the label changes
+// are compared to find labeled and unlabeled events.
+func convertIssueAction(src *issueHook) scm.Action {
+	switch src.ObjectAttributes.Action {
+	case "", "open":
+		return scm.ActionOpen
+	case "close":
+		return scm.ActionClose
+	case "reopen":
+		return scm.ActionReopen
+	}
+	if c := src.Changes.Labels; c != nil {
+		if len(c.Current) > len(c.Previous) {
+			return scm.ActionLabel
+		}
+		if len(c.Current) < len(c.Previous) {
+			return scm.ActionUnlabel
+		}
+	}
+	return scm.ActionUpdate
+}
+
+func convertPipelineHook(src *pipelineHook) *scm.PipelineHook {
+	return &scm.PipelineHook{
+		Action: convertPipelineAction(src.ObjectAttributes.Status),
+		Pipeline: scm.Pipeline{
+			ID:       src.ObjectAttributes.ID,
+			Number:   src.ObjectAttributes.Iid,
+			Name:     src.ObjectAttributes.Name,
+			Status:   convertState(src.ObjectAttributes.Status),
+			Ref:      src.ObjectAttributes.Ref,
+			Sha:      src.ObjectAttributes.Sha,
+			Source:   src.ObjectAttributes.Source,
+			Link:     src.ObjectAttributes.URL,
+			Author:   *convertHookUser(&src.User),
+			Created:  parseHookTime(src.ObjectAttributes.CreatedAt),
+			Finished: parseHookTime(src.ObjectAttributes.FinishedAt),
+		},
+		Repo:   *convertRepositoryHook(&src.Project),
+		Sender: *convertHookUser(&src.User),
+	}
+}
+
+func convertJobHook(src *jobHook) *scm.JobHook {
+	repo := *convertRepositoryHook(&src.Project)
+	return &scm.JobHook{
+		Action: convertPipelineAction(src.BuildStatus),
+		Job: scm.Job{
+			ID:         src.BuildID,
+			PipelineID: src.PipelineID,
+			Name:       src.BuildName,
+			Stage:      src.BuildStage,
+			Status:     convertState(src.BuildStatus),
+			Ref:        src.Ref,
+			Sha:        src.Sha,
+			Link:       fmt.Sprintf("%s/-/jobs/%d", repo.Link, src.BuildID),
+			Runner:     src.Runner.Description,
+			Created:    parseHookTime(src.BuildCreatedAt),
+			Started:    parseHookTime(src.BuildStartedAt),
+			Finished:   parseHookTime(src.BuildFinishedAt),
+		},
+		Repo:   repo,
+		Sender: *convertHookUser(&src.User),
+	}
+}
+
+// convertPipelineAction returns the action of a pipeline or
+// job hook, which GitLab sends on every status change.
+func convertPipelineAction(status string) scm.Action {
+	switch status {
+	case "created", "pending", "waiting_for_resource", "preparing", "scheduled":
+		return scm.ActionCreate
+	case "success", "failed", "canceled", "skipped":
+		return scm.ActionCompleted
+	default:
+		return scm.ActionUpdate
+	}
+}
+
+func convertDeploymentHook(src *deploymentHook) *scm.DeploymentStatusHook {
+	repo := *convertRepositoryHook(&src.Project)
+	sender := convertHookUser(&src.User)
+	id := strconv.Itoa(src.DeploymentID)
+	updated := parseHookTime(src.StatusChangedAt)
+	return &scm.DeploymentStatusHook{
+		Action: scm.ActionCreate,
+		Deployment: scm.Deployment{
+			ID:             id,
+			Namespace:      repo.Namespace,
+			Name:           repo.Name,
+			FullName:       repo.FullName,
+			Sha:            path.Base(src.CommitURL),
+			Ref:            src.Ref,
+			Description:    src.CommitTitle,
+			Environment:    src.Environment,
+			RepositoryLink: repo.Link,
+			Author:         sender,
+			Updated:        updated,
+		},
+		DeploymentStatus: scm.DeploymentStatus{
+			ID:              id,
+			State:           src.Status,
+			Author:          sender,
+			Environment:     src.Environment,
+			EnvironmentLink: src.EnvironmentExternalURL,
+			LogLink:         src.DeployableURL,
+			RepositoryLink:  repo.Link,
+			Updated:         updated,
+		},
+		Repo:   repo,
+		Sender: *sender,
+	}
+}
+
+func convertWikiPageHook(src *wikiPageHook) *scm.WikiPageHook {
+	action := scm.ActionUpdate
+	switch src.ObjectAttributes.Action {
+	case "create":
+		action = scm.ActionCreate
+	case "delete":
+		action = scm.ActionDelete
+	}
+	return &scm.WikiPageHook{
+		Action: action,
+		Page: scm.WikiPage{
+			Title:   src.ObjectAttributes.Title,
+			Slug:    src.ObjectAttributes.Slug,
+			Format:  src.ObjectAttributes.Format,
+			Content: src.ObjectAttributes.Content,
+			Message: src.ObjectAttributes.Message,
+			Link:    src.ObjectAttributes.URL,
+		},
+		Repo:   *convertRepositoryHook(&src.Project),
+		Sender: *convertHookUser(&src.User),
+	}
+}
+
+func convertMemberHook(src *memberHook, action scm.Action) *scm.MemberHook {
+	return &scm.MemberHook{
+		Action: action,
+		Member: scm.User{
+			ID:    src.UserID,
+			Login: src.UserUsername,
+			Name:  src.UserName,
+			Email: src.UserEmail,
+		},
+		Role: src.GroupAccess,
+		Org: scm.Organization{
+			ID:   src.GroupID,
+			Name: src.GroupPath,
+		},
+	}
+}
+
+func convertHookUser(from *hookUser) *scm.User {
+	return &scm.User{
+		ID:     from.ID,
+		Login:  from.Username,
+		Name:   from.Name,
+		Email:  from.Email,
+		Avatar: from.AvatarURL,
+	}
+}
+
+// parseHookTime parses the timestamps of webhook payloads,
+// which use different formats depending on the event and
+// GitLab version. It returns the zero time if unset.
+func parseHookTime(s string) time.Time {
+	for _, layout := range []string{
+		"2006-01-02 15:04:05 MST",
+		"2006-01-02 15:04:05 -0700",
+		time.RFC3339,
+	} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
 func convertRepositoryHook(from *project) *scm.Repository {
 	namespace, name := scm.Split(from.PathWithNamespace)
 	return &scm.Repository{
@@ -736,14 +1117,24 @@ type (
 				} `json:"author"`
 			} `json:"last_commit"`
 			WorkInProgress      bool        `json:"work_in_progress"`
+			Draft               bool        `json:"draft"`
 			TotalTimeSpent      int         `json:"total_time_spent"`
 			HumanTotalTimeSpent interface{} `json:"human_total_time_spent"`
 			HumanTimeEstimate   interface{} `json:"human_time_estimate"`
 			Action              string      `json:"action"`
 			OldRev              string      `json:"oldrev"`
 		} `json:"object_attributes"`
-		Labels     []interface{} `json:"labels"`
-		Changes    struct{}      `json:"changes"`
+		Labels  []interface{} `json:"labels"`
+		Changes struct {
+			Draft *struct {
+				Previous bool `json:"previous"`
+				Current  bool `json:"current"`
+			} `json:"draft"`
+			Title *struct {
+				Previous string `json:"previous"`
+				Current  string `json:"current"`
+			} `json:"title"`
+		} `json:"changes"`
 		Repository struct {
 			Name        string `json:"name"`
 			URL         string `json:"url"`
@@ -752,6 +1143,138 @@ type (
 		} `json:"repository"`
 	}
 
+	hookUser struct {
+		ID        int    `json:"id"`
+		Name      string `json:"name"`
+		Username  string `json:"username"`
+		AvatarURL string `json:"avatar_url"`
+		Email     string `json:"email"`
+	}
+
+	hookLabel struct {
+		ID    int    `json:"id"`
+		Title string `json:"title"`
+		Color string `json:"color"`
+	}
+
+	issueHook struct {
+		ObjectKind       string   `json:"object_kind"`
+		User             hookUser `json:"user"`
+		Project          project  `json:"project"`
+		ObjectAttributes struct {
+			ID          int    `json:"id"`
+			Iid         int    `json:"iid"`
+			AuthorID    int    `json:"author_id"`
+			Title       string `json:"title"`
+			Description string `json:"description"`
+			State       string `json:"state"`
+			URL         string `json:"url"`
+			CreatedAt   string `json:"created_at"`
+			UpdatedAt   string `json:"updated_at"`
+			Action      string `json:"action"`
+		} `json:"object_attributes"`
+		Labels    []hookLabel `json:"labels"`
+		Assignees []hookUser  `json:"assignees"`
+		Changes   struct {
+			Labels *struct {
+				Previous []hookLabel `json:"previous"`
+				Current  []hookLabel `json:"current"`
+			} `json:"labels"`
+		} `json:"changes"`
+	}
+
+	pipelineHook struct {
+		ObjectKind       string `json:"object_kind"`
+		ObjectAttributes struct {
+			ID         int    `json:"id"`
+			Iid        int    `json:"iid"`
+			Name       string `json:"name"`
+			Ref        string `json:"ref"`
+			Tag        bool   `json:"tag"`
+			Sha        string `json:"sha"`
+			BeforeSha  string `json:"before_sha"`
+			Source     string `json:"source"`
+			Status     string `json:"status"`
+			CreatedAt  string `json:"created_at"`
+			FinishedAt string `json:"finished_at"`
+			URL        string `json:"url"`
+		} `json:"object_attributes"`
+		User    hookUser `json:"user"`
+		Project project  `json:"project"`
+	}
+
+	jobHook struct {
+		ObjectKind      string   `json:"object_kind"`
+		Ref             string   `json:"ref"`
+		Tag             bool     `json:"tag"`
+		Sha             string   `json:"sha"`
+		BuildID         int      `json:"build_id"`
+		BuildName       string   `json:"build_name"`
+		BuildStage      string   `json:"build_stage"`
+		BuildStatus     string   `json:"build_status"`
+		BuildCreatedAt  string   `json:"build_created_at"`
+		BuildStartedAt  string   `json:"build_started_at"`
+		BuildFinishedAt string   `json:"build_finished_at"`
+		PipelineID      int      `json:"pipeline_id"`
+		User            hookUser `json:"user"`
+		Project         project  `json:"project"`
+		Runner          struct {
+			ID          int    `json:"id"`
+			Description string `json:"description"`
+		} `json:"runner"`
+	}
+
+	deploymentHook struct {
+		ObjectKind             string   `json:"object_kind"`
+		Status                 string   `json:"status"`
+		StatusChangedAt        string   `json:"status_changed_at"`
+		DeploymentID           int      `json:"deployment_id"`
+		DeployableURL          string   `json:"deployable_url"`
+		Environment            string   `json:"environment"`
+		EnvironmentExternalURL string   `json:"environment_external_url"`
+		Project                project  `json:"project"`
+		Ref                    string   `json:"ref"`
+		User                   hookUser `json:"user"`
+		CommitURL              string   `json:"commit_url"`
+		CommitTitle            string   `json:"commit_title"`
+	}
+
+	wikiPageHook struct {
+		ObjectKind       string   `json:"object_kind"`
+		User             hookUser `json:"user"`
+		Project          project  `json:"project"`
+		ObjectAttributes struct {
+			Title   string `json:"title"`
+			Content string `json:"content"`
+			Format  string `json:"format"`
+			Message string `json:"message"`
+			Slug    string `json:"slug"`
+			URL     string `json:"url"`
+			Action  string `json:"action"`
+		} `json:"object_attributes"`
+	}
+
+	memberHook struct {
+		EventName    string `json:"event_name"`
+		GroupName    string `json:"group_name"`
+		GroupPath    string `json:"group_path"`
+		GroupID      int    `json:"group_id"`
+		UserUsername string `json:"user_username"`
+		UserName     string `json:"user_name"`
+		UserEmail    string `json:"user_email"`
+		UserID       int    `json:"user_id"`
+		GroupAccess  string `json:"group_access"`
+	}
+
+	subgroupHook struct {
+		EventName     string `json:"event_name"`
+		Name          string `json:"name"`
+		Path          string `json:"path"`
+		FullPath      string `json:"full_path"`
+		GroupID       int    `json:"group_id"`
+		ParentGroupID int    `json:"parent_group_id"`
+	}
+
 	releaseHook struct {
 		ID          int     `json:"id"`
 		CreatedAt   string  `json:"created_at"`
@@ -0,0 +1,380 @@
+package gitlab
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/hookenc"
+)
+
+// emptyCommit is the sha GitLab reports as the before or
+// after commit of a created or deleted ref.
+const emptyCommit = "0000000000000000000000000000000000000000"
+
+// hookTimeLayout is the timestamp layout of webhook payloads.
+const hookTimeLayout = "2006-01-02 15:04:05 MST"
+
+// Encode returns the GitLab webhook request for the hook. The
+// secret is sent as the X-Gitlab-Token since GitLab does not
+// sign the payload.
+//
+// GitLab reports created branches and tags as push events, so
+// a created BranchHook or TagHook is parsed back as a PushHook.
+func (s *webhookService) Encode(hook scm.Webhook, secret string) (*http.Request, error) {
+	var (
+		event   string
+		payload interface{}
+	)
+	switch v := hook.(type) {
+	case *scm.PushHook:
+		src := encodePushHook(v)
+		event, payload = encodePushEvent(src), src
+	case *scm.BranchHook:
+		src := encodeRefHook(v.Action, scm.ExpandRef(v.Ref.Name, "refs/heads/"), v.Ref.Sha, &v.Repo, &v.Sender)
+		event, payload = encodePushEvent(src), src
+	case *scm.TagHook:
+		src := encodeRefHook(v.Action, scm.ExpandRef(v.Ref.Name, "refs/tags/"), v.Ref.Sha, &v.Repo, &v.Sender)
+		event, payload = encodePushEvent(src), src
+	case *scm.PullRequestHook:
+		event, payload = "Merge Request Hook", encodePullRequestHook(v)
+	case *scm.PullRequestCommentHook:
+		event, payload = "Note Hook", encodeMergeRequestCommentHook(v)
+	case *scm.IssueHook:
+		event, payload = "Issue Hook", encodeIssueHook(v)
+	case *scm.IssueCommentHook:
+		event, payload = "Note Hook", encodeIssueCommentHook(v)
+	default:
+		return nil, scm.ErrNotSupported
+	}
+
+	req, _, err := hookenc.NewRequest(payload)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Gitlab-Event", event)
+	req.Header.Set("X-Gitlab-Event-UUID", hookenc.DeliveryID(""))
+	if secret != "" {
+		req.Header.Set("X-Gitlab-Token", secret)
+	}
+	return req, nil
+}
+
+func encodePushEvent(src *pushHook) string {
+	if src.ObjectKind == "tag_push" {
+		return "Tag Push Hook"
+	}
+	return "Push Hook"
+}
+
+func encodePushHook(src *scm.PushHook) *pushHook {
+	dst := &pushHook{
+		ObjectKind:   "push",
+		EventName:    "push",
+		Before:       src.Before,
+		After:        src.After,
+		Ref:          src.Ref,
+		CheckoutSha:  src.Commit.Sha,
+		UserID:       src.Sender.ID,
+		UserName:     src.Sender.Name,
+		UserUsername: src.Sender.Login,
+		UserEmail:    src.Sender.Email,
+		UserAvatar:   src.Sender.Avatar,
+		Project:      *encodeRepositoryHook(&src.Repo),
+	}
+	if scm.IsTag(src.Ref) {
+		dst.ObjectKind = "tag_push"
+		dst.EventName = "tag_push"
+	}
+	dst.ProjectID = dst.Project.ID
+	for _, c := range src.Commits {
+		commit := struct {
+			ID        string `json:"id"`
+			Message   string `json:"message"`
+			Timestamp string `json:"timestamp"`
+			URL       string `json:"url"`
+			Author    struct {
+				Name  string `json:"name"`
+				Email string `json:"email"`
+			} `json:"author"`
+			Added    []string `json:"added"`
+			Modified []string `json:"modified"`
+			Removed  []string `json:"removed"`
+		}{
+			URL:      c.ID,
+			Message:  c.Message,
+			Added:    c.Added,
+			Modified: c.Modified,
+			Removed:  c.Removed,
+		}
+		dst.Commits = append(dst.Commits, commit)
+	}
+	// the head commit is the last commit of the push.
+	if n := len(dst.Commits); n > 0 {
+		dst.Commits[n-1].ID = src.Commit.Sha
+		dst.Commits[n-1].Message = src.Commit.Message
+		dst.Commits[n-1].URL = src.Commit.Link
+	}
+	dst.TotalCommitsCount = len(dst.Commits)
+	return dst
+}
+
+func encodeRefHook(action scm.Action, ref, sha string, repo *scm.Repository, sender *scm.User) *pushHook {
+	dst := &pushHook{
+		ObjectKind:   "push",
+		EventName:    "push",
+		Before:       emptyCommit,
+		After:        sha,
+		Ref:          ref,
+		CheckoutSha:  sha,
+		UserID:       sender.ID,
+		UserName:     sender.Name,
+		UserUsername: sender.Login,
+		UserEmail:    sender.Email,
+		UserAvatar:   sender.Avatar,
+		Project:      *encodeRepositoryHook(repo),
+	}
+	if scm.IsTag(ref) {
+		dst.ObjectKind = "tag_push"
+		dst.EventName = "tag_push"
+	}
+	if action == scm.ActionDelete {
+		dst.Before = sha
+		dst.After = emptyCommit
+		dst.CheckoutSha = ""
+	}
+	dst.ProjectID = dst.Project.ID
+	return dst
+}
+
+func encodePullRequestHook(src *scm.PullRequestHook) *pullRequestHook {
+	dst := &pullRequestHook{
+		ObjectKind: "merge_request",
+		Project:    *encodeRepositoryHook(&src.Repo),
+	}
+	dst.User.Name = src.Sender.Name
+	dst.User.Username = src.Sender.Login
+	dst.User.AvatarURL = src.Sender.Avatar
+
+	pr := &src.PullRequest
+	attrs := &dst.ObjectAttributes
+	attrs.AuthorID = pr.Author.ID
+	attrs.Iid = pr.Number
+	attrs.Title = pr.Title
+	attrs.Description = pr.Body
+	attrs.State = encodePullRequestState(pr)
+	attrs.SourceBranch = pr.Source
+	attrs.TargetBranch = pr.Target
+	attrs.URL = pr.Link
+	attrs.MergeCommitSha = pr.MergeSha
+	attrs.Draft = pr.Draft
+	attrs.LastCommit.ID = pr.Sha
+	attrs.OldRev = src.Changes.Base.Sha.From
+	attrs.Source = encodePullRequestProject(&pr.Head.Repo, pr.Fork)
+	attrs.Target = encodePullRequestProject(&pr.Base.Repo, "")
+
+	switch src.Action {
+	case scm.ActionOpen:
+		attrs.Action = "open"
+	case scm.ActionClose:
+		attrs.Action = "close"
+	case scm.ActionReopen:
+		attrs.Action = "reopen"
+	case scm.ActionMerge:
+		attrs.Action = "merge"
+	case scm.ActionConvertedToDraft, scm.ActionReadyForReview:
+		attrs.Action = "update"
+		draft := src.Action == scm.ActionConvertedToDraft
+		dst.Changes.Draft = &struct {
+			Previous bool `json:"previous"`
+			Current  bool `json:"current"`
+		}{Previous: !draft, Current: draft}
+	default:
+		attrs.Action = "update"
+	}
+	return dst
+}
+
+func encodeMergeRequestCommentHook(src *scm.PullRequestCommentHook) *commentHook {
+	dst := &commentHook{
+		ObjectKind: "note",
+		Project:    *encodeRepositoryHook(&src.Repo),
+	}
+	dst.ProjectID = dst.Project.ID
+	dst.User.Name = src.Sender.Name
+	dst.User.Username = src.Sender.Login
+	dst.User.AvatarURL = src.Sender.Avatar
+	encodeNote(dst, &src.Comment)
+	dst.ObjectAttributes.NoteableType = "MergeRequest"
+
+	pr := &src.PullRequest
+	mr := &dst.MergeRequest
+	mr.AuthorID = pr.Author.ID
+	mr.Iid = pr.Number
+	mr.Title = pr.Title
+	mr.Description = pr.Body
+	mr.State = encodePullRequestState(pr)
+	mr.SourceBranch = pr.Source
+	mr.TargetBranch = pr.Target
+	mr.URL = pr.Link
+	mr.LastCommit.ID = pr.Sha
+	mr.CreatedAt = encodeHookTime(pr.Created)
+	mr.UpdatedAt = encodeHookTime(pr.Updated)
+	mr.Source = encodePullRequestProject(&pr.Head.Repo, pr.Fork)
+	mr.Target = encodePullRequestProject(&pr.Base.Repo, "")
+	return dst
+}
+
+func encodeIssueCommentHook(src *scm.IssueCommentHook) *commentHook {
+	dst := &commentHook{
+		ObjectKind: "note",
+		Project:    *encodeRepositoryHook(&src.Repo),
+	}
+	dst.ProjectID = dst.Project.ID
+	dst.User.Name = src.Sender.Name
+	dst.User.Username = src.Sender.Login
+	dst.User.AvatarURL = src.Sender.Avatar
+	encodeNote(dst, &src.Comment)
+	dst.ObjectAttributes.NoteableType = "Issue"
+
+	dst.Issue.Iid = src.Issue.Number
+	dst.Issue.Title = src.Issue.Title
+	dst.Issue.Description = src.Issue.Body
+	dst.Issue.AuthorID = src.Issue.Author.ID
+	dst.Issue.State = "opened"
+	if src.Issue.Closed {
+		dst.Issue.State = "closed"
+	}
+	dst.Issue.CreatedAt = encodeHookTime(src.Issue.Created)
+	dst.Issue.UpdatedAt = encodeHookTime(src.Issue.Updated)
+	return dst
+}
+
+func encodeNote(dst *commentHook, src *scm.Comment) {
+	dst.ObjectAttributes.ID = src.ID
+	dst.ObjectAttributes.Note = src.Body
+	dst.ObjectAttributes.AuthorID = src.Author.ID
+	dst.ObjectAttributes.URL = src.Link
+	dst.ObjectAttributes.CreatedAt = encodeHookTime(src.Created)
+	dst.ObjectAttributes.UpdatedAt = encodeHookTime(src.Updated)
+	dst.ObjectAttributes.ProjectID = dst.ProjectID
+}
+
+func encodeIssueHook(src *scm.IssueHook) *issueHook {
+	dst := &issueHook{
+		ObjectKind: "issue",
+		User:       *encodeHookUser(&src.Sender),
+		Project:    *encodeRepositoryHook(&src.Repo),
+	}
+	attrs := &dst.ObjectAttributes
+	attrs.Iid = src.Issue.Number
+	attrs.AuthorID = src.Issue.Author.ID
+	attrs.Title = src.Issue.Title
+	attrs.Description = src.Issue.Body
+	attrs.URL = src.Issue.Link
+	attrs.State = "opened"
+	if src.Issue.Closed {
+		attrs.State = "closed"
+	}
+	attrs.CreatedAt = encodeHookTime(src.Issue.Created)
+	attrs.UpdatedAt = encodeHookTime(src.Issue.Updated)
+	for _, name := range src.Issue.Labels {
+		dst.Labels = append(dst.Labels, hookLabel{Title: name})
+	}
+	for k := range src.Issue.Assignees {
+		dst.Assignees = append(dst.Assignees, *encodeHookUser(&src.Issue.Assignees[k]))
+	}
+
+	switch src.Action {
+	case scm.ActionOpen:
+		attrs.Action = "open"
+	case scm.ActionClose:
+		attrs.Action = "close"
+	case scm.ActionReopen:
+		attrs.Action = "reopen"
+	case scm.ActionLabel, scm.ActionUnlabel:
+		// GitLab reports label changes as updates, so the
+		// label changes are used to tell them apart.
+		attrs.Action = "update"
+		current := dst.Labels
+		if current == nil {
+			current = []hookLabel{}
+		}
+		previous := []hookLabel{}
+		if src.Action == scm.ActionLabel && len(current) > 0 {
+			previous = current[:len(current)-1]
+		} else if src.Action == scm.ActionUnlabel {
+			previous = append(append(previous, current...), hookLabel{})
+		}
+		dst.Changes.Labels = &struct {
+			Previous []hookLabel `json:"previous"`
+			Current  []hookLabel `json:"current"`
+		}{Previous: previous, Current: current}
+	default:
+		attrs.Action = "update"
+	}
+	return dst
+}
+
+func encodePullRequestState(src *scm.PullRequest) string {
+	switch {
+	case src.Merged:
+		return "merged"
+	case src.Closed:
+		return "closed"
+	default:
+		return "opened"
+	}
+}
+
+// encodePullRequestProject returns the source or target project
+// of a merge request. The namespace and name of the source
+// project are taken from the fork.
+func encodePullRequestProject(src *scm.Repository, fork string) *project {
+	dst := encodeRepositoryHook(src)
+	if fork == "" {
+		fork = dst.PathWithNamespace
+	}
+	dst.Namespace, dst.Name = scm.Split(fork)
+	return dst
+}
+
+func encodeRepositoryHook(src *scm.Repository) *project {
+	id, _ := strconv.Atoi(src.ID)
+	fullName := src.FullName
+	if fullName == "" && src.Name != "" {
+		fullName = scm.Join(src.Namespace, src.Name)
+	}
+	return &project{
+		ID:                id,
+		Name:              src.Name,
+		WebURL:            src.Link,
+		GitSSHURL:         src.CloneSSH,
+		GitHTTPURL:        src.Clone,
+		Namespace:         src.Namespace,
+		PathWithNamespace: fullName,
+		DefaultBranch:     src.Branch,
+		URL:               src.CloneSSH,
+		SSHURL:            src.CloneSSH,
+		HTTPURL:           src.Clone,
+	}
+}
+
+func encodeHookUser(src *scm.User) *hookUser {
+	return &hookUser{
+		ID:        src.ID,
+		Name:      src.Name,
+		Username:  src.Login,
+		AvatarURL: src.Avatar,
+		Email:     src.Email,
+	}
+}
+
+func encodeHookTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return strings.TrimSpace(t.Format(hookTimeLayout))
+}
@@ -0,0 +1,113 @@
+package gitlab
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+func TestWebhookEncode(t *testing.T) {
+	tests := []struct {
+		event string
+		file  string
+	}{
+		{event: "Push Hook", file: "testdata/webhooks/push.json"},
+		{event: "Push Hook", file: "testdata/webhooks/push2.json"},
+		{event: "Push Hook", file: "testdata/webhooks/branch_delete.json"},
+		{event: "Tag Push Hook", file: "testdata/webhooks/tag_delete.json"},
+		{event: "Issue Hook", file: "testdata/webhooks/issue_create.json"},
+		{event: "Issue Hook", file: "testdata/webhooks/issue_edited.json"},
+		{event: "Issue Hook", file: "testdata/webhooks/issue_labeled.json"},
+		{event: "Issue Hook", file: "testdata/webhooks/issue_closed.json"},
+		{event: "Issue Hook", file: "testdata/webhooks/issue_reopen.json"},
+		{event: "Note Hook", file: "testdata/webhooks/issue_comment_create.json"},
+		{event: "Merge Request Hook", file: "testdata/webhooks/pull_request_create.json"},
+		{event: "Merge Request Hook", file: "testdata/webhooks/pull_request_edited.json"},
+		{event: "Merge Request Hook", file: "testdata/webhooks/pull_request_ready.json"},
+		{event: "Merge Request Hook", file: "testdata/webhooks/pull_request_draft.json"},
+		{event: "Merge Request Hook", file: "testdata/webhooks/pull_request_close.json"},
+		{event: "Merge Request Hook", file: "testdata/webhooks/pull_request_reopen.json"},
+		{event: "Merge Request Hook", file: "testdata/webhooks/pull_request_merge.json"},
+		{event: "Note Hook", file: "testdata/webhooks/pull_request_comment_create.json"},
+	}
+
+	s := new(webhookService)
+	s.userService = &mockUserService{
+		users: map[int]*scm.User{
+			51764: {
+				ID:     51764,
+				Login:  "sytses",
+				Name:   "Sid Sijbrandij",
+				Avatar: "https://secure.gravatar.com/avatar/8c58a0be77ee441bb8f8595b7f1b4e87?s=80&d=identicon",
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			data, err := os.ReadFile(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, _ := http.NewRequest("POST", "/", bytes.NewReader(data))
+			r.Header.Set("X-Gitlab-Event", test.event)
+			r.Header.Set("X-Gitlab-Token", "topsecret")
+			want, err := s.Parse(r, secretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req, err := s.Encode(want, "topsecret")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := req.Header.Get("X-Gitlab-Event"); got != test.event {
+				t.Errorf("Want event %s, got %s", test.event, got)
+			}
+
+			got, err := s.Parse(req, secretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Encoded webhook does not round trip")
+				t.Log(diff)
+			}
+		})
+	}
+}
+
+func TestWebhookEncode_Token(t *testing.T) {
+	hook := &scm.PushHook{Ref: "refs/heads/master"}
+
+	s := new(webhookService)
+	req, err := s.Encode(hook, "topsecret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := req.Header.Get("X-Gitlab-Token"), "topsecret"; got != want {
+		t.Errorf("Want token %s, got %s", want, got)
+	}
+	if req.Header.Get("X-Gitlab-Event-UUID") == "" {
+		t.Errorf("Want delivery id")
+	}
+
+	req, err = s.Encode(hook, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if req.Header.Get("X-Gitlab-Token") != "" {
+		t.Errorf("Want no token without a secret")
+	}
+}
+
+func TestWebhookEncode_NotSupported(t *testing.T) {
+	s := new(webhookService)
+	_, err := s.Encode(&scm.ReleaseHook{}, "topsecret")
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect not supported error, got %v", err)
+	}
+}
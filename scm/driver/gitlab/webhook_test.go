@@ -77,13 +77,92 @@ func TestWebhooks(t *testing.T) {
 			after:  "testdata/webhooks/push2.json.golden",
 			obj:    new(scm.PushHook),
 		},
-		// // issue hooks
-		// {
-		// 	event:  "issues",
-		// 	before: "testdata/webhooks/issues_opened.json",
-		// 	after:  "testdata/webhooks/issues_opened.json.golden",
-		// 	obj:    new(scm.IssueHook),
-		// },
+		// issue hooks
+		{
+			event:  "Issue Hook",
+			before: "testdata/webhooks/issue_create.json",
+			after:  "testdata/webhooks/issue_create.json.golden",
+			obj:    new(scm.IssueHook),
+			mockUserService: &mockUserService{
+				users: map[int]*scm.User{
+					51764: {
+						ID:     51764,
+						Login:  "sytses",
+						Name:   "Sid Sijbrandij",
+						Email:  "",
+						Avatar: "https://secure.gravatar.com/avatar/8c58a0be77ee441bb8f8595b7f1b4e87?s=80&d=identicon",
+					},
+				},
+			},
+		},
+		{
+			event:  "Issue Hook",
+			before: "testdata/webhooks/issue_edited.json",
+			after:  "testdata/webhooks/issue_edited.json.golden",
+			obj:    new(scm.IssueHook),
+			mockUserService: &mockUserService{
+				users: map[int]*scm.User{
+					51764: {
+						ID:     51764,
+						Login:  "sytses",
+						Name:   "Sid Sijbrandij",
+						Email:  "",
+						Avatar: "https://secure.gravatar.com/avatar/8c58a0be77ee441bb8f8595b7f1b4e87?s=80&d=identicon",
+					},
+				},
+			},
+		},
+		{
+			event:  "Issue Hook",
+			before: "testdata/webhooks/issue_labeled.json",
+			after:  "testdata/webhooks/issue_labeled.json.golden",
+			obj:    new(scm.IssueHook),
+			mockUserService: &mockUserService{
+				users: map[int]*scm.User{
+					51764: {
+						ID:     51764,
+						Login:  "sytses",
+						Name:   "Sid Sijbrandij",
+						Email:  "",
+						Avatar: "https://secure.gravatar.com/avatar/8c58a0be77ee441bb8f8595b7f1b4e87?s=80&d=identicon",
+					},
+				},
+			},
+		},
+		{
+			event:  "Issue Hook",
+			before: "testdata/webhooks/issue_closed.json",
+			after:  "testdata/webhooks/issue_closed.json.golden",
+			obj:    new(scm.IssueHook),
+			mockUserService: &mockUserService{
+				users: map[int]*scm.User{
+					51764: {
+						ID:     51764,
+						Login:  "sytses",
+						Name:   "Sid Sijbrandij",
+						Email:  "",
+						Avatar: "https://secure.gravatar.com/avatar/8c58a0be77ee441bb8f8595b7f1b4e87?s=80&d=identicon",
+					},
+				},
+			},
+		},
+		{
+			event:  "Issue Hook",
+			before: "testdata/webhooks/issue_reopen.json",
+			after:  "testdata/webhooks/issue_reopen.json.golden",
+			obj:    new(scm.IssueHook),
+			mockUserService: &mockUserService{
+				users: map[int]*scm.User{
+					51764: {
+						ID:     51764,
+						Login:  "sytses",
+						Name:   "Sid Sijbrandij",
+						Email:  "",
+						Avatar: "https://secure.gravatar.com/avatar/8c58a0be77ee441bb8f8595b7f1b4e87?s=80&d=identicon",
+					},
+				},
+			},
+		},
 		// issue comment hooks
 		{
 			event:  "Note Hook",
@@ -115,6 +194,18 @@ func TestWebhooks(t *testing.T) {
 			after:  "testdata/webhooks/pull_request_edited.json.golden",
 			obj:    new(scm.PullRequestHook),
 		},
+		{
+			event:  "Merge Request Hook",
+			before: "testdata/webhooks/pull_request_ready.json",
+			after:  "testdata/webhooks/pull_request_ready.json.golden",
+			obj:    new(scm.PullRequestHook),
+		},
+		{
+			event:  "Merge Request Hook",
+			before: "testdata/webhooks/pull_request_draft.json",
+			after:  "testdata/webhooks/pull_request_draft.json.golden",
+			obj:    new(scm.PullRequestHook),
+		},
 		// {
 		// 	event:  "Merge Request Hook",
 		// 	before: "testdata/webhooks/pull_request_synchronized.json",
@@ -157,6 +248,48 @@ func TestWebhooks(t *testing.T) {
 				},
 			},
 		},
+		// pipeline hooks
+		{
+			event:  "Pipeline Hook",
+			before: "testdata/webhooks/pipeline.json",
+			after:  "testdata/webhooks/pipeline.json.golden",
+			obj:    new(scm.PipelineHook),
+		},
+		// job hooks
+		{
+			event:  "Job Hook",
+			before: "testdata/webhooks/job.json",
+			after:  "testdata/webhooks/job.json.golden",
+			obj:    new(scm.JobHook),
+		},
+		// deployment hooks
+		{
+			event:  "Deployment Hook",
+			before: "testdata/webhooks/deployment.json",
+			after:  "testdata/webhooks/deployment.json.golden",
+			obj:    new(scm.DeploymentStatusHook),
+		},
+		// wiki page hooks
+		{
+			event:  "Wiki Page Hook",
+			before: "testdata/webhooks/wiki_page.json",
+			after:  "testdata/webhooks/wiki_page.json.golden",
+			obj:    new(scm.WikiPageHook),
+		},
+		// member hooks
+		{
+			event:  "Member Hook",
+			before: "testdata/webhooks/member.json",
+			after:  "testdata/webhooks/member.json.golden",
+			obj:    new(scm.MemberHook),
+		},
+		// subgroup hooks
+		{
+			event:  "Subgroup Hook",
+			before: "testdata/webhooks/subgroup.json",
+			after:  "testdata/webhooks/subgroup.json.golden",
+			obj:    new(scm.OrganizationHook),
+		},
 		// release hooks
 		{
 			event:  "Release Hook",
@@ -228,6 +361,46 @@ func TestWebhooks(t *testing.T) {
 	}
 }
 
+func TestWebhook_WithoutClient(t *testing.T) {
+	tests := []struct {
+		event string
+		file  string
+	}{
+		{"Issue Hook", "testdata/webhooks/issue_create.json"},
+		{"Note Hook", "testdata/webhooks/issue_comment_create.json"},
+		{"Note Hook", "testdata/webhooks/pull_request_comment_create.json"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			f, _ := os.ReadFile(test.file)
+			r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+			r.Header.Set("X-Gitlab-Event", test.event)
+			r.Header.Set("X-Gitlab-Token", "topsecret")
+			r.Header.Set("X-Request-Id", "ee8d97b4-1479-43f1-9cac-fbbd1b80da55")
+
+			hook, err := NewWebHookService().Parse(r, secretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var author scm.User
+			switch v := hook.(type) {
+			case *scm.IssueHook:
+				author = v.Issue.Author
+			case *scm.IssueCommentHook:
+				author = v.Comment.Author
+			case *scm.PullRequestCommentHook:
+				author = v.Comment.Author
+			default:
+				t.Fatalf("Unexpected hook %T", hook)
+			}
+			if author.ID == 0 || author.Login == "" {
+				t.Errorf("Expect the author from the payload, got %+v", author)
+			}
+		})
+	}
+}
+
 func TestWebhook_SignatureValid(t *testing.T) {
 	f, _ := os.ReadFile("testdata/webhooks/branch_delete.json")
 	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
@@ -270,6 +443,22 @@ func TestWebhook_SignatureMissing(t *testing.T) {
 	}
 }
 
+func TestWebhook_SignatureRotated(t *testing.T) {
+	f, _ := os.ReadFile("testdata/webhooks/branch_delete.json")
+	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-Gitlab-Event", "Push Hook")
+	r.Header.Set("X-Gitlab-Token", "topsecret")
+	r.Header.Set("X-Request-Id", "ee8d97b4-1479-43f1-9cac-fbbd1b80da55")
+
+	s := new(webhookService)
+	_, err := s.ParseWithSecrets(r, func(scm.Webhook) ([]string, error) {
+		return []string{"newsecret", "topsecret"}, nil
+	})
+	if err != nil {
+		t.Error(err)
+	}
+}
+
 func secretFunc(scm.Webhook) (string, error) {
 	return "topsecret", nil
 }
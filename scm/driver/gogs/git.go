@@ -65,7 +65,25 @@ func (s *gitService) ListChanges(ctx context.Context, repo, ref string, _ *scm.L
 	return nil, nil, scm.ErrNotSupported
 }
 
-func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string, _ *scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+// CompareCommits returns the changes of the comparison of ref2 to
+// ref1, see Compare.
+func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string, opts *scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
+	out, res, err := s.Compare(ctx, repo, ref1, ref2, opts)
+	if err != nil {
+		return nil, res, err
+	}
+	return out.Changes, res, nil
+}
+
+func (s *gitService) Compare(ctx context.Context, repo, base, head string, _ *scm.ListOptions) (*scm.Comparison, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gitService) CompareDiff(ctx context.Context, repo, ref1, ref2 string) (*scm.Diff, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *gitService) FindTree(ctx context.Context, repo, ref string, recursive bool) (*scm.Tree, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
 
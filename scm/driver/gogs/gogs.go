@@ -43,7 +43,9 @@ func New(uri string) (*scm.Client, error) {
 	client.Organizations = &organizationService{client}
 	client.PullRequests = &pullService{client}
 	client.Repositories = &repositoryService{client}
+	client.Reactions = &reactionService{client}
 	client.Reviews = &reviewService{client}
+	client.Search = &searchService{client}
 	client.Users = &userService{client}
 	client.Webhooks = &webhookService{client}
 	return client.Client, nil
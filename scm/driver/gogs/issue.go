@@ -211,3 +211,7 @@ func convertIssueComment(from *issueComment) *scm.Comment {
 		Updated: from.UpdatedAt,
 	}
 }
+
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
@@ -7,6 +7,7 @@ package gogs
 import (
 	"context"
 	"fmt"
+	"io"
 	"strconv"
 	"time"
 
@@ -104,24 +105,24 @@ func (s *repositoryService) ListStatus(context.Context, string, string, *scm.Lis
 
 func (s *repositoryService) CreateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
 	path := fmt.Sprintf("api/v1/repos/%s/hooks", repo)
-	in := new(hook)
-	in.Type = "gogs"
-	in.Active = true
-	in.Config.Secret = input.Secret
-	in.Config.ContentType = "json"
-	in.Config.URL = input.Target
-	// nolint
-	in.Events = append(
-		input.NativeEvents,
-		convertHookEvent(input.Events)...,
-	)
+	in := convertHookInput(input)
 	out := new(hook)
 	res, err := s.client.do(ctx, "POST", path, in, out)
 	return convertHook(out), res, err
 }
 
 func (s *repositoryService) UpdateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	path := fmt.Sprintf("api/v1/repos/%s/hooks/%s", repo, input.Name)
+	in := convertHookInput(input)
+	out := new(hook)
+	res, err := s.client.do(ctx, "PATCH", path, in, out)
+	return convertHook(out), res, err
+}
+
+// ConvertHookEvents returns the events reported for a webhook
+// created from the input.
+func (s *repositoryService) ConvertHookEvents(input *scm.HookInput) []string {
+	return append(append([]string(nil), input.NativeEvents...), convertHookEvent(input.Events)...)
 }
 
 func (s *repositoryService) CreateStatus(context.Context, string, string, *scm.StatusInput) (*scm.Status, *scm.Response, error) {
@@ -133,10 +134,30 @@ func (s *repositoryService) DeleteHook(ctx context.Context, repo, id string) (*s
 	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
+func (s *repositoryService) ListHookDeliveries(context.Context, string, string, *scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *repositoryService) FindHookDelivery(context.Context, string, string, string) (*scm.HookDelivery, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *repositoryService) RedeliverHook(context.Context, string, string, string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *repositoryService) TestHook(context.Context, string, string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
 func (s *repositoryService) Delete(context.Context, string) (*scm.Response, error) {
 	return nil, scm.ErrNotSupported
 }
 
+func (s *repositoryService) Archive(context.Context, string, string, scm.ArchiveFormat) (io.ReadCloser, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
 //
 // native data structures
 //
@@ -234,6 +255,21 @@ func convertHook(from *hook) *scm.Hook {
 	}
 }
 
+func convertHookInput(input *scm.HookInput) *hook {
+	in := new(hook)
+	in.Type = "gogs"
+	in.Active = true
+	in.Config.Secret = input.Secret
+	in.Config.ContentType = "json"
+	in.Config.URL = input.Target
+	// nolint
+	in.Events = append(
+		input.NativeEvents,
+		convertHookEvent(input.Events)...,
+	)
+	return in
+}
+
 func convertHookEvent(from scm.HookEvents) []string {
 	var events []string
 	if from.PullRequest {
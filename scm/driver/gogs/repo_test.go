@@ -208,6 +208,38 @@ func TestHookCreate(t *testing.T) {
 	}
 }
 
+func TestHookUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("https://try.gogs.io").
+		Patch("/api/v1/repos/gogits/gogs/hooks/20").
+		Reply(200).
+		Type("application/json").
+		File("testdata/hook.json")
+
+	client, _ := New("https://try.gogs.io")
+	got, _, err := client.Repositories.UpdateHook(context.Background(), "gogits/gogs", &scm.HookInput{
+		Name:   "20",
+		Target: "http://gogs.io",
+		Events: scm.HookEvents{Push: true},
+	})
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := new(scm.Hook)
+	raw, _ := os.ReadFile("testdata/hook.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestHookDelete(t *testing.T) {
 	defer gock.Off()
 
@@ -20,6 +20,10 @@ type webhookService struct {
 }
 
 func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
+	return s.ParseWithSecrets(req, fn.Secrets())
+}
+
+func (s *webhookService) ParseWithSecrets(req *http.Request, fn scm.SecretsFunc) (scm.Webhook, error) {
 	data, err := io.ReadAll(
 		io.LimitReader(req.Body, 10000000),
 	)
@@ -56,10 +60,12 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	// get the gogs signature key to verify the payload
 	// signature. If no key is provided, no validation
 	// is performed.
-	key, err := fn(hook)
+	keys, err := fn(hook)
 	if err != nil {
 		return hook, err
-	} else if key == "" {
+	}
+	keys = hmac.Keys(keys)
+	if len(keys) == 0 {
 		return hook, nil
 	}
 
@@ -68,7 +74,7 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 		return hook, scm.ErrSignatureInvalid
 	}
 
-	if !hmac.Validate(sha256.New, data, []byte(key), sig) {
+	if !hmac.ValidateAny(sha256.New, data, keys, sig) {
 		return hook, scm.ErrSignatureInvalid
 	}
 
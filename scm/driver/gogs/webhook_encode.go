@@ -0,0 +1,257 @@
+package gogs
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"strconv"
+
+	"github.com/jenkins-x/go-scm/pkg/hmac"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/hookenc"
+)
+
+// Encode returns the Gogs webhook request for the hook, signed
+// with the sha256 signature.
+func (s *webhookService) Encode(hook scm.Webhook, secret string) (*http.Request, error) {
+	var (
+		event   string
+		guid    string
+		payload interface{}
+	)
+	switch v := hook.(type) {
+	case *scm.PushHook:
+		event, guid, payload = "push", v.GUID, encodePushHook(v)
+	case *scm.BranchHook:
+		event, payload = encodeCreateDeleteEvent(v.Action), encodeCreateHook("branch", &v.Ref, &v.Repo, &v.Sender)
+	case *scm.TagHook:
+		event, payload = encodeCreateDeleteEvent(v.Action), encodeCreateHook("tag", &v.Ref, &v.Repo, &v.Sender)
+	case *scm.IssueHook:
+		event, payload = "issues", encodeIssueHook(v)
+	case *scm.IssueCommentHook:
+		event, guid, payload = "issue_comment", v.GUID, encodeIssueCommentHook(v)
+	case *scm.PullRequestHook:
+		event, payload = "pull_request", encodePullRequestHook(v)
+	case *scm.PullRequestCommentHook:
+		event, guid, payload = "issue_comment", v.GUID, encodePullRequestCommentHook(v)
+	default:
+		return nil, scm.ErrNotSupported
+	}
+
+	req, data, err := hookenc.NewRequest(payload)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Gogs-Event", event)
+	req.Header.Set("X-Gogs-Delivery", hookenc.DeliveryID(guid))
+	if secret != "" {
+		req.Header.Set("X-Gogs-Signature", hmac.Sign(sha256.New, data, []byte(secret)))
+	}
+	return req, nil
+}
+
+func encodeCreateDeleteEvent(action scm.Action) string {
+	if action == scm.ActionDelete {
+		return "delete"
+	}
+	return "create"
+}
+
+func encodePushHook(src *scm.PushHook) *pushHook {
+	// gogs always sends at least one commit, which describes
+	// the head commit of the push.
+	return &pushHook{
+		Ref:     src.Ref,
+		Before:  src.Before,
+		After:   src.Commit.Sha,
+		Compare: src.Commit.Link,
+		Commits: []commit{{
+			ID:      src.Commit.Sha,
+			Message: src.Commit.Message,
+			Author: signature{
+				Name:     src.Commit.Author.Name,
+				Email:    src.Commit.Author.Email,
+				Username: src.Commit.Author.Login,
+			},
+			Committer: signature{
+				Name:     src.Commit.Committer.Name,
+				Email:    src.Commit.Committer.Email,
+				Username: src.Commit.Committer.Login,
+			},
+			Timestamp: src.Commit.Author.Date,
+		}},
+		Repository: *encodeRepository(&src.Repo),
+		Pusher:     *encodeUser(&src.Sender),
+		Sender:     *encodeUser(&src.Sender),
+	}
+}
+
+func encodeCreateHook(refType string, ref *scm.Reference, repo *scm.Repository, sender *scm.User) *createHook {
+	return &createHook{
+		Ref:           ref.Name,
+		RefType:       refType,
+		DefaultBranch: repo.Branch,
+		Repository:    *encodeRepository(repo),
+		Sender:        *encodeUser(sender),
+	}
+}
+
+func encodeIssueHook(src *scm.IssueHook) *issueHook {
+	return &issueHook{
+		Action:     encodeAction(src.Action),
+		Issue:      *encodeIssue(&src.Issue),
+		Repository: *encodeRepository(&src.Repo),
+		Sender:     *encodeUser(&src.Sender),
+	}
+}
+
+func encodeIssueCommentHook(src *scm.IssueCommentHook) *issueHook {
+	return &issueHook{
+		Action:     encodeAction(src.Action),
+		Issue:      *encodeIssue(&src.Issue),
+		Comment:    *encodeIssueComment(&src.Comment),
+		Repository: *encodeRepository(&src.Repo),
+		Sender:     *encodeUser(&src.Sender),
+	}
+}
+
+func encodePullRequestCommentHook(src *scm.PullRequestCommentHook) *issueHook {
+	pr := &src.PullRequest
+	dst := &issueHook{
+		Action: encodeAction(src.Action),
+		Issue: issue{
+			Number:  pr.Number,
+			Title:   pr.Title,
+			Body:    pr.Body,
+			State:   encodeState(pr.Closed),
+			User:    *encodeUser(&pr.Author),
+			Created: pr.Created,
+			Updated: pr.Updated,
+		},
+		Comment:    *encodeIssueComment(&src.Comment),
+		Repository: *encodeRepository(&src.Repo),
+		Sender:     *encodeUser(&src.Sender),
+	}
+	dst.Issue.PullRequest = &struct {
+		Merged   bool        `json:"merged"`
+		MergedAt interface{} `json:"merged_at"`
+	}{Merged: pr.Merged}
+	return dst
+}
+
+func encodePullRequestHook(src *scm.PullRequestHook) *pullRequestHook {
+	pr := &src.PullRequest
+	dst := &pullRequestHook{
+		Action: encodeAction(src.Action),
+		Number: pr.Number,
+		PullRequest: pullRequest{
+			Number:     pr.Number,
+			User:       *encodeUser(&pr.Author),
+			Title:      pr.Title,
+			Body:       pr.Body,
+			State:      encodeState(pr.Closed),
+			HeadBranch: pr.Source,
+			HeadRepo:   *encodeRepository(&pr.Head.Repo),
+			BaseBranch: pr.Target,
+			BaseRepo:   *encodeRepository(&pr.Base.Repo),
+			HTMLURL:    pr.Link,
+			Mergeable:  pr.Mergeable,
+			Merged:     pr.Merged,
+		},
+		Repository: *encodeRepository(&src.Repo),
+		Sender:     *encodeUser(&src.Sender),
+	}
+	if pr.Fork != "" {
+		dst.PullRequest.HeadRepo.FullName = pr.Fork
+	}
+	return dst
+}
+
+func encodeIssue(src *scm.Issue) *issue {
+	return &issue{
+		Number:  src.Number,
+		Title:   src.Title,
+		Body:    src.Body,
+		State:   encodeState(src.Closed),
+		Labels:  src.Labels,
+		User:    *encodeUser(&src.Author),
+		Created: src.Created,
+		Updated: src.Updated,
+	}
+}
+
+func encodeIssueComment(src *scm.Comment) *issueComment {
+	return &issueComment{
+		ID:        src.ID,
+		HTMLURL:   src.Link,
+		User:      *encodeUser(&src.Author),
+		Body:      src.Body,
+		CreatedAt: src.Created,
+		UpdatedAt: src.Updated,
+	}
+}
+
+func encodeRepository(src *scm.Repository) *repository {
+	id, _ := strconv.Atoi(src.ID)
+	dst := &repository{
+		ID:            id,
+		Owner:         user{Login: src.Namespace, Username: src.Namespace},
+		Name:          src.Name,
+		FullName:      src.FullName,
+		Private:       src.Private,
+		HTMLURL:       src.Link,
+		SSHURL:        src.CloneSSH,
+		CloneURL:      src.Clone,
+		DefaultBranch: src.Branch,
+	}
+	if src.Perm != nil {
+		dst.Permissions = perm{
+			Admin: src.Perm.Admin,
+			Push:  src.Perm.Push,
+			Pull:  src.Perm.Pull,
+		}
+	}
+	return dst
+}
+
+func encodeUser(src *scm.User) *user {
+	return &user{
+		ID:       src.ID,
+		Login:    src.Login,
+		Username: src.Login,
+		Fullname: src.Name,
+		Email:    src.Email,
+		Avatar:   src.Avatar,
+	}
+}
+
+func encodeState(closed bool) string {
+	if closed {
+		return "closed"
+	}
+	return "open"
+}
+
+func encodeAction(action scm.Action) string {
+	switch action {
+	case scm.ActionCreate:
+		return "created"
+	case scm.ActionDelete:
+		return "deleted"
+	case scm.ActionUpdate:
+		return "edited"
+	case scm.ActionOpen:
+		return "opened"
+	case scm.ActionReopen:
+		return "reopened"
+	case scm.ActionClose, scm.ActionMerge:
+		return "closed"
+	case scm.ActionLabel:
+		return "labeled"
+	case scm.ActionUnlabel:
+		return "unlabeled"
+	case scm.ActionSync:
+		return "synchronized"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,79 @@
+package gogs
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+func TestWebhookEncode(t *testing.T) {
+	tests := []struct {
+		event string
+		file  string
+	}{
+		{event: "push", file: "testdata/webhooks/push.json"},
+		{event: "create", file: "testdata/webhooks/branch_create.json"},
+		{event: "delete", file: "testdata/webhooks/branch_delete.json"},
+		{event: "create", file: "testdata/webhooks/tag_create.json"},
+		{event: "delete", file: "testdata/webhooks/tag_delete.json"},
+		{event: "issues", file: "testdata/webhooks/issues_opened.json"},
+		{event: "issues", file: "testdata/webhooks/issues_closed.json"},
+		{event: "issue_comment", file: "testdata/webhooks/issue_comment_created.json"},
+		{event: "issue_comment", file: "testdata/webhooks/issue_comment_edited.json"},
+		{event: "pull_request", file: "testdata/webhooks/pull_request_opened.json"},
+		{event: "pull_request", file: "testdata/webhooks/pull_request_edited.json"},
+		{event: "pull_request", file: "testdata/webhooks/pull_request_synchronized.json"},
+		{event: "pull_request", file: "testdata/webhooks/pull_request_closed.json"},
+		{event: "issue_comment", file: "testdata/webhooks/pull_request_comment_created.json"},
+	}
+
+	s := new(webhookService)
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			data, err := os.ReadFile(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, _ := http.NewRequest("POST", "/", bytes.NewReader(data))
+			r.Header.Set("X-Gogs-Event", test.event)
+			r.Header.Set("X-Gogs-Delivery", "ee8d97b4-1479-43f1-9cac-fbbd1b80da55")
+			want, err := s.Parse(r, noSecretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req, err := s.Encode(want, "topsecret")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := req.Header.Get("X-Gogs-Event"); got != test.event {
+				t.Errorf("Want event %s, got %s", test.event, got)
+			}
+
+			got, err := s.Parse(req, secretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Encoded webhook does not round trip")
+				t.Log(diff)
+			}
+		})
+	}
+}
+
+func TestWebhookEncode_NotSupported(t *testing.T) {
+	s := new(webhookService)
+	_, err := s.Encode(&scm.ReleaseHook{}, "topsecret")
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect not supported error, got %v", err)
+	}
+}
+
+func noSecretFunc(scm.Webhook) (string, error) {
+	return "", nil
+}
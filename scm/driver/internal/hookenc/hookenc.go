@@ -0,0 +1,40 @@
+// Package hookenc provides helpers shared by the drivers to
+// render webhooks as provider native http requests.
+package hookenc
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// NewRequest returns a webhook request with the json encoded
+// payload as the body, along with the encoded body so that
+// the caller can sign it.
+func NewRequest(payload interface{}) (*http.Request, []byte, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, "/", bytes.NewReader(data))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return req, data, nil
+}
+
+// DeliveryID returns the guid if it is not empty, otherwise
+// a random version 4 uuid identifying the delivery.
+func DeliveryID(guid string) string {
+	if guid != "" {
+		return guid
+	}
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
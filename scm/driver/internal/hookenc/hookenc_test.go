@@ -0,0 +1,35 @@
+package hookenc
+
+import (
+	"io"
+	"regexp"
+	"testing"
+)
+
+func TestDeliveryID(t *testing.T) {
+	if got := DeliveryID("f2467dea-70d6-11e8-8955-3c83993e0aef"); got != "f2467dea-70d6-11e8-8955-3c83993e0aef" {
+		t.Errorf("Want the guid to be preserved, got %s", got)
+	}
+	re := regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+	a, b := DeliveryID(""), DeliveryID("")
+	if !re.MatchString(a) {
+		t.Errorf("Want a version 4 uuid, got %s", a)
+	}
+	if a == b {
+		t.Errorf("Want unique delivery ids, got %s twice", a)
+	}
+}
+
+func TestNewRequest(t *testing.T) {
+	req, data, err := NewRequest(map[string]string{"ref": "refs/heads/master"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := req.Header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Want json content type, got %s", got)
+	}
+	body, _ := io.ReadAll(req.Body)
+	if string(body) != string(data) || string(data) != `{"ref":"refs/heads/master"}` {
+		t.Errorf("Unexpected body %s", body)
+	}
+}
@@ -40,6 +40,15 @@ func (b *Bool) UnmarshalJSON(data []byte) error {
 	return err
 }
 
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this Bool is null.
+func (b Bool) MarshalJSON() ([]byte, error) {
+	if !b.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(b.Bool)
+}
+
 // IsZero returns true for invalid Bools, for future omitempty
 // support (Go 1.4?). A non-null Bool with a 0 value will not be
 // considered zero.
@@ -50,6 +50,15 @@ func (i *Int) UnmarshalJSON(data []byte) error {
 	return err
 }
 
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this Int is null.
+func (i Int) MarshalJSON() ([]byte, error) {
+	if !i.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(i.Int64)
+}
+
 // IsZero returns true for invalid Ints, for future omitempty
 // support (Go 1.4?). A non-null Int with a 0 value will not
 // be considered zero.
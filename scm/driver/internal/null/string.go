@@ -17,6 +17,12 @@ type String struct {
 	sql.NullString
 }
 
+// StringFrom creates a new String that will be null if s
+// is blank.
+func StringFrom(s string) String {
+	return String{sql.NullString{String: s, Valid: s != ""}}
+}
+
 // UnmarshalJSON implements json.Unmarshaler.
 // It supports string and null input. Blank string input
 // does not produce a null String. It also supports
@@ -42,6 +48,15 @@ func (s *String) UnmarshalJSON(data []byte) error {
 	return err
 }
 
+// MarshalJSON implements json.Marshaler.
+// It will encode null if this String is null.
+func (s String) MarshalJSON() ([]byte, error) {
+	if !s.Valid {
+		return []byte("null"), nil
+	}
+	return json.Marshal(s.String)
+}
+
 // IsZero returns true for null strings, for potential
 // future omitempty support.
 func (s String) IsZero() bool {
@@ -8,6 +8,8 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"path"
+	"strconv"
 	"strings"
 	"time"
 
@@ -139,6 +141,100 @@ func (s *gitService) ListChanges(ctx context.Context, repo, ref string, opts *sc
 	return convertDiffstats(out), res, err
 }
 
+// CompareDiff returns the diff of ref2 against its merge base with
+// ref1. Bitbucket Server returns the hunks of the comparison as
+// json, so they are rendered as a unified diff.
+func (s *gitService) CompareDiff(ctx context.Context, repo, ref1, ref2 string) (*scm.Diff, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	params := url.Values{}
+	params.Set("from", ref2)
+	params.Set("to", ref1)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/compare/diff?%s", namespace, name, params.Encode())
+	out := new(diffs)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	diff, err := scm.ParseDiff(scm.FormatChanges(convertDiffs(out)))
+	return diff, res, err
+}
+
+// Compare returns the comparison of the head commit to the base
+// commit. Bitbucket Server does not report how far apart the commits
+// are, so the commits on each side are listed to count them.
+func (s *gitService) Compare(ctx context.Context, repo, base, head string, _ *scm.ListOptions) (*scm.Comparison, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	ahead, res, err := s.compareCommits(ctx, repo, head, base)
+	if err != nil {
+		return nil, res, err
+	}
+	behind, res, err := s.compareCommits(ctx, repo, base, head)
+	if err != nil {
+		return nil, res, err
+	}
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/commits/%s/merge-base?otherCommitId=%s", namespace, name, url.PathEscape(head), url.QueryEscape(base))
+	mergeBase := new(commit)
+	res, err = s.client.do(ctx, "GET", path, nil, mergeBase)
+	if err != nil {
+		return nil, res, err
+	}
+	params := url.Values{}
+	params.Set("from", head)
+	params.Set("to", base)
+	params.Set("limit", "1000")
+	changes := []*scm.Change{}
+	for {
+		path = fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/compare/changes?%s", namespace, name, params.Encode())
+		out := new(diffstats)
+		res, err = s.client.do(ctx, "GET", path, nil, out)
+		if err != nil {
+			return nil, res, err
+		}
+		changes = append(changes, convertDiffstats(out)...)
+		if out.LastPage.Bool || !out.NextPage.Valid {
+			break
+		}
+		params.Set("start", strconv.FormatInt(out.NextPage.Int64, 10))
+	}
+	return &scm.Comparison{
+		Status:    scm.ComparisonStatusOf(len(ahead), len(behind)),
+		AheadBy:   len(ahead),
+		BehindBy:  len(behind),
+		MergeBase: mergeBase.ID,
+		Commits:   ahead,
+		Changes:   changes,
+	}, res, nil
+}
+
+// compareCommits returns the commits reachable from the from commit
+// but not from the to commit, oldest first.
+func (s *gitService) compareCommits(ctx context.Context, repo, from, to string) ([]*scm.Commit, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	params := url.Values{}
+	params.Set("from", from)
+	params.Set("to", to)
+	params.Set("limit", "1000")
+	list := []*scm.Commit{}
+	for {
+		path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/compare/commits?%s", namespace, name, params.Encode())
+		out := new(commits)
+		res, err := s.client.do(ctx, "GET", path, nil, out)
+		if err != nil {
+			return nil, res, err
+		}
+		for _, v := range out.Values {
+			list = append(list, convertCommit(v))
+		}
+		if out.LastPage.Bool || !out.NextPage.Valid {
+			for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+				list[i], list[j] = list[j], list[i]
+			}
+			return list, res, nil
+		}
+		params.Set("start", strconv.FormatInt(out.NextPage.Int64, 10))
+	}
+}
+
 func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string, opts *scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	opts.From = url.PathEscape(ref1)
@@ -153,6 +249,60 @@ func (s *gitService) CompareCommits(ctx context.Context, repo, ref1, ref2 string
 	return convertDiffstats(out), res, err
 }
 
+// FindTree returns the git tree for the given ref. The recursive
+// listing uses the files endpoint which only reports file paths, while
+// the single level listing uses the browse endpoint which includes the
+// blob id and size of each entry.
+func (s *gitService) FindTree(ctx context.Context, repo, ref string, recursive bool) (*scm.Tree, *scm.Response, error) {
+	if recursive {
+		return s.listFiles(ctx, repo, ref)
+	}
+	namespace, name := scm.Split(repo)
+	to := &scm.Tree{Entries: []*scm.TreeEntry{}}
+	params := url.Values{}
+	params.Set("at", ref)
+	params.Set("limit", "1000")
+	for {
+		endpoint := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/browse?%s", namespace, name, params.Encode())
+		out := new(browse)
+		res, err := s.client.do(ctx, "GET", endpoint, nil, out)
+		if err != nil {
+			return nil, res, err
+		}
+		to.Entries = append(to.Entries, convertBrowseEntryList(out.Children.Values)...)
+		if out.Children.LastPage.Bool || !out.Children.NextPage.Valid {
+			return to, res, nil
+		}
+		params.Set("start", strconv.FormatInt(out.Children.NextPage.Int64, 10))
+	}
+}
+
+func (s *gitService) listFiles(ctx context.Context, repo, ref string) (*scm.Tree, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	to := &scm.Tree{Entries: []*scm.TreeEntry{}}
+	params := url.Values{}
+	params.Set("at", ref)
+	params.Set("limit", "1000")
+	for {
+		endpoint := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/files?%s", namespace, name, params.Encode())
+		out := new(contents)
+		res, err := s.client.do(ctx, "GET", endpoint, nil, out)
+		if err != nil {
+			return nil, res, err
+		}
+		for _, v := range out.Values {
+			to.Entries = append(to.Entries, &scm.TreeEntry{
+				Path: v,
+				Type: scm.TreeEntryBlob,
+			})
+		}
+		if out.LastPage.Bool || !out.NextPage.Valid {
+			return to, res, nil
+		}
+		params.Set("start", strconv.FormatInt(out.NextPage.Int64, 10))
+	}
+}
+
 func (s *gitService) GetDefaultBranch(ctx context.Context, repo string) (*scm.Reference, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	branch := new(branch)
@@ -161,6 +311,22 @@ func (s *gitService) GetDefaultBranch(ctx context.Context, repo string) (*scm.Re
 	return convertBranch(branch), resp, err
 }
 
+type browse struct {
+	Children struct {
+		pagination
+		Values []*browseEntry `json:"values"`
+	} `json:"children"`
+}
+
+type browseEntry struct {
+	Path struct {
+		ToString string `json:"toString"`
+	} `json:"path"`
+	ContentID string `json:"contentId"`
+	Type      string `json:"type"`
+	Size      int64  `json:"size"`
+}
+
 type deleteRefInput struct {
 	DryRun   bool   `json:"dryRun,omitempty"`
 	EndPoint string `json:"endPoint,omitempty"`
@@ -186,6 +352,11 @@ type branches struct {
 	Values []*branch `json:"values"`
 }
 
+type commits struct {
+	pagination
+	Values []*commit `json:"values"`
+}
+
 type diffstats struct {
 	pagination
 	Values []*diffstat
@@ -218,6 +389,31 @@ type diffstat struct {
 	} `json:"properties"`
 }
 
+type diffs struct {
+	Diffs []*fileDiff `json:"diffs"`
+}
+
+type fileDiff struct {
+	Source      *diffpath   `json:"source"`
+	Destination *diffpath   `json:"destination"`
+	Hunks       []*diffHunk `json:"hunks"`
+}
+
+type diffHunk struct {
+	SourceLine      int            `json:"sourceLine"`
+	SourceSpan      int            `json:"sourceSpan"`
+	DestinationLine int            `json:"destinationLine"`
+	DestinationSpan int            `json:"destinationSpan"`
+	Segments        []*diffSegment `json:"segments"`
+}
+
+type diffSegment struct {
+	Type  string `json:"type"`
+	Lines []struct {
+		Line string `json:"line"`
+	} `json:"lines"`
+}
+
 type commit struct {
 	ID        string `json:"id"`
 	DisplayID string `json:"displayId"`
@@ -295,7 +491,59 @@ func convertDiffstat(from *diffstat) *scm.Change {
 	return to
 }
 
+func convertDiffs(from *diffs) []*scm.Change {
+	to := []*scm.Change{}
+	for _, v := range from.Diffs {
+		to = append(to, convertFileDiff(v))
+	}
+	return to
+}
+
+// convertFileDiff returns the change of the file with the hunks
+// rendered as a unified patch.
+func convertFileDiff(from *fileDiff) *scm.Change {
+	to := new(scm.Change)
+	switch {
+	case from.Source == nil && from.Destination != nil:
+		to.Path = from.Destination.ToString
+		to.Added = true
+	case from.Destination == nil && from.Source != nil:
+		to.Path = from.Source.ToString
+		to.Deleted = true
+	case from.Source != nil && from.Destination != nil:
+		to.Path = from.Destination.ToString
+		to.Modified = true
+		if from.Source.ToString != from.Destination.ToString {
+			to.PreviousPath = from.Source.ToString
+			to.Renamed = true
+			to.Modified = false
+		}
+	}
+	sb := &strings.Builder{}
+	for _, h := range from.Hunks {
+		fmt.Fprintf(sb, "@@ -%d,%d +%d,%d @@\n", h.SourceLine, h.SourceSpan, h.DestinationLine, h.DestinationSpan)
+		for _, seg := range h.Segments {
+			prefix := " "
+			switch seg.Type {
+			case "ADDED":
+				prefix = "+"
+			case "REMOVED":
+				prefix = "-"
+			}
+			for _, line := range seg.Lines {
+				sb.WriteString(prefix + line.Line + "\n")
+			}
+		}
+	}
+	to.Patch = sb.String()
+	return to
+}
+
 func convertCommit(from *commit) *scm.Commit {
+	var parents []string
+	for _, p := range from.Parents {
+		parents = append(parents, p.ID)
+	}
 	return &scm.Commit{
 		Message: from.Message,
 		Sha:     from.ID,
@@ -314,6 +562,7 @@ func convertCommit(from *commit) *scm.Commit {
 			Login:  from.Committer.Slug,
 			Avatar: avatarLink(from.Committer.EmailAddress),
 		},
+		Parents: parents,
 	}
 }
 
@@ -348,3 +597,24 @@ func convertTag(from *branch) *scm.Reference {
 		Sha:  from.LatestCommit,
 	}
 }
+
+func convertBrowseEntryList(from []*browseEntry) []*scm.TreeEntry {
+	to := []*scm.TreeEntry{}
+	for _, v := range from {
+		entry := &scm.TreeEntry{
+			Path: path.Clean(v.Path.ToString),
+			Sha:  v.ContentID,
+			Size: v.Size,
+		}
+		switch v.Type {
+		case "DIRECTORY":
+			entry.Type = scm.TreeEntryTree
+		case "SUBMODULE":
+			entry.Type = scm.TreeEntryCommit
+		default:
+			entry.Type = scm.TreeEntryBlob
+		}
+		to = append(to, entry)
+	}
+	return to
+}
@@ -44,6 +44,66 @@ func TestGitFindCommit(t *testing.T) {
 	}
 }
 
+func TestGitFindTree(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/browse").
+		MatchParam("at", "master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tree.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Git.FindTree(context.Background(), "PRJ/my-repo", "master", false)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Tree)
+	raw, _ := os.ReadFile("testdata/tree.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitFindTreeRecursive(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/files").
+		MatchParam("at", "master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/tree_recursive.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Git.FindTree(context.Background(), "PRJ/my-repo", "master", true)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Tree)
+	raw, _ := os.ReadFile("testdata/tree_recursive.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestGitCreateRef(t *testing.T) {
 	defer gock.Off()
 
@@ -302,3 +362,92 @@ func TestGitGetDefaultBranch(t *testing.T) {
 		t.Log(diff)
 	}
 }
+
+func TestGitCompare(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/compare/commits").
+		MatchParam("from", "feature").
+		MatchParam("to", "master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare_commits.json")
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/compare/commits").
+		MatchParam("from", "master").
+		MatchParam("to", "feature").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare_commits_behind.json")
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/commits/feature/merge-base").
+		MatchParam("otherCommitId", "master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/merge_base.json")
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/compare/changes").
+		MatchParam("from", "feature").
+		MatchParam("to", "master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/changes.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Git.Compare(context.Background(), "PRJ/my-repo", "master", "feature", &scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Comparison)
+	raw, _ := os.ReadFile("testdata/compare.json.golden")
+	err = json.Unmarshal(raw, want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestGitCompareDiff(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/compare/diff").
+		MatchParam("from", "feature").
+		MatchParam("to", "master").
+		Reply(200).
+		Type("application/json").
+		File("testdata/compare_diff.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Git.CompareDiff(context.Background(), "PRJ/my-repo", "master", "feature")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := len(got.Files), 2; got != want {
+		t.Fatalf("Want %d files, got %d", want, got)
+	}
+	hunks := got.Files[0].Hunks
+	if got, want := len(hunks), 1; got != want {
+		t.Fatalf("Want %d hunks, got %d", want, got)
+	}
+	if got, want := len(hunks[0].Lines), 4; got != want {
+		t.Fatalf("Want %d lines, got %d", want, got)
+	}
+	if line := hunks[0].Lines[3]; line.Type != scm.DiffLineAdded || line.Content != "Welcome" || line.NewLine != 3 {
+		t.Errorf("Unexpected last line %+v", line)
+	}
+	if file := got.Files[1]; !file.Added || file.NewPath != "docs/index.md" {
+		t.Errorf("Want added file docs/index.md, got %+v", file)
+	}
+}
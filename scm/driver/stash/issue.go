@@ -115,3 +115,7 @@ func (s *issueService) SetMilestone(ctx context.Context, repo string, issueID, n
 func (s *issueService) ClearMilestone(ctx context.Context, repo string, id int) (*scm.Response, error) {
 	return nil, scm.ErrNotSupported
 }
+
+func (s *issueService) Update(ctx context.Context, repo string, number int, input *scm.IssueInput) (*scm.Issue, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
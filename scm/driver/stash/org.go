@@ -180,6 +180,60 @@ func (s *organizationService) ListMemberships(ctx context.Context, opts *scm.Lis
 	return nil, nil, scm.ErrNotSupported
 }
 
+// FindHook returns a project webhook.
+func (s *organizationService) FindHook(ctx context.Context, org, id string) (*scm.Hook, *scm.Response, error) {
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/webhooks/%s", org, id)
+	out := new(hook)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertHook(out), res, err
+}
+
+// ListHooks returns the project webhooks, which receive the
+// events of every repository in the project.
+func (s *organizationService) ListHooks(ctx context.Context, org string, opts *scm.ListOptions) ([]*scm.Hook, *scm.Response, error) {
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/webhooks?%s", org, encodeListOptions(opts))
+	out := new(hooks)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if !out.LastPage.Bool {
+		res.Page.First = 1
+		res.Page.Next = opts.Page + 1
+	}
+	return convertHookList(out), res, nil
+}
+
+// CreateHook creates a project webhook.
+func (s *organizationService) CreateHook(ctx context.Context, org string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/webhooks", org)
+	in := convertHookInput(input)
+	out := new(hook)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	return convertHook(out), res, err
+}
+
+// UpdateHook edits a project webhook. The hook name is left
+// unchanged, because the input name holds the hook id.
+func (s *organizationService) UpdateHook(ctx context.Context, org string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	current, res, err := s.FindHook(ctx, org, input.Name)
+	if err != nil {
+		return nil, res, err
+	}
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/webhooks/%s", org, current.ID)
+	in := convertHookInput(input)
+	in.Name = current.Name
+	out := new(hook)
+	res, err = s.client.do(ctx, "PUT", path, in, out)
+	return convertHook(out), res, err
+}
+
+// DeleteHook deletes a project webhook.
+func (s *organizationService) DeleteHook(ctx context.Context, org, id string) (*scm.Response, error) {
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/webhooks/%s", org, id)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
 func convertParticipantsToTeamMembers(from *participants) []*scm.TeamMember {
 	teamMembers := make([]*scm.TeamMember, 0, len(from.Values))
 	for _, f := range from.Values {
@@ -311,3 +311,184 @@ func TestIsMemberGroupWithSpaces(t *testing.T) {
 		t.Log(diff)
 	}
 }
+
+func TestOrganizationHookFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/webhooks/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/webhook.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Organizations.FindHook(context.Background(), "PRJ", "1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Hook)
+	raw, _ := os.ReadFile("testdata/webhook.json.golden")
+	err = json.Unmarshal(raw, want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestOrganizationHookList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/webhooks").
+		MatchParam("limit", "30").
+		Reply(200).
+		Type("application/json").
+		File("testdata/webhooks.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Organizations.ListHooks(context.Background(), "PRJ", &scm.ListOptions{Size: 30, Page: 1})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.Hook{}
+	raw, _ := os.ReadFile("testdata/webhooks.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestOrganizationHookListError(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/webhooks").
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"errors":[{"message":"Project PRJ does not exist."}]}`)
+
+	client, _ := New("http://example.com:7990")
+	got, res, err := client.Organizations.ListHooks(context.Background(), "PRJ", &scm.ListOptions{Size: 30, Page: 1})
+	if err == nil {
+		t.Errorf("Expect an error listing the hooks of a missing project")
+	}
+	if got != nil {
+		t.Errorf("Expect no hooks, got %v", got)
+	}
+	if res != nil && res.Page.Next != 0 {
+		t.Errorf("Expect no next page, got %d", res.Page.Next)
+	}
+}
+
+func TestOrganizationHookCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Post("/rest/api/1.0/projects/PRJ/webhooks").
+		Reply(201).
+		Type("application/json").
+		File("testdata/webhook.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Organizations.CreateHook(context.Background(), "PRJ", &scm.HookInput{
+		Name:   "example",
+		Target: "http://example.com",
+		Secret: "12345",
+		Events: scm.HookEvents{
+			PullRequest: true,
+			Push:        true,
+		},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Hook)
+	raw, _ := os.ReadFile("testdata/webhook.json.golden")
+	err = json.Unmarshal(raw, want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestOrganizationHookUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/webhooks/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/webhook.json")
+
+	gock.New("http://example.com:7990").
+		Put("/rest/api/1.0/projects/PRJ/webhooks/1").
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"name":          "example",
+			"url":           "http://example.com",
+			"active":        true,
+			"events":        []string{"repo:refs_changed"},
+			"configuration": map[string]string{"secret": "12345"},
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/webhook.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Organizations.UpdateHook(context.Background(), "PRJ", &scm.HookInput{
+		Name:   "1",
+		Target: "http://example.com",
+		Secret: "12345",
+		Events: scm.HookEvents{Push: true},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Hook)
+	raw, _ := os.ReadFile("testdata/webhook.json.golden")
+	err = json.Unmarshal(raw, want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestOrganizationHookDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Delete("/rest/api/1.0/projects/PRJ/webhooks/1").
+		Reply(204).
+		Type("application/json")
+
+	client, _ := New("http://example.com:7990")
+	_, err := client.Organizations.DeleteHook(context.Background(), "PRJ", "1")
+	if err != nil {
+		t.Error(err)
+	}
+}
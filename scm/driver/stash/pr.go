@@ -5,6 +5,7 @@
 package stash
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"net/http"
@@ -39,14 +40,45 @@ func (s *pullService) FindComment(ctx context.Context, repo string, number, id i
 
 func (s *pullService) List(ctx context.Context, repo string, opts *scm.PullRequestListOptions) ([]*scm.PullRequest, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
-	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests", namespace, name)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests?%s", namespace, name, encodePullRequestListOptions(opts))
 	out := new(pullRequests)
 	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
 	if !out.LastPage.Bool {
 		res.Page.First = 1
 		res.Page.Next = opts.Page + 1
 	}
-	return convertPullRequests(out), res, err
+	prs := scm.FilterPullRequests(convertPullRequests(out), opts)
+	if opts.Closed && !opts.Open {
+		prs = filterClosedPullRequests(prs)
+	}
+	return prs, res, nil
+}
+
+// filterClosedPullRequests returns the declined and merged pull
+// requests, as the api can only list a single state or all of
+// them.
+func filterClosedPullRequests(from []*scm.PullRequest) []*scm.PullRequest {
+	to := []*scm.PullRequest{}
+	for _, v := range from {
+		if v.Closed {
+			to = append(to, v)
+		}
+	}
+	return to
+}
+
+func (s *pullService) ListForCommit(ctx context.Context, repo, sha string) ([]*scm.PullRequest, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/commits/%s/pull-requests", namespace, name, url.PathEscape(sha))
+	out := new(pullRequests)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	return convertPullRequests(out), res, nil
 }
 
 func (s *pullService) ListChanges(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Change, *scm.Response, error) {
@@ -61,6 +93,18 @@ func (s *pullService) ListChanges(ctx context.Context, repo string, number int,
 	return convertDiffstats(out), res, err
 }
 
+func (s *pullService) FindDiff(ctx context.Context, repo string, number int) (*scm.Diff, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d.diff", namespace, name, number)
+	buf := new(bytes.Buffer)
+	res, err := s.client.do(ctx, "GET", path, nil, buf)
+	if err != nil {
+		return nil, res, err
+	}
+	diff, err := scm.ParseDiff(buf.String())
+	return diff, res, err
+}
+
 func (s *pullService) ListCommits(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Commit, *scm.Response, error) {
 	return nil, nil, scm.ErrNotSupported
 }
@@ -320,6 +364,99 @@ func (s *pullService) DeletePullRequest(ctx context.Context, repo string, prID i
 	return s.client.do(ctx, http.MethodDelete, path, &in, nil)
 }
 
+func (s *pullService) MarkReady(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) ConvertToDraft(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+// EnableAutoMerge requests the pull request to be merged once the
+// merge checks pass. Bitbucket generates the commit subject when no
+// CommitTitle is given.
+func (s *pullService) EnableAutoMerge(ctx context.Context, repo string, number int, opts *scm.AutoMergeOptions) (*scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/auto-merge", namespace, name, number)
+	in := &autoMergeInput{AutoSubject: true}
+	if opts != nil {
+		in.StrategyID = encodeMergeStrategy(opts.MergeMethod)
+		in.Message = opts.CommitMessage
+		if opts.CommitTitle != "" {
+			in.AutoSubject = false
+			in.Message = strings.TrimSpace(opts.CommitTitle + "\n\n" + opts.CommitMessage)
+		}
+	}
+	return s.client.do(ctx, "POST", path, in, nil)
+}
+
+// DisableAutoMerge cancels the auto-merge request of the pull request.
+func (s *pullService) DisableAutoMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/auto-merge", namespace, name, number)
+	return s.client.do(ctx, "DELETE", path, nil, nil)
+}
+
+type autoMergeInput struct {
+	AutoSubject bool   `json:"autoSubject"`
+	Message     string `json:"message,omitempty"`
+	StrategyID  string `json:"strategyId,omitempty"`
+}
+
+// encodeMergeStrategy returns the id of the Bitbucket merge strategy
+// of the merge method, or empty to use the repository default.
+func encodeMergeStrategy(method string) string {
+	switch method {
+	case "merge":
+		return "no-ff"
+	case "squash":
+		return "squash"
+	case "rebase":
+		return "rebase-no-ff"
+	default:
+		return ""
+	}
+}
+
+func (s *pullService) EnqueueMerge(ctx context.Context, repo string, number int, opts *scm.AutoMergeOptions) (*scm.MergeQueueEntry, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *pullService) DequeueMerge(ctx context.Context, repo string, number int) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+func (s *pullService) FindMergeQueueEntry(ctx context.Context, repo string, number int) (*scm.MergeQueueEntry, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+// UpdateBranch rebases the pull request source branch onto the target
+// branch. Bitbucket Server only supports the rebase method, which is
+// the default.
+func (s *pullService) UpdateBranch(ctx context.Context, repo string, number int, method string) (string, *scm.Response, error) {
+	if method != "" && method != scm.UpdateBranchRebase {
+		return "", nil, scm.ErrNotSupported
+	}
+	namespace, name := scm.Split(repo)
+	getPath := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d", namespace, name, number)
+	getOut := new(pullRequest)
+	res, err := s.client.do(ctx, http.MethodGet, getPath, nil, getOut)
+	if err != nil {
+		return "", res, err
+	}
+	path := fmt.Sprintf("rest/git/1.0/projects/%s/repos/%s/pull-requests/%d/rebase", namespace, name, number)
+	in := map[string]int{"version": getOut.Version}
+	out := new(prRebase)
+	res, err = s.client.do(ctx, http.MethodPost, path, &in, out)
+	if res != nil && res.Status == http.StatusConflict {
+		return "", res, scm.MergeConflict{Number: number, Message: err.Error()}
+	}
+	if err != nil {
+		return "", res, err
+	}
+	return out.Ref.LatestCommit, res, nil
+}
+
 type createPRInput struct {
 	Title       string           `json:"title,omitempty"`
 	Description string           `json:"description,omitempty"`
@@ -373,6 +510,10 @@ type prRepoRef struct {
 	Repository   repository `json:"repository"`
 }
 
+type prRebase struct {
+	Ref prRepoRef `json:"ref"`
+}
+
 type prUser struct {
 	User               user   `json:"user"`
 	LastReviewedCommit string `json:"lastReviewedCommit"`
@@ -449,6 +590,9 @@ type pullRequestComment struct {
 	UpdatedDate         int64                `json:"updatedDate"`
 	Comments            []pullRequestComment `json:"comments"`
 	Tasks               []interface{}        `json:"tasks"`
+	Severity            string               `json:"severity"`
+	State               string               `json:"state"`
+	ThreadResolved      bool                 `json:"threadResolved"`
 	PermittedOperations struct {
 		Editable  bool `json:"editable"`
 		Deletable bool `json:"deletable"`
@@ -480,7 +624,27 @@ type pullRequestActivity struct {
 	Action        string              `json:"action"`
 	CommentAction string              `json:"commentAction"`
 	Comment       *pullRequestComment `json:"comment"`
-	CommentAnchor interface{}         `json:"commentAnchor"`
+	CommentAnchor *commentAnchor      `json:"commentAnchor"`
+}
+
+type commentAnchor struct {
+	FromHash        string           `json:"fromHash,omitempty"`
+	ToHash          string           `json:"toHash,omitempty"`
+	Line            int              `json:"line,omitempty"`
+	LineType        string           `json:"lineType,omitempty"`
+	FileType        string           `json:"fileType,omitempty"`
+	Path            string           `json:"path"`
+	SrcPath         string           `json:"srcPath,omitempty"`
+	DiffType        string           `json:"diffType,omitempty"`
+	MultilineMarker *multilineMarker `json:"multilineMarker,omitempty"`
+	Orphaned        bool             `json:"orphaned,omitempty"`
+}
+
+// multilineMarker marks the first line of a comment spanning several
+// lines, the anchor line being the last one.
+type multilineMarker struct {
+	StartLine     int    `json:"startLine"`
+	StartLineType string `json:"startLineType"`
 }
 
 func convertPullRequestActivities(from *pullRequestActivities) []*scm.Comment {
@@ -190,6 +190,86 @@ func TestPullList(t *testing.T) {
 	}
 }
 
+func TestPullListFilters(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests").
+		MatchParam("at", "refs/heads/feature/x").
+		MatchParam("direction", "OUTGOING").
+		MatchParam("username.1", "jcitizen").
+		MatchParam("role.1", "AUTHOR").
+		Times(2).
+		Reply(200).
+		Type("application/json").
+		File("testdata/prs.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.PullRequests.List(context.Background(), "PRJ/my-repo", &scm.PullRequestListOptions{Head: "feature/x", Base: "master", Author: "jcitizen"})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(got) != 1 {
+		t.Errorf("Want 1 pull request, got %d", len(got))
+	}
+
+	got, _, err = client.PullRequests.List(context.Background(), "PRJ/my-repo", &scm.PullRequestListOptions{Head: "feature/x", Base: "develop", Author: "jcitizen"})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Want pull requests filtered by base, got %d", len(got))
+	}
+}
+
+func TestPullListClosed(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests").
+		MatchParam("state", "all").
+		Reply(200).
+		Type("application/json").
+		File("testdata/prs.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.PullRequests.List(context.Background(), "PRJ/my-repo", &scm.PullRequestListOptions{Closed: true})
+	if err != nil {
+		t.Error(err)
+	}
+	if len(got) != 0 {
+		t.Errorf("Want open pull requests filtered out, got %d", len(got))
+	}
+}
+
+func TestPullListForCommit(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/commits/131cb13f4aed12e725177bc4b7c28db67839bf9f/pull-requests").
+		Reply(200).
+		Type("application/json").
+		File("testdata/prs.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.PullRequests.ListForCommit(context.Background(), "PRJ/my-repo", "131cb13f4aed12e725177bc4b7c28db67839bf9f")
+	if err != nil {
+		t.Error(err)
+	}
+
+	want := []*scm.PullRequest{}
+	raw, _ := os.ReadFile("testdata/prs.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
 func TestPullListChanges(t *testing.T) {
 	defer gock.Off()
 
@@ -243,6 +323,105 @@ func TestPullMerge(t *testing.T) {
 	}
 }
 
+func TestPullEnableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Post("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/auto-merge").
+		JSON(map[string]interface{}{
+			"autoSubject": false,
+			"message":     "JS fix\n\nFixes the build.",
+			"strategyId":  "squash",
+		}).
+		Reply(200)
+
+	client, _ := New("http://example.com:7990")
+	_, err := client.PullRequests.EnableAutoMerge(context.Background(), "PRJ/my-repo", 1, &scm.AutoMergeOptions{
+		MergeMethod:   "squash",
+		CommitTitle:   "JS fix",
+		CommitMessage: "Fixes the build.",
+	})
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestPullDisableAutoMerge(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Delete("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/auto-merge").
+		Reply(204)
+
+	client, _ := New("http://example.com:7990")
+	_, err := client.PullRequests.DisableAutoMerge(context.Background(), "PRJ/my-repo", 1)
+	if err != nil {
+		t.Error(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestPullUpdateBranch(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	gock.New("http://example.com:7990").
+		Post("rest/git/1.0/projects/PRJ/repos/my-repo/pull-requests/1/rebase").
+		JSON(map[string]int{"version": 0}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_rebase.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.PullRequests.UpdateBranch(context.Background(), "PRJ/my-repo", 1, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "f7d0b3b9c62f3d4a3c6a8f2e0c4b5d6e7f8091a2"; got != want {
+		t.Errorf("Want head sha %q, got %q", want, got)
+	}
+}
+
+func TestPullUpdateBranchConflict(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr.json")
+
+	gock.New("http://example.com:7990").
+		Post("rest/git/1.0/projects/PRJ/repos/my-repo/pull-requests/1/rebase").
+		Reply(409).
+		Type("application/json").
+		File("testdata/pr_rebase_conflict.json")
+
+	client, _ := New("http://example.com:7990")
+	_, _, err := client.PullRequests.UpdateBranch(context.Background(), "PRJ/my-repo", 1, scm.UpdateBranchRebase)
+	if !scm.IsMergeConflict(err) {
+		t.Errorf("Expected merge conflict error, got %v", err)
+	}
+}
+
+func TestPullUpdateBranchMergeNotSupported(t *testing.T) {
+	client, _ := New("http://example.com:7990")
+	_, _, err := client.PullRequests.UpdateBranch(context.Background(), "PRJ/my-repo", 1, scm.UpdateBranchMerge)
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expected scm.ErrNotSupported, got %v", err)
+	}
+}
+
 func TestPullClose(t *testing.T) {
 	defer gock.Off()
 
@@ -465,3 +644,33 @@ func TestPullRequestDelete(t *testing.T) {
 		t.Errorf("DeletePullRequest returned %v", resp.Status)
 	}
 }
+
+func TestPullFindDiff(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1.diff").
+		Reply(200).
+		Type("text/plain").
+		File("testdata/pr.diff")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.PullRequests.FindDiff(context.Background(), "PRJ/my-repo", 1)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := len(got.Files), 2; got != want {
+		t.Fatalf("Want %d files, got %d", want, got)
+	}
+	if got, want := got.Files[0].NewPath, "README.md"; got != want {
+		t.Errorf("Want file %q, got %q", want, got)
+	}
+	if got, want := len(got.Files[0].Hunks), 2; got != want {
+		t.Errorf("Want %d hunks, got %d", want, got)
+	}
+	if !got.Files[1].Added || !got.Files[1].Binary {
+		t.Errorf("Want added binary file %s", got.Files[1].NewPath)
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stash
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// reactionService implements reactions on pull request
+// comments with the comment likes API. Bitbucket Server does
+// not support reactions on pull requests themselves and has no
+// issues.
+type reactionService struct {
+	client *wrapper
+}
+
+type commentReaction struct {
+	User     user `json:"user"`
+	Emoticon struct {
+		Shortcut string `json:"shortcut"`
+	} `json:"emoticon"`
+}
+
+type commentReactions struct {
+	Properties struct {
+		Reactions []struct {
+			Emoticon struct {
+				Shortcut string `json:"shortcut"`
+			} `json:"emoticon"`
+			Users []user `json:"users"`
+		} `json:"reactions"`
+	} `json:"properties"`
+}
+
+// emoticonNames maps reactions to emoticon shortcuts.
+var emoticonNames = map[scm.ReactionContent]string{
+	scm.ReactionPlusOne:  "thumbsup",
+	scm.ReactionMinusOne: "thumbsdown",
+	scm.ReactionLaugh:    "laughing",
+	scm.ReactionConfused: "confused",
+	scm.ReactionHeart:    "heart",
+	scm.ReactionHooray:   "tada",
+	scm.ReactionRocket:   "rocket",
+	scm.ReactionEyes:     "eyes",
+}
+
+// List returns the reactions from the comment properties, as
+// the comment likes API cannot list reactions.
+func (s *reactionService) List(ctx context.Context, repo string, target scm.ReactionTarget, opts *scm.ListOptions) ([]*scm.Reaction, *scm.Response, error) {
+	if !isCommentReaction(target) {
+		return nil, nil, scm.ErrNotSupported
+	}
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments/%d", namespace, name, target.Number, target.Comment)
+	out := new(commentReactions)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertCommentReactions(out), res, err
+}
+
+func (s *reactionService) Create(ctx context.Context, repo string, target scm.ReactionTarget, content scm.ReactionContent) (*scm.Reaction, *scm.Response, error) {
+	if !isCommentReaction(target) {
+		return nil, nil, scm.ErrNotSupported
+	}
+	out := new(commentReaction)
+	res, err := s.client.do(ctx, "PUT", commentReactionPath(repo, target, content), nil, out)
+	return convertCommentReaction(out), res, err
+}
+
+func (s *reactionService) Delete(ctx context.Context, repo string, target scm.ReactionTarget, content scm.ReactionContent) (*scm.Response, error) {
+	if !isCommentReaction(target) {
+		return nil, scm.ErrNotSupported
+	}
+	return s.client.do(ctx, "DELETE", commentReactionPath(repo, target, content), nil, nil)
+}
+
+// isCommentReaction returns true if the target is a pull
+// request comment. Review comments are pull request comments
+// anchored to a file.
+func isCommentReaction(target scm.ReactionTarget) bool {
+	switch target.Type {
+	case scm.ReactionOnPullRequestComment, scm.ReactionOnReviewComment:
+		return true
+	default:
+		return false
+	}
+}
+
+func commentReactionPath(repo string, target scm.ReactionTarget, content scm.ReactionContent) string {
+	namespace, name := scm.Split(repo)
+	return fmt.Sprintf("rest/comment-likes/latest/projects/%s/repos/%s/pull-requests/%d/comments/%d/reactions/%s", namespace, name, target.Number, target.Comment, encodeEmoticon(content))
+}
+
+func encodeEmoticon(content scm.ReactionContent) string {
+	if name, ok := emoticonNames[content]; ok {
+		return name
+	}
+	return string(content)
+}
+
+func decodeEmoticon(name string) scm.ReactionContent {
+	for content, v := range emoticonNames {
+		if v == name {
+			return content
+		}
+	}
+	return scm.ReactionContent(name)
+}
+
+func convertCommentReactions(from *commentReactions) []*scm.Reaction {
+	to := []*scm.Reaction{}
+	for _, v := range from.Properties.Reactions {
+		for i := range v.Users {
+			to = append(to, &scm.Reaction{
+				Content: decodeEmoticon(v.Emoticon.Shortcut),
+				User:    *convertUser(&v.Users[i]),
+			})
+		}
+	}
+	return to
+}
+
+func convertCommentReaction(from *commentReaction) *scm.Reaction {
+	return &scm.Reaction{
+		Content: decodeEmoticon(from.Emoticon.Shortcut),
+		User:    *convertUser(&from.User),
+	}
+}
@@ -0,0 +1,92 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestReactionList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/comments/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_comment_reactions.json")
+
+	client, _ := New("http://example.com:7990")
+	target := scm.ReactionTarget{Type: scm.ReactionOnPullRequestComment, Number: 1, Comment: 1}
+	got, _, err := client.Reactions.List(context.Background(), "PRJ/my-repo", target, &scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Reaction{}
+	raw, _ := os.ReadFile("testdata/pr_comment_reactions.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReactionCreate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Put("rest/comment-likes/latest/projects/PRJ/repos/my-repo/pull-requests/1/comments/1/reactions/thumbsup").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_comment_reaction.json")
+
+	client, _ := New("http://example.com:7990")
+	target := scm.ReactionTarget{Type: scm.ReactionOnReviewComment, Number: 1, Comment: 1}
+	got, _, err := client.Reactions.Create(context.Background(), "PRJ/my-repo", target, scm.ReactionPlusOne)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := new(scm.Reaction)
+	raw, _ := os.ReadFile("testdata/pr_comment_reaction.json.golden")
+	_ = json.Unmarshal(raw, want)
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReactionDelete(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Delete("rest/comment-likes/latest/projects/PRJ/repos/my-repo/pull-requests/1/comments/1/reactions/thumbsup").
+		Reply(204)
+
+	client, _ := New("http://example.com:7990")
+	target := scm.ReactionTarget{Type: scm.ReactionOnPullRequestComment, Number: 1, Comment: 1}
+	_, err := client.Reactions.Delete(context.Background(), "PRJ/my-repo", target, scm.ReactionPlusOne)
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReactionNotSupported(t *testing.T) {
+	client, _ := New("http://example.com:7990")
+	target := scm.ReactionTarget{Type: scm.ReactionOnPullRequest, Number: 1}
+	_, _, err := client.Reactions.Create(context.Background(), "PRJ/my-repo", target, scm.ReactionPlusOne)
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error, got %v", err)
+	}
+}
@@ -7,9 +7,11 @@ package stash
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/url"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
@@ -67,6 +69,28 @@ type hook struct {
 	} `json:"configuration"`
 }
 
+type hookStatistics struct {
+	LastSuccess *hookInvocation `json:"lastSuccess"`
+	LastFailure *hookInvocation `json:"lastFailure"`
+	LastError   *hookInvocation `json:"lastError"`
+}
+
+type hookInvocation struct {
+	ID       int    `json:"id"`
+	Event    string `json:"event"`
+	Duration int64  `json:"duration"`
+	Start    int64  `json:"start"`
+	Finish   int64  `json:"finish"`
+	Request  struct {
+		Method string `json:"method"`
+		URL    string `json:"url"`
+	} `json:"request"`
+	Result struct {
+		Description string `json:"description"`
+		Outcome     string `json:"outcome"`
+	} `json:"result"`
+}
+
 type hookInput struct {
 	Name   string   `json:"name"`
 	Events []string `json:"events"`
@@ -449,23 +473,32 @@ func (s *repositoryService) ListStatus(ctx context.Context, _, ref string, opts
 func (s *repositoryService) CreateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
 	namespace, name := scm.Split(repo)
 	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/webhooks", namespace, name)
-	in := new(hookInput)
-	in.URL = input.Target
-	in.Active = true
-	in.Name = input.Name
-	in.Config.Secret = input.Secret
-	// nolint
-	in.Events = append(
-		input.NativeEvents,
-		convertHookEvents(input.Events)...,
-	)
+	in := convertHookInput(input)
 	out := new(hook)
 	res, err := s.client.do(ctx, "POST", path, in, out)
 	return convertHook(out), res, err
 }
 
+// UpdateHook edits a repository webhook. The hook name is left
+// unchanged, because the input name holds the hook id.
 func (s *repositoryService) UpdateHook(ctx context.Context, repo string, input *scm.HookInput) (*scm.Hook, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	current, res, err := s.FindHook(ctx, repo, input.Name)
+	if err != nil {
+		return nil, res, err
+	}
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/webhooks/%s", namespace, name, current.ID)
+	in := convertHookInput(input)
+	in.Name = current.Name
+	out := new(hook)
+	res, err = s.client.do(ctx, "PUT", path, in, out)
+	return convertHook(out), res, err
+}
+
+// ConvertHookEvents returns the events reported for a webhook
+// created from the input.
+func (s *repositoryService) ConvertHookEvents(input *scm.HookInput) []string {
+	return append(append([]string(nil), input.NativeEvents...), convertHookEvents(input.Events)...)
 }
 
 // CreateStatus creates a new commit status.
@@ -495,10 +528,69 @@ func (s *repositoryService) DeleteHook(ctx context.Context, repo, id string) (*s
 	return s.client.do(ctx, "DELETE", path, nil, nil)
 }
 
+// ListHookDeliveries returns the latest successful, failed and
+// erroneous invocations of a repository webhook, which are the
+// only invocations recorded by Bitbucket Server.
+func (s *repositoryService) ListHookDeliveries(ctx context.Context, repo, id string, _ *scm.ListOptions) ([]*scm.HookDelivery, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/webhooks/%s/statistics", namespace, name, id)
+	out := new(hookStatistics)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	return convertHookStatistics(out), res, err
+}
+
+// FindHookDelivery returns a recent invocation of a repository webhook.
+func (s *repositoryService) FindHookDelivery(ctx context.Context, repo, id, delivery string) (*scm.HookDelivery, *scm.Response, error) {
+	deliveries, res, err := s.ListHookDeliveries(ctx, repo, id, &scm.ListOptions{})
+	if err != nil {
+		return nil, res, err
+	}
+	for _, v := range deliveries {
+		if v.ID == delivery {
+			return v, res, nil
+		}
+	}
+	return nil, res, scm.ErrNotFound
+}
+
+func (s *repositoryService) RedeliverHook(context.Context, string, string, string) (*scm.Response, error) {
+	return nil, scm.ErrNotSupported
+}
+
+// TestHook sends a test request to the url of a repository webhook.
+func (s *repositoryService) TestHook(ctx context.Context, repo, id string) (*scm.Response, error) {
+	hook, res, err := s.FindHook(ctx, repo, id)
+	if err != nil {
+		return res, err
+	}
+	namespace, name := scm.Split(repo)
+	params := url.Values{}
+	params.Set("webhookId", id)
+	params.Set("url", hook.Target)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/webhooks/test?%s", namespace, name, params.Encode())
+	return s.client.do(ctx, "POST", path, nil, nil)
+}
+
 func (s *repositoryService) Delete(context.Context, string) (*scm.Response, error) {
 	return nil, scm.ErrNotSupported
 }
 
+// Archive downloads the repository archive at the given ref, which
+// is streamed by the archive endpoint of the rest api.
+func (s *repositoryService) Archive(ctx context.Context, repo, ref string, format scm.ArchiveFormat) (io.ReadCloser, *scm.Response, error) {
+	switch format {
+	case scm.ArchiveFormatTarball, scm.ArchiveFormatZip:
+	default:
+		return nil, nil, scm.ErrNotSupported
+	}
+	namespace, name := scm.Split(repo)
+	params := url.Values{}
+	params.Set("at", ref)
+	params.Set("format", string(format))
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/archive?%s", namespace, name, params.Encode())
+	return s.client.stream(ctx, "GET", path)
+}
+
 // helper function to convert from the gogs repository list to
 // the common repository structure.
 func convertRepositoryList(from *repositories) []*scm.Repository {
@@ -568,6 +660,57 @@ func convertHook(from *hook) *scm.Hook {
 	}
 }
 
+// convertHookInput returns the request body used to create
+// or edit a repository or project webhook.
+func convertHookInput(input *scm.HookInput) *hookInput {
+	in := new(hookInput)
+	in.URL = input.Target
+	in.Active = true
+	in.Name = input.Name
+	in.Config.Secret = input.Secret
+	// nolint
+	in.Events = append(
+		input.NativeEvents,
+		convertHookEvents(input.Events)...,
+	)
+	return in
+}
+
+func convertHookStatistics(from *hookStatistics) []*scm.HookDelivery {
+	to := []*scm.HookDelivery{}
+	seen := map[int]bool{}
+	for _, v := range []*hookInvocation{from.LastSuccess, from.LastFailure, from.LastError} {
+		if v == nil || seen[v.ID] {
+			continue
+		}
+		seen[v.ID] = true
+		to = append(to, convertHookInvocation(v))
+	}
+	// return the most recent invocation first.
+	sort.SliceStable(to, func(i, j int) bool {
+		return to[i].Delivered.After(to[j].Delivered)
+	})
+	return to
+}
+
+func convertHookInvocation(from *hookInvocation) *scm.HookDelivery {
+	to := &scm.HookDelivery{
+		ID:        strconv.Itoa(from.ID),
+		Event:     from.Event,
+		Status:    from.Result.Outcome,
+		Duration:  time.Duration(from.Duration) * time.Millisecond,
+		Delivered: time.Unix(0, from.Start*int64(time.Millisecond)).UTC(),
+		Request: scm.HookDeliveryRequest{
+			URL:    from.Request.URL,
+			Method: from.Request.Method,
+		},
+	}
+	// the result description is the response status code, or
+	// a description of the failure if no response was received.
+	to.StatusCode, _ = strconv.Atoi(from.Result.Description)
+	return to
+}
+
 func convertHookEvents(from scm.HookEvents) []string {
 	var events []string
 	if from.Push || from.Branch || from.Tag {
@@ -7,6 +7,7 @@ package stash
 import (
 	"context"
 	"encoding/json"
+	"io"
 	"os"
 	"testing"
 
@@ -82,6 +83,51 @@ func TestRepositoryFork(t *testing.T) {
 	}
 }
 
+func TestRepositoryArchive(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/archive").
+		MatchParam("at", "master").
+		MatchParam("format", "zip").
+		Reply(200).
+		Type("application/zip").
+		BodyString("archive")
+
+	client, _ := New("http://example.com:7990")
+	rc, _, err := client.Repositories.Archive(context.Background(), "PRJ/my-repo", "master", scm.ArchiveFormatZip)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(rc)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if got, want := string(data), "archive"; got != want {
+		t.Errorf("Want archive contents %q, got %q", want, got)
+	}
+}
+
+func TestRepositoryArchiveNotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/archive").
+		Reply(404).
+		Type("application/json").
+		BodyString(`{"message":"404 Not Found"}`)
+
+	client, _ := New("http://example.com:7990")
+	_, _, err := client.Repositories.Archive(context.Background(), "PRJ/my-repo", "master", scm.ArchiveFormatZip)
+	if err != scm.ErrNotFound {
+		t.Errorf("Want error %v, got %v", scm.ErrNotFound, err)
+	}
+}
+
 func TestRepositoryFind(t *testing.T) {
 	defer gock.Off()
 
@@ -557,6 +603,158 @@ func TestRepositoryHookCreate(t *testing.T) {
 	}
 }
 
+func TestRepositoryHookUpdate(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/webhooks/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/webhook.json")
+
+	gock.New("http://example.com:7990").
+		Put("/rest/api/1.0/projects/PRJ/repos/my-repo/webhooks/1").
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"name":          "example",
+			"url":           "http://example.com",
+			"active":        true,
+			"events":        []string{"repo:refs_changed"},
+			"configuration": map[string]string{"secret": "12345"},
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/webhook.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Repositories.UpdateHook(context.Background(), "PRJ/my-repo", &scm.HookInput{
+		Name:   "1",
+		Target: "http://example.com",
+		Secret: "12345",
+		Events: scm.HookEvents{Push: true},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := new(scm.Hook)
+	raw, _ := os.ReadFile("testdata/webhook.json.golden")
+	err = json.Unmarshal(raw, want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRepositoryHookDeliveryList(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/webhooks/1/statistics").
+		Reply(200).
+		Type("application/json").
+		File("testdata/webhook_statistics.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Repositories.ListHookDeliveries(context.Background(), "PRJ/my-repo", "1", &scm.ListOptions{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.HookDelivery{}
+	raw, _ := os.ReadFile("testdata/webhook_deliveries.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestRepositoryHookDeliveryFind(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/webhooks/1/statistics").
+		Times(2).
+		Reply(200).
+		Type("application/json").
+		File("testdata/webhook_statistics.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Repositories.FindHookDelivery(context.Background(), "PRJ/my-repo", "1", "57")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	want := []*scm.HookDelivery{}
+	raw, _ := os.ReadFile("testdata/webhook_deliveries.json.golden")
+	err = json.Unmarshal(raw, &want)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if diff := cmp.Diff(want[1], got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+
+	_, _, err = client.Repositories.FindHookDelivery(context.Background(), "PRJ/my-repo", "1", "1")
+	if err != scm.ErrNotFound {
+		t.Errorf("Want error %v, got %v", scm.ErrNotFound, err)
+	}
+}
+
+func TestRepositoryHookRedeliver(t *testing.T) {
+	client, _ := New("http://example.com:7990")
+	_, err := client.Repositories.RedeliverHook(context.Background(), "PRJ/my-repo", "1", "57")
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
+
+func TestRepositoryHookTest(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/projects/PRJ/repos/my-repo/webhooks/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/webhook.json")
+
+	gock.New("http://example.com:7990").
+		Post("/rest/api/1.0/projects/PRJ/repos/my-repo/webhooks/test").
+		MatchParam("webhookId", "1").
+		MatchParam("url", "http://example.com").
+		Reply(200).
+		Type("application/json").
+		BodyString(`{"request":{"method":"POST","url":"http://example.com"},"response":{"statusCode":200}}`)
+
+	client, _ := New("http://example.com:7990")
+	res, err := client.Repositories.TestHook(context.Background(), "PRJ/my-repo", "1")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+
+	if got, want := res.Status, 200; got != want {
+		t.Errorf("Want response status %d, got %d", want, got)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
 func TestConvertFromState(t *testing.T) {
 	tests := []struct {
 		src scm.State
@@ -6,24 +6,92 @@ package stash
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
 
 	"github.com/jenkins-x/go-scm/scm"
 )
 
+// Bitbucket Server has no review resource. Reviews are read from the
+// pull request activities: approvals and needs work are participant
+// status changes and comment reviews are comments anchored to a file.
+// The review id is the activity id.
 type reviewService struct {
 	client *wrapper
 }
 
 func (s *reviewService) Find(ctx context.Context, repo string, number, id int) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	activity, res, err := s.findActivity(ctx, repo, number, id)
+	if err != nil {
+		return nil, res, err
+	}
+	review := convertActivityReview(activity)
+	if review == nil {
+		return nil, res, scm.ErrNotFound
+	}
+	return review, res, nil
 }
 
 func (s *reviewService) List(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/activities?%s", namespace, name, number, encodeListOptions(opts))
+	out := new(pullRequestActivities)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if !out.LastPage.Bool {
+		res.Page.First = 1
+		res.Page.Next = opts.Page + 1
+	}
+	return convertActivityReviewList(out), res, nil
 }
 
+// Create adds the review comments to the pull request and sets the
+// participant status of the current user from the review event.
 func (s *reviewService) Create(ctx context.Context, repo string, number int, input *scm.ReviewInput) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	status, ok := participantStatus(input.Event)
+	if !ok {
+		return nil, nil, scm.ErrNotSupported
+	}
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments", namespace, name, number)
+
+	var res *scm.Response
+	var err error
+	for _, c := range input.Comments {
+		res, err = s.client.do(ctx, "POST", path, convertReviewCommentInput(c), nil)
+		if err != nil {
+			return nil, res, err
+		}
+	}
+	if input.Body != "" {
+		res, err = s.client.do(ctx, "POST", path, &reviewCommentInput{Text: input.Body}, nil)
+		if err != nil {
+			return nil, res, err
+		}
+	}
+
+	review := &scm.Review{
+		Body:  input.Body,
+		Sha:   input.Sha,
+		State: scm.ReviewStateCommented,
+	}
+	if status == "" {
+		return review, res, nil
+	}
+	out, res, err := s.setStatus(ctx, repo, number, status)
+	if err != nil {
+		return nil, res, err
+	}
+	review.State = convertParticipantStatus(out.Status)
+	review.Author = *convertUser(&out.User)
+	if review.Sha == "" {
+		review.Sha = out.LastReviewedCommit
+	}
+	return review, res, nil
 }
 
 func (s *reviewService) Delete(ctx context.Context, repo string, number, id int) (*scm.Response, error) {
@@ -31,7 +99,133 @@ func (s *reviewService) Delete(ctx context.Context, repo string, number, id int)
 }
 
 func (s *reviewService) ListComments(ctx context.Context, repo string, prID, reviewID int, options *scm.ListOptions) ([]*scm.ReviewComment, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	activity, res, err := s.findActivity(ctx, repo, prID, reviewID)
+	if err != nil {
+		return nil, res, err
+	}
+	to := []*scm.ReviewComment{}
+	if activity.Comment != nil {
+		to = appendReviewComments(to, activity.Comment, activity.CommentAnchor, 0, "")
+	}
+	return to, res, nil
+}
+
+// CreateComment adds an anchored comment to the pull request, or
+// replies to the comment with the InReplyTo or ThreadID id.
+func (s *reviewService) CreateComment(ctx context.Context, repo string, number int, input *scm.ReviewCommentInput) (*scm.ReviewComment, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments", namespace, name, number)
+	in := convertReviewCommentInput(input)
+	out := new(pullRequestComment)
+	res, err := s.client.do(ctx, "POST", path, in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if in.Parent == nil {
+		comments := appendReviewComments(nil, out, in.Anchor, 0, "")
+		comments[0].Sha = input.Sha
+		return comments[0], res, nil
+	}
+	thread, err := s.findThread(ctx, repo, number, in.Parent.ID)
+	if err != nil {
+		return nil, res, err
+	}
+	comments := appendReviewComments(nil, out, thread.CommentAnchor, in.Parent.ID, strconv.Itoa(thread.Comment.ID))
+	return comments[0], res, nil
+}
+
+// findThread returns the comment activity of the thread the comment
+// belongs to, the root comment of the thread being the activity
+// comment.
+func (s *reviewService) findThread(ctx context.Context, repo string, number, id int) (*pullRequestActivity, error) {
+	namespace, name := scm.Split(repo)
+	opts := &scm.ListOptions{Page: 1, Size: 100}
+	for {
+		path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/activities?%s", namespace, name, number, encodeListOptions(opts))
+		out := new(pullRequestActivities)
+		if _, err := s.client.do(ctx, "GET", path, nil, out); err != nil {
+			return nil, err
+		}
+		for _, v := range out.Values {
+			if v.Action == "COMMENTED" && v.CommentAction == "ADDED" && v.Comment != nil && hasComment(v.Comment, id) {
+				return v, nil
+			}
+		}
+		if out.LastPage.Bool || len(out.Values) == 0 {
+			return nil, scm.ErrNotFound
+		}
+		opts.Page++
+	}
+}
+
+// hasComment returns true if the comment or one of its replies has
+// the id.
+func hasComment(from *pullRequestComment, id int) bool {
+	if from.ID == id {
+		return true
+	}
+	for i := range from.Comments {
+		if hasComment(&from.Comments[i], id) {
+			return true
+		}
+	}
+	return false
+}
+
+// ListThreads returns the comments anchored to the diff of the pull
+// request. The thread id is the id of the root comment.
+func (s *reviewService) ListThreads(ctx context.Context, repo string, number int, opts *scm.ListOptions) ([]*scm.ReviewThread, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/activities?%s", namespace, name, number, encodeListOptions(opts))
+	out := new(pullRequestActivities)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if !out.LastPage.Bool {
+		res.Page.First = 1
+		res.Page.Next = opts.Page + 1
+	}
+	to := []*scm.ReviewThread{}
+	for _, v := range out.Values {
+		if v.Action != "COMMENTED" || v.CommentAction != "ADDED" || v.Comment == nil || v.CommentAnchor == nil {
+			continue
+		}
+		to = append(to, convertReviewThread(v))
+	}
+	return to, res, nil
+}
+
+func (s *reviewService) ResolveThread(ctx context.Context, repo string, number int, id string) (*scm.Response, error) {
+	return s.setThreadResolved(ctx, repo, number, id, true)
+}
+
+func (s *reviewService) UnresolveThread(ctx context.Context, repo string, number int, id string) (*scm.Response, error) {
+	return s.setThreadResolved(ctx, repo, number, id, false)
+}
+
+// setThreadResolved resolves or reopens the thread of the root
+// comment. Blocker comments are tasks and are resolved through their
+// state instead.
+func (s *reviewService) setThreadResolved(ctx context.Context, repo string, number int, id string, resolved bool) (*scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/comments/%s", namespace, name, number, id)
+	out := new(pullRequestComment)
+	res, err := s.client.do(ctx, "GET", path, nil, out)
+	if err != nil {
+		return res, err
+	}
+	in := &commentResolveInput{
+		Version:        out.Version,
+		ThreadResolved: resolved,
+	}
+	if out.Severity == "BLOCKER" {
+		in.State = "OPEN"
+		if resolved {
+			in.State = "RESOLVED"
+		}
+	}
+	return s.client.do(ctx, "PUT", path, in, nil)
 }
 
 func (s *reviewService) Update(ctx context.Context, repo string, prID, reviewID int, body string) (*scm.Review, *scm.Response, error) {
@@ -42,6 +236,220 @@ func (s *reviewService) Submit(ctx context.Context, repo string, prID, reviewID
 	return nil, nil, scm.ErrNotSupported
 }
 
+// Dismiss resets the participant status of the current user. Bitbucket
+// Server does not allow changing the status of another participant.
 func (s *reviewService) Dismiss(ctx context.Context, repo string, prID, reviewID int, msg string) (*scm.Review, *scm.Response, error) {
-	return nil, nil, scm.ErrNotSupported
+	out, res, err := s.setStatus(ctx, repo, prID, "UNAPPROVED")
+	if err != nil {
+		return nil, res, err
+	}
+	return &scm.Review{
+		ID:     reviewID,
+		Body:   msg,
+		State:  scm.ReviewStateDismissed,
+		Author: *convertUser(&out.User),
+	}, res, nil
+}
+
+func (s *reviewService) setStatus(ctx context.Context, repo string, number int, status string) (*prUser, *scm.Response, error) {
+	self, res, err := s.client.Users.Find(ctx)
+	if err != nil {
+		return nil, res, err
+	}
+	namespace, name := scm.Split(repo)
+	path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/participants/%s", namespace, name, number, url.PathEscape(self.Login))
+	in := new(pullRequestAssignInput)
+	in.User.Name = self.Login
+	in.Approved = status == "APPROVED"
+	in.Status = status
+	out := new(prUser)
+	res, err = s.client.do(ctx, "PUT", path, in, out)
+	return out, res, err
+}
+
+func (s *reviewService) findActivity(ctx context.Context, repo string, number, id int) (*pullRequestActivity, *scm.Response, error) {
+	namespace, name := scm.Split(repo)
+	opts := &scm.ListOptions{Page: 1, Size: 100}
+	for {
+		path := fmt.Sprintf("rest/api/1.0/projects/%s/repos/%s/pull-requests/%d/activities?%s", namespace, name, number, encodeListOptions(opts))
+		out := new(pullRequestActivities)
+		res, err := s.client.do(ctx, "GET", path, nil, out)
+		if err != nil {
+			return nil, res, err
+		}
+		for _, v := range out.Values {
+			if v.ID == id {
+				return v, res, nil
+			}
+		}
+		if out.LastPage.Bool || len(out.Values) == 0 {
+			return nil, res, scm.ErrNotFound
+		}
+		opts.Page++
+	}
+}
+
+type reviewCommentInput struct {
+	Text   string         `json:"text"`
+	Anchor *commentAnchor `json:"anchor,omitempty"`
+	Parent *commentParent `json:"parent,omitempty"`
+}
+
+type commentParent struct {
+	ID int `json:"id"`
+}
+
+type commentResolveInput struct {
+	Version        int    `json:"version"`
+	ThreadResolved bool   `json:"threadResolved"`
+	State          string `json:"state,omitempty"`
+}
+
+// convertReviewCommentInput returns the comment input anchored to the
+// file line, or the reply to the parent comment.
+func convertReviewCommentInput(from *scm.ReviewCommentInput) *reviewCommentInput {
+	to := &reviewCommentInput{Text: from.Body}
+	parent := from.InReplyTo
+	if parent == 0 && from.ThreadID != "" {
+		parent, _ = strconv.Atoi(from.ThreadID)
+	}
+	if parent != 0 {
+		to.Parent = &commentParent{ID: parent}
+		return to
+	}
+	to.Anchor = &commentAnchor{
+		Line:     from.Line,
+		LineType: "ADDED",
+		FileType: "TO",
+		Path:     from.Path,
+		DiffType: "EFFECTIVE",
+	}
+	if from.Side == scm.DiffSideLeft {
+		to.Anchor.LineType = "REMOVED"
+		to.Anchor.FileType = "FROM"
+	}
+	if from.StartLine != 0 && from.StartLine != from.Line {
+		to.Anchor.MultilineMarker = &multilineMarker{
+			StartLine:     from.StartLine,
+			StartLineType: to.Anchor.LineType,
+		}
+	}
+	return to
+}
+
+// participantStatus returns the participant status for the review
+// event, or false if the event is not supported.
+func participantStatus(event string) (string, bool) {
+	switch event {
+	case "COMMENT":
+		return "", true
+	case "APPROVE", scm.ReviewStateApproved:
+		return "APPROVED", true
+	case "REQUEST_CHANGES", scm.ReviewStateChangesRequested:
+		return "NEEDS_WORK", true
+	default:
+		return "", false
+	}
+}
+
+func convertParticipantStatus(status string) string {
+	switch status {
+	case "APPROVED":
+		return scm.ReviewStateApproved
+	case "NEEDS_WORK":
+		return scm.ReviewStateChangesRequested
+	case "UNAPPROVED":
+		return scm.ReviewStateDismissed
+	default:
+		return scm.ReviewStatePending
+	}
+}
+
+func convertActivityReviewList(from *pullRequestActivities) []*scm.Review {
+	to := []*scm.Review{}
+	for _, v := range from.Values {
+		if review := convertActivityReview(v); review != nil {
+			to = append(to, review)
+		}
+	}
+	return to
+}
+
+// convertActivityReview converts the activity to a review, returning
+// nil if the activity is not part of a review.
+func convertActivityReview(from *pullRequestActivity) *scm.Review {
+	review := &scm.Review{
+		ID:      from.ID,
+		Author:  *convertUser(&from.User),
+		Created: time.Unix(from.CreatedDate/1000, 0),
+		Updated: time.Unix(from.CreatedDate/1000, 0),
+	}
+	switch from.Action {
+	case "APPROVED":
+		review.State = scm.ReviewStateApproved
+	case "UNAPPROVED":
+		review.State = scm.ReviewStateDismissed
+	case "REVIEWED":
+		review.State = scm.ReviewStateChangesRequested
+	case "COMMENTED":
+		if from.Comment == nil || from.CommentAnchor == nil || from.CommentAction != "ADDED" {
+			return nil
+		}
+		review.State = scm.ReviewStateCommented
+		review.Body = from.Comment.Text
+		review.Sha = from.CommentAnchor.ToHash
+	default:
+		return nil
+	}
+	return review
+}
+
+func convertReviewThread(from *pullRequestActivity) *scm.ReviewThread {
+	comments := appendReviewComments(nil, from.Comment, from.CommentAnchor, 0, "")
+	return &scm.ReviewThread{
+		ID:        strconv.Itoa(from.Comment.ID),
+		Path:      comments[0].Path,
+		Line:      comments[0].Line,
+		StartLine: comments[0].StartLine,
+		Side:      comments[0].Side,
+		Resolved:  from.Comment.ThreadResolved || (from.Comment.Severity == "BLOCKER" && from.Comment.State == "RESOLVED"),
+		Outdated:  from.CommentAnchor.Orphaned,
+		Comments:  comments,
+	}
+}
+
+// appendReviewComments appends the comment and its replies to the list.
+// The thread id is the id of the root comment, the comment itself when
+// the thread is empty.
+func appendReviewComments(to []*scm.ReviewComment, from *pullRequestComment, anchor *commentAnchor, parent int, thread string) []*scm.ReviewComment {
+	comment := &scm.ReviewComment{
+		ID:        from.ID,
+		Body:      from.Text,
+		InReplyTo: parent,
+		Author:    *convertUser(&from.Author),
+		Created:   time.Unix(from.CreatedDate/1000, 0),
+		Updated:   time.Unix(from.UpdatedDate/1000, 0),
+	}
+	if anchor != nil {
+		comment.Path = anchor.Path
+		comment.Line = anchor.Line
+		comment.FileLine = anchor.Line
+		comment.Sha = anchor.ToHash
+		comment.Side = scm.DiffSideRight
+		if anchor.FileType == "FROM" {
+			comment.Side = scm.DiffSideLeft
+		}
+		if m := anchor.MultilineMarker; m != nil {
+			comment.StartLine = m.StartLine
+		}
+	}
+	if thread == "" {
+		thread = strconv.Itoa(from.ID)
+	}
+	comment.ThreadID = thread
+	to = append(to, comment)
+	for i := range from.Comments {
+		to = appendReviewComments(to, &from.Comments[i], anchor, from.ID, thread)
+	}
+	return to
 }
@@ -6,27 +6,171 @@ package stash
 
 import (
 	"context"
+	"encoding/json"
+	"os"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/jenkins-x/go-scm/scm"
+	"gopkg.in/h2non/gock.v1"
 )
 
 func TestReviewFind(t *testing.T) {
-	_, _, err := NewDefault().Reviews.Find(context.Background(), "", 0, 0)
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/activities").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_activities.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Reviews.Find(context.Background(), "PRJ/my-repo", 1, 202)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Review{}
+	raw, _ := os.ReadFile("testdata/pr_activities.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want[2], got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReviewFindNotFound(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/activities").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_activities.json")
+
+	client, _ := New("http://example.com:7990")
+	_, _, err := client.Reviews.Find(context.Background(), "PRJ/my-repo", 1, 201)
+	if err != scm.ErrNotFound {
+		t.Errorf("Expect Not Found error, got %v", err)
 	}
 }
 
 func TestReviewList(t *testing.T) {
-	_, _, err := NewDefault().Reviews.List(context.Background(), "", 0, &scm.ListOptions{})
-	if err != scm.ErrNotSupported {
-		t.Errorf("Expect Not Supported error")
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/activities").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_activities.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Reviews.List(context.Background(), "PRJ/my-repo", 1, &scm.ListOptions{Page: 1, Size: 25})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.Review{}
+	raw, _ := os.ReadFile("testdata/pr_activities.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReviewListComments(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/activities").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_activities.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Reviews.ListComments(context.Background(), "PRJ/my-repo", 1, 202, &scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.ReviewComment{}
+	raw, _ := os.ReadFile("testdata/pr_activity_comments.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
 	}
 }
 
 func TestReviewCreate(t *testing.T) {
-	_, _, err := NewDefault().Reviews.Create(context.Background(), "", 0, &scm.ReviewInput{})
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Post("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/comments").
+		JSON(map[string]interface{}{
+			"text": "Should this be configurable?",
+			"anchor": map[string]interface{}{
+				"line":     24,
+				"lineType": "ADDED",
+				"fileType": "TO",
+				"path":     "docs/README.md",
+				"diffType": "EFFECTIVE",
+			},
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr_comment.json")
+
+	gock.New("http://example.com:7990").
+		Get("plugins/servlet/applinks/whoami").
+		Reply(200).
+		Type("text/plain").
+		BodyString("jcitizen")
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/users/jcitizen").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
+
+	gock.New("http://example.com:7990").
+		Put("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/participants/jcitizen").
+		BodyString(`"status":"APPROVED"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_participant.json")
+
+	input := &scm.ReviewInput{
+		Event: "APPROVE",
+		Comments: []*scm.ReviewCommentInput{
+			{Body: "Should this be configurable?", Path: "docs/README.md", Line: 24},
+		},
+	}
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Reviews.Create(context.Background(), "PRJ/my-repo", 1, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.State != scm.ReviewStateApproved {
+		t.Errorf("Want review state %s, got %s", scm.ReviewStateApproved, got.State)
+	}
+	if got.Author.Login != "jcitizen" {
+		t.Errorf("Want review author jcitizen, got %s", got.Author.Login)
+	}
+	if got.Sha != "c7d3a9e6b1f24e5d8a0b3c6f9e2d5a8b1c4e7f0a" {
+		t.Errorf("Want review sha from the participant, got %s", got.Sha)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewCreatePending(t *testing.T) {
+	_, _, err := NewDefault().Reviews.Create(context.Background(), "PRJ/my-repo", 1, &scm.ReviewInput{})
 	if err != scm.ErrNotSupported {
 		t.Errorf("Expect Not Supported error")
 	}
@@ -38,3 +182,167 @@ func TestReviewDelete(t *testing.T) {
 		t.Errorf("Expect Not Supported error")
 	}
 }
+
+func TestReviewDismiss(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("plugins/servlet/applinks/whoami").
+		Reply(200).
+		Type("text/plain").
+		BodyString("jcitizen")
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/users/jcitizen").
+		Reply(200).
+		Type("application/json").
+		File("testdata/user.json")
+
+	gock.New("http://example.com:7990").
+		Put("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/participants/jcitizen").
+		BodyString(`"status":"UNAPPROVED"`).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_participant.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Reviews.Dismiss(context.Background(), "PRJ/my-repo", 1, 205, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.State != scm.ReviewStateDismissed {
+		t.Errorf("Want review state %s, got %s", scm.ReviewStateDismissed, got.State)
+	}
+}
+
+func TestReviewCreateComment(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Post("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/comments").
+		JSON(map[string]interface{}{
+			"text": "Why were these removed?",
+			"anchor": map[string]interface{}{
+				"line":     12,
+				"lineType": "REMOVED",
+				"fileType": "FROM",
+				"path":     "docs/README.md",
+				"diffType": "EFFECTIVE",
+				"multilineMarker": map[string]interface{}{
+					"startLine":     10,
+					"startLineType": "REMOVED",
+				},
+			},
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr_comment.json")
+
+	input := &scm.ReviewCommentInput{
+		Body:      "Why were these removed?",
+		Path:      "docs/README.md",
+		Line:      12,
+		StartLine: 10,
+		Side:      scm.DiffSideLeft,
+	}
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Reviews.CreateComment(context.Background(), "PRJ/my-repo", 1, input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != 1 || got.ThreadID != "1" {
+		t.Errorf("Want comment 1 in thread 1, got %d in thread %q", got.ID, got.ThreadID)
+	}
+	if got.Side != scm.DiffSideLeft || got.StartLine != 10 || got.Line != 12 {
+		t.Errorf("Unexpected comment range %s %d-%d", got.Side, got.StartLine, got.Line)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewCreateCommentReply(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Post("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/comments").
+		JSON(map[string]interface{}{
+			"text":   "Done.",
+			"parent": map[string]interface{}{"id": 13},
+		}).
+		Reply(201).
+		Type("application/json").
+		File("testdata/pr_comment.json")
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/activities").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_activities.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Reviews.CreateComment(context.Background(), "PRJ/my-repo", 1, &scm.ReviewCommentInput{Body: "Done.", InReplyTo: 13})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.InReplyTo != 13 {
+		t.Errorf("Want in reply to 13, got %d", got.InReplyTo)
+	}
+	if got.ThreadID != "11" {
+		t.Errorf("Want thread 11, got %q", got.ThreadID)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
+
+func TestReviewListThreads(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/activities").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_activities.json")
+
+	client, _ := New("http://example.com:7990")
+	got, _, err := client.Reviews.ListThreads(context.Background(), "PRJ/my-repo", 1, &scm.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*scm.ReviewThread{}
+	raw, _ := os.ReadFile("testdata/pr_threads.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestReviewResolveThread(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/comments/1").
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_comment.json")
+
+	gock.New("http://example.com:7990").
+		Put("rest/api/1.0/projects/PRJ/repos/my-repo/pull-requests/1/comments/1").
+		JSON(map[string]interface{}{"version": 5, "threadResolved": true}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/pr_comment.json")
+
+	client, _ := New("http://example.com:7990")
+	_, err := client.Reviews.ResolveThread(context.Background(), "PRJ/my-repo", 1, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !gock.IsDone() {
+		t.Errorf("Pending mocks")
+	}
+}
@@ -0,0 +1,149 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stash
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+// searchService implements code search on top of the search
+// plugin and repository search on top of the repository list.
+// Bitbucket Server has no issues and no commit search.
+type searchService struct {
+	client *wrapper
+}
+
+type codeSearchInput struct {
+	Query    string `json:"query"`
+	Entities struct {
+		Code struct {
+			Start int `json:"start"`
+			Limit int `json:"limit,omitempty"`
+		} `json:"code"`
+	} `json:"entities"`
+}
+
+type codeSearchResults struct {
+	Code struct {
+		IsLastPage bool          `json:"isLastPage"`
+		Count      int           `json:"count"`
+		Start      int           `json:"start"`
+		NextStart  int           `json:"nextStart"`
+		Values     []*codeResult `json:"values"`
+	} `json:"code"`
+}
+
+type codeResult struct {
+	Repository  repository `json:"repository"`
+	File        string     `json:"file"`
+	HitContexts [][]struct {
+		Line int    `json:"line"`
+		Text string `json:"text"`
+	} `json:"hitContexts"`
+	HitCount int `json:"hitCount"`
+}
+
+func (s *searchService) Code(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchCode, *scm.Response, error) {
+	in := new(codeSearchInput)
+	in.Query = encodeCodeSearchQuery(q)
+	if opts != nil {
+		if opts.Page > 1 {
+			in.Entities.Code.Start = (opts.Page - 1) * opts.Size
+		}
+		in.Entities.Code.Limit = opts.Size
+	}
+	out := new(codeSearchResults)
+	res, err := s.client.do(ctx, "POST", "rest/search/latest/search", in, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if !out.Code.IsLastPage && opts != nil {
+		res.Page.First = 1
+		res.Page.Next = max(opts.Page, 1) + 1
+	}
+	return convertCodeResults(out), res, nil
+}
+
+func (s *searchService) Repositories(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.Repository, *scm.Response, error) {
+	if opts == nil {
+		opts = &scm.ListOptions{}
+	}
+	params, _ := url.ParseQuery(encodeListRoleOptions(opts))
+	if q.Text != "" {
+		params.Set("name", q.Text)
+	}
+	if q.Org != "" {
+		params.Set("projectname", q.Org)
+	}
+	out := new(repositories)
+	res, err := s.client.do(ctx, "GET", "rest/api/1.0/repos?"+params.Encode(), nil, out)
+	if err != nil {
+		return nil, res, err
+	}
+	if !out.LastPage.Bool {
+		res.Page.First = 1
+		res.Page.Next = max(opts.Page, 1) + 1
+	}
+	return convertRepositoryList(out), res, nil
+}
+
+func (s *searchService) Issues(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchIssue, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+func (s *searchService) Commits(ctx context.Context, q *scm.SearchQuery, opts *scm.ListOptions) ([]*scm.SearchCommit, *scm.Response, error) {
+	return nil, nil, scm.ErrNotSupported
+}
+
+// encodeCodeSearchQuery compiles the query to the code search
+// syntax, which combines the text with the project, repo,
+// lang and path modifiers.
+func encodeCodeSearchQuery(q *scm.SearchQuery) string {
+	terms := []string{}
+	if q.Text != "" {
+		terms = append(terms, q.Text)
+	}
+	if q.Repo != "" {
+		project, name := scm.Split(q.Repo)
+		terms = append(terms, "project:"+project, "repo:"+name)
+	} else if q.Org != "" {
+		terms = append(terms, "project:"+q.Org)
+	}
+	if q.Language != "" {
+		terms = append(terms, "lang:"+q.Language)
+	}
+	if q.Path != "" {
+		terms = append(terms, "path:"+q.Path)
+	}
+	return strings.Join(terms, " ")
+}
+
+func convertCodeResults(from *codeSearchResults) []*scm.SearchCode {
+	to := []*scm.SearchCode{}
+	for _, v := range from.Code.Values {
+		repo := convertRepository(&v.Repository)
+		code := &scm.SearchCode{
+			Name:       path.Base(v.File),
+			Path:       v.File,
+			Link:       fmt.Sprintf("%s/browse/%s", strings.TrimSuffix(repo.Link, "/browse"), v.File),
+			Repository: *repo,
+		}
+		for _, hits := range v.HitContexts {
+			lines := []string{}
+			for _, hit := range hits {
+				lines = append(lines, hit.Text)
+			}
+			code.Fragments = append(code.Fragments, strings.Join(lines, "\n"))
+		}
+		to = append(to, code)
+	}
+	return to
+}
@@ -0,0 +1,118 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package stash
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+
+	"github.com/google/go-cmp/cmp"
+	"gopkg.in/h2non/gock.v1"
+)
+
+func TestSearchCode(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Post("/rest/search/latest/search").
+		JSON(map[string]interface{}{
+			"query": "hello project:PRJ repo:my-repo lang:java path:src",
+			"entities": map[string]interface{}{
+				"code": map[string]int{"start": 25, "limit": 25},
+			},
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/search_code.json")
+
+	q := &scm.SearchQuery{Text: "hello", Repo: "PRJ/my-repo", Language: "java", Path: "src"}
+	client, _ := New("http://example.com:7990")
+	got, res, err := client.Search.Code(context.Background(), q, &scm.ListOptions{Page: 2, Size: 25})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := res.Page.Next, 3; got != want {
+		t.Errorf("Want Page.Next %d, got %d", want, got)
+	}
+
+	want := []*scm.SearchCode{}
+	raw, _ := os.ReadFile("testdata/search_code.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchCodeFirstPage(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Post("/rest/search/latest/search").
+		JSON(map[string]interface{}{
+			"query": "hello",
+			"entities": map[string]interface{}{
+				"code": map[string]int{"start": 0, "limit": 25},
+			},
+		}).
+		Reply(200).
+		Type("application/json").
+		File("testdata/search_code.json")
+
+	client, _ := New("http://example.com:7990")
+	_, res, err := client.Search.Code(context.Background(), &scm.SearchQuery{Text: "hello"}, &scm.ListOptions{Size: 25})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := res.Page.Next, 2; got != want {
+		t.Errorf("Want Page.Next %d, got %d", want, got)
+	}
+}
+
+func TestSearchRepositories(t *testing.T) {
+	defer gock.Off()
+
+	gock.New("http://example.com:7990").
+		Get("/rest/api/1.0/repos").
+		MatchParam("name", "my-repo").
+		MatchParam("projectname", "PRJ").
+		MatchParam("permission", "REPO_READ").
+		Reply(200).
+		Type("application/json").
+		File("testdata/repos.json")
+
+	client, _ := New("http://example.com:7990")
+	got, res, err := client.Search.Repositories(context.Background(), &scm.SearchQuery{Text: "my-repo", Org: "PRJ"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := res.Page.Next, 2; got != want {
+		t.Errorf("Want Page.Next %d, got %d", want, got)
+	}
+
+	want := []*scm.Repository{}
+	raw, _ := os.ReadFile("testdata/repos.json.golden")
+	_ = json.Unmarshal(raw, &want)
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("Unexpected Results")
+		t.Log(diff)
+	}
+}
+
+func TestSearchIssues(t *testing.T) {
+	_, _, err := NewDefault().Search.Issues(context.Background(), &scm.SearchQuery{}, nil)
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect Not Supported error")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"io"
 	"mime/multipart"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -47,7 +48,9 @@ func New(uri string) (*scm.Client, error) {
 	client.Organizations = &organizationService{client}
 	client.PullRequests = &pullService{client}
 	client.Repositories = &repositoryService{client}
+	client.Reactions = &reactionService{client}
 	client.Reviews = &reviewService{client}
+	client.Search = &searchService{client}
 	client.Users = &userService{client}
 	client.Webhooks = &webhookService{client}
 	return client.Client, nil
@@ -137,6 +140,33 @@ func (c *wrapper) do(ctx context.Context, method, path string, in, out interface
 	return res, json.NewDecoder(res.Body).Decode(out)
 }
 
+// stream wraps the Client.Do function by creating the Request and
+// returning the raw response body. The caller must close the body.
+func (c *wrapper) stream(ctx context.Context, method, path string) (io.ReadCloser, *scm.Response, error) {
+	req := &scm.Request{
+		Method: method,
+		Path:   path,
+		Header: map[string][]string{
+			"X-Atlassian-Token": {"no-check"},
+		},
+	}
+	res, err := c.Do(ctx, req)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.Status > 300 {
+		defer res.Body.Close()
+		if res.Status == http.StatusNotFound {
+			return nil, res, scm.ErrNotFound
+		}
+		err := new(Error)
+		// nolint
+		json.NewDecoder(res.Body).Decode(err) // #nosec
+		return nil, res, err
+	}
+	return res.Body, res, nil
+}
+
 // pagination represents Bitbucket pagination properties
 // embedded in list responses.
 type pagination struct {
@@ -5,6 +5,7 @@
 package stash
 
 import (
+	"fmt"
 	"net/url"
 	"strconv"
 
@@ -54,10 +55,32 @@ func encodePullRequestListOptions(opts *scm.PullRequestListOptions) string {
 	if opts.Size != 0 {
 		params.Set("limit", strconv.Itoa(opts.Size))
 	}
-	if opts.Open && opts.Closed {
+	// the state is one of OPEN (the default), DECLINED, MERGED
+	// or ALL. Closed pull requests are either declined or merged,
+	// so the open pull requests are filtered by the caller.
+	if opts.Closed {
 		params.Set("state", "all")
-	} else if opts.Closed {
-		params.Set("state", "closed")
+	}
+	// only a single branch can be filtered on, the base
+	// branch is filtered by the caller when both are set.
+	if opts.Head != "" {
+		params.Set("at", scm.ExpandRef(opts.Head, "refs/heads"))
+		params.Set("direction", "OUTGOING")
+	} else if opts.Base != "" {
+		params.Set("at", scm.ExpandRef(opts.Base, "refs/heads"))
+		params.Set("direction", "INCOMING")
+	}
+	// participants are numbered from one.
+	n := 0
+	if opts.Author != "" {
+		n++
+		params.Set(fmt.Sprintf("username.%d", n), opts.Author)
+		params.Set(fmt.Sprintf("role.%d", n), "AUTHOR")
+	}
+	if opts.Reviewer != "" {
+		n++
+		params.Set(fmt.Sprintf("username.%d", n), opts.Reviewer)
+		params.Set(fmt.Sprintf("role.%d", n), "REVIEWER")
 	}
 	return params.Encode()
 }
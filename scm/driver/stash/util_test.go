@@ -46,3 +46,18 @@ func Test_encodePullRequestListOptions(t *testing.T) {
 		t.Errorf("Want encoded pr list options %q, got %q", want, got)
 	}
 }
+
+func Test_encodePullRequestListOptions_Filters(t *testing.T) {
+	t.Parallel()
+	opts := &scm.PullRequestListOptions{
+		Closed:   true,
+		Base:     "master",
+		Author:   "jcitizen",
+		Reviewer: "octocat",
+	}
+	want := "at=refs%2Fheads%2Fmaster&direction=INCOMING&role.1=AUTHOR&role.2=REVIEWER&state=all&username.1=jcitizen&username.2=octocat"
+	got := encodePullRequestListOptions(opts)
+	if got != want {
+		t.Errorf("Want encoded pr list options %q, got %q", want, got)
+	}
+}
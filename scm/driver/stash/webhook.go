@@ -32,6 +32,10 @@ type webhookService struct {
 
 // Parse for the bitbucket server webhook payloads see: https://confluence.atlassian.com/bitbucketserver/event-payload-938025882.html
 func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
+	return s.ParseWithSecrets(req, fn.Secrets())
+}
+
+func (s *webhookService) ParseWithSecrets(req *http.Request, fn scm.SecretsFunc) (scm.Webhook, error) {
 	data, err := io.ReadAll(
 		io.LimitReader(req.Body, 10000000),
 	)
@@ -65,15 +69,17 @@ func (s *webhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhoo
 	// get the gogs signature key to verify the payload
 	// signature. If no key is provided, no validation
 	// is performed.
-	key, err := fn(hook)
+	keys, err := fn(hook)
 	if err != nil {
 		return hook, err
-	} else if key == "" {
+	}
+	keys = hmac.Keys(keys)
+	if len(keys) == 0 {
 		return hook, nil
 	}
 
 	sig := req.Header.Get("X-Hub-Signature")
-	if !hmac.ValidatePrefix(data, []byte(key), sig) {
+	if !hmac.ValidatePrefixAny(data, keys, sig) {
 		return hook, scm.ErrSignatureInvalid
 	}
 
@@ -0,0 +1,277 @@
+package stash
+
+import (
+	"crypto/sha256"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jenkins-x/go-scm/pkg/hmac"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/internal/hookenc"
+)
+
+// emptyCommit is the hash Bitbucket Server reports as the
+// previous or next commit of a created or deleted ref.
+const emptyCommit = "0000000000000000000000000000000000000000"
+
+// hookDateLayout is the timestamp layout of webhook payloads.
+const hookDateLayout = "2006-01-02T15:04:05+0000"
+
+// Encode returns the Bitbucket Server webhook request for the
+// hook, signed with the sha256 signature.
+//
+// Bitbucket Server reports created branches as pushes, so a
+// created BranchHook is parsed back as a PushHook.
+func (s *webhookService) Encode(hook scm.Webhook, secret string) (*http.Request, error) {
+	var (
+		event   string
+		guid    string
+		payload interface{}
+	)
+	switch v := hook.(type) {
+	case *scm.PushHook:
+		event, guid, payload = "repo:refs_changed", v.GUID, encodePushHook(v)
+	case *scm.BranchHook:
+		event, payload = "repo:refs_changed", encodeRefHook(v.Action, "BRANCH", "refs/heads/", &v.Ref, &v.Repo, &v.Sender)
+	case *scm.TagHook:
+		event, payload = "repo:refs_changed", encodeRefHook(v.Action, "TAG", "refs/tags/", &v.Ref, &v.Repo, &v.Sender)
+	case *scm.PullRequestHook:
+		src := encodePullRequestHook(v)
+		event, payload = src.EventKey, src
+	case *scm.PullRequestCommentHook:
+		event, guid, payload = "pr:comment:added", v.GUID, encodePullRequestCommentHook(v)
+	case *scm.ReviewHook:
+		src := encodePullRequestApprovalHook(v)
+		event, payload = src.EventKey, src
+	default:
+		return nil, scm.ErrNotSupported
+	}
+	if event == "" {
+		return nil, scm.ErrNotSupported
+	}
+
+	req, data, err := hookenc.NewRequest(payload)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Event-Key", event)
+	req.Header.Set("X-Request-Id", hookenc.DeliveryID(guid))
+	if secret != "" {
+		req.Header.Set("X-Hub-Signature", "sha256="+hmac.Sign(sha256.New, data, []byte(secret)))
+	}
+	return req, nil
+}
+
+func encodePushHook(src *scm.PushHook) *pushHook {
+	c := &change{
+		RefID:    src.Ref,
+		FromHash: src.Before,
+		ToHash:   src.Commit.Sha,
+		Type:     "UPDATE",
+	}
+	c.Ref.ID = src.Ref
+	c.Ref.DisplayID = scm.TrimRef(src.Ref)
+	c.Ref.Type = "BRANCH"
+	if src.Created {
+		c.Type = "ADD"
+	}
+	return &pushHook{
+		EventKey:   "repo:refs_changed",
+		Date:       encodeHookDate(src.Commit.Author.Date),
+		Actor:      encodeUser(&src.Sender),
+		Repository: encodeRepository(&src.Repo),
+		Changes:    []*change{c},
+	}
+}
+
+func encodeRefHook(action scm.Action, refType, prefix string, ref *scm.Reference, repo *scm.Repository, sender *scm.User) *pushHook {
+	c := &change{
+		RefID:    scm.ExpandRef(ref.Name, prefix),
+		FromHash: emptyCommit,
+		ToHash:   ref.Sha,
+		Type:     "ADD",
+	}
+	c.Ref.ID = c.RefID
+	c.Ref.DisplayID = ref.Name
+	c.Ref.Type = refType
+	if action == scm.ActionDelete {
+		c.FromHash, c.ToHash = ref.Sha, emptyCommit
+		c.Type = "DELETE"
+	}
+	return &pushHook{
+		EventKey:   "repo:refs_changed",
+		Actor:      encodeUser(sender),
+		Repository: encodeRepository(repo),
+		Changes:    []*change{c},
+	}
+}
+
+func encodePullRequestHook(src *scm.PullRequestHook) *pullRequestHook {
+	dst := &pullRequestHook{
+		Date:        encodeHookDate(src.PullRequest.Updated),
+		Actor:       encodeUser(&src.Sender),
+		PullRequest: encodePullRequest(&src.PullRequest),
+	}
+	switch src.Action {
+	case scm.ActionOpen:
+		dst.EventKey = "pr:opened"
+	case scm.ActionClose:
+		dst.EventKey = "pr:declined"
+	case scm.ActionDelete:
+		dst.EventKey = "pr:deleted"
+	case scm.ActionMerge:
+		dst.EventKey = "pr:merged"
+	case scm.ActionSync:
+		dst.EventKey = "pr:from_ref_updated"
+	case scm.ActionUpdate:
+		dst.EventKey = "pr:modified"
+	}
+	return dst
+}
+
+func encodePullRequestCommentHook(src *scm.PullRequestCommentHook) *pullRequestCommentHook {
+	return &pullRequestCommentHook{
+		EventKey:    "pr:comment:added",
+		Date:        encodeHookDate(src.Comment.Created),
+		Author:      encodeUser(&src.Sender),
+		PullRequest: encodePullRequest(&src.PullRequest),
+		Comment: &prComment{
+			ID:        src.Comment.ID,
+			Text:      src.Comment.Body,
+			Author:    encodeUser(&src.Comment.Author),
+			CreatedAt: encodeMillis(src.Comment.Created),
+			UpdatedAt: encodeMillis(src.Comment.Updated),
+		},
+	}
+}
+
+func encodePullRequestApprovalHook(src *scm.ReviewHook) *pullRequestApprovalHook {
+	dst := &pullRequestApprovalHook{
+		Actor:       encodeUser(&src.Review.Author),
+		PullRequest: encodePullRequest(&src.PullRequest),
+		Participant: &prUser{
+			User: *encodeUser(&src.Review.Author),
+			Role: "REVIEWER",
+		},
+	}
+	switch src.Review.State {
+	case scm.ReviewStateApproved:
+		dst.EventKey = "pr:reviewer:approved"
+		dst.Participant.Approved = true
+		dst.Participant.Status = "APPROVED"
+	case scm.ReviewStateDismissed:
+		dst.EventKey = "pr:reviewer:unapproved"
+		dst.Participant.Status = "UNAPPROVED"
+	case scm.ReviewStateChangesRequested:
+		dst.EventKey = "pr:reviewer:needs_work"
+		dst.Participant.Status = "NEEDS_WORK"
+	}
+	return dst
+}
+
+func encodePullRequest(src *scm.PullRequest) *pullRequest {
+	dst := &pullRequest{
+		ID:          src.Number,
+		Title:       src.Title,
+		Description: src.Body,
+		State:       strings.ToUpper(src.State),
+		Open:        !src.Closed,
+		Closed:      src.Closed,
+		CreatedDate: encodeMillis(src.Created),
+		UpdatedDate: encodeMillis(src.Updated),
+		FromRef:     *encodePullRequestRef(&src.Head, src.Source),
+		ToRef:       *encodePullRequestRef(&src.Base, src.Target),
+		Author: prUser{
+			User: *encodeUser(&src.Author),
+			Role: "AUTHOR",
+		},
+	}
+	if dst.State == "" {
+		switch {
+		case src.Merged:
+			dst.State = "MERGED"
+		case src.Closed:
+			dst.State = "DECLINED"
+		default:
+			dst.State = "OPEN"
+		}
+	}
+	if src.Sha != "" {
+		dst.FromRef.LatestCommit = src.Sha
+	}
+	if src.Link != "" {
+		dst.Links.Self = []link{{Href: src.Link}}
+	}
+	for k := range src.Reviewers {
+		dst.Reviewers = append(dst.Reviewers, prUser{
+			User: *encodeUser(&src.Reviewers[k]),
+			Role: "REVIEWER",
+		})
+	}
+	return dst
+}
+
+func encodePullRequestRef(src *scm.PullRequestBranch, name string) *prRepoRef {
+	if name == "" {
+		name = src.Ref
+	}
+	return &prRepoRef{
+		ID:           scm.ExpandRef(name, "refs/heads/"),
+		DisplayID:    name,
+		LatestCommit: src.Sha,
+		Repository:   *encodeRepository(&src.Repo),
+	}
+}
+
+func encodeRepository(src *scm.Repository) *repository {
+	id, _ := strconv.Atoi(src.ID)
+	dst := &repository{
+		ID:     id,
+		Slug:   src.Name,
+		Name:   src.Name,
+		ScmID:  "git",
+		State:  "AVAILABLE",
+		Public: !src.Private,
+	}
+	dst.Project.Key = src.Namespace
+	if src.Link != "" {
+		dst.Links.Self = []link{{Href: src.Link}}
+	}
+	if src.Clone != "" {
+		dst.Links.Clone = append(dst.Links.Clone, link{Href: src.Clone, Name: "http"})
+	}
+	if src.CloneSSH != "" {
+		dst.Links.Clone = append(dst.Links.Clone, link{Href: src.CloneSSH, Name: "ssh"})
+	}
+	return dst
+}
+
+func encodeUser(src *scm.User) *user {
+	return &user{
+		ID:           src.ID,
+		Name:         src.Login,
+		Slug:         src.Login,
+		DisplayName:  src.Name,
+		EmailAddress: src.Email,
+		Active:       true,
+		Type:         "NORMAL",
+	}
+}
+
+func encodeHookDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(hookDateLayout)
+}
+
+// encodeMillis returns the time in milliseconds since the
+// epoch, as used by the Bitbucket Server api.
+func encodeMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix() * 1000
+}
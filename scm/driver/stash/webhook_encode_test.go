@@ -0,0 +1,80 @@
+package stash
+
+import (
+	"bytes"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+)
+
+func TestWebhookEncode(t *testing.T) {
+	tests := []struct {
+		event string
+		file  string
+	}{
+		{event: "repo:refs_changed", file: "testdata/webhooks/push.json"},
+		{event: "repo:refs_changed", file: "testdata/webhooks/push_tag_create.json"},
+		{event: "repo:refs_changed", file: "testdata/webhooks/push_tag_delete.json"},
+		{event: "repo:refs_changed", file: "testdata/webhooks/push_branch_create.json"},
+		{event: "repo:refs_changed", file: "testdata/webhooks/push_branch_delete.json"},
+		{event: "pr:opened", file: "testdata/webhooks/pr_open.json"},
+		{event: "pr:from_ref_updated", file: "testdata/webhooks/pr_ref_updated.json"},
+		{event: "pr:modified", file: "testdata/webhooks/pr_modified.json"},
+		{event: "pr:merged", file: "testdata/webhooks/pr_merged.json"},
+		{event: "pr:declined", file: "testdata/webhooks/pr_declined.json"},
+		{event: "pr:deleted", file: "testdata/webhooks/pr_deleted.json"},
+		{event: "pr:comment:added", file: "testdata/webhooks/pr_comment.json"},
+		{event: "pr:reviewer:approved", file: "testdata/webhooks/pr_approved.json"},
+		{event: "pr:reviewer:unapproved", file: "testdata/webhooks/pr_unapproved.json"},
+		{event: "pr:reviewer:needs_work", file: "testdata/webhooks/pr_needs_work.json"},
+	}
+
+	s := new(webhookService)
+	for _, test := range tests {
+		t.Run(test.file, func(t *testing.T) {
+			data, err := os.ReadFile(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, _ := http.NewRequest("POST", "/", bytes.NewReader(data))
+			r.Header.Set("X-Event-Key", test.event)
+			r.Header.Set("X-Request-Id", "ee8d97b4-1479-43f1-9cac-fbbd1b80da55")
+			want, err := s.Parse(r, noSecretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req, err := s.Encode(want, "71295b197fa25f4356d2fb9965df3f2379d903d7")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := req.Header.Get("X-Event-Key"); got != test.event {
+				t.Errorf("Want event %s, got %s", test.event, got)
+			}
+
+			got, err := s.Parse(req, secretFunc)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("Encoded webhook does not round trip")
+				t.Log(diff)
+			}
+		})
+	}
+}
+
+func TestWebhookEncode_NotSupported(t *testing.T) {
+	s := new(webhookService)
+	_, err := s.Encode(&scm.IssueHook{}, "71295b197fa25f4356d2fb9965df3f2379d903d7")
+	if err != scm.ErrNotSupported {
+		t.Errorf("Expect not supported error, got %v", err)
+	}
+}
+
+func noSecretFunc(scm.Webhook) (string, error) {
+	return "", nil
+}
@@ -247,6 +247,21 @@ func TestWebhookVerified(t *testing.T) {
 	}
 }
 
+func TestWebhookRotatedSecret(t *testing.T) {
+	f, _ := os.ReadFile("testdata/webhooks/push.json")
+	r, _ := http.NewRequest("GET", "/", bytes.NewBuffer(f))
+	r.Header.Set("X-Event-Key", "repo:refs_changed")
+	r.Header.Set("X-Hub-Signature", "sha256=c90565fa018f3039414a7929c9187a147f1ac463076961c4cf411e3c67c541f8")
+
+	s := new(webhookService)
+	_, err := s.ParseWithSecrets(r, func(scm.Webhook) ([]string, error) {
+		return []string{"newsecret", "71295b197fa25f4356d2fb9965df3f2379d903d7"}, nil
+	})
+	if err != nil {
+		t.Errorf("Expect valid signature, got %v", err)
+	}
+}
+
 func secretFunc(scm.Webhook) (string, error) {
 	return "71295b197fa25f4356d2fb9965df3f2379d903d7", nil
 }
@@ -60,3 +60,23 @@ type MissingHeader struct {
 func (e MissingHeader) Error() string {
 	return fmt.Sprintf("400 Bad Request: Missing Header: %s", e.Header)
 }
+
+// MergeConflict is returned when a pull request branch cannot be
+// updated because it conflicts with the base branch.
+type MergeConflict struct {
+	Number  int
+	Message string
+}
+
+func (e MergeConflict) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("pull request %d conflicts with its base branch", e.Number)
+	}
+	return fmt.Sprintf("pull request %d conflicts with its base branch: %s", e.Number, e.Message)
+}
+
+// IsMergeConflict returns true if the error is a merge conflict
+func IsMergeConflict(err error) bool {
+	_, ok := err.(MergeConflict)
+	return ok
+}
@@ -291,6 +291,8 @@ func NewWebHookService(driver string) (scm.WebhookService, error) {
 	}
 	var service scm.WebhookService
 	switch driver {
+	case "azure":
+		service = azure.NewWebHookService()
 	case "bitbucket", "bitbucketcloud":
 		service = bitbucket.NewWebHookService()
 	case "fake", "fakegit":
@@ -311,3 +313,17 @@ func NewWebHookService(driver string) (scm.WebhookService, error) {
 
 	return service, nil
 }
+
+// NewWebHookEncoder creates a new instance of the webhook encoder for the
+// driver, which renders webhooks as provider native requests
+func NewWebHookEncoder(driver string) (scm.WebhookEncoder, error) {
+	service, err := NewWebHookService(driver)
+	if err != nil {
+		return nil, err
+	}
+	encoder, ok := service.(scm.WebhookEncoder)
+	if !ok {
+		return nil, fmt.Errorf("webhook encoding is not supported by GIT_KIND value: %s", driver)
+	}
+	return encoder, nil
+}
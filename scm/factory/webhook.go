@@ -0,0 +1,102 @@
+package factory
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/azure"
+)
+
+// maxPayloadSize matches the payload limit of the drivers.
+const maxPayloadSize = 10000000
+
+// DetectWebhookDriver returns the name of the driver that sent
+// the webhook request, based on the provider specific headers.
+// Azure DevOps does not send any provider header, so the
+// payload is inspected for its eventType. The request body
+// can still be read once the driver has been detected.
+func DetectWebhookDriver(req *http.Request) (string, error) {
+	switch {
+	// gitea also sends the gogs and github headers, so it
+	// must be checked first.
+	case req.Header.Get("X-Gitea-Event") != "":
+		return scm.DriverGitea.String(), nil
+	case req.Header.Get("X-Gogs-Event") != "":
+		return scm.DriverGogs.String(), nil
+	case req.Header.Get("X-GitHub-Event") != "":
+		return scm.DriverGithub.String(), nil
+	case req.Header.Get("X-Gitlab-Event") != "":
+		return scm.DriverGitlab.String(), nil
+	case req.Header.Get("X-Event-Key") != "":
+		// bitbucket cloud identifies each delivery with the
+		// X-Request-UUID header, bitbucket server with the
+		// X-Request-Id header.
+		if req.Header.Get("X-Request-UUID") != "" || req.Header.Get("X-Hook-UUID") != "" {
+			return scm.DriverBitbucket.String(), nil
+		}
+		return scm.DriverStash.String(), nil
+	}
+
+	if req.Body == nil {
+		return "", errUnknownWebhookDriver
+	}
+	data, err := io.ReadAll(io.LimitReader(req.Body, maxPayloadSize))
+	if err != nil {
+		return "", err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+
+	payload := struct {
+		EventType   string `json:"eventType"`
+		PublisherID string `json:"publisherId"`
+	}{}
+	if err := json.Unmarshal(data, &payload); err == nil && payload.EventType != "" && payload.PublisherID != "" {
+		return scm.DriverAzure.String(), nil
+	}
+	return "", errUnknownWebhookDriver
+}
+
+var errUnknownWebhookDriver = fmt.Errorf("unable to detect the webhook driver")
+
+// NewDetectingWebHookService returns a webhook service that
+// detects the driver of each webhook request and delegates
+// parsing to the webhook service of that driver. It allows a
+// single endpoint to receive webhooks from every provider.
+func NewDetectingWebHookService() scm.WebhookService {
+	return &detectingWebhookService{}
+}
+
+// NewDetectingWebHookServiceWithSecretValidation is like
+// NewDetectingWebHookService, but also validates the secret of
+// the azure webhooks, which are not signed and are otherwise
+// accepted without validation.
+func NewDetectingWebHookServiceWithSecretValidation() scm.WebhookService {
+	return &detectingWebhookService{validateSecret: true}
+}
+
+type detectingWebhookService struct {
+	validateSecret bool
+}
+
+func (s *detectingWebhookService) Parse(req *http.Request, fn scm.SecretFunc) (scm.Webhook, error) {
+	return s.ParseWithSecrets(req, fn.Secrets())
+}
+
+func (s *detectingWebhookService) ParseWithSecrets(req *http.Request, fn scm.SecretsFunc) (scm.Webhook, error) {
+	driver, err := DetectWebhookDriver(req)
+	if err != nil {
+		return nil, err
+	}
+	if s.validateSecret && driver == scm.DriverAzure.String() {
+		return azure.NewWebHookServiceWithSecretValidation().ParseWithSecrets(req, fn)
+	}
+	service, err := NewWebHookService(driver)
+	if err != nil {
+		return nil, err
+	}
+	return service.ParseWithSecrets(req, fn)
+}
@@ -0,0 +1,153 @@
+package factory
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookHeaders are the headers sent with a push webhook by
+// each provider.
+var webhookHeaders = map[string]map[string]string{
+	"azure": {},
+	"bitbucket": {
+		"X-Event-Key":    "repo:push",
+		"X-Hook-UUID":    "d2a7ac74-2a6b-4b6b-8f8b-2b1f0a6e8c61",
+		"X-Request-UUID": "e4f9b1a0-7c1d-4a3e-9a4f-3c2d1b0a9e8f",
+	},
+	"gitea": {
+		"X-Gitea-Event":    "push",
+		"X-Gitea-Delivery": "ee8d97b4-1479-43f1-9cac-fbbd1b80da55",
+		"X-Gogs-Event":     "push",
+		"X-Gogs-Delivery":  "ee8d97b4-1479-43f1-9cac-fbbd1b80da55",
+		"X-GitHub-Event":   "push",
+	},
+	"github": {
+		"X-GitHub-Event":    "push",
+		"X-GitHub-Delivery": "ee8d97b4-1479-43f1-9cac-fbbd1b80da55",
+	},
+	"gitlab": {
+		"X-Gitlab-Event": "Push Hook",
+	},
+	"gogs": {
+		"X-Gogs-Event":    "push",
+		"X-Gogs-Delivery": "ee8d97b4-1479-43f1-9cac-fbbd1b80da55",
+	},
+	"stash": {
+		"X-Event-Key":  "repo:refs_changed",
+		"X-Request-Id": "ee8d97b4-1479-43f1-9cac-fbbd1b80da55",
+	},
+}
+
+func newWebhookRequest(t *testing.T, driver, file string) *http.Request {
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	req, err := http.NewRequest("POST", "/hook", bytes.NewReader(data))
+	require.NoError(t, err)
+	for k, v := range webhookHeaders[driver] {
+		req.Header.Set(k, v)
+	}
+	return req
+}
+
+func TestDetectWebhookDriver(t *testing.T) {
+	for driver := range webhookHeaders {
+		files, err := filepath.Glob(filepath.Join("..", "driver", driver, "testdata", "webhooks", "*.json"))
+		require.NoError(t, err)
+		require.NotEmpty(t, files, "no webhook fixtures for driver %s", driver)
+
+		for _, file := range files {
+			t.Run(driver+"/"+filepath.Base(file), func(t *testing.T) {
+				req := newWebhookRequest(t, driver, file)
+
+				got, err := DetectWebhookDriver(req)
+				require.NoError(t, err)
+				assert.Equal(t, driver, got)
+
+				// the body must still be readable by the driver
+				data, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+				want, err := os.ReadFile(file)
+				require.NoError(t, err)
+				assert.Equal(t, want, data)
+			})
+		}
+	}
+}
+
+func TestDetectWebhookDriver_Unknown(t *testing.T) {
+	req, err := http.NewRequest("POST", "/hook", strings.NewReader(`{"action":"opened"}`))
+	require.NoError(t, err)
+	_, err = DetectWebhookDriver(req)
+	assert.Error(t, err)
+
+	req, err = http.NewRequest("POST", "/hook", nil)
+	require.NoError(t, err)
+	_, err = DetectWebhookDriver(req)
+	assert.Error(t, err)
+}
+
+func TestDetectingWebHookService(t *testing.T) {
+	service := NewDetectingWebHookService()
+	noSecret := func(scm.Webhook) (string, error) {
+		return "", nil
+	}
+
+	for driver := range webhookHeaders {
+		t.Run(driver, func(t *testing.T) {
+			file := filepath.Join("..", "driver", driver, "testdata", "webhooks", "push.json")
+			hook, err := service.Parse(newWebhookRequest(t, driver, file), noSecret)
+			require.NoError(t, err)
+			require.IsType(t, &scm.PushHook{}, hook)
+			assert.NotEmpty(t, hook.Repository().Name)
+		})
+	}
+}
+
+func TestNewWebHookEncoder(t *testing.T) {
+	service := NewDetectingWebHookService()
+	secret := func(scm.Webhook) (string, error) {
+		return "topsecret", nil
+	}
+	noSecret := func(scm.Webhook) (string, error) {
+		return "", nil
+	}
+
+	for driver := range webhookHeaders {
+		t.Run(driver, func(t *testing.T) {
+			file := filepath.Join("..", "driver", driver, "testdata", "webhooks", "push.json")
+			hook, err := service.Parse(newWebhookRequest(t, driver, file), noSecret)
+			require.NoError(t, err)
+
+			encoder, err := NewWebHookEncoder(driver)
+			require.NoError(t, err)
+			req, err := encoder.Encode(hook, "topsecret")
+			require.NoError(t, err)
+
+			detected, err := DetectWebhookDriver(req)
+			require.NoError(t, err)
+			assert.Equal(t, driver, detected)
+
+			got, err := service.Parse(req, secret)
+			require.NoError(t, err)
+			if diff := cmp.Diff(hook, got); diff != "" {
+				t.Errorf("Encoded webhook does not round trip")
+				t.Log(diff)
+			}
+		})
+	}
+}
+
+func TestNewWebHookEncoder_Unsupported(t *testing.T) {
+	_, err := NewWebHookEncoder("fake")
+	assert.Error(t, err)
+}
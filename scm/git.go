@@ -12,6 +12,21 @@ import (
 // EmptyCommit is an empty commit sha.
 const EmptyCommit = "0000000000000000000000000000000000000000"
 
+// ComparisonStatus values.
+const (
+	ComparisonIdentical ComparisonStatus = "identical"
+	ComparisonAhead     ComparisonStatus = "ahead"
+	ComparisonBehind    ComparisonStatus = "behind"
+	ComparisonDiverged  ComparisonStatus = "diverged"
+)
+
+// Tree entry types.
+const (
+	TreeEntryBlob   = "blob"
+	TreeEntryTree   = "tree"
+	TreeEntryCommit = "commit"
+)
+
 type (
 	// Reference represents a git reference.
 	Reference struct {
@@ -40,6 +55,10 @@ type (
 		Author    Signature
 		Committer Signature
 		Link      string
+
+		// Parents holds the sha of each parent commit when
+		// known to the driver.
+		Parents []string
 	}
 
 	// CommitListOptions provides options for querying a
@@ -52,6 +71,46 @@ type (
 		Path string
 	}
 
+	// ComparisonStatus describes how the head of a
+	// comparison relates to its base.
+	ComparisonStatus string
+
+	// Comparison represents the comparison of two commits.
+	Comparison struct {
+		Status   ComparisonStatus
+		AheadBy  int
+		BehindBy int
+
+		// MergeBase is the sha of the best common ancestor of
+		// base and head.
+		MergeBase string
+
+		// Commits are the commits reachable from head but not
+		// from base, oldest first.
+		Commits []*Commit
+		Changes []*Change
+	}
+
+	// Tree represents a git tree.
+	Tree struct {
+		Sha     string
+		Entries []*TreeEntry
+
+		// Truncated is true if the provider limited the
+		// number of entries returned.
+		Truncated bool
+	}
+
+	// TreeEntry represents an entry in a git tree.
+	TreeEntry struct {
+		Path string
+		Mode string
+		Type string
+		Sha  string
+		Size int64
+		Link string
+	}
+
 	// Signature identifies a git commit creator.
 	Signature struct {
 		Name  string
@@ -90,6 +149,13 @@ type (
 		// CompareCommits returns the changeset between two commits.
 		CompareCommits(ctx context.Context, repo, ref1, ref2 string, opts *ListOptions) ([]*Change, *Response, error)
 
+		// Compare returns the comparison of the head commit to
+		// the base commit.
+		Compare(ctx context.Context, repo, base, head string, opts *ListOptions) (*Comparison, *Response, error)
+
+		// CompareDiff returns the unified diff between two commits.
+		CompareDiff(ctx context.Context, repo, ref1, ref2 string) (*Diff, *Response, error)
+
 		// ListTags returns a list of git tags.
 		ListTags(ctx context.Context, repo string, opts *ListOptions) ([]*Reference, *Response, error)
 
@@ -101,5 +167,25 @@ type (
 
 		// CreateRef creates a new ref
 		CreateRef(ctx context.Context, repo, ref, sha string) (*Reference, *Response, error)
+
+		// FindTree returns the git tree for the given ref. If recursive
+		// is true the entries of all sub trees are included.
+		FindTree(ctx context.Context, repo, ref string, recursive bool) (*Tree, *Response, error)
 	}
 )
+
+// ComparisonStatusOf returns the status of a comparison whose
+// head is ahead and behind its base by the given number of
+// commits.
+func ComparisonStatusOf(ahead, behind int) ComparisonStatus {
+	switch {
+	case ahead > 0 && behind > 0:
+		return ComparisonDiverged
+	case ahead > 0:
+		return ComparisonAhead
+	case behind > 0:
+		return ComparisonBehind
+	default:
+		return ComparisonIdentical
+	}
+}
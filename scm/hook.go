@@ -0,0 +1,171 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"sort"
+)
+
+// HookEventsConverter is implemented by repository services that can
+// tell the events a webhook created from the input reports, which lets
+// EnsureHook compare the input with an existing webhook.
+type HookEventsConverter interface {
+	ConvertHookEvents(input *HookInput) []string
+}
+
+// EnsureHook creates the repository webhook, or updates the existing
+// webhook with the same target url so that its events, secret and
+// active flag match the input. Updating a webhook also activates it.
+// The existing webhook is returned unchanged when it is active and
+// matches the input. Its events can only be compared when the
+// repository service is a HookEventsConverter, and its secret when
+// the driver keeps the secret in the target url, otherwise the
+// webhook is updated. Drivers that cannot edit webhooks return
+// ErrNotSupported.
+func EnsureHook(ctx context.Context, client *Client, repo string, input *HookInput) (*Hook, *Response, error) {
+	return ensureHook(ctx, client, repo, input, false)
+}
+
+// EnsureHookRecreate is like EnsureHook, but on drivers that cannot
+// edit webhooks the existing webhook is deleted and created again,
+// which loses its delivery history.
+func EnsureHookRecreate(ctx context.Context, client *Client, repo string, input *HookInput) (*Hook, *Response, error) {
+	return ensureHook(ctx, client, repo, input, true)
+}
+
+func ensureHook(ctx context.Context, client *Client, repo string, input *HookInput, recreate bool) (*Hook, *Response, error) {
+	existing, res, err := findHookByTarget(ctx, client, repo, input.Target)
+	if err != nil {
+		return nil, res, err
+	}
+	if existing == nil {
+		return client.Repositories.CreateHook(ctx, repo, copyHookInput(input))
+	}
+
+	if hookMatches(client, existing, input) {
+		return existing, res, nil
+	}
+
+	in := copyHookInput(input)
+	in.Name = existing.ID
+	hook, res, err := client.Repositories.UpdateHook(ctx, repo, in)
+	if !recreate || !errors.Is(err, ErrNotSupported) {
+		return hook, res, err
+	}
+	res, err = client.Repositories.DeleteHook(ctx, repo, existing.ID)
+	if err != nil {
+		return nil, res, err
+	}
+	return client.Repositories.CreateHook(ctx, repo, copyHookInput(input))
+}
+
+// hookMatches returns true if the webhook is active and its events,
+// secret and ssl verification match the input.
+func hookMatches(client *Client, hook *Hook, input *HookInput) bool {
+	if !hook.Active || hook.SkipVerify != input.SkipVerify {
+		return false
+	}
+	if !sameHookSecret(hook.Target, input.Secret) {
+		return false
+	}
+	converter, ok := client.Repositories.(HookEventsConverter)
+	if !ok {
+		return false
+	}
+	return sameHookEvents(hook.Events, converter.ConvertHookEvents(input))
+}
+
+// sameHookSecret returns true if the secret query parameter of the
+// webhook url is the secret. Without the parameter the secret of the
+// webhook cannot be read, and only an empty secret is assumed equal.
+func sameHookSecret(target, secret string) bool {
+	u, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+	params := u.Query()
+	if !params.Has("secret") {
+		return secret == ""
+	}
+	return params.Get("secret") == secret
+}
+
+// sameHookEvents returns true if both lists hold the same events,
+// regardless of their order.
+func sameHookEvents(a, b []string) bool {
+	a, b = uniqueHookEvents(a), uniqueHookEvents(b)
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func uniqueHookEvents(events []string) []string {
+	set := map[string]bool{}
+	out := []string{}
+	for _, e := range events {
+		if !set[e] {
+			set[e] = true
+			out = append(out, e)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+// findHookByTarget returns the repository webhook with the
+// target url, or nil if there is none.
+func findHookByTarget(ctx context.Context, client *Client, repo, target string) (*Hook, *Response, error) {
+	opts := &ListOptions{Page: 1, Size: 100}
+	for {
+		hooks, res, err := client.Repositories.ListHooks(ctx, repo, opts)
+		if err != nil {
+			return nil, res, err
+		}
+		for _, hook := range hooks {
+			if sameHookTarget(hook.Target, target) {
+				return hook, res, nil
+			}
+		}
+		if res == nil || res.Page.Next <= opts.Page {
+			return nil, res, nil
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+// sameHookTarget returns true if the webhook urls are equal,
+// ignoring the secret query parameter some drivers add to the
+// url of the webhook.
+func sameHookTarget(a, b string) bool {
+	return stripHookSecret(a) == stripHookSecret(b)
+}
+
+func stripHookSecret(s string) string {
+	u, err := url.Parse(s)
+	if err != nil {
+		return s
+	}
+	params := u.Query()
+	params.Del("secret")
+	u.RawQuery = params.Encode()
+	return u.String()
+}
+
+// copyHookInput returns a copy of the input, as drivers append
+// the converted events to the native events of the input.
+func copyHookInput(input *HookInput) *HookInput {
+	in := *input
+	in.NativeEvents = append([]string(nil), input.NativeEvents...)
+	return &in
+}
@@ -0,0 +1,157 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// readOnlyHookService rejects webhook edits with a wrapped
+// ErrNotSupported, like drivers whose api cannot update a webhook.
+type readOnlyHookService struct {
+	scm.RepositoryService
+}
+
+func (s *readOnlyHookService) UpdateHook(context.Context, string, *scm.HookInput) (*scm.Hook, *scm.Response, error) {
+	return nil, nil, fmt.Errorf("updating hooks: %w", scm.ErrNotSupported)
+}
+
+func TestEnsureHook_Create(t *testing.T) {
+	ctx := context.Background()
+	client, data := fake.NewDefault()
+
+	got, _, err := scm.EnsureHook(ctx, client, "octocat/hello-world", &scm.HookInput{
+		Target:       "https://ci.example.com/hook",
+		NativeEvents: []string{"push"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "https://ci.example.com/hook", got.Target)
+	assert.Len(t, data.Hooks["octocat/hello-world"], 1)
+}
+
+func TestEnsureHook_Update(t *testing.T) {
+	ctx := context.Background()
+	client, data := fake.NewDefault()
+	data.Hooks["octocat/hello-world"] = []*scm.Hook{
+		{ID: "1", Target: "https://other.example.com/hook", Active: true},
+		{ID: "2", Target: "https://ci.example.com/hook?secret=old", Events: []string{"push"}},
+	}
+
+	got, _, err := scm.EnsureHook(ctx, client, "octocat/hello-world", &scm.HookInput{
+		Target:       "https://ci.example.com/hook",
+		Secret:       "topsecret",
+		NativeEvents: []string{"push", "pull_request"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2", got.ID)
+	assert.True(t, got.Active)
+	assert.Equal(t, []string{"push", "pull_request"}, got.Events)
+	assert.Equal(t, "topsecret", data.HookSecrets["2"])
+	assert.Len(t, data.Hooks["octocat/hello-world"], 2)
+}
+
+func TestEnsureHook_UpdateEvents(t *testing.T) {
+	ctx := context.Background()
+	client, data := fake.NewDefault()
+	data.Hooks["octocat/hello-world"] = []*scm.Hook{
+		{ID: "1", Target: "https://ci.example.com/hook", Events: []string{"push"}},
+	}
+
+	got, _, err := scm.EnsureHook(ctx, client, "octocat/hello-world", &scm.HookInput{
+		Target: "https://ci.example.com/hook",
+		Secret: "topsecret",
+		Events: scm.HookEvents{Push: true, PullRequest: true, IssueComment: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1", got.ID)
+	assert.Equal(t, []string{"push", "pull_request", "issue_comment"}, data.Hooks["octocat/hello-world"][0].Events)
+	assert.Equal(t, "topsecret", data.HookSecrets["1"])
+}
+
+func TestEnsureHook_Unchanged(t *testing.T) {
+	ctx := context.Background()
+	client, data := fake.NewDefault()
+	data.Hooks["octocat/hello-world"] = []*scm.Hook{
+		{ID: "1", Target: "https://ci.example.com/hook", Events: []string{"pull_request", "push"}, Active: true},
+		{ID: "2", Target: "https://ci.example.com/signed?secret=topsecret", Events: []string{"push"}, Active: true},
+	}
+
+	got, _, err := scm.EnsureHook(ctx, client, "octocat/hello-world", &scm.HookInput{
+		Target: "https://ci.example.com/hook",
+		Events: scm.HookEvents{Push: true, PullRequest: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1", got.ID)
+
+	got, _, err = scm.EnsureHook(ctx, client, "octocat/hello-world", &scm.HookInput{
+		Target: "https://ci.example.com/signed",
+		Secret: "topsecret",
+		Events: scm.HookEvents{Push: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "2", got.ID)
+	assert.Empty(t, data.HookSecrets, "Expected the webhooks not to be updated")
+}
+
+func TestEnsureHook_Reactivate(t *testing.T) {
+	ctx := context.Background()
+	client, data := fake.NewDefault()
+	data.Hooks["octocat/hello-world"] = []*scm.Hook{
+		{ID: "1", Target: "https://ci.example.com/hook", Events: []string{"push"}},
+	}
+
+	got, _, err := scm.EnsureHook(ctx, client, "octocat/hello-world", &scm.HookInput{
+		Target: "https://ci.example.com/hook",
+		Events: scm.HookEvents{Push: true},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "1", got.ID)
+	assert.True(t, got.Active)
+	assert.Contains(t, data.HookSecrets, "1")
+}
+
+func TestEnsureHook_NotSupported(t *testing.T) {
+	ctx := context.Background()
+	client, data := fake.NewDefault()
+	client.Repositories = &readOnlyHookService{RepositoryService: client.Repositories}
+	data.Hooks["octocat/hello-world"] = []*scm.Hook{
+		{ID: "1", Target: "https://ci.example.com/hook"},
+	}
+
+	_, _, err := scm.EnsureHook(ctx, client, "octocat/hello-world", &scm.HookInput{
+		Target: "https://ci.example.com/hook",
+	})
+	assert.ErrorIs(t, err, scm.ErrNotSupported)
+	require.Len(t, data.Hooks["octocat/hello-world"], 1)
+	assert.Equal(t, "1", data.Hooks["octocat/hello-world"][0].ID)
+}
+
+func TestEnsureHookRecreate(t *testing.T) {
+	ctx := context.Background()
+	client, data := fake.NewDefault()
+	client.Repositories = &readOnlyHookService{RepositoryService: client.Repositories}
+	data.Hooks["octocat/hello-world"] = []*scm.Hook{
+		{ID: "1", Target: "https://ci.example.com/hook"},
+	}
+
+	got, _, err := scm.EnsureHookRecreate(ctx, client, "octocat/hello-world", &scm.HookInput{
+		Target: "https://ci.example.com/hook",
+		Secret: "topsecret",
+		Events: scm.HookEvents{Push: true},
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, "1", got.ID)
+	require.Len(t, data.Hooks["octocat/hello-world"], 1)
+	assert.Equal(t, got.ID, data.Hooks["octocat/hello-world"][0].ID)
+	assert.Equal(t, []string{"push"}, got.Events)
+	assert.Equal(t, "topsecret", data.HookSecrets[got.ID])
+}
@@ -36,19 +36,66 @@ type (
 	}
 
 	// IssueInput provides the input fields required for
-	// creating or updating an issue.
+	// creating or updating an issue. When updating an issue,
+	// empty fields are left unchanged and the labels and
+	// assignees that are set replace the existing ones.
 	IssueInput struct {
 		Title string
 		Body  string
+
+		// Labels are the label names of the issue.
+		Labels []string
+
+		// Assignees are the logins of the users assigned to the
+		// issue.
+		Assignees []string
+
+		// Milestone is the milestone id, as accepted by
+		// SetMilestone.
+		Milestone int
+
+		// Confidential hides the issue from users without
+		// access to confidential issues (GitLab only). It is
+		// left unchanged when nil.
+		Confidential *bool
+
+		// DueDate is the date the issue is due.
+		DueDate *time.Time
 	}
 
 	// IssueListOptions provides options for querying a
-	// list of repository issues.
+	// list of repository issues. GitHub, GitLab, Gitea and
+	// Bitbucket Cloud apply the filters server-side; Gitea
+	// ignores Sort, and Bitbucket Cloud ignores Labels as it
+	// has no issue labels. The other drivers ignore the
+	// filters.
 	IssueListOptions struct {
 		Page   int
 		Size   int
 		Open   bool
 		Closed bool
+
+		// Labels filters by label name; an issue must have
+		// all of the labels to match.
+		Labels []string
+
+		// Author filters by the login of the author.
+		Author string
+
+		// Assignee filters by the login of an assignee.
+		Assignee string
+
+		// Milestone filters by milestone title.
+		Milestone string
+
+		// Since only returns issues updated at or after the
+		// given time.
+		Since *time.Time
+
+		// Sort orders the results by SortCreated or
+		// SortUpdated, newest first unless Ascending is set.
+		Sort      string
+		Ascending bool
 	}
 
 	// Comment represents a comment.
@@ -109,6 +156,9 @@ type (
 		// Create creates a new issue.
 		Create(context.Context, string, *IssueInput) (*Issue, *Response, error)
 
+		// Update updates an issue.
+		Update(context.Context, string, int, *IssueInput) (*Issue, *Response, error)
+
 		// CreateComment creates a new issue comment.
 		CreateComment(context.Context, string, int, *CommentInput) (*Comment, *Response, error)
 
@@ -0,0 +1,283 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrMergeabilityUnknown is returned by WaitForMergeability when the
+// provider has not computed the mergeable state of the pull request
+// within the maximum number of attempts.
+var ErrMergeabilityUnknown = errors.New("mergeable state is still being computed")
+
+type (
+	// MergeBlocker describes a reason a pull request cannot be merged.
+	MergeBlocker string
+
+	// MergeabilityOptions provides options for waiting on the
+	// mergeability of a pull request.
+	MergeabilityOptions struct {
+		// Interval is the initial delay between polls. It doubles
+		// after every poll. Defaults to one second.
+		Interval time.Duration
+
+		// MaxInterval caps the delay between polls. Defaults to
+		// thirty seconds.
+		MaxInterval time.Duration
+
+		// MaxAttempts caps the number of times the pull request
+		// is fetched while its mergeable state is computed.
+		// Defaults to ten.
+		MaxAttempts int
+
+		// RequiredApprovals is the number of approving reviews
+		// required before the pull request can be merged.
+		RequiredApprovals int
+
+		// RequiredContexts limits the status check to the named
+		// contexts. All statuses are checked when empty.
+		RequiredContexts []string
+	}
+
+	// Mergeability describes whether a pull request can be merged
+	// and, if not, what is blocking it.
+	Mergeability struct {
+		PullRequest *PullRequest
+		Status      *CombinedStatus
+		Reviews     []*Review
+		Approvals   int
+		Blockers    []MergeBlocker
+	}
+)
+
+// MergeBlocker values.
+const (
+	// MergeBlockerClosed The pull request is closed or already merged.
+	MergeBlockerClosed MergeBlocker = "closed"
+	// MergeBlockerDraft The pull request is a draft.
+	MergeBlockerDraft MergeBlocker = "draft"
+	// MergeBlockerConflict The pull request has merge conflicts.
+	MergeBlockerConflict MergeBlocker = "conflict"
+	// MergeBlockerFailingChecks One or more required checks failed.
+	MergeBlockerFailingChecks MergeBlocker = "failing_checks"
+	// MergeBlockerPendingChecks One or more required checks have not completed.
+	MergeBlockerPendingChecks MergeBlocker = "pending_checks"
+	// MergeBlockerChangesRequested A reviewer requested changes.
+	MergeBlockerChangesRequested MergeBlocker = "changes_requested"
+	// MergeBlockerMissingApprovals The pull request has fewer approvals than required.
+	MergeBlockerMissingApprovals MergeBlocker = "missing_approvals"
+)
+
+// Mergeable returns true if nothing is blocking the merge.
+func (m *Mergeability) Mergeable() bool {
+	return len(m.Blockers) == 0
+}
+
+// Blocked returns true if the pull request is blocked by b.
+func (m *Mergeability) Blocked(b MergeBlocker) bool {
+	for _, blocker := range m.Blockers {
+		if blocker == b {
+			return true
+		}
+	}
+	return false
+}
+
+// String returns the string representation
+func (b MergeBlocker) String() string {
+	return string(b)
+}
+
+// WaitForMergeability polls the pull request until its mergeable state
+// has been computed, backing off between polls, and then combines it
+// with the commit statuses and reviews to explain what is blocking the
+// merge. GitHub and GitLab compute mergeability lazily so are polled;
+// other drivers are checked once. If the context is cancelled, or the
+// state is still unknown after MaxAttempts polls, the last known pull
+// request is returned with the context error or ErrMergeabilityUnknown.
+func WaitForMergeability(ctx context.Context, client *Client, repo string, number int, opts *MergeabilityOptions) (*Mergeability, error) {
+	if opts == nil {
+		opts = &MergeabilityOptions{}
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+	maxInterval := opts.MaxInterval
+	if maxInterval <= 0 {
+		maxInterval = 30 * time.Second
+	}
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 10
+	}
+
+	result := &Mergeability{}
+	for attempt := 1; ; attempt++ {
+		pr, _, err := client.PullRequests.Find(ctx, repo, number)
+		if err != nil {
+			return result, err
+		}
+		result.PullRequest = pr
+		if !pollMergeability(client.Driver, pr) {
+			break
+		}
+		if attempt >= maxAttempts {
+			return result, ErrMergeabilityUnknown
+		}
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(interval):
+		}
+		interval *= 2
+		if interval > maxInterval {
+			interval = maxInterval
+		}
+	}
+
+	pr := result.PullRequest
+	if pr.Closed || pr.Merged {
+		result.Blockers = append(result.Blockers, MergeBlockerClosed)
+		return result, nil
+	}
+	if pr.Draft {
+		result.Blockers = append(result.Blockers, MergeBlockerDraft)
+	}
+	if pr.MergeableState == MergeableStateConflicting {
+		result.Blockers = append(result.Blockers, MergeBlockerConflict)
+	}
+
+	if pr.Sha != "" {
+		status, _, err := client.Repositories.FindCombinedStatus(ctx, repo, pr.Sha)
+		switch {
+		case err == ErrNotSupported:
+		case err != nil:
+			return result, err
+		default:
+			result.Status = status
+			result.Blockers = append(result.Blockers, statusBlockers(status, opts.RequiredContexts)...)
+		}
+	}
+
+	reviews, err := listAllReviews(ctx, client, repo, number)
+	switch {
+	case err == ErrNotSupported:
+	case err != nil:
+		return result, err
+	default:
+		result.Reviews = reviews
+		approvals, changesRequested := tallyReviews(reviews)
+		result.Approvals = approvals
+		if changesRequested {
+			result.Blockers = append(result.Blockers, MergeBlockerChangesRequested)
+		}
+		if approvals < opts.RequiredApprovals {
+			result.Blockers = append(result.Blockers, MergeBlockerMissingApprovals)
+		}
+	}
+	return result, nil
+}
+
+// pollMergeability returns true if the driver is still computing the
+// mergeable state of the pull request. GitHub reports states such as
+// blocked or unstable as unknown, but marks the pull request mergeable
+// once the state has been computed.
+func pollMergeability(driver Driver, pr *PullRequest) bool {
+	if pr.Closed || pr.Merged || pr.Mergeable || pr.MergeableState != MergeableStateUnknown {
+		return false
+	}
+	return driver == DriverGithub || driver == DriverGitlab
+}
+
+// statusBlockers returns the blockers caused by failing or incomplete
+// statuses, limited to the required contexts when given.
+func statusBlockers(status *CombinedStatus, required []string) []MergeBlocker {
+	var failing, pending bool
+	seen := map[string]bool{}
+	for _, s := range status.Statuses {
+		if len(required) > 0 && !containsString(required, s.Label) {
+			continue
+		}
+		seen[s.Label] = true
+		switch s.State {
+		case StateFailure, StateError, StateCanceled:
+			failing = true
+		case StatePending, StateRunning, StateExpected, StateUnknown:
+			pending = true
+		}
+	}
+	for _, label := range required {
+		if !seen[label] {
+			pending = true
+		}
+	}
+
+	var blockers []MergeBlocker
+	if failing {
+		blockers = append(blockers, MergeBlockerFailingChecks)
+	}
+	if pending {
+		blockers = append(blockers, MergeBlockerPendingChecks)
+	}
+	return blockers
+}
+
+// tallyReviews counts the approvals from the latest review of each
+// author and reports whether any author has requested changes.
+func tallyReviews(reviews []*Review) (approvals int, changesRequested bool) {
+	latest := map[string]string{}
+	var authors []string
+	for _, r := range reviews {
+		state := strings.ToUpper(r.State)
+		switch state {
+		case ReviewStateApproved, ReviewStateChangesRequested, ReviewStateDismissed:
+		default:
+			continue
+		}
+		if _, ok := latest[r.Author.Login]; !ok {
+			authors = append(authors, r.Author.Login)
+		}
+		latest[r.Author.Login] = state
+	}
+	for _, author := range authors {
+		switch latest[author] {
+		case ReviewStateApproved:
+			approvals++
+		case ReviewStateChangesRequested:
+			changesRequested = true
+		}
+	}
+	return approvals, changesRequested
+}
+
+func listAllReviews(ctx context.Context, client *Client, repo string, number int) ([]*Review, error) {
+	var all []*Review
+	opts := &ListOptions{Page: 1, Size: 100}
+	for {
+		reviews, res, err := client.Reviews.List(ctx, repo, number, opts)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, reviews...)
+		if res == nil || res.Page.Next == 0 {
+			return all, nil
+		}
+		opts.Page = res.Page.Next
+	}
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,128 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/driver/fake"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lazyPullService reports an unknown mergeable state for the
+// first few calls to Find, like GitHub does while computing it.
+type lazyPullService struct {
+	scm.PullRequestService
+	pending int
+	calls   int
+}
+
+func (s *lazyPullService) Find(ctx context.Context, repo string, number int) (*scm.PullRequest, *scm.Response, error) {
+	s.calls++
+	pr, res, err := s.PullRequestService.Find(ctx, repo, number)
+	if err != nil || s.calls > s.pending {
+		return pr, res, err
+	}
+	out := *pr
+	out.MergeableState = scm.MergeableStateUnknown
+	return &out, res, nil
+}
+
+func TestWaitForMergeability(t *testing.T) {
+	client, data := fake.NewDefault()
+	client.Driver = scm.DriverGithub
+	pulls := &lazyPullService{PullRequestService: client.PullRequests, pending: 2}
+	client.PullRequests = pulls
+
+	data.PullRequests[1] = &scm.PullRequest{
+		Number:         1,
+		Sha:            "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		MergeableState: scm.MergeableStateMergeable,
+	}
+	data.Statuses["6dcb09b5b57875f334f61aebed695e2e4193db5e"] = []*scm.Status{
+		{Label: "build", State: scm.StateSuccess},
+		{Label: "lint", State: scm.StateFailure},
+	}
+	data.Reviews[1] = []*scm.Review{
+		{Author: scm.User{Login: "alice"}, State: scm.ReviewStateChangesRequested},
+		{Author: scm.User{Login: "alice"}, State: scm.ReviewStateApproved},
+		{Author: scm.User{Login: "bob"}, State: scm.ReviewStateCommented},
+	}
+
+	got, err := scm.WaitForMergeability(context.Background(), client, "octocat/hello-world", 1, &scm.MergeabilityOptions{
+		Interval:          time.Millisecond,
+		RequiredApprovals: 2,
+		RequiredContexts:  []string{"build"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 3, pulls.calls)
+	assert.Equal(t, 1, got.Approvals)
+	assert.Equal(t, []scm.MergeBlocker{scm.MergeBlockerMissingApprovals}, got.Blockers)
+	assert.False(t, got.Mergeable())
+}
+
+func TestWaitForMergeabilityBlockers(t *testing.T) {
+	client, data := fake.NewDefault()
+	data.PullRequests[1] = &scm.PullRequest{
+		Number:         1,
+		Sha:            "6dcb09b5b57875f334f61aebed695e2e4193db5e",
+		Draft:          true,
+		MergeableState: scm.MergeableStateConflicting,
+	}
+	data.Statuses["6dcb09b5b57875f334f61aebed695e2e4193db5e"] = []*scm.Status{
+		{Label: "build", State: scm.StatePending},
+		{Label: "lint", State: scm.StateError},
+	}
+	data.Reviews[1] = []*scm.Review{
+		{Author: scm.User{Login: "alice"}, State: scm.ReviewStateChangesRequested},
+	}
+
+	got, err := scm.WaitForMergeability(context.Background(), client, "octocat/hello-world", 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []scm.MergeBlocker{
+		scm.MergeBlockerDraft,
+		scm.MergeBlockerConflict,
+		scm.MergeBlockerFailingChecks,
+		scm.MergeBlockerPendingChecks,
+		scm.MergeBlockerChangesRequested,
+	}, got.Blockers)
+	assert.True(t, got.Blocked(scm.MergeBlockerConflict))
+}
+
+func TestWaitForMergeabilityCancel(t *testing.T) {
+	client, data := fake.NewDefault()
+	client.Driver = scm.DriverGitlab
+	data.PullRequests[1] = &scm.PullRequest{Number: 1}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	got, err := scm.WaitForMergeability(ctx, client, "octocat/hello-world", 1, &scm.MergeabilityOptions{
+		Interval: time.Millisecond,
+	})
+	assert.Equal(t, context.DeadlineExceeded, err)
+	require.NotNil(t, got.PullRequest)
+	assert.Equal(t, 1, got.PullRequest.Number)
+}
+
+func TestWaitForMergeabilityMaxAttempts(t *testing.T) {
+	client, data := fake.NewDefault()
+	client.Driver = scm.DriverGithub
+	pulls := &lazyPullService{PullRequestService: client.PullRequests, pending: 10}
+	client.PullRequests = pulls
+	data.PullRequests[1] = &scm.PullRequest{Number: 1}
+
+	got, err := scm.WaitForMergeability(context.Background(), client, "octocat/hello-world", 1, &scm.MergeabilityOptions{
+		Interval:    time.Millisecond,
+		MaxAttempts: 3,
+	})
+	assert.Equal(t, scm.ErrMergeabilityUnknown, err)
+	assert.Equal(t, 3, pulls.calls)
+	require.NotNil(t, got.PullRequest)
+	assert.Equal(t, 1, got.PullRequest.Number)
+}
@@ -104,5 +104,24 @@ type (
 
 		// ListMemberships lists organisation memberships for the authenticated user
 		ListMemberships(ctx context.Context, opts *ListOptions) ([]*Membership, *Response, error)
+
+		// FindHook returns an organization webhook.
+		FindHook(ctx context.Context, org, id string) (*Hook, *Response, error)
+
+		// ListHooks returns the organization webhooks.
+		ListHooks(ctx context.Context, org string, opts *ListOptions) ([]*Hook, *Response, error)
+
+		// CreateHook creates an organization webhook, which
+		// receives the events of every repository in the
+		// organization.
+		CreateHook(ctx context.Context, org string, input *HookInput) (*Hook, *Response, error)
+
+		// UpdateHook edits an organization webhook. The hook is
+		// identified by the input name, as with the repository
+		// UpdateHook.
+		UpdateHook(ctx context.Context, org string, input *HookInput) (*Hook, *Response, error)
+
+		// DeleteHook deletes an organization webhook.
+		DeleteHook(ctx context.Context, org, id string) (*Response, error)
 	}
 )
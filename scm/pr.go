@@ -55,6 +55,10 @@ type (
 		Head  string
 		Base  string
 		Body  string
+
+		// Draft opens the pull request as a draft. It is
+		// only honoured on create.
+		Draft bool
 	}
 
 	// Milestone the milestone
@@ -70,6 +74,24 @@ type (
 
 	// PullRequestListOptions provides options for querying
 	// a list of repository merge requests.
+	//
+	// Filters a provider cannot apply server-side are emulated
+	// by FilterPullRequests on the returned page, which may then
+	// hold fewer than Size results:
+	//
+	//   - GitHub filters by state, Head and Base and sorts
+	//     natively; Labels, Author, Assignee, Reviewer,
+	//     Milestone and UpdatedAfter are emulated.
+	//   - GitLab applies every filter and the sort natively.
+	//   - Gitea filters by state and sorts natively; the other
+	//     filters are emulated.
+	//   - Bitbucket Cloud applies Head, Base, Author, Reviewer
+	//     and UpdatedAfter and sorts natively; it has no labels,
+	//     assignees or milestones.
+	//   - Azure DevOps filters by Head and Base natively and
+	//     emulates the other filters; Sort is ignored.
+	//   - Bitbucket Server emulates Head, Base and the user
+	//     filters; Sort is ignored.
 	PullRequestListOptions struct {
 		Page          int
 		Size          int
@@ -80,6 +102,30 @@ type (
 		UpdatedBefore *time.Time
 		CreatedAfter  *time.Time
 		CreatedBefore *time.Time
+
+		// Head filters by the source branch. GitHub also
+		// accepts the user:branch form for forks.
+		Head string
+
+		// Base filters by the target branch.
+		Base string
+
+		// Author filters by the login of the author.
+		Author string
+
+		// Assignee filters by the login of an assignee.
+		Assignee string
+
+		// Reviewer filters by the login of a requested reviewer.
+		Reviewer string
+
+		// Milestone filters by milestone title.
+		Milestone string
+
+		// Sort orders the results by SortCreated or
+		// SortUpdated, newest first unless Ascending is set.
+		Sort      string
+		Ascending bool
 	}
 
 	// PullRequestBranch contains information about a particular branch in a PR.
@@ -120,6 +166,32 @@ type (
 		DeleteSourceBranch bool
 	}
 
+	// AutoMergeOptions lets you define how a pull request will be
+	// merged once its requirements are met.
+	AutoMergeOptions struct {
+		// The merge method to use. Possible values include: "merge", "squash", and "rebase" with the default being merge. (Optional.)
+		MergeMethod string
+
+		CommitTitle   string // Title of the merge commit. (Optional.)
+		CommitMessage string // Body of the merge commit. (Optional.)
+		SHA           string // SHA that pull request head must match to allow merge. (Optional.)
+
+		// Signals to the SCM to remove the source branch during merge
+		DeleteSourceBranch bool
+	}
+
+	// MergeQueueEntry represents a pull request waiting in a
+	// merge queue or merge train.
+	MergeQueueEntry struct {
+		ID       string
+		Number   int
+		Position int
+		State    string
+		Sha      string
+		Created  time.Time
+		Updated  time.Time
+	}
+
 	// PullRequestService provides access to pull request resources.
 	PullRequestService interface {
 		// Find returns the repository pull request by number.
@@ -134,9 +206,16 @@ type (
 		// Find returns the repository pull request list.
 		List(context.Context, string, *PullRequestListOptions) ([]*PullRequest, *Response, error)
 
+		// ListForCommit returns the pull requests associated
+		// with the commit.
+		ListForCommit(ctx context.Context, repo, sha string) ([]*PullRequest, *Response, error)
+
 		// ListChanges returns the pull request changeset.
 		ListChanges(context.Context, string, int, *ListOptions) ([]*Change, *Response, error)
 
+		// FindDiff returns the unified diff of the pull request.
+		FindDiff(ctx context.Context, repo string, number int) (*Diff, *Response, error)
+
 		// ListCommits returns the pull request commits.
 		ListCommits(context.Context, string, int, *ListOptions) ([]*Commit, *Response, error)
 
@@ -196,9 +275,49 @@ type (
 
 		// DeletePullRequest deletes a pull request from a repo.
 		DeletePullRequest(ctx context.Context, repo string, prID int) (*Response, error)
+
+		// MarkReady marks a draft pull request as ready for review.
+		MarkReady(ctx context.Context, repo string, number int) (*Response, error)
+
+		// ConvertToDraft converts a pull request back to a draft.
+		ConvertToDraft(ctx context.Context, repo string, number int) (*Response, error)
+
+		// EnableAutoMerge merges the pull request automatically
+		// once its required checks and reviews pass.
+		EnableAutoMerge(ctx context.Context, repo string, number int, opts *AutoMergeOptions) (*Response, error)
+
+		// DisableAutoMerge cancels a pending automatic merge.
+		DisableAutoMerge(ctx context.Context, repo string, number int) (*Response, error)
+
+		// EnqueueMerge adds the pull request to the merge queue.
+		EnqueueMerge(ctx context.Context, repo string, number int, opts *AutoMergeOptions) (*MergeQueueEntry, *Response, error)
+
+		// DequeueMerge removes the pull request from the merge queue.
+		DequeueMerge(ctx context.Context, repo string, number int) (*Response, error)
+
+		// FindMergeQueueEntry returns the merge queue entry for the
+		// pull request, or ErrNotFound if it is not queued.
+		FindMergeQueueEntry(ctx context.Context, repo string, number int) (*MergeQueueEntry, *Response, error)
+
+		// UpdateBranch brings the pull request branch up to date with
+		// its base branch using the UpdateBranchMerge or
+		// UpdateBranchRebase method, and returns the new head sha.
+		// An empty method uses the merge method, or the rebase method
+		// on providers that can only rebase. ErrNotSupported is
+		// returned for a method the provider does not support, and a
+		// MergeConflict error if the branches conflict.
+		UpdateBranch(ctx context.Context, repo string, number int, method string) (string, *Response, error)
 	}
 )
 
+// Update branch methods.
+const (
+	// UpdateBranchMerge merges the base branch into the pull request branch.
+	UpdateBranchMerge = "merge"
+	// UpdateBranchRebase rebases the pull request branch onto the base branch.
+	UpdateBranchRebase = "rebase"
+)
+
 // Action values.
 const (
 	// MergeableStateMergeable The pull request can be merged.
@@ -230,3 +349,83 @@ func ToMergeableState(text string) MergeableState {
 func (s MergeableState) String() string {
 	return string(s)
 }
+
+// FilterPullRequests returns the pull requests matching the
+// Head, Base, Author, Assignee, Reviewer, Milestone, Labels
+// and UpdatedAfter options. It is used by drivers whose API cannot
+// filter the pull request list by those fields.
+func FilterPullRequests(prs []*PullRequest, opts *PullRequestListOptions) []*PullRequest {
+	if opts == nil || (opts.Head == "" && opts.Base == "" && opts.Author == "" &&
+		opts.Assignee == "" && opts.Reviewer == "" && opts.Milestone == "" &&
+		len(opts.Labels) == 0 && opts.UpdatedAfter == nil) {
+		return prs
+	}
+	head := opts.Head
+	if i := strings.Index(head, ":"); i != -1 {
+		head = head[i+1:]
+	}
+	head = TrimRef(head)
+	base := TrimRef(opts.Base)
+	to := []*PullRequest{}
+	for _, pr := range prs {
+		if head != "" && pr.Head.Ref != head && pr.Source != head {
+			continue
+		}
+		if base != "" && pr.Base.Ref != base && pr.Target != base {
+			continue
+		}
+		if opts.Author != "" && !strings.EqualFold(pr.Author.Login, opts.Author) {
+			continue
+		}
+		if opts.Assignee != "" && !containsLogin(pr.Assignees, opts.Assignee) {
+			continue
+		}
+		if opts.Reviewer != "" && !containsLogin(pr.Reviewers, opts.Reviewer) {
+			continue
+		}
+		if opts.Milestone != "" && pr.Milestone.Title != opts.Milestone {
+			continue
+		}
+		if !hasLabels(pr.Labels, opts.Labels) {
+			continue
+		}
+		if opts.UpdatedAfter != nil {
+			// a pull request that was never updated reports
+			// no update time on some providers.
+			updated := pr.Updated
+			if updated.IsZero() {
+				updated = pr.Created
+			}
+			if updated.Before(*opts.UpdatedAfter) {
+				continue
+			}
+		}
+		to = append(to, pr)
+	}
+	return to
+}
+
+func containsLogin(users []User, login string) bool {
+	for _, user := range users {
+		if strings.EqualFold(user.Login, login) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasLabels(labels []*Label, names []string) bool {
+	for _, name := range names {
+		found := false
+		for _, label := range labels {
+			if label.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,76 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"context"
+	"time"
+)
+
+// ReactionContent is a provider independent reaction. Drivers
+// translate it to the native emoji name, such as GitLab award
+// emoji, and report unknown native reactions as is.
+type ReactionContent string
+
+// Reaction values.
+const (
+	ReactionPlusOne  ReactionContent = "+1"
+	ReactionMinusOne ReactionContent = "-1"
+	ReactionLaugh    ReactionContent = "laugh"
+	ReactionConfused ReactionContent = "confused"
+	ReactionHeart    ReactionContent = "heart"
+	ReactionHooray   ReactionContent = "hooray"
+	ReactionRocket   ReactionContent = "rocket"
+	ReactionEyes     ReactionContent = "eyes"
+)
+
+// ReactionTargetType identifies the kind of object a reaction
+// is on.
+type ReactionTargetType int
+
+// ReactionTargetType values.
+const (
+	ReactionOnIssue ReactionTargetType = iota
+	ReactionOnPullRequest
+	ReactionOnIssueComment
+	ReactionOnPullRequestComment
+	ReactionOnReviewComment
+)
+
+type (
+	// Reaction represents an emoji reaction.
+	Reaction struct {
+		ID      int
+		Content ReactionContent
+		User    User
+		Created time.Time
+	}
+
+	// ReactionTarget identifies the issue, pull request or
+	// comment a reaction is on.
+	ReactionTarget struct {
+		Type ReactionTargetType
+
+		// Number is the issue or pull request number.
+		Number int
+
+		// Comment is the comment id of the comment targets.
+		Comment int
+	}
+
+	// ReactionService provides access to reactions on issues,
+	// pull requests and their comments.
+	ReactionService interface {
+		// List returns the reactions on the target.
+		List(context.Context, string, ReactionTarget, *ListOptions) ([]*Reaction, *Response, error)
+
+		// Create adds a reaction to the target.
+		Create(context.Context, string, ReactionTarget, ReactionContent) (*Reaction, *Response, error)
+
+		// Delete removes the reaction of the authenticated
+		// user from the target.
+		Delete(context.Context, string, ReactionTarget, ReactionContent) (*Response, error)
+	}
+)
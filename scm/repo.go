@@ -6,6 +6,7 @@ package scm
 
 import (
 	"context"
+	"io"
 	"time"
 )
 
@@ -20,6 +21,15 @@ const (
 	AdminPermission = "admin"
 )
 
+// ArchiveFormat represents the format of a repository archive.
+type ArchiveFormat string
+
+// ArchiveFormat values.
+const (
+	ArchiveFormatTarball ArchiveFormat = "tar.gz"
+	ArchiveFormatZip     ArchiveFormat = "zip"
+)
+
 type (
 	// Repository represents a git repository.
 	Repository struct {
@@ -87,13 +97,54 @@ type (
 		DeploymentStatus   bool
 		Issue              bool
 		IssueComment       bool
+		Job                bool
+		Member             bool
+		Organization       bool
+		Pipeline           bool
 		PullRequest        bool
 		PullRequestComment bool
 		Push               bool
 		Release            bool
 		Review             bool
 		ReviewComment      bool
+		ReviewThread       bool
+		Star               bool
 		Tag                bool
+		Team               bool
+	}
+
+	// HookDelivery represents a webhook delivery attempt.
+	HookDelivery struct {
+		ID         string
+		GUID       string
+		Event      string
+		Action     string
+		Status     string
+		StatusCode int
+		Redelivery bool
+		Duration   time.Duration
+		Delivered  time.Time
+
+		// Request and Response are only populated when the
+		// delivery is returned by FindHookDelivery.
+		Request  HookDeliveryRequest
+		Response HookDeliveryResponse
+	}
+
+	// HookDeliveryRequest represents the request sent by a
+	// webhook delivery.
+	HookDeliveryRequest struct {
+		URL    string
+		Method string
+		Header map[string]string
+		Body   string
+	}
+
+	// HookDeliveryResponse represents the response received
+	// for a webhook delivery.
+	HookDeliveryResponse struct {
+		Header map[string]string
+		Body   string
 	}
 
 	// CombinedStatus is the latest statuses for a ref.
@@ -182,6 +233,21 @@ type (
 		// DeleteHook deletes a repository webhook.
 		DeleteHook(context.Context, string, string) (*Response, error)
 
+		// ListHookDeliveries returns the recent deliveries of a
+		// repository webhook.
+		ListHookDeliveries(ctx context.Context, repo, id string, opts *ListOptions) ([]*HookDelivery, *Response, error)
+
+		// FindHookDelivery returns a repository webhook delivery,
+		// including the request and response.
+		FindHookDelivery(ctx context.Context, repo, id, delivery string) (*HookDelivery, *Response, error)
+
+		// RedeliverHook sends a repository webhook delivery again.
+		RedeliverHook(ctx context.Context, repo, id, delivery string) (*Response, error)
+
+		// TestHook triggers a test delivery of a repository
+		// webhook, eg a ping or push event.
+		TestHook(ctx context.Context, repo, id string) (*Response, error)
+
 		// IsCollaborator returns true if the user is a collaborator on the repository
 		IsCollaborator(ctx context.Context, repo string, user string) (bool, *Response, error)
 
@@ -196,6 +262,10 @@ type (
 
 		// Delete deletes a repository
 		Delete(ctx context.Context, repo string) (*Response, error)
+
+		// Archive returns a stream of the repository contents at the
+		// given ref in the given format. The caller must close it.
+		Archive(ctx context.Context, repo, ref string, format ArchiveFormat) (io.ReadCloser, *Response, error)
 	}
 )
 
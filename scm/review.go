@@ -24,15 +24,43 @@ type (
 
 	// ReviewComment represents a review comment.
 	ReviewComment struct {
-		ID      int
-		Body    string
-		Path    string
-		Sha     string
-		Line    int
-		Link    string
-		Author  User
-		Created time.Time
-		Updated time.Time
+		ID   int
+		Body string
+		Path string
+		Sha  string
+
+		// Line is the line the comment applies to. GitHub
+		// reports the position of the line in the diff.
+		Line int
+
+		// FileLine is the line of the file the comment ends
+		// on, on every driver.
+		FileLine int
+
+		// StartLine is the first line of the file of a
+		// multi-line comment.
+		StartLine int
+
+		Side      DiffSide
+		InReplyTo int
+		ThreadID  string
+		Link      string
+		Author    User
+		Created   time.Time
+		Updated   time.Time
+	}
+
+	// ReviewThread represents a conversation on a line or
+	// range of lines of a pull request diff.
+	ReviewThread struct {
+		ID        string
+		Path      string
+		Line      int
+		StartLine int
+		Side      DiffSide
+		Resolved  bool
+		Outdated  bool
+		Comments  []*ReviewComment
 	}
 
 	// ReviewHook represents a review web hook
@@ -48,10 +76,35 @@ type (
 
 	// ReviewCommentInput provides the input fields required for
 	// creating a review comment.
+	//
+	// When Side or StartLine is set Line is the line number in
+	// the file on that side, for every driver. Otherwise GitHub
+	// reads Line as the position in the diff, see
+	// DiffPosition.ReviewLine.
 	ReviewCommentInput struct {
 		Body string
 		Path string
 		Line int
+
+		// StartLine is the first line of a multi-line comment
+		// ending at Line.
+		StartLine int
+
+		// Side is the side of the diff the comment applies to.
+		// Defaults to the right (new) side.
+		Side DiffSide
+
+		// Sha is the commit to comment on. Defaults to the
+		// head of the pull request.
+		Sha string
+
+		// InReplyTo is the id of the comment to reply to. The
+		// position fields are ignored for replies.
+		InReplyTo int
+
+		// ThreadID is the thread to reply to, for drivers that
+		// address threads rather than comments.
+		ThreadID string
 	}
 
 	// ReviewSubmitInput provides the input fields required for submitting a pending review.
@@ -93,6 +146,19 @@ type (
 
 		// Dismiss dismisses a review
 		Dismiss(context.Context, string, int, int, string) (*Review, *Response, error)
+
+		// CreateComment creates a single review comment outside of
+		// a review, or a reply when InReplyTo or ThreadID is set.
+		CreateComment(context.Context, string, int, *ReviewCommentInput) (*ReviewComment, *Response, error)
+
+		// ListThreads returns the review threads of a pull request.
+		ListThreads(context.Context, string, int, *ListOptions) ([]*ReviewThread, *Response, error)
+
+		// ResolveThread marks a review thread as resolved.
+		ResolveThread(context.Context, string, int, string) (*Response, error)
+
+		// UnresolveThread marks a review thread as unresolved.
+		UnresolveThread(context.Context, string, int, string) (*Response, error)
 	}
 )
 
@@ -0,0 +1,93 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import "context"
+
+// Search issue types.
+const (
+	SearchTypeIssue       = "issue"
+	SearchTypePullRequest = "pr"
+)
+
+type (
+	// SearchQuery is a provider independent search query.
+	// Each driver compiles it to the native search syntax of
+	// the provider; filters a provider cannot express are
+	// ignored unless documented otherwise by the driver.
+	SearchQuery struct {
+		// Text is the free text to search for.
+		Text string
+
+		// Repo scopes the search to a repository, in the
+		// namespace/name form.
+		Repo string
+
+		// Org scopes the search to an organization, group or
+		// project. It is ignored when Repo is set.
+		Org string
+
+		// State filters issues and pull requests by state,
+		// either "open" or "closed".
+		State string
+
+		// Type filters issues from pull requests using
+		// SearchTypeIssue or SearchTypePullRequest.
+		Type string
+
+		// Labels filters issues and pull requests by label
+		// name.
+		Labels []string
+
+		// Author filters issues, pull requests and commits by
+		// the login of their author.
+		Author string
+
+		// Path filters code by file path.
+		Path string
+
+		// Language filters code and repositories by
+		// programming language.
+		Language string
+	}
+
+	// SearchCode is a file matching a code search.
+	SearchCode struct {
+		Name       string
+		Path       string
+		Sha        string
+		Ref        string
+		Link       string
+		Repository Repository
+
+		// Fragments are the matching snippets of the file
+		// content, when the provider returns them.
+		Fragments []string
+	}
+
+	// SearchCommit is a commit matching a commit search.
+	SearchCommit struct {
+		Commit
+		Repository Repository
+	}
+
+	// SearchService provides access to code, repository,
+	// issue and commit search.
+	SearchService interface {
+		// Code returns the files matching the query.
+		Code(context.Context, *SearchQuery, *ListOptions) ([]*SearchCode, *Response, error)
+
+		// Repositories returns the repositories matching the
+		// query.
+		Repositories(context.Context, *SearchQuery, *ListOptions) ([]*Repository, *Response, error)
+
+		// Issues returns the issues and pull requests matching
+		// the query.
+		Issues(context.Context, *SearchQuery, *ListOptions) ([]*SearchIssue, *Response, error)
+
+		// Commits returns the commits matching the query.
+		Commits(context.Context, *SearchQuery, *ListOptions) ([]*SearchCommit, *Response, error)
+	}
+)
@@ -45,8 +45,18 @@ const (
 	WebhookKindIssue WebhookKind = "issue"
 	// WebhookKindIssueComment is for issue comment events
 	WebhookKindIssueComment WebhookKind = "issue_comment"
+	// WebhookKindJob is for CI job events
+	WebhookKindJob WebhookKind = "job"
+	// WebhookKindMergeGroup is for merge queue group events
+	WebhookKindMergeGroup WebhookKind = "merge_group"
 	// WebhookKindLabel is for label events
 	WebhookKindLabel WebhookKind = "label"
+	// WebhookKindMember is for membership events
+	WebhookKindMember WebhookKind = "member"
+	// WebhookKindOrganization is for organization and group events
+	WebhookKindOrganization WebhookKind = "organization"
+	// WebhookKindPipeline is for CI pipeline events
+	WebhookKindPipeline WebhookKind = "pipeline"
 	// WebhookKindPing is for ping events
 	WebhookKindPing WebhookKind = "ping"
 	// WebhookKindPullRequest is for pull request events
@@ -63,14 +73,20 @@ const (
 	WebhookKindReview WebhookKind = "review"
 	// WebhookKindReviewCommentHook is for review comment events
 	WebhookKindReviewCommentHook WebhookKind = "review_comment"
+	// WebhookKindReviewThread is for review thread events
+	WebhookKindReviewThread WebhookKind = "review_thread"
 	// WebhookKindStar is for star events
 	WebhookKindStar WebhookKind = "star"
 	// WebhookKindStatus is for status events
 	WebhookKindStatus WebhookKind = "status"
 	// WebhookKindTag is for tag events
 	WebhookKindTag WebhookKind = "tag"
+	// WebhookKindTeam is for team events
+	WebhookKindTeam WebhookKind = "team"
 	// WebhookKindWatch is for watch events
 	WebhookKindWatch WebhookKind = "watch"
+	// WebhookKindWikiPage is for wiki page events
+	WebhookKindWikiPage WebhookKind = "wiki_page"
 )
 
 var (
@@ -99,6 +115,7 @@ type (
 	// PingHook a ping webhook.
 	PingHook struct {
 		Repo         Repository
+		Org          Organization
 		Sender       User
 		Installation *InstallationRef
 		GUID         string
@@ -169,10 +186,15 @@ type (
 		Sender       User
 		Label        Label
 		Installation *InstallationRef
+
+		// CallbackURL is set for deployment protection rule
+		// events and is used to approve or reject the
+		// deployment.
+		CallbackURL string
 	}
 
-	// DeploymentStatusHook represents a deployment status event.
-	// This is currently a GitHub-specific event type.
+	// DeploymentStatusHook represents a deployment status event,
+	// eg deployment_status on GitHub and Deployment Hook on GitLab.
 	DeploymentStatusHook struct {
 		Deployment       Deployment
 		DeploymentStatus DeploymentStatus
@@ -330,6 +352,18 @@ type (
 		Installation *InstallationRef
 	}
 
+	// ReviewThreadHook represents a pull request review
+	// thread being resolved or unresolved, eg
+	// pull_request_review_thread.
+	ReviewThreadHook struct {
+		Action       Action
+		Repo         Repository
+		PullRequest  PullRequest
+		Thread       ReviewThread
+		Sender       User
+		Installation *InstallationRef
+	}
+
 	// ReviewCommentHook represents a pull request review
 	// comment, eg pull_request_review_comment.
 	ReviewCommentHook struct {
@@ -356,6 +390,133 @@ type (
 		Sender    User
 	}
 
+	// MergeGroup represents a group of pull requests being
+	// tested together in a merge queue.
+	MergeGroup struct {
+		HeadSha    string
+		HeadRef    string
+		BaseSha    string
+		BaseRef    string
+		HeadCommit Commit
+	}
+
+	// MergeGroupHook represents a merge queue event. This is
+	// currently GitHub-specific.
+	MergeGroupHook struct {
+		Action       Action
+		Reason       string // why a merge group was destroyed
+		MergeGroup   MergeGroup
+		Repo         Repository
+		Sender       User
+		Installation *InstallationRef
+	}
+
+	// Pipeline represents a CI pipeline run.
+	Pipeline struct {
+		ID       int
+		Number   int
+		Name     string
+		Status   State
+		Ref      string
+		Sha      string
+		Source   string // what triggered the pipeline, eg push
+		Link     string
+		Author   User
+		Created  time.Time
+		Finished time.Time
+	}
+
+	// PipelineHook represents a CI pipeline event, eg
+	// Pipeline Hook on GitLab.
+	PipelineHook struct {
+		Action       Action
+		Pipeline     Pipeline
+		Repo         Repository
+		Sender       User
+		Installation *InstallationRef
+	}
+
+	// Job represents a single job of a CI pipeline.
+	Job struct {
+		ID         int
+		PipelineID int
+		Name       string
+		Stage      string
+		Status     State
+		Ref        string
+		Sha        string
+		Link       string
+		Runner     string
+		Created    time.Time
+		Started    time.Time
+		Finished   time.Time
+	}
+
+	// JobHook represents a CI job event, eg Job Hook on
+	// GitLab.
+	JobHook struct {
+		Action       Action
+		Job          Job
+		Repo         Repository
+		Sender       User
+		Installation *InstallationRef
+	}
+
+	// WikiPage represents a wiki page.
+	WikiPage struct {
+		Title   string
+		Slug    string
+		Format  string
+		Content string
+		Message string
+		Link    string
+	}
+
+	// WikiPageHook represents a wiki page event.
+	WikiPageHook struct {
+		Action       Action
+		Page         WikiPage
+		Repo         Repository
+		Sender       User
+		Installation *InstallationRef
+	}
+
+	// MemberHook represents a change to the members of an
+	// organization, team or repository. Repo is empty for
+	// organization and team members and Team is only set for
+	// team members.
+	MemberHook struct {
+		Action       Action
+		Member       User
+		Role         string
+		Org          Organization
+		Team         *Team
+		Repo         Repository
+		Sender       User
+		Installation *InstallationRef
+	}
+
+	// TeamHook represents a team event. A team being added
+	// to or removed from a repository is reported as a
+	// create or delete action with the Repo set.
+	TeamHook struct {
+		Action       Action
+		Team         Team
+		Org          Organization
+		Repo         Repository
+		Sender       User
+		Installation *InstallationRef
+	}
+
+	// OrganizationHook represents an organization event, eg
+	// Subgroup Hook on GitLab.
+	OrganizationHook struct {
+		Action       Action
+		Org          Organization
+		Sender       User
+		Installation *InstallationRef
+	}
+
 	// WebhookWrapper lets us parse any webhook
 	WebhookWrapper struct {
 		PingHook                   *PingHook                   `json:",omitempty"`
@@ -369,30 +530,71 @@ type (
 		TagHook                    *TagHook                    `json:",omitempty"`
 		IssueHook                  *IssueHook                  `json:",omitempty"`
 		IssueCommentHook           *IssueCommentHook           `json:",omitempty"`
+		JobHook                    *JobHook                    `json:",omitempty"`
 		InstallationHook           *InstallationHook           `json:",omitempty"`
 		InstallationRepositoryHook *InstallationRepositoryHook `json:",omitempty"`
 		LabelHook                  *LabelHook                  `json:",omitempty"`
+		MemberHook                 *MemberHook                 `json:",omitempty"`
+		MergeGroupHook             *MergeGroupHook             `json:",omitempty"`
+		OrganizationHook           *OrganizationHook           `json:",omitempty"`
+		PipelineHook               *PipelineHook               `json:",omitempty"`
 		ReleaseHook                *ReleaseHook                `json:",omitempty"`
 		RepositoryHook             *RepositoryHook             `json:",omitempty"`
 		PullRequestHook            *PullRequestHook            `json:",omitempty"`
 		PullRequestCommentHook     *PullRequestCommentHook     `json:",omitempty"`
 		ReviewCommentHook          *ReviewCommentHook          `json:",omitempty"`
+		ReviewThreadHook           *ReviewThreadHook           `json:",omitempty"`
+		TeamHook                   *TeamHook                   `json:",omitempty"`
 		WatchHook                  *WatchHook                  `json:",omitempty"`
 		StarHook                   *StarHook                   `json:",omitempty"`
+		WikiPageHook               *WikiPageHook               `json:",omitempty"`
 	}
 
 	// SecretFunc provides the Webhook parser with the
 	// secret key used to validate webhook authenticity.
 	SecretFunc func(webhook Webhook) (string, error)
 
+	// SecretsFunc provides the Webhook parser with the
+	// candidate secret keys used to validate webhook
+	// authenticity. The webhook is accepted if it was signed
+	// with any of the keys, which allows the old and new
+	// secret to be used side by side while rotating.
+	SecretsFunc func(webhook Webhook) ([]string, error)
+
 	// WebhookService provides abstract functions for
 	// parsing and validating webhooks requests.
 	WebhookService interface {
 		// Parse returns the parsed the repository webhook payload.
 		Parse(req *http.Request, fn SecretFunc) (Webhook, error)
+
+		// ParseWithSecrets returns the parsed repository webhook
+		// payload, validated against any of the candidate secrets.
+		ParseWithSecrets(req *http.Request, fn SecretsFunc) (Webhook, error)
+	}
+
+	// WebhookEncoder provides functions for rendering webhooks
+	// as provider native http requests. It is the inverse of
+	// WebhookService.Parse and is intended for testing webhook
+	// consumers without a live git provider.
+	WebhookEncoder interface {
+		// Encode returns the provider native webhook request,
+		// signed with the secret if it is not empty.
+		Encode(webhook Webhook, secret string) (*http.Request, error)
 	}
 )
 
+// Secrets adapts the SecretFunc to a SecretsFunc returning
+// its single secret key, or no keys if the secret is empty.
+func (fn SecretFunc) Secrets() SecretsFunc {
+	return func(webhook Webhook) ([]string, error) {
+		key, err := fn(webhook)
+		if err != nil || key == "" {
+			return nil, err
+		}
+		return []string{key}, nil
+	}
+}
+
 // Kind returns the kind of webhook
 func (h *PingHook) Kind() WebhookKind { return WebhookKindPing }
 
@@ -462,9 +664,33 @@ func (h *WatchHook) Kind() WebhookKind { return WebhookKindWatch }
 // Kind returns the kind of webhook
 func (h *StarHook) Kind() WebhookKind { return WebhookKindStar }
 
+// Kind returns the kind of webhook
+func (h *MergeGroupHook) Kind() WebhookKind { return WebhookKindMergeGroup }
+
+// Kind returns the kind of webhook
+func (h *PipelineHook) Kind() WebhookKind { return WebhookKindPipeline }
+
+// Kind returns the kind of webhook
+func (h *JobHook) Kind() WebhookKind { return WebhookKindJob }
+
+// Kind returns the kind of webhook
+func (h *WikiPageHook) Kind() WebhookKind { return WebhookKindWikiPage }
+
+// Kind returns the kind of webhook
+func (h *MemberHook) Kind() WebhookKind { return WebhookKindMember }
+
+// Kind returns the kind of webhook
+func (h *OrganizationHook) Kind() WebhookKind { return WebhookKindOrganization }
+
+// Kind returns the kind of webhook
+func (h *TeamHook) Kind() WebhookKind { return WebhookKindTeam }
+
+// Kind returns the kind of webhook
+func (h *ReviewThreadHook) Kind() WebhookKind { return WebhookKindReviewThread }
+
 // Repository defines the repository webhook and provides a convenient way to get the associated repository without
 // having to cast the type.
-func (h *PingHook) Repository() Repository { return h.Repo }
+func (h *PingHook) Repository() Repository { return orgRepository(h.Repo, h.Org) }
 
 // Repository defines the repository webhook and provides a convenient way to get the associated repository without
 // having to cast the type.
@@ -546,6 +772,49 @@ func (h *WatchHook) Repository() Repository { return h.Repo }
 // having to cast the type.
 func (h *StarHook) Repository() Repository { return h.Repo }
 
+// Repository defines the repository webhook and provides a convenient way to get the associated repository without
+// having to cast the type.
+func (h *MergeGroupHook) Repository() Repository { return h.Repo }
+
+// Repository defines the repository webhook and provides a convenient way to get the associated repository without
+// having to cast the type.
+func (h *PipelineHook) Repository() Repository { return h.Repo }
+
+// Repository defines the repository webhook and provides a convenient way to get the associated repository without
+// having to cast the type.
+func (h *JobHook) Repository() Repository { return h.Repo }
+
+// Repository defines the repository webhook and provides a convenient way to get the associated repository without
+// having to cast the type.
+func (h *WikiPageHook) Repository() Repository { return h.Repo }
+
+// Repository defines the repository webhook and provides a convenient way to get the associated repository without
+// having to cast the type.
+func (h *MemberHook) Repository() Repository { return orgRepository(h.Repo, h.Org) }
+
+// Repository returns a repository with only the namespace
+// set, as organization events are not associated with a
+// repository.
+func (h *OrganizationHook) Repository() Repository { return orgRepository(Repository{}, h.Org) }
+
+// Repository defines the repository webhook and provides a convenient way to get the associated repository without
+// having to cast the type.
+func (h *TeamHook) Repository() Repository { return orgRepository(h.Repo, h.Org) }
+
+// orgRepository returns the repository, or a repository with
+// only the organization namespace set if the event was sent
+// by an organization webhook without a repository.
+func orgRepository(repo Repository, org Organization) Repository {
+	if repo.Name == "" && repo.Namespace == "" {
+		repo.Namespace = org.Name
+	}
+	return repo
+}
+
+// Repository defines the repository webhook and provides a convenient way to get the associated repository without
+// having to cast the type.
+func (h *ReviewThreadHook) Repository() Repository { return h.Repo }
+
 // Repository defines the repository webhook and provides a convenient way to get the associated repository without
 // having to cast the type.
 func (h *InstallationHook) Repository() Repository {
@@ -648,6 +917,38 @@ func (h *WatchHook) GetInstallationRef() *InstallationRef { return h.Installatio
 // GitHub App
 func (h *StarHook) GetInstallationRef() *InstallationRef { return nil }
 
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *MergeGroupHook) GetInstallationRef() *InstallationRef { return h.Installation }
+
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *PipelineHook) GetInstallationRef() *InstallationRef { return h.Installation }
+
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *JobHook) GetInstallationRef() *InstallationRef { return h.Installation }
+
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *WikiPageHook) GetInstallationRef() *InstallationRef { return h.Installation }
+
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *MemberHook) GetInstallationRef() *InstallationRef { return h.Installation }
+
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *OrganizationHook) GetInstallationRef() *InstallationRef { return h.Installation }
+
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *TeamHook) GetInstallationRef() *InstallationRef { return h.Installation }
+
+// GetInstallationRef returns the installation reference if the webhook is invoked on a
+// GitHub App
+func (h *ReviewThreadHook) GetInstallationRef() *InstallationRef { return h.Installation }
+
 // GetInstallationRef returns the installation reference if the webhook is invoked on a
 // GitHub App
 func (h *InstallationHook) GetInstallationRef() *InstallationRef {
@@ -708,6 +1009,9 @@ func (h *WebhookWrapper) ToWebhook() (Webhook, error) {
 	if h.IssueCommentHook != nil {
 		return h.IssueCommentHook, nil
 	}
+	if h.JobHook != nil {
+		return h.JobHook, nil
+	}
 	if h.InstallationHook != nil {
 		return h.InstallationHook, nil
 	}
@@ -717,6 +1021,18 @@ func (h *WebhookWrapper) ToWebhook() (Webhook, error) {
 	if h.LabelHook != nil {
 		return h.LabelHook, nil
 	}
+	if h.MemberHook != nil {
+		return h.MemberHook, nil
+	}
+	if h.MergeGroupHook != nil {
+		return h.MergeGroupHook, nil
+	}
+	if h.OrganizationHook != nil {
+		return h.OrganizationHook, nil
+	}
+	if h.PipelineHook != nil {
+		return h.PipelineHook, nil
+	}
 	if h.RepositoryHook != nil {
 		return h.RepositoryHook, nil
 	}
@@ -729,11 +1045,20 @@ func (h *WebhookWrapper) ToWebhook() (Webhook, error) {
 	if h.ReviewCommentHook != nil {
 		return h.ReviewCommentHook, nil
 	}
+	if h.ReviewThreadHook != nil {
+		return h.ReviewThreadHook, nil
+	}
+	if h.TeamHook != nil {
+		return h.TeamHook, nil
+	}
 	if h.WatchHook != nil {
 		return h.WatchHook, nil
 	}
 	if h.StarHook != nil {
 		return h.StarHook, nil
 	}
+	if h.WikiPageHook != nil {
+		return h.WikiPageHook, nil
+	}
 	return nil, fmt.Errorf("unsupported webhook")
 }
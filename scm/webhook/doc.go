@@ -0,0 +1,9 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package webhook provides an http.Handler that parses and
+// validates incoming webhooks, discards duplicate deliveries
+// and dispatches the parsed hooks to typed callbacks using a
+// bounded pool of workers.
+package webhook
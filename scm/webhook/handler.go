@@ -0,0 +1,454 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/jenkins-x/go-scm/scm/factory"
+	"github.com/sirupsen/logrus"
+)
+
+// maxPayloadSize matches the payload limit of the drivers.
+const maxPayloadSize = 10000000
+
+// ErrSecretRequired is returned by New when the handler is
+// configured without a secret and signature verification has
+// not been explicitly disabled.
+var ErrSecretRequired = errors.New("webhook: a secret is required unless signature verification is disabled")
+
+// Handler is an http.Handler that parses and validates
+// webhooks, discards duplicate deliveries and dispatches the
+// parsed hooks to the registered callbacks.
+//
+// Webhooks are acknowledged as soon as they are validated
+// and processed asynchronously by a bounded pool of workers,
+// unless the handler is configured with no workers, in which
+// case the callbacks run before the response is written and a
+// failed callback is reported to the provider so that the
+// delivery is retried.
+type Handler struct {
+	service scm.WebhookService
+	secrets scm.SecretsFunc
+	store   Store
+	// insecure is set when signature verification is disabled.
+	insecure bool
+	workers  int
+	size     int
+	log      logrus.FieldLogger
+	onError  func(hook scm.Webhook, err error)
+
+	mu        sync.RWMutex
+	callbacks []func(ctx context.Context, hook scm.Webhook) error
+	closed    bool
+	queue     chan scm.Webhook
+	wg        sync.WaitGroup
+}
+
+// Option configures a Handler.
+type Option func(*Handler)
+
+// WithService sets the webhook service used to parse the
+// webhooks. By default the driver is detected from each
+// request using factory.DetectWebhookDriver, and the secret of
+// azure webhooks is validated unless signature verification
+// is disabled.
+func WithService(service scm.WebhookService) Option {
+	return func(h *Handler) {
+		h.service = service
+	}
+}
+
+// WithSecret sets the secret used to validate the webhooks.
+func WithSecret(secret string) Option {
+	return WithSecretFunc(func(scm.Webhook) (string, error) {
+		return secret, nil
+	})
+}
+
+// WithSecretFunc sets the function providing the secret used
+// to validate the webhooks.
+func WithSecretFunc(fn scm.SecretFunc) Option {
+	return WithSecrets(fn.Secrets())
+}
+
+// WithSecrets sets the function providing the candidate
+// secrets used to validate the webhooks.
+func WithSecrets(fn scm.SecretsFunc) Option {
+	return func(h *Handler) {
+		h.secrets = fn
+		h.insecure = false
+	}
+}
+
+// WithoutSignatureVerification disables the validation of the
+// webhook signatures, so that any request is accepted. It
+// should only be used when the webhooks are authenticated by
+// other means.
+func WithoutSignatureVerification() Option {
+	return func(h *Handler) {
+		h.secrets = nil
+		h.insecure = true
+	}
+}
+
+// WithStore sets the store used to discard duplicate
+// deliveries. By default delivery ids are kept in memory for
+// one hour.
+func WithStore(store Store) Option {
+	return func(h *Handler) {
+		h.store = store
+	}
+}
+
+// WithWorkers sets the number of workers processing the
+// webhooks and the number of webhooks that can be queued
+// before the handler starts rejecting requests. If workers
+// is zero, webhooks are processed synchronously.
+func WithWorkers(workers, queueSize int) Option {
+	return func(h *Handler) {
+		h.workers = workers
+		h.size = queueSize
+	}
+}
+
+// WithErrorHandler sets the function called when a callback
+// returns an error or panics.
+func WithErrorHandler(fn func(hook scm.Webhook, err error)) Option {
+	return func(h *Handler) {
+		h.onError = fn
+	}
+}
+
+// WithLogger sets the logger.
+func WithLogger(log logrus.FieldLogger) Option {
+	return func(h *Handler) {
+		h.log = log
+	}
+}
+
+// New returns a new webhook Handler and starts its workers.
+// Close should be called to stop the workers once the handler
+// is no longer serving requests. A secret is required, unless
+// WithoutSignatureVerification is used.
+func New(opts ...Option) (*Handler, error) {
+	h := &Handler{
+		store:   NewMemoryStore(time.Hour),
+		workers: 4,
+		size:    100,
+		log:     logrus.StandardLogger(),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	if h.secrets == nil {
+		if !h.insecure {
+			return nil, ErrSecretRequired
+		}
+		h.secrets = func(scm.Webhook) ([]string, error) { return nil, nil }
+	}
+	if h.service == nil {
+		h.service = factory.NewDetectingWebHookServiceWithSecretValidation()
+		if h.insecure {
+			h.service = factory.NewDetectingWebHookService()
+		}
+	}
+	if h.onError == nil {
+		h.onError = func(hook scm.Webhook, err error) {
+			h.log.WithError(err).WithField("Kind", hook.Kind()).Error("failed to handle webhook")
+		}
+	}
+	if h.workers > 0 {
+		h.queue = make(chan scm.Webhook, h.size)
+		for i := 0; i < h.workers; i++ {
+			h.wg.Add(1)
+			go h.work()
+		}
+	}
+	return h, nil
+}
+
+// OnWebhook registers a callback invoked for every webhook.
+func (h *Handler) OnWebhook(fn func(ctx context.Context, hook scm.Webhook) error) {
+	h.mu.Lock()
+	h.callbacks = append(h.callbacks, fn)
+	h.mu.Unlock()
+}
+
+// OnPush registers a callback invoked for push webhooks.
+func (h *Handler) OnPush(fn func(ctx context.Context, hook *scm.PushHook) error) {
+	h.OnWebhook(func(ctx context.Context, hook scm.Webhook) error {
+		if v, ok := hook.(*scm.PushHook); ok {
+			return fn(ctx, v)
+		}
+		return nil
+	})
+}
+
+// OnBranch registers a callback invoked for branch webhooks.
+func (h *Handler) OnBranch(fn func(ctx context.Context, hook *scm.BranchHook) error) {
+	h.OnWebhook(func(ctx context.Context, hook scm.Webhook) error {
+		if v, ok := hook.(*scm.BranchHook); ok {
+			return fn(ctx, v)
+		}
+		return nil
+	})
+}
+
+// OnTag registers a callback invoked for tag webhooks.
+func (h *Handler) OnTag(fn func(ctx context.Context, hook *scm.TagHook) error) {
+	h.OnWebhook(func(ctx context.Context, hook scm.Webhook) error {
+		if v, ok := hook.(*scm.TagHook); ok {
+			return fn(ctx, v)
+		}
+		return nil
+	})
+}
+
+// OnIssue registers a callback invoked for issue webhooks. If
+// actions are provided, the callback is only invoked for
+// webhooks with one of the actions.
+func (h *Handler) OnIssue(fn func(ctx context.Context, hook *scm.IssueHook) error, actions ...scm.Action) {
+	h.OnWebhook(func(ctx context.Context, hook scm.Webhook) error {
+		if v, ok := hook.(*scm.IssueHook); ok && matchAction(v.Action, actions) {
+			return fn(ctx, v)
+		}
+		return nil
+	})
+}
+
+// OnIssueComment registers a callback invoked for issue
+// comment webhooks.
+func (h *Handler) OnIssueComment(fn func(ctx context.Context, hook *scm.IssueCommentHook) error) {
+	h.OnWebhook(func(ctx context.Context, hook scm.Webhook) error {
+		if v, ok := hook.(*scm.IssueCommentHook); ok {
+			return fn(ctx, v)
+		}
+		return nil
+	})
+}
+
+// OnPullRequest registers a callback invoked for pull request
+// webhooks. If actions are provided, the callback is only
+// invoked for webhooks with one of the actions.
+func (h *Handler) OnPullRequest(fn func(ctx context.Context, hook *scm.PullRequestHook) error, actions ...scm.Action) {
+	h.OnWebhook(func(ctx context.Context, hook scm.Webhook) error {
+		if v, ok := hook.(*scm.PullRequestHook); ok && matchAction(v.Action, actions) {
+			return fn(ctx, v)
+		}
+		return nil
+	})
+}
+
+// OnPullRequestComment registers a callback invoked for pull
+// request comment webhooks.
+func (h *Handler) OnPullRequestComment(fn func(ctx context.Context, hook *scm.PullRequestCommentHook) error) {
+	h.OnWebhook(func(ctx context.Context, hook scm.Webhook) error {
+		if v, ok := hook.(*scm.PullRequestCommentHook); ok {
+			return fn(ctx, v)
+		}
+		return nil
+	})
+}
+
+// OnReview registers a callback invoked for pull request
+// review webhooks.
+func (h *Handler) OnReview(fn func(ctx context.Context, hook *scm.ReviewHook) error) {
+	h.OnWebhook(func(ctx context.Context, hook scm.Webhook) error {
+		if v, ok := hook.(*scm.ReviewHook); ok {
+			return fn(ctx, v)
+		}
+		return nil
+	})
+}
+
+// ServeHTTP parses, validates and dispatches the webhook.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	data, err := io.ReadAll(io.LimitReader(req.Body, maxPayloadSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	req.Body = io.NopCloser(bytes.NewReader(data))
+
+	hook, err := h.service.ParseWithSecrets(req, h.secrets)
+	switch {
+	case scm.IsUnknownWebhook(err) || err == scm.ErrUnknownEvent:
+		// acknowledge events we do not handle so that the
+		// provider does not report the delivery as failed.
+		h.log.WithError(err).Debug("ignoring webhook")
+		w.WriteHeader(http.StatusOK)
+		return
+	case err == scm.ErrSignatureInvalid:
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	case err != nil:
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case hook == nil:
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	id := deliveryID(req, data)
+	if id != "" {
+		added, err := h.store.Add(req.Context(), id)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		if !added {
+			h.log.WithField("Delivery", id).Debug("ignoring duplicate webhook")
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	if h.workers == 0 {
+		if err := h.dispatch(req.Context(), hook); err != nil {
+			h.forget(req.Context(), id)
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if !h.enqueue(hook) {
+		h.forget(req.Context(), id)
+		http.Error(w, "webhook queue is full", http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// forget removes the delivery from the store so that it is
+// processed when the provider retries it.
+func (h *Handler) forget(ctx context.Context, id string) {
+	if id == "" {
+		return
+	}
+	if err := h.store.Remove(ctx, id); err != nil {
+		h.log.WithError(err).WithField("Delivery", id).Warn("failed to remove webhook delivery")
+	}
+}
+
+// Close stops accepting webhooks and waits for the queued
+// webhooks to be processed.
+func (h *Handler) Close() {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return
+	}
+	h.closed = true
+	if h.queue != nil {
+		close(h.queue)
+	}
+	h.mu.Unlock()
+	h.wg.Wait()
+}
+
+func (h *Handler) enqueue(hook scm.Webhook) bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.closed {
+		return false
+	}
+	select {
+	case h.queue <- hook:
+		return true
+	default:
+		return false
+	}
+}
+
+func (h *Handler) work() {
+	defer h.wg.Done()
+	for hook := range h.queue {
+		_ = h.dispatch(context.Background(), hook)
+	}
+}
+
+// dispatch invokes the callbacks and returns the first error,
+// after reporting every error to the error handler.
+func (h *Handler) dispatch(ctx context.Context, hook scm.Webhook) error {
+	h.mu.RLock()
+	callbacks := h.callbacks
+	h.mu.RUnlock()
+
+	var first error
+	for _, fn := range callbacks {
+		if err := call(ctx, fn, hook); err != nil {
+			h.onError(hook, err)
+			if first == nil {
+				first = err
+			}
+		}
+	}
+	return first
+}
+
+// call invokes the callback, recovering from panics so that
+// a misbehaving callback does not stop the worker.
+func call(ctx context.Context, fn func(context.Context, scm.Webhook) error, hook scm.Webhook) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic handling %s webhook: %v", hook.Kind(), r)
+		}
+	}()
+	return fn(ctx, hook)
+}
+
+func matchAction(action scm.Action, actions []scm.Action) bool {
+	if len(actions) == 0 {
+		return true
+	}
+	for _, a := range actions {
+		if a == action {
+			return true
+		}
+	}
+	return false
+}
+
+// deliveryHeaders are the headers carrying the unique id of a
+// webhook delivery for each provider.
+var deliveryHeaders = []string{
+	"X-Gitea-Delivery",
+	"X-Gogs-Delivery",
+	"X-GitHub-Delivery",
+	"X-Gitlab-Event-UUID",
+	"X-Request-UUID",
+}
+
+// deliveryID returns the unique id of the webhook delivery,
+// or an empty string if the provider does not send one.
+func deliveryID(req *http.Request, data []byte) string {
+	for _, name := range deliveryHeaders {
+		if id := req.Header.Get(name); id != "" {
+			return id
+		}
+	}
+
+	// azure devops sends the notification id in the payload.
+	payload := struct {
+		ID        string `json:"id"`
+		EventType string `json:"eventType"`
+	}{}
+	if err := json.Unmarshal(data, &payload); err == nil && payload.EventType != "" {
+		return payload.ID
+	}
+	return ""
+}
@@ -0,0 +1,288 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRequest(t *testing.T, file, event, delivery string) *http.Request {
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	req := httptest.NewRequest("POST", "/hook", bytes.NewReader(data))
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", delivery)
+	// the sha can be recalculated with the below command
+	// openssl dgst -sha256 -hmac <secret> <file>
+	req.Header.Set("X-Hub-Signature-256", "sha256=951ebeea37401e9f8519e45d66d1fe09cdbfb5fe09c0620a781b180d548dd6e1")
+	return req
+}
+
+func newHandler(t *testing.T, opts ...Option) *Handler {
+	h, err := New(opts...)
+	require.NoError(t, err)
+	return h
+}
+
+func serve(h http.Handler, req *http.Request) int {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w.Code
+}
+
+func TestHandler_Dispatch(t *testing.T) {
+	h := newHandler(t, WithSecret("topsecret"), WithWorkers(0, 0))
+
+	var pushes, pullRequests, any int
+	h.OnPush(func(ctx context.Context, hook *scm.PushHook) error {
+		pushes++
+		assert.Equal(t, "refs/heads/master", hook.Ref)
+		return nil
+	})
+	h.OnPullRequest(func(ctx context.Context, hook *scm.PullRequestHook) error {
+		pullRequests++
+		return nil
+	})
+	h.OnWebhook(func(ctx context.Context, hook scm.Webhook) error {
+		any++
+		return nil
+	})
+
+	code := serve(h, newRequest(t, "../driver/github/testdata/webhooks/push.json", "push", "1"))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 1, pushes)
+	assert.Equal(t, 0, pullRequests)
+	assert.Equal(t, 1, any)
+}
+
+func TestHandler_PullRequestActions(t *testing.T) {
+	h := newHandler(t, WithoutSignatureVerification(), WithWorkers(0, 0))
+
+	var opened, closed int
+	h.OnPullRequest(func(ctx context.Context, hook *scm.PullRequestHook) error {
+		opened++
+		return nil
+	}, scm.ActionOpen)
+	h.OnPullRequest(func(ctx context.Context, hook *scm.PullRequestHook) error {
+		closed++
+		return nil
+	}, scm.ActionClose)
+
+	code := serve(h, newRequest(t, "../driver/github/testdata/webhooks/pr_closed.json", "pull_request", "1"))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 0, opened)
+	assert.Equal(t, 1, closed)
+}
+
+func TestHandler_Duplicate(t *testing.T) {
+	h := newHandler(t, WithSecret("topsecret"), WithWorkers(0, 0))
+
+	var pushes int
+	h.OnPush(func(ctx context.Context, hook *scm.PushHook) error {
+		pushes++
+		return nil
+	})
+
+	serve(h, newRequest(t, "../driver/github/testdata/webhooks/push.json", "push", "1"))
+	serve(h, newRequest(t, "../driver/github/testdata/webhooks/push.json", "push", "1"))
+	serve(h, newRequest(t, "../driver/github/testdata/webhooks/push.json", "push", "2"))
+	assert.Equal(t, 2, pushes)
+}
+
+func TestHandler_SignatureInvalid(t *testing.T) {
+	h := newHandler(t, WithSecret("wrongsecret"), WithWorkers(0, 0))
+	h.OnPush(func(ctx context.Context, hook *scm.PushHook) error {
+		t.Error("callback should not be invoked for an invalid signature")
+		return nil
+	})
+
+	code := serve(h, newRequest(t, "../driver/github/testdata/webhooks/push.json", "push", "1"))
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestHandler_UnknownEvent(t *testing.T) {
+	h := newHandler(t, WithSecret("topsecret"), WithWorkers(0, 0))
+
+	code := serve(h, newRequest(t, "../driver/github/testdata/webhooks/push.json", "unknown", "1"))
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestHandler_UnknownDriver(t *testing.T) {
+	h := newHandler(t, WithSecret("topsecret"), WithWorkers(0, 0))
+
+	req := httptest.NewRequest("POST", "/hook", nil)
+	code := serve(h, req)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestHandler_Errors(t *testing.T) {
+	var errs []error
+	h := newHandler(t, WithSecret("topsecret"), WithWorkers(0, 0), WithErrorHandler(func(hook scm.Webhook, err error) {
+		errs = append(errs, err)
+	}))
+	h.OnPush(func(ctx context.Context, hook *scm.PushHook) error {
+		return errors.New("failed")
+	})
+	h.OnPush(func(ctx context.Context, hook *scm.PushHook) error {
+		panic("oops")
+	})
+
+	code := serve(h, newRequest(t, "../driver/github/testdata/webhooks/push.json", "push", "1"))
+	assert.Equal(t, http.StatusInternalServerError, code)
+	require.Len(t, errs, 2)
+	assert.EqualError(t, errs[0], "failed")
+	assert.EqualError(t, errs[1], "panic handling push webhook: oops")
+
+	// the failed delivery is forgotten so that the retry is processed
+	code = serve(h, newRequest(t, "../driver/github/testdata/webhooks/push.json", "push", "1"))
+	assert.Equal(t, http.StatusInternalServerError, code)
+	assert.Len(t, errs, 4)
+}
+
+func TestNew_SecretRequired(t *testing.T) {
+	_, err := New(WithWorkers(0, 0))
+	assert.Equal(t, ErrSecretRequired, err)
+
+	_, err = New(WithSecrets(nil))
+	assert.Equal(t, ErrSecretRequired, err)
+
+	h, err := New(WithoutSignatureVerification(), WithWorkers(0, 0))
+	require.NoError(t, err)
+	h.Close()
+}
+
+func TestDeliveryID_GenericRequestID(t *testing.T) {
+	req := httptest.NewRequest("POST", "/hook", nil)
+	req.Header.Set("X-Request-Id", "added-by-a-proxy")
+	assert.Equal(t, "", deliveryID(req, []byte(`{}`)))
+}
+
+func TestHandler_Async(t *testing.T) {
+	h := newHandler(t, WithSecret("topsecret"), WithWorkers(2, 10))
+
+	var mu sync.Mutex
+	var pushes int
+	h.OnPush(func(ctx context.Context, hook *scm.PushHook) error {
+		mu.Lock()
+		pushes++
+		mu.Unlock()
+		return nil
+	})
+
+	for _, id := range []string{"1", "2", "3"} {
+		code := serve(h, newRequest(t, "../driver/github/testdata/webhooks/push.json", "push", id))
+		assert.Equal(t, http.StatusAccepted, code)
+	}
+	h.Close()
+	assert.Equal(t, 3, pushes)
+
+	code := serve(h, newRequest(t, "../driver/github/testdata/webhooks/push.json", "push", "4"))
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+func TestHandler_QueueFull(t *testing.T) {
+	h := newHandler(t, WithSecret("topsecret"), WithWorkers(1, 1))
+	defer h.Close()
+
+	block := make(chan struct{})
+	started := make(chan struct{})
+	h.OnPush(func(ctx context.Context, hook *scm.PushHook) error {
+		if hook.GUID == "1" {
+			close(started)
+			<-block
+		}
+		return nil
+	})
+
+	assert.Equal(t, http.StatusAccepted, serve(h, newRequest(t, "../driver/github/testdata/webhooks/push.json", "push", "1")))
+	<-started
+	assert.Equal(t, http.StatusAccepted, serve(h, newRequest(t, "../driver/github/testdata/webhooks/push.json", "push", "2")))
+	assert.Equal(t, http.StatusServiceUnavailable, serve(h, newRequest(t, "../driver/github/testdata/webhooks/push.json", "push", "3")))
+	close(block)
+
+	// the rejected delivery is forgotten so that the retry is accepted
+	added, err := h.store.Add(context.Background(), "3")
+	require.NoError(t, err)
+	assert.True(t, added)
+}
+
+func TestDeliveryID(t *testing.T) {
+	req := httptest.NewRequest("POST", "/hook", nil)
+	req.Header.Set("X-Gitlab-Event-UUID", "13792a45-8b5f-4f4b-8f3c-8d6b4d4f4d2e")
+	assert.Equal(t, "13792a45-8b5f-4f4b-8f3c-8d6b4d4f4d2e", deliveryID(req, nil))
+
+	data, err := os.ReadFile("../driver/azure/testdata/webhooks/push.json")
+	require.NoError(t, err)
+	req = httptest.NewRequest("POST", "/hook", nil)
+	assert.Equal(t, "03c164c2-8912-4d5e-8009-3707d5f83734", deliveryID(req, data))
+
+	assert.Equal(t, "", deliveryID(req, []byte(`{}`)))
+}
+
+func TestHandler_DetectDriver(t *testing.T) {
+	h := newHandler(t, WithoutSignatureVerification(), WithWorkers(0, 0))
+
+	var repos []string
+	h.OnPush(func(ctx context.Context, hook *scm.PushHook) error {
+		repos = append(repos, hook.Repo.FullName)
+		return nil
+	})
+
+	data, err := os.ReadFile("../driver/azure/testdata/webhooks/push.json")
+	require.NoError(t, err)
+	code := serve(h, httptest.NewRequest("POST", "/hook", bytes.NewReader(data)))
+	assert.Equal(t, http.StatusOK, code)
+
+	data, err = os.ReadFile("../driver/gitlab/testdata/webhooks/push.json")
+	require.NoError(t, err)
+	req := httptest.NewRequest("POST", "/hook", bytes.NewReader(data))
+	req.Header.Set("X-Gitlab-Event", "Push Hook")
+	code = serve(h, req)
+	assert.Equal(t, http.StatusOK, code)
+
+	assert.Len(t, repos, 2)
+}
+
+func TestHandler_AzureSecret(t *testing.T) {
+	h := newHandler(t, WithSecret("topsecret"), WithWorkers(0, 0))
+	h.OnPush(func(ctx context.Context, hook *scm.PushHook) error {
+		t.Error("callback should not be invoked for an unsigned request")
+		return nil
+	})
+
+	data, err := os.ReadFile("../driver/azure/testdata/webhooks/push.json")
+	require.NoError(t, err)
+	code := serve(h, httptest.NewRequest("POST", "/hook", bytes.NewReader(data)))
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestHandler_AzureSecretValid(t *testing.T) {
+	h := newHandler(t, WithSecret("topsecret"), WithWorkers(0, 0))
+
+	var pushes int
+	h.OnPush(func(ctx context.Context, hook *scm.PushHook) error {
+		pushes++
+		return nil
+	})
+
+	data, err := os.ReadFile("../driver/azure/testdata/webhooks/push.json")
+	require.NoError(t, err)
+	req := httptest.NewRequest("POST", "/hook", bytes.NewReader(data))
+	req.SetBasicAuth("jenkins", "topsecret")
+	code := serve(h, req)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 1, pushes)
+}
@@ -0,0 +1,75 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package webhook
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Store records the delivery ids of webhooks that have been
+// accepted so that redelivered webhooks are processed once.
+type Store interface {
+	// Add records the delivery id, returning false if the id
+	// was already recorded.
+	Add(ctx context.Context, id string) (bool, error)
+
+	// Remove forgets the delivery id so that a redelivery of
+	// the webhook is processed.
+	Remove(ctx context.Context, id string) error
+}
+
+// NewMemoryStore returns a Store that keeps delivery ids in
+// memory for the given duration.
+func NewMemoryStore(ttl time.Duration) Store {
+	return &memoryStore{
+		ttl:  ttl,
+		ids:  map[string]time.Time{},
+		now:  time.Now,
+		next: time.Now().Add(ttl),
+	}
+}
+
+type memoryStore struct {
+	sync.Mutex
+	ttl  time.Duration
+	ids  map[string]time.Time
+	now  func() time.Time
+	next time.Time
+}
+
+func (s *memoryStore) Add(_ context.Context, id string) (bool, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	now := s.now()
+	if now.After(s.next) {
+		s.expire(now)
+	}
+	if expires, ok := s.ids[id]; ok && now.Before(expires) {
+		return false, nil
+	}
+	s.ids[id] = now.Add(s.ttl)
+	return true, nil
+}
+
+func (s *memoryStore) Remove(_ context.Context, id string) error {
+	s.Lock()
+	delete(s.ids, id)
+	s.Unlock()
+	return nil
+}
+
+// expire removes the expired delivery ids. It is run at
+// most once per ttl to keep Add cheap.
+func (s *memoryStore) expire(now time.Time) {
+	for id, expires := range s.ids {
+		if !now.Before(expires) {
+			delete(s.ids, id)
+		}
+	}
+	s.next = now.Add(s.ttl)
+}
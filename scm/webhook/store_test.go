@@ -0,0 +1,45 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package webhook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryStore(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	store := NewMemoryStore(time.Minute).(*memoryStore)
+	store.now = func() time.Time { return now }
+	store.next = now.Add(time.Minute)
+
+	added, err := store.Add(ctx, "1")
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	added, err = store.Add(ctx, "1")
+	require.NoError(t, err)
+	assert.False(t, added, "duplicate delivery should not be added")
+
+	require.NoError(t, store.Remove(ctx, "1"))
+	added, err = store.Add(ctx, "1")
+	require.NoError(t, err)
+	assert.True(t, added, "removed delivery should be added again")
+
+	now = now.Add(2 * time.Minute)
+	added, err = store.Add(ctx, "2")
+	require.NoError(t, err)
+	assert.True(t, added)
+	assert.NotContains(t, store.ids, "1", "expired delivery should be removed")
+
+	added, err = store.Add(ctx, "1")
+	require.NoError(t, err)
+	assert.True(t, added, "expired delivery should be added again")
+}
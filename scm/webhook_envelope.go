@@ -0,0 +1,168 @@
+// Copyright 2017 Drone.IO Inc. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package scm
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WebhookSchemaVersion is the version of the webhook envelope
+// written by MarshalWebhook. It is incremented when the encoding
+// of a webhook changes in a way older readers cannot decode.
+const WebhookSchemaVersion = 1
+
+type (
+	// WebhookEnvelope describes a webhook serialized for storage
+	// or for forwarding through a message queue.
+	WebhookEnvelope struct {
+		Kind          WebhookKind     `json:"kind"`
+		Version       int             `json:"version"`
+		Driver        string          `json:"driver,omitempty"`
+		GUID          string          `json:"guid,omitempty"`
+		ReceivedAt    time.Time       `json:"received_at"`
+		HeadersDigest string          `json:"headers_digest,omitempty"`
+		Payload       json.RawMessage `json:"payload"`
+	}
+
+	// WebhookMeta provides the delivery metadata recorded in the
+	// webhook envelope.
+	WebhookMeta struct {
+		// Driver is the name of the driver that parsed the
+		// webhook, eg github.
+		Driver string
+
+		// GUID is the provider delivery identifier.
+		GUID string
+
+		// ReceivedAt is the time the webhook was received. The
+		// current time is used if it is zero.
+		ReceivedAt time.Time
+
+		// HeadersDigest is the digest of the original request
+		// headers, as returned by WebhookHeadersDigest.
+		HeadersDigest string
+	}
+)
+
+// webhookKinds returns a new, empty webhook for each kind.
+var webhookKinds = map[WebhookKind]func() Webhook{
+	WebhookKindBranch:                 func() Webhook { return new(BranchHook) },
+	WebhookKindCheckRun:               func() Webhook { return new(CheckRunHook) },
+	WebhookKindCheckSuite:             func() Webhook { return new(CheckSuiteHook) },
+	WebhookKindDeploy:                 func() Webhook { return new(DeployHook) },
+	WebhookKindDeploymentStatus:       func() Webhook { return new(DeploymentStatusHook) },
+	WebhookKindFork:                   func() Webhook { return new(ForkHook) },
+	WebhookKindInstallation:           func() Webhook { return new(InstallationHook) },
+	WebhookKindInstallationRepository: func() Webhook { return new(InstallationRepositoryHook) },
+	WebhookKindIssue:                  func() Webhook { return new(IssueHook) },
+	WebhookKindIssueComment:           func() Webhook { return new(IssueCommentHook) },
+	WebhookKindJob:                    func() Webhook { return new(JobHook) },
+	WebhookKindMergeGroup:             func() Webhook { return new(MergeGroupHook) },
+	WebhookKindLabel:                  func() Webhook { return new(LabelHook) },
+	WebhookKindMember:                 func() Webhook { return new(MemberHook) },
+	WebhookKindOrganization:           func() Webhook { return new(OrganizationHook) },
+	WebhookKindPipeline:               func() Webhook { return new(PipelineHook) },
+	WebhookKindPing:                   func() Webhook { return new(PingHook) },
+	WebhookKindPullRequest:            func() Webhook { return new(PullRequestHook) },
+	WebhookKindPullRequestComment:     func() Webhook { return new(PullRequestCommentHook) },
+	WebhookKindPush:                   func() Webhook { return new(PushHook) },
+	WebhookKindRelease:                func() Webhook { return new(ReleaseHook) },
+	WebhookKindRepository:             func() Webhook { return new(RepositoryHook) },
+	WebhookKindReview:                 func() Webhook { return new(ReviewHook) },
+	WebhookKindReviewCommentHook:      func() Webhook { return new(ReviewCommentHook) },
+	WebhookKindReviewThread:           func() Webhook { return new(ReviewThreadHook) },
+	WebhookKindStar:                   func() Webhook { return new(StarHook) },
+	WebhookKindStatus:                 func() Webhook { return new(StatusHook) },
+	WebhookKindTag:                    func() Webhook { return new(TagHook) },
+	WebhookKindTeam:                   func() Webhook { return new(TeamHook) },
+	WebhookKindWatch:                  func() Webhook { return new(WatchHook) },
+	WebhookKindWikiPage:               func() Webhook { return new(WikiPageHook) },
+}
+
+// MarshalWebhook returns the webhook encoded as a versioned
+// JSON envelope, which can be decoded with UnmarshalWebhook.
+func MarshalWebhook(hook Webhook, meta WebhookMeta) ([]byte, error) {
+	if hook == nil {
+		return nil, fmt.Errorf("no webhook supplied")
+	}
+	kind := hook.Kind()
+	if _, ok := webhookKinds[kind]; !ok {
+		return nil, fmt.Errorf("unsupported webhook kind: %s", kind)
+	}
+	payload, err := json.Marshal(hook)
+	if err != nil {
+		return nil, err
+	}
+	receivedAt := meta.ReceivedAt
+	if receivedAt.IsZero() {
+		receivedAt = time.Now()
+	}
+	return json.Marshal(&WebhookEnvelope{
+		Kind:          kind,
+		Version:       WebhookSchemaVersion,
+		Driver:        meta.Driver,
+		GUID:          meta.GUID,
+		ReceivedAt:    receivedAt.UTC(),
+		HeadersDigest: meta.HeadersDigest,
+		Payload:       payload,
+	})
+}
+
+// UnmarshalWebhook decodes a webhook envelope created by
+// MarshalWebhook, returning the webhook and its delivery
+// metadata. Envelopes written with a newer schema version
+// are rejected.
+func UnmarshalWebhook(data []byte) (Webhook, *WebhookMeta, error) {
+	envelope := new(WebhookEnvelope)
+	if err := json.Unmarshal(data, envelope); err != nil {
+		return nil, nil, err
+	}
+	if envelope.Version < 1 || envelope.Version > WebhookSchemaVersion {
+		return nil, nil, fmt.Errorf("unsupported webhook schema version: %d", envelope.Version)
+	}
+	fn, ok := webhookKinds[envelope.Kind]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported webhook kind: %s", envelope.Kind)
+	}
+	hook := fn()
+	if err := json.Unmarshal(envelope.Payload, hook); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode %s webhook: %w", envelope.Kind, err)
+	}
+	return hook, &WebhookMeta{
+		Driver:        envelope.Driver,
+		GUID:          envelope.GUID,
+		ReceivedAt:    envelope.ReceivedAt,
+		HeadersDigest: envelope.HeadersDigest,
+	}, nil
+}
+
+// WebhookHeadersDigest returns the sha256 digest of the
+// webhook request headers. The digest does not depend on the
+// order or case of the header names.
+func WebhookHeadersDigest(header http.Header) string {
+	keys := make([]string, 0, len(header))
+	canonical := make(map[string][]string, len(header))
+	for k, v := range header {
+		k = http.CanonicalHeaderKey(k)
+		if _, ok := canonical[k]; !ok {
+			keys = append(keys, k)
+		}
+		canonical[k] = append(canonical[k], v...)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		fmt.Fprintf(h, "%s: %s\n", k, strings.Join(canonical[k], ", "))
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,172 @@
+package scm_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jenkins-x/go-scm/scm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalWebhook(t *testing.T) {
+	testCases := []struct {
+		kind scm.WebhookKind
+		obj  scm.Webhook
+	}{
+		{kind: scm.WebhookKindBranch, obj: new(scm.BranchHook)},
+		{kind: scm.WebhookKindCheckRun, obj: new(scm.CheckRunHook)},
+		{kind: scm.WebhookKindCheckSuite, obj: new(scm.CheckSuiteHook)},
+		{kind: scm.WebhookKindDeploy, obj: new(scm.DeployHook)},
+		{kind: scm.WebhookKindDeploymentStatus, obj: new(scm.DeploymentStatusHook)},
+		{kind: scm.WebhookKindFork, obj: new(scm.ForkHook)},
+		{kind: scm.WebhookKindInstallation, obj: new(scm.InstallationHook)},
+		{kind: scm.WebhookKindInstallationRepository, obj: new(scm.InstallationRepositoryHook)},
+		{kind: scm.WebhookKindIssue, obj: new(scm.IssueHook)},
+		{kind: scm.WebhookKindIssueComment, obj: new(scm.IssueCommentHook)},
+		{kind: scm.WebhookKindJob, obj: new(scm.JobHook)},
+		{kind: scm.WebhookKindMergeGroup, obj: new(scm.MergeGroupHook)},
+		{kind: scm.WebhookKindLabel, obj: new(scm.LabelHook)},
+		{kind: scm.WebhookKindMember, obj: new(scm.MemberHook)},
+		{kind: scm.WebhookKindOrganization, obj: new(scm.OrganizationHook)},
+		{kind: scm.WebhookKindPipeline, obj: new(scm.PipelineHook)},
+		{kind: scm.WebhookKindPing, obj: new(scm.PingHook)},
+		{kind: scm.WebhookKindPullRequest, obj: new(scm.PullRequestHook)},
+		{kind: scm.WebhookKindPullRequestComment, obj: new(scm.PullRequestCommentHook)},
+		{kind: scm.WebhookKindPush, obj: new(scm.PushHook)},
+		{kind: scm.WebhookKindRelease, obj: new(scm.ReleaseHook)},
+		{kind: scm.WebhookKindRepository, obj: new(scm.RepositoryHook)},
+		{kind: scm.WebhookKindReview, obj: new(scm.ReviewHook)},
+		{kind: scm.WebhookKindReviewCommentHook, obj: new(scm.ReviewCommentHook)},
+		{kind: scm.WebhookKindReviewThread, obj: new(scm.ReviewThreadHook)},
+		{kind: scm.WebhookKindStar, obj: new(scm.StarHook)},
+		{kind: scm.WebhookKindStatus, obj: new(scm.StatusHook)},
+		{kind: scm.WebhookKindTag, obj: new(scm.TagHook)},
+		{kind: scm.WebhookKindTeam, obj: new(scm.TeamHook)},
+		{kind: scm.WebhookKindWatch, obj: new(scm.WatchHook)},
+		{kind: scm.WebhookKindWikiPage, obj: new(scm.WikiPageHook)},
+	}
+
+	dir := filepath.Join("testdata", "webhooks", "envelope")
+	for _, tc := range testCases {
+		t.Run(string(tc.kind), func(t *testing.T) {
+			path := filepath.Join(dir, string(tc.kind)+".json")
+			data, err := os.ReadFile(path)
+			require.NoError(t, err, "failed to load file %s", path)
+
+			hook, meta, err := scm.UnmarshalWebhook(data)
+			require.NoError(t, err, "failed to unmarshal file %s", path)
+			require.IsType(t, tc.obj, hook)
+			assert.Equal(t, tc.kind, hook.Kind())
+			assert.NotEmpty(t, meta.Driver)
+			assert.Equal(t, "72d3162e-cc78-11e3-81ab-4c9367dc0958", meta.GUID)
+			assert.Equal(t, time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC), meta.ReceivedAt)
+			assert.Equal(t, "sha256:6fc83d791fb676fd68684f76e3c7df83aa07e7a442d105eccfdafd380a4b2e26", meta.HeadersDigest)
+
+			// every field of the stored envelope must survive a
+			// round trip, so that envelopes written by older
+			// releases keep their meaning. Fields added since the
+			// fixture was written are ignored.
+			out, err := scm.MarshalWebhook(hook, *meta)
+			require.NoError(t, err)
+			var want, got interface{}
+			require.NoError(t, json.Unmarshal(data, &want))
+			require.NoError(t, json.Unmarshal(out, &got))
+			assertSubset(t, "envelope", want, got)
+		})
+	}
+}
+
+// assertSubset asserts the decoded json value got contains
+// every field of want.
+func assertSubset(t *testing.T, path string, want, got interface{}) {
+	t.Helper()
+	if list, ok := want.([]interface{}); ok {
+		gotList, ok := got.([]interface{})
+		if !assert.True(t, ok, "expected an array for %s", path) ||
+			!assert.Len(t, gotList, len(list), "unexpected length of %s", path) {
+			return
+		}
+		for i, v := range list {
+			assertSubset(t, fmt.Sprintf("%s[%d]", path, i), v, gotList[i])
+		}
+		return
+	}
+	obj, ok := want.(map[string]interface{})
+	if !ok {
+		assert.Equal(t, want, got, "unexpected value for %s", path)
+		return
+	}
+	gotObj, ok := got.(map[string]interface{})
+	if !assert.True(t, ok, "expected an object for %s", path) {
+		return
+	}
+	for k, v := range obj {
+		if assert.Contains(t, gotObj, k, "missing field %s.%s", path, k) {
+			assertSubset(t, path+"."+k, v, gotObj[k])
+		}
+	}
+}
+
+func TestMarshalWebhook(t *testing.T) {
+	hook := &scm.CheckRunHook{
+		Action: scm.ActionCompleted,
+		Repo:   scm.Repository{Namespace: "octocat", Name: "hello-world"},
+		Sender: scm.User{Login: "octocat"},
+	}
+	before := time.Now().UTC()
+	data, err := scm.MarshalWebhook(hook, scm.WebhookMeta{Driver: "github", GUID: "1"})
+	require.NoError(t, err)
+
+	got, meta, err := scm.UnmarshalWebhook(data)
+	require.NoError(t, err)
+	assert.Equal(t, hook, got)
+	assert.Equal(t, "github", meta.Driver)
+	assert.Equal(t, "1", meta.GUID)
+	assert.False(t, meta.ReceivedAt.Before(before.Truncate(time.Second)), "received at should default to the current time")
+}
+
+func TestMarshalWebhook_Nil(t *testing.T) {
+	_, err := scm.MarshalWebhook(nil, scm.WebhookMeta{})
+	assert.Error(t, err)
+}
+
+func TestUnmarshalWebhook_Invalid(t *testing.T) {
+	testCases := []struct {
+		name string
+		data string
+	}{
+		{name: "malformed", data: `{`},
+		{name: "missing version", data: `{"kind":"push","payload":{}}`},
+		{name: "newer version", data: `{"kind":"push","version":2,"payload":{}}`},
+		{name: "unknown kind", data: `{"kind":"unknown","version":1,"payload":{}}`},
+		{name: "bad payload", data: `{"kind":"push","version":1,"payload":[]}`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, _, err := scm.UnmarshalWebhook([]byte(tc.data))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWebhookHeadersDigest(t *testing.T) {
+	a := http.Header{}
+	a.Add("Content-Type", "application/json")
+	a.Add("X-GitHub-Delivery", "72d3162e-cc78-11e3-81ab-4c9367dc0958")
+
+	b := http.Header{
+		"x-github-delivery": {"72d3162e-cc78-11e3-81ab-4c9367dc0958"},
+		"Content-Type":      {"application/json"},
+	}
+	assert.Equal(t, "sha256:6fc83d791fb676fd68684f76e3c7df83aa07e7a442d105eccfdafd380a4b2e26", scm.WebhookHeadersDigest(a))
+	assert.Equal(t, scm.WebhookHeadersDigest(a), scm.WebhookHeadersDigest(b))
+
+	b.Set("X-GitHub-Event", "push")
+	assert.NotEqual(t, scm.WebhookHeadersDigest(a), scm.WebhookHeadersDigest(b))
+}
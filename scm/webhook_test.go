@@ -43,3 +43,42 @@ func TestWebhookWrapper(t *testing.T) {
 		require.NotNil(t, hook, "nil wehhook returned")
 	}
 }
+
+func TestSecretFuncSecrets(t *testing.T) {
+	fn := scm.SecretFunc(func(scm.Webhook) (string, error) {
+		return "topsecret", nil
+	})
+	keys, err := fn.Secrets()(nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"topsecret"}, keys)
+
+	fn = func(scm.Webhook) (string, error) {
+		return "", nil
+	}
+	keys, err = fn.Secrets()(nil)
+	require.NoError(t, err)
+	require.Empty(t, keys, "an empty secret should disable validation")
+}
+
+func TestOrganizationWebhookRepository(t *testing.T) {
+	org := scm.Organization{Name: "octocat"}
+	repo := scm.Repository{Namespace: "octocat", Name: "hello-world"}
+
+	testCases := []struct {
+		name string
+		hook scm.Webhook
+		want scm.Repository
+	}{
+		{name: "member", hook: &scm.MemberHook{Org: org}, want: scm.Repository{Namespace: "octocat"}},
+		{name: "team", hook: &scm.TeamHook{Org: org}, want: scm.Repository{Namespace: "octocat"}},
+		{name: "organization", hook: &scm.OrganizationHook{Org: org}, want: scm.Repository{Namespace: "octocat"}},
+		{name: "org ping", hook: &scm.PingHook{Org: org}, want: scm.Repository{Namespace: "octocat"}},
+		{name: "repo ping", hook: &scm.PingHook{Repo: repo, Org: org}, want: repo},
+		{name: "repo member", hook: &scm.MemberHook{Repo: repo, Org: org}, want: repo},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			require.Equal(t, tc.want, tc.hook.Repository())
+		})
+	}
+}